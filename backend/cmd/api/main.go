@@ -9,6 +9,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
 	"github.com/Rin0530/DinnerDecider/backend/internal/handler"
 	"github.com/Rin0530/DinnerDecider/backend/internal/repository"
 	"github.com/Rin0530/DinnerDecider/backend/internal/service"
@@ -16,6 +17,7 @@ import (
 	"github.com/Rin0530/DinnerDecider/backend/pkg/config"
 	"github.com/Rin0530/DinnerDecider/backend/pkg/database"
 	"github.com/Rin0530/DinnerDecider/backend/pkg/logger"
+	"github.com/Rin0530/DinnerDecider/backend/pkg/ratelimit"
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -30,6 +32,11 @@ import (
 // @BasePath        /api
 // @schemes         http
 
+// @securityDefinitions.apikey  BearerAuth
+// @in                          header
+// @name                        Authorization
+// @description                 "Bearer " followed by the token issued by POST /auth/login or an API key created with POST /admin/api-keys
+
 func main() {
 	// Load configuration
 	cfg, err := config.Load("")
@@ -66,21 +73,109 @@ func main() {
 	// Initialize dependencies (Dependency Injection)
 	// Repository layer
 	ingredientRepo := repository.NewIngredientRepository(db)
+	recipeRepo := repository.NewRecipeRepository(db)
+	productRepo := repository.NewProductRepository(db)
+	ingredientEventRepo := repository.NewIngredientEventRepository(db)
+	consumptionRepo := repository.NewConsumptionRepository(db)
+	suggestionRepo := repository.NewSuggestionRepository(db)
+	statsRepo := repository.NewStatsRepository(db)
+	dietaryProfileRepo := repository.NewDietaryProfileRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	householdRepo := repository.NewHouseholdRepository(db)
+	apiKeyRepo := repository.NewAPIKeyRepository(db)
+
+	defaultDictionary, err := service.DefaultDictionary()
+	if err != nil {
+		logger.Fatalf("Failed to load ingredient dictionary: %v", err)
+	}
+	synonymRepo, err := repository.NewFileSynonymRepository(cfg.Dictionary.Path, defaultDictionary)
+	if err != nil {
+		logger.Fatalf("Failed to load synonym dictionary: %v", err)
+	}
+	dictionary, err := synonymRepo.GetAll(context.Background())
+	if err != nil {
+		logger.Fatalf("Failed to load synonym dictionary: %v", err)
+	}
 
 	// Service layer
 	ollamaService := service.NewOllamaService(&cfg.Ollama)
+	recipeImporter := service.NewRecipeImporter()
+	inventoryCodec := service.NewInventoryCodec()
+	ingredientTextParser := service.NewIngredientTextParser()
+
+	ingredientNormalizer := service.NewIngredientNormalizer(dictionary)
+	ingredientMatcher := service.NewIngredientMatcher(ingredientNormalizer)
+
+	var fallbackRecommender service.FallbackRecommender
+	if cfg.Fallback.Enabled {
+		fallbackRecommender, err = service.NewFallbackRecommender(&cfg.Fallback, ingredientMatcher)
+		if err != nil {
+			logger.Fatalf("Failed to load fallback recipe catalog: %v", err)
+		}
+	}
+
+	nutritionTable, err := service.NewNutritionTable(&cfg.Nutrition, ingredientNormalizer)
+	if err != nil {
+		logger.Fatalf("Failed to load nutrition table: %v", err)
+	}
+
+	dietaryChecker, err := service.NewDietaryChecker(&cfg.Dietary, ingredientNormalizer)
+	if err != nil {
+		logger.Fatalf("Failed to load dietary dictionary: %v", err)
+	}
+
+	if cfg.Auth.TokenSecret == "" {
+		logger.Warn("auth.token_secret is not set; session tokens will stop working when the server restarts")
+	}
+	tokenService, err := service.NewTokenService(&cfg.Auth)
+	if err != nil {
+		logger.Fatalf("Failed to initialize token service: %v", err)
+	}
 
 	// Usecase layer
-	ingredientUsecase := usecase.NewIngredientUsecase(ingredientRepo)
-	recipeUsecase := usecase.NewRecipeUsecase(ingredientRepo, ollamaService)
+	ingredientUsecase := usecase.NewIngredientUsecase(ingredientRepo, productRepo, ingredientNormalizer, inventoryCodec, nutritionTable)
+	var groundingRepo repository.RecipeRepository
+	if cfg.Catalog.GroundSuggestions {
+		groundingRepo = recipeRepo
+	}
+	recipeUsecase := usecase.NewRecipeUsecase(ingredientRepo, groundingRepo, suggestionRepo, dietaryProfileRepo, ollamaService, fallbackRecommender, ingredientMatcher, dietaryChecker, nutritionTable)
+	catalogUsecase := usecase.NewCatalogUsecase(recipeRepo, recipeImporter)
+	synonymUsecase := usecase.NewSynonymUsecase(synonymRepo, ingredientRepo, ingredientNormalizer)
+	intakeUsecase := usecase.NewIntakeUsecase(ingredientUsecase, ingredientNormalizer, ingredientTextParser, ollamaService)
+	productUsecase := usecase.NewProductUsecase(productRepo, ingredientNormalizer, inventoryCodec)
+	historyUsecase := usecase.NewHistoryUsecase(ingredientEventRepo)
+	consumptionUsecase := usecase.NewConsumptionUsecase(ingredientRepo, consumptionRepo, suggestionRepo)
+	statsUsecase := usecase.NewStatsUsecase(statsRepo)
+	dietaryUsecase := usecase.NewDietaryUsecase(dietaryProfileRepo)
+	householdUsecase := usecase.NewHouseholdUsecase(householdRepo)
+	userUsecase := usecase.NewUserUsecase(userRepo, householdRepo, tokenService, cfg.Auth.OpenRegistration)
+	apiKeyUsecase := usecase.NewAPIKeyUsecase(apiKeyRepo, userRepo)
+
+	// Backfill canonical names for ingredients stored before the dictionary changed
+	if result, err := synonymUsecase.ReindexIngredients(domain.WithAllHouseholds(context.Background())); err != nil {
+		logger.WithError(err).Warn("Failed to reindex ingredient names")
+	} else if result.Updated > 0 {
+		logger.Infof("Reindexed %d ingredient names", result.Updated)
+	}
 
 	// Handler layer
 	ingredientHandler := handler.NewIngredientHandler(ingredientUsecase)
 	recipeHandler := handler.NewRecipeHandler(recipeUsecase)
+	catalogHandler := handler.NewCatalogHandler(catalogUsecase)
+	synonymHandler := handler.NewSynonymHandler(synonymUsecase)
+	intakeHandler := handler.NewIntakeHandler(intakeUsecase)
+	productHandler := handler.NewProductHandler(productUsecase)
+	historyHandler := handler.NewHistoryHandler(historyUsecase)
+	consumptionHandler := handler.NewConsumptionHandler(consumptionUsecase)
+	statsHandler := handler.NewStatsHandler(statsUsecase)
+	dietaryHandler := handler.NewDietaryHandler(dietaryUsecase)
+	userHandler := handler.NewUserHandler(userUsecase)
+	householdHandler := handler.NewHouseholdHandler(householdUsecase)
+	apiKeyHandler := handler.NewAPIKeyHandler(apiKeyUsecase)
 	healthHandler := handler.NewHealthHandler(db, ollamaService)
 
 	// Setup Gin router
-	router := setupRouter(ingredientHandler, recipeHandler, healthHandler)
+	router := setupRouter(ingredientHandler, intakeHandler, historyHandler, consumptionHandler, productHandler, recipeHandler, catalogHandler, synonymHandler, statsHandler, dietaryHandler, userHandler, householdHandler, apiKeyHandler, healthHandler, handler.RequireUser(userUsecase, apiKeyUsecase), handler.RequireHousehold(householdUsecase), cfg)
 
 	// Create HTTP server
 	serverAddr := fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port)
@@ -89,6 +184,11 @@ func main() {
 		Handler: router,
 	}
 
+	// Purge the ingredient trash in the background until shutdown
+	purgeCtx, stopPurge := context.WithCancel(domain.WithAllHouseholds(context.Background()))
+	defer stopPurge()
+	go runTrashPurge(purgeCtx, ingredientUsecase, cfg.Trash)
+
 	// Start server in a goroutine
 	go func() {
 		logger.Infof("Server is listening on %s", serverAddr)
@@ -103,6 +203,7 @@ func main() {
 	<-quit
 
 	logger.Info("Shutting down server...")
+	stopPurge()
 
 	// Graceful shutdown with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -115,11 +216,59 @@ func main() {
 	logger.Info("Server exited")
 }
 
+// newRateLimiter creates the limiter of a rate limit policy, or nil when rate limiting is disabled
+func newRateLimiter(cfg config.RateLimitConfig, policy config.RateLimitPolicy) *ratelimit.Limiter {
+	if !cfg.Enabled {
+		return nil
+	}
+	return ratelimit.New(policy.Requests, policy.Period, policy.Burst)
+}
+
+// runTrashPurge permanently removes ingredients that stayed in the trash longer than the retention period,
+// once at startup and then at every purge interval, until ctx is cancelled
+func runTrashPurge(ctx context.Context, ingredientUsecase usecase.IngredientUsecase, cfg config.TrashConfig) {
+	if cfg.Retention <= 0 || cfg.PurgeInterval <= 0 {
+		logger.Info("Trash purge is disabled")
+		return
+	}
+
+	ticker := time.NewTicker(cfg.PurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		if purged, err := ingredientUsecase.PurgeTrash(ctx, cfg.Retention); err != nil {
+			logger.WithError(err).Warn("Failed to purge ingredient trash")
+		} else if purged > 0 {
+			logger.Infof("Purged %d ingredients from the trash", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 // setupRouter configures the Gin router with all routes and middleware
 func setupRouter(
 	ingredientHandler *handler.IngredientHandler,
+	intakeHandler *handler.IntakeHandler,
+	historyHandler *handler.HistoryHandler,
+	consumptionHandler *handler.ConsumptionHandler,
+	productHandler *handler.ProductHandler,
 	recipeHandler *handler.RecipeHandler,
+	catalogHandler *handler.CatalogHandler,
+	synonymHandler *handler.SynonymHandler,
+	statsHandler *handler.StatsHandler,
+	dietaryHandler *handler.DietaryHandler,
+	userHandler *handler.UserHandler,
+	householdHandler *handler.HouseholdHandler,
+	apiKeyHandler *handler.APIKeyHandler,
 	healthHandler *handler.HealthHandler,
+	requireUser gin.HandlerFunc,
+	requireHousehold gin.HandlerFunc,
+	cfg *config.Config,
 ) *gin.Engine {
 	// Set Gin mode based on environment
 	gin.SetMode(gin.ReleaseMode)
@@ -127,37 +276,166 @@ func setupRouter(
 	// Create router
 	router := gin.New()
 
+	// Without trusted proxies, clients could pick their IP address for the rate limits with X-Forwarded-For
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		logger.Fatalf("Invalid server.trusted_proxies: %v", err)
+	}
+
 	// Add middleware
 	router.Use(gin.Recovery())
 	router.Use(logger.GinLogger())
+	router.Use(handler.SecurityHeaders(&cfg.Security))
+	router.Use(handler.CORS(&cfg.CORS))
+	router.Use(handler.LimitBodySize(cfg.Server.MaxBodySize))
+	router.Use(handler.EventOrigin())
 
 	// Health check endpoints
 	router.GET("/health", healthHandler.Health)
 	router.GET("/health/db", healthHandler.HealthDB)
 	router.GET("/health/ollama", healthHandler.HealthOllama)
 
+	// Every route group limits its clients separately, with the same bucket for reads and writes
+	rateLimits := cfg.RateLimit
+	rateLimit := func(policy config.RateLimitPolicy) gin.HandlerFunc {
+		limiter := newRateLimiter(rateLimits, policy)
+		return handler.RateLimit(limiter, limiter)
+	}
+
+	// Sign-in endpoints, the only API routes open without a session token
+	auth := router.Group("/api/auth", rateLimit(rateLimits.Auth))
+	{
+		auth.POST("/register", userHandler.Register)
+		auth.POST("/login", userHandler.Login)
+	}
+
+	// Routes API keys may use, by scope; account and household management need a signed-in user
+	inventoryScope := handler.RequireScope(domain.APIKeyScopeInventoryRead, domain.APIKeyScopeInventoryWrite)
+	// Asking for suggestions changes no recipes, so reading the recipes is enough for it
+	suggestionScope := handler.RequireScope(domain.APIKeyScopeRecipesRead, domain.APIKeyScopeRecipesRead)
+	catalogScope := handler.RequireScope(domain.APIKeyScopeRecipesRead, domain.APIKeyScopeRecipesWrite)
+	requireSession := handler.RequireSession()
+
 	// API routes
-	api := router.Group("/api")
+	api := router.Group("/api", requireUser)
 	{
-		// Ingredient endpoints
-		ingredients := api.Group("/ingredients")
+		// Account endpoints
+		account := api.Group("/auth", rateLimit(rateLimits.Default), requireSession)
+		{
+			account.GET("/me", userHandler.Me)
+			account.PUT("/password", userHandler.ChangePassword)
+		}
+
+		users := api.Group("/users", rateLimit(rateLimits.Default), requireSession, requireHousehold)
+		{
+			users.GET("", userHandler.ListUsers)
+			users.POST("", userHandler.CreateUser)
+		}
+
+		// Household endpoints, which work across the households of the signed-in user
+		households := api.Group("/households", rateLimit(rateLimits.Default), requireSession)
+		{
+			households.GET("", householdHandler.ListHouseholds)
+			households.POST("", householdHandler.CreateHousehold)
+			households.POST("/join", householdHandler.JoinHousehold)
+			households.PUT("/:id", householdHandler.UpdateHousehold)
+			households.GET("/:id/members", householdHandler.ListMembers)
+			households.PUT("/:id/members/:user_id", householdHandler.UpdateMember)
+			households.DELETE("/:id/members/:user_id", householdHandler.RemoveMember)
+			households.GET("/:id/invitations", householdHandler.ListInvitations)
+			households.POST("/:id/invitations", householdHandler.CreateInvitation)
+			households.DELETE("/:id/invitations/:invitation_id", householdHandler.RevokeInvitation)
+		}
+
+		// Ingredient endpoints; reads, such as a kitchen tablet polling the inventory, get a laxer limit than writes
+		ingredientLimit := handler.RateLimit(newRateLimiter(rateLimits, rateLimits.IngredientReads), newRateLimiter(rateLimits, rateLimits.Default))
+		ingredients := api.Group("/ingredients", ingredientLimit, inventoryScope, requireHousehold)
 		{
 			ingredients.POST("", ingredientHandler.CreateIngredient)
+			ingredients.POST("/batch", ingredientHandler.BatchIngredients)
+			ingredients.POST("/import", ingredientHandler.ImportIngredients)
+			ingredients.GET("/export", ingredientHandler.ExportIngredients)
+			ingredients.POST("/parse-receipt", intakeHandler.ParseReceipt)
+			ingredients.POST("/quick-add", intakeHandler.QuickAdd)
+			ingredients.GET("/trash", ingredientHandler.GetTrash)
+			ingredients.GET("/activity", historyHandler.GetActivity)
+			ingredients.GET("/snapshot", historyHandler.GetInventorySnapshot)
 			ingredients.GET("", ingredientHandler.GetAllIngredients)
+			ingredients.GET("/:id", ingredientHandler.GetIngredientByID)
 			ingredients.PUT("/:id", ingredientHandler.UpdateIngredient)
+			ingredients.PATCH("/:id", ingredientHandler.PatchIngredient)
 			ingredients.DELETE("/:id", ingredientHandler.DeleteIngredient)
+			ingredients.POST("/:id/restore", ingredientHandler.RestoreIngredient)
+			ingredients.GET("/:id/history", historyHandler.GetIngredientHistory)
+			ingredients.POST("/:id/consume", consumptionHandler.ConsumeIngredient)
+			ingredients.GET("/:id/consumptions", consumptionHandler.GetConsumptions)
+		}
+
+		// Barcode product endpoints
+		products := api.Group("/products", rateLimit(rateLimits.Default), inventoryScope, requireHousehold)
+		{
+			products.POST("/import", productHandler.ImportProducts)
+			products.GET("/:barcode", productHandler.GetProduct)
 		}
 
 		// Recipe endpoints
-		recipes := api.Group("/recipes")
+		recipes := api.Group("/recipes", rateLimit(rateLimits.Recipes), suggestionScope, requireHousehold)
 		{
 			recipes.POST("/suggestion", recipeHandler.GetRecipeSuggestion)
 		}
+
+		// Recipe catalog endpoints
+		catalog := api.Group("/catalog/recipes", rateLimit(rateLimits.Default), catalogScope, requireHousehold)
+		{
+			catalog.GET("", catalogHandler.SearchRecipes)
+			catalog.POST("/import", catalogHandler.ImportRecipes)
+			catalog.GET("/:id", catalogHandler.GetRecipe)
+			catalog.DELETE("/:id", catalogHandler.DeleteRecipe)
+		}
+
+		// Synonym dictionary endpoints
+		synonyms := api.Group("/admin/synonyms", rateLimit(rateLimits.Default), requireSession, requireHousehold)
+		{
+			synonyms.GET("", synonymHandler.ListSynonyms)
+			synonyms.POST("/reindex", synonymHandler.ReindexIngredients)
+			synonyms.GET("/:canonical", synonymHandler.GetSynonym)
+			synonyms.PUT("/:canonical", synonymHandler.SaveSynonym)
+			synonyms.DELETE("/:canonical", synonymHandler.DeleteSynonym)
+		}
+
+		// API key endpoints
+		apiKeys := api.Group("/admin/api-keys", rateLimit(rateLimits.Default), requireSession, requireHousehold)
+		{
+			apiKeys.GET("", apiKeyHandler.ListAPIKeys)
+			apiKeys.POST("", apiKeyHandler.CreateAPIKey)
+			apiKeys.DELETE("/:id", apiKeyHandler.RevokeAPIKey)
+		}
+
+		// Statistics endpoints
+		stats := api.Group("/stats", rateLimit(rateLimits.Default), inventoryScope, requireHousehold)
+		{
+			stats.GET("/usage", statsHandler.GetUsage)
+			stats.GET("/purchase-to-use", statsHandler.GetPurchaseToUse)
+			stats.GET("/most-wasted", statsHandler.GetMostWasted)
+			stats.GET("/suggestions", statsHandler.GetSuggestionStats)
+			stats.GET("/spend", statsHandler.GetSpend)
+			stats.GET("/recipe-costs", statsHandler.GetRecipeCosts)
+		}
+
+		// Dietary profile endpoints, kept from API keys since the profiles hold the members' health details
+		dietary := api.Group("/dietary", rateLimit(rateLimits.Default), requireSession, requireHousehold)
+		{
+			dietary.GET("/rules", dietaryHandler.ListRules)
+			dietary.GET("/profiles", dietaryHandler.ListProfiles)
+			dietary.POST("/profiles", dietaryHandler.CreateProfile)
+			dietary.GET("/profiles/:id", dietaryHandler.GetProfile)
+			dietary.PUT("/profiles/:id", dietaryHandler.UpdateProfile)
+			dietary.DELETE("/profiles/:id", dietaryHandler.DeleteProfile)
+		}
 	}
 
 	// Swagger endpoint
 	// URL: http://localhost:8080/swagger/index.html
-	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	router.GET("/swagger/*any", handler.ContentSecurityPolicy(cfg.Security.SwaggerContentSecurityPolicy), ginSwagger.WrapHandler(swaggerFiles.Handler))
 
 	return router
 }
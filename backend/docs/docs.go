@@ -15,9 +15,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/ingredients": {
+        "/admin/api-keys": {
             "get": {
-                "description": "冷蔵庫にあるすべての食材のリストを取得します。",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "世帯のAPIキーを新しい順に返します。キー本体は含まれません。世帯のオーナーのみ利用できます。",
                 "consumes": [
                     "application/json"
                 ],
@@ -25,19 +30,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ingredients"
+                    "api-keys"
                 ],
-                "summary": "すべての食材を取得",
+                "summary": "APIキーの一覧を取得",
                 "responses": {
                     "200": {
-                        "description": "食材のリスト",
+                        "description": "APIキーのリスト",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/domain.Ingredient"
+                                "$ref": "#/definitions/domain.APIKey"
                             }
                         }
                     },
+                    "401": {
+                        "description": "認証が必要です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "世帯のオーナーではありません",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "サーバー内部エラー",
                         "schema": {
@@ -47,7 +64,12 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "新しい食材を冷蔵庫に追加します。",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "タブレットやスクリプトから使うAPIキーを作成します。キー本体はこのレスポンスでのみ返され、サーバーにはハッシュだけが保存されます。\nキーは作成したユーザーとして、作成した世帯の中で、scopes で許可された操作だけを行えます。世帯のオーナーのみ利用できます。",
                 "consumes": [
                     "application/json"
                 ],
@@ -55,25 +77,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ingredients"
+                    "api-keys"
                 ],
-                "summary": "食材を作成",
+                "summary": "APIキーを作成",
                 "parameters": [
                     {
-                        "description": "作成する食材の情報",
-                        "name": "ingredient",
+                        "description": "キーの名前とスコープ",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/usecase.CreateIngredientRequest"
+                            "$ref": "#/definitions/usecase.CreateAPIKeyRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "作成された食材",
+                        "description": "作成されたAPIキー",
                         "schema": {
-                            "$ref": "#/definitions/domain.Ingredient"
+                            "$ref": "#/definitions/usecase.CreateAPIKeyResponse"
                         }
                     },
                     "400": {
@@ -82,6 +104,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/usecase.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "認証が必要です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "世帯のオーナーではありません",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "サーバー内部エラー",
                         "schema": {
@@ -91,9 +125,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/ingredients/{id}": {
-            "get": {
-                "description": "指定されたIDの食材情報を取得します。",
+        "/admin/api-keys/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "APIキーを削除し、以降のリクエストで使えなくします。世帯のオーナーのみ利用できます。",
                 "consumes": [
                     "application/json"
                 ],
@@ -101,33 +140,162 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ingredients"
+                    "api-keys"
                 ],
-                "summary": "IDで食材を取得",
+                "summary": "APIキーを取り消す",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "食材ID",
+                        "description": "APIキーID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
+                "responses": {
+                    "204": {
+                        "description": "削除成功"
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "世帯のオーナーではありません",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "APIキーが見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/synonyms": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "食材名の正規化に使用する同義語辞書の全エントリを取得します。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "同義語辞書を取得",
                 "responses": {
                     "200": {
-                        "description": "食材の情報",
+                        "description": "辞書エントリのリスト",
                         "schema": {
-                            "$ref": "#/definitions/domain.Ingredient"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.DictionaryEntry"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "リクエストが不正です",
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/synonyms/reindex": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "現在の世帯の登録済みの食材について、現在の同義語辞書で正規名を再計算します。サーバー管理者のみ利用できます。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "食材の正規名を再計算",
+                "responses": {
+                    "200": {
+                        "description": "更新された食材の件数",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ReindexResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "サーバー管理者ではありません",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
                         "schema": {
                             "$ref": "#/definitions/usecase.ErrorResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/admin/synonyms/{canonical}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定された正規名の辞書エントリを取得します。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "同義語辞書のエントリを取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "正規名",
+                        "name": "canonical",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "辞書エントリ",
+                        "schema": {
+                            "$ref": "#/definitions/domain.DictionaryEntry"
+                        }
                     },
                     "404": {
-                        "description": "食材が見つかりません",
+                        "description": "エントリが見つかりません",
                         "schema": {
                             "$ref": "#/definitions/usecase.ErrorResponse"
                         }
@@ -141,7 +309,12 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "指定されたIDの食材情報を更新します。",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定された正規名の辞書エントリを登録、または置き換えます。現在の世帯の登録済みの食材の正規名も再計算されます。サーバー管理者のみ利用できます。",
                 "consumes": [
                     "application/json"
                 ],
@@ -149,32 +322,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ingredients"
+                    "admin"
                 ],
-                "summary": "食材を更新",
+                "summary": "同義語辞書のエントリを登録・更新",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "食材ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "正規名",
+                        "name": "canonical",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "更新する食材の情報",
-                        "name": "ingredient",
+                        "description": "別名と上位の食材",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/usecase.UpdateIngredientRequest"
+                            "$ref": "#/definitions/usecase.SaveSynonymRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "更新された食材",
+                        "description": "保存された辞書エントリ",
                         "schema": {
-                            "$ref": "#/definitions/domain.Ingredient"
+                            "$ref": "#/definitions/domain.DictionaryEntry"
                         }
                     },
                     "400": {
@@ -183,8 +356,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/usecase.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "食材が見つかりません",
+                    "403": {
+                        "description": "サーバー管理者ではありません",
                         "schema": {
                             "$ref": "#/definitions/usecase.ErrorResponse"
                         }
@@ -198,7 +371,12 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "指定されたIDの食材を削除します。",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定された正規名の辞書エントリを削除します。現在の世帯の登録済みの食材の正規名も再計算されます。サーバー管理者のみ利用できます。",
                 "consumes": [
                     "application/json"
                 ],
@@ -206,14 +384,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ingredients"
+                    "admin"
                 ],
-                "summary": "食材を削除",
+                "summary": "同義語辞書のエントリを削除",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "食材ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "正規名",
+                        "name": "canonical",
                         "in": "path",
                         "required": true
                     }
@@ -222,8 +400,14 @@ const docTemplate = `{
                     "204": {
                         "description": "削除成功"
                     },
-                    "400": {
-                        "description": "リクエストが不正です",
+                    "403": {
+                        "description": "サーバー管理者ではありません",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "エントリが見つかりません",
                         "schema": {
                             "$ref": "#/definitions/usecase.ErrorResponse"
                         }
@@ -237,9 +421,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/recipes/suggestion": {
+        "/auth/login": {
             "post": {
-                "description": "登録されている食材を基にAIが献立を提案します",
+                "description": "ユーザー名とパスワードを確認し、セッショントークンを発行します。以降のリクエストでは Authorization: Bearer \u003ctoken\u003e を指定してください。",
                 "consumes": [
                     "application/json"
                 ],
@@ -247,80 +431,4461 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "recipes"
+                    "auth"
+                ],
+                "summary": "ログイン",
+                "parameters": [
+                    {
+                        "description": "ユーザー名とパスワード",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.LoginRequest"
+                        }
+                    }
                 ],
-                "summary": "献立提案を取得",
                 "responses": {
                     "200": {
-                        "description": "献立提案のリスト",
+                        "description": "発行されたトークン",
                         "schema": {
-                            "$ref": "#/definitions/domain.RecipeResponse"
+                            "$ref": "#/definitions/usecase.LoginResponse"
                         }
                     },
-                    "500": {
-                        "description": "内部サーバーエラー",
+                    "400": {
+                        "description": "リクエストが不正です",
                         "schema": {
                             "$ref": "#/definitions/usecase.ErrorResponse"
                         }
                     },
-                    "503": {
-                        "description": "サービス利用不可（AI APIが利用できない場合）",
+                    "401": {
+                        "description": "ユーザー名またはパスワードが違います",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
                         "schema": {
                             "$ref": "#/definitions/usecase.ErrorResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "domain.Ingredient": {
-            "type": "object",
-            "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "expiration_date": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "quantity": {
-                    "type": "string"
-                },
-                "updated_at": {
-                    "type": "string"
-                }
-            }
         },
-        "domain.RecipeResponse": {
-            "type": "object",
-            "properties": {
-                "suggestions": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.RecipeSuggestion"
+        "/auth/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "セッショントークンのユーザーを返します。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "ログイン中のユーザーを取得",
+                "responses": {
+                    "200": {
+                        "description": "ログイン中のユーザー",
+                        "schema": {
+                            "$ref": "#/definitions/domain.User"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "domain.RecipeSuggestion": {
-            "type": "object",
+        "/auth/password": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ログイン中のユーザーのパスワードを変更します。それまでに発行したトークンはすべて無効になり、新しいトークンを返します。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "パスワードを変更",
+                "parameters": [
+                    {
+                        "description": "現在のパスワードと新しいパスワード",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ChangePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "新しいトークン",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "サインインせずにアカウントを作成し、セッショントークンを発行します。\n最初のユーザー、または auth.open_registration が有効な場合のみ利用できます。それ以外は POST /users で作成してください。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "ユーザー登録",
+                "parameters": [
+                    {
+                        "description": "ユーザー名とパスワード",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "発行されたトークン",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "登録は締め切られています",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/catalog/recipes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "料理名または材料名でカタログのレシピを検索します。条件を指定しない場合はすべてのレシピを返します。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "catalog"
+                ],
+                "summary": "レシピを検索",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "料理名（部分一致）",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "材料名（部分一致）",
+                        "name": "ingredient",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "レシピのリスト",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Recipe"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/catalog/recipes/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "schema.org Recipe の JSON-LD、またはそれを含む HTML からレシピを取り込みます。ネットワークからの取得は行いません。\nJSON のリクエストボディのほか、Content-Type が application/ld+json または text/html の生データも受け付けます。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "catalog"
+                ],
+                "summary": "レシピをインポート",
+                "parameters": [
+                    {
+                        "description": "インポートする内容",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ImportRecipesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "取り込まれたレシピ",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Recipe"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/catalog/recipes/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたIDのカタログレシピを取得します。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "catalog"
+                ],
+                "summary": "IDでレシピを取得",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "レシピID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "レシピ",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Recipe"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "レシピが見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたIDのカタログレシピを削除します。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "catalog"
+                ],
+                "summary": "レシピを削除",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "レシピID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "削除成功"
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "レシピが見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/dietary/profiles": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "登録されているすべての食事プロフィールを取得します。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "dietary"
+                ],
+                "summary": "食事プロフィール一覧を取得",
+                "responses": {
+                    "200": {
+                        "description": "食事プロフィールのリスト",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.DietaryProfile"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "家族それぞれのアレルギー・食事制限・苦手な食材を登録します。アレルゲンと食事制限はコードまたは日本語名で指定できます。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "dietary"
+                ],
+                "summary": "食事プロフィールを作成",
+                "parameters": [
+                    {
+                        "description": "食事プロフィール",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.SaveDietaryProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "作成された食事プロフィール",
+                        "schema": {
+                            "$ref": "#/definitions/domain.DietaryProfile"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/dietary/profiles/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたIDの食事プロフィールを取得します。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "dietary"
+                ],
+                "summary": "IDで食事プロフィールを取得",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "食事プロフィールID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "食事プロフィール",
+                        "schema": {
+                            "$ref": "#/definitions/domain.DietaryProfile"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "食事プロフィールが見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたIDの食事プロフィールを置き換えます。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "dietary"
+                ],
+                "summary": "食事プロフィールを更新",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "食事プロフィールID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "食事プロフィール",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.SaveDietaryProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新された食事プロフィール",
+                        "schema": {
+                            "$ref": "#/definitions/domain.DietaryProfile"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "食事プロフィールが見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたIDの食事プロフィールを削除します。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "dietary"
+                ],
+                "summary": "食事プロフィールを削除",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "食事プロフィールID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "削除成功"
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "食事プロフィールが見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/dietary/rules": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "プロフィールに指定できるアレルゲン（特定原材料・準ずるもの）と食事制限の一覧を返します。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "dietary"
+                ],
+                "summary": "食事制限の一覧を取得",
+                "responses": {
+                    "200": {
+                        "description": "アレルゲンと食事制限の一覧",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.DietaryRulesResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/households": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ログイン中のユーザーが所属する世帯と、それぞれでの役割を返します。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "households"
+                ],
+                "summary": "所属する世帯の一覧を取得",
+                "responses": {
+                    "200": {
+                        "description": "世帯のリスト",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Household"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "新しい世帯を作成し、ログイン中のユーザーをオーナーにします。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "households"
+                ],
+                "summary": "世帯を作成",
+                "parameters": [
+                    {
+                        "description": "世帯名",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.SaveHouseholdRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "作成された世帯",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Household"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/households/join": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "世帯のオーナーから受け取った招待コードで世帯に参加します。招待コードは一度だけ使えます。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "households"
+                ],
+                "summary": "招待コードで世帯に参加",
+                "parameters": [
+                    {
+                        "description": "招待コード",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.JoinHouseholdRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "参加した世帯",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Household"
+                        }
+                    },
+                    "400": {
+                        "description": "招待コードが無効です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/households/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "世帯の名前を変更します。世帯のオーナーのみ利用できます。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "households"
+                ],
+                "summary": "世帯名を変更",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "世帯ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "世帯名",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.SaveHouseholdRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新された世帯",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Household"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "世帯のオーナーではありません",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "世帯が見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/households/{id}/invitations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "未使用で有効期限内の招待コードを新しい順に返します。世帯のオーナーのみ利用できます。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "households"
+                ],
+                "summary": "招待コードの一覧を取得",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "世帯ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "招待コードのリスト",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.HouseholdInvitation"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "世帯のオーナーではありません",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "世帯が見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "世帯に参加するための招待コードを発行します。コードは一度だけ使え、7日で失効します。世帯のオーナーのみ利用できます。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "households"
+                ],
+                "summary": "招待コードを発行",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "世帯ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "参加者の役割（省略時は member）",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.CreateInvitationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "発行された招待コード",
+                        "schema": {
+                            "$ref": "#/definitions/domain.HouseholdInvitation"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "世帯のオーナーではありません",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "世帯が見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/households/{id}/invitations/{invitation_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "未使用の招待コードを無効にします。世帯のオーナーのみ利用できます。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "households"
+                ],
+                "summary": "招待コードを取り消す",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "世帯ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "招待コードID",
+                        "name": "invitation_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "削除成功"
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "世帯のオーナーではありません",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "世帯または招待コードが見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/households/{id}/members": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "世帯に所属するユーザーと役割を参加順に返します。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "households"
+                ],
+                "summary": "世帯のメンバー一覧を取得",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "世帯ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "メンバーのリスト",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.HouseholdMember"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "世帯が見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/households/{id}/members/{user_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "メンバーの役割を owner、member、viewer のいずれかに変更します。世帯のオーナーのみ利用できます。最後のオーナーは役割を変更できません。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "households"
+                ],
+                "summary": "メンバーの役割を変更",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "世帯ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ユーザーID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "役割",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.UpdateHouseholdMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "変更成功"
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "世帯のオーナーではありません",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "世帯またはメンバーが見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "メンバーを世帯から外します。オーナーは誰でも外せ、それ以外のメンバーは自分自身のみ外せます。最後のオーナーは抜けられません。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "households"
+                ],
+                "summary": "メンバーを外す・世帯から抜ける",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "世帯ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ユーザーID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "削除成功"
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "世帯のオーナーではありません",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "世帯またはメンバーが見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ingredients": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "冷蔵庫にある食材のリストを取得します。名前での検索、カテゴリ・保存場所・購入日での絞り込み、並び替えができます。\n結果はページ単位で返され、次のページがある場合は next_cursor を cursor に指定して続きを取得します。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ingredients"
+                ],
+                "summary": "すべての食材を取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "食材名の部分一致検索",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "vegetable",
+                            "fruit",
+                            "meat",
+                            "seafood",
+                            "dairy",
+                            "soy",
+                            "grain",
+                            "seasoning",
+                            "other"
+                        ],
+                        "type": "string",
+                        "description": "カテゴリ",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "fridge",
+                            "freezer",
+                            "pantry"
+                        ],
+                        "type": "string",
+                        "description": "保存場所",
+                        "name": "location",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "この日付より前に購入した食材 (YYYY-MM-DD)",
+                        "name": "purchased_before",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at",
+                            "name",
+                            "purchase_date",
+                            "expires_at"
+                        ],
+                        "type": "string",
+                        "description": "並び替えの基準 (デフォルト: created_at)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "並び順 (デフォルト: created_at は desc、それ以外は asc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "前のページの next_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "1ページの件数 (デフォルト: 50、最大: 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "前回の ETag。一覧に変更がなければ 304 を返します",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "食材のリスト",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.IngredientListResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "一覧の内容を表すエンティティタグ"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "変更なし"
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "新しい食材を冷蔵庫に追加します。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ingredients"
+                ],
+                "summary": "食材を作成",
+                "parameters": [
+                    {
+                        "description": "作成する食材の情報",
+                        "name": "ingredient",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.CreateIngredientRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "作成された食材",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Ingredient"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "食材のバージョンを表すエンティティタグ"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ingredients/activity": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "すべての食材の追加・変更・削除の履歴を新しい順に取得します。「誰が牛乳を使い切ったか」は action=update や action=delete と actor で絞り込めます。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "history"
+                ],
+                "summary": "食材のアクティビティを取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "変更した人",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "api",
+                            "import",
+                            "cook",
+                            "system"
+                        ],
+                        "type": "string",
+                        "description": "変更の経路",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "create",
+                            "update",
+                            "delete",
+                            "restore",
+                            "purge"
+                        ],
+                        "type": "string",
+                        "description": "操作",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "この日時以降の履歴 (YYYY-MM-DD または RFC3339)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "この日時より前の履歴。日付のみの場合はその日を含みます (YYYY-MM-DD または RFC3339)",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "前のページの next_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "1ページの件数 (デフォルト: 50、最大: 200)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "変更履歴",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.IngredientEventListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ingredients/batch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "複数の食材の作成・更新・削除を 1 つのトランザクションでまとめて実行し、操作ごとの結果を返します。\nmode が atomic (デフォルト) の場合は 1 件でも失敗するとすべて取り消され、失敗した操作のエラーに応じたステータスを返します。\nbest_effort の場合は成功した操作だけを反映し、常に 200 を返します。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ingredients"
+                ],
+                "summary": "食材を一括で作成・更新・削除",
+                "parameters": [
+                    {
+                        "description": "実行する操作の一覧 (最大 200 件)",
+                        "name": "batch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.BatchIngredientsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "操作ごとの結果",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.BatchIngredientsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正、または atomic モードで入力エラーの操作がありました",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.BatchIngredientsResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "atomic モードで対象の食材が見つからない操作がありました",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.BatchIngredientsResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "atomic モードでバージョンが一致しない操作がありました",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.BatchIngredientsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ingredients/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "すべての食材を CSV または JSON でダウンロードします。出力したファイルはそのまま POST /ingredients/import で取り込めます。",
+                "produces": [
+                    "text/csv",
+                    "application/json"
+                ],
+                "tags": [
+                    "ingredients"
+                ],
+                "summary": "食材をエクスポート",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "json"
+                        ],
+                        "type": "string",
+                        "description": "出力形式 (デフォルト: csv)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "食材の一覧。列は name, category, location, quantity, barcode, price, store, purchase_date, expires_at",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ingredients/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "CSV または JSON の食材一覧を取り込み、行ごとの結果を返します。列名は自動で判別され、mapping で明示することもできます。\n日付の書式は自動で判別され、date_format で指定することもできます。dry_run を指定すると保存せずに結果だけを確認できます。\nJSON のリクエストボディのほか、Content-Type が text/csv の生データも受け付けます。その場合のオプションはクエリパラメータで指定します。",
+                "consumes": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ingredients"
+                ],
+                "summary": "食材をインポート",
+                "parameters": [
+                    {
+                        "description": "インポートする内容",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ImportIngredientsRequest"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "json"
+                        ],
+                        "type": "string",
+                        "description": "text/csv 以外の生データの形式",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "日付の書式 (例: YYYY/MM/DD)",
+                        "name": "date_format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "skip",
+                            "merge",
+                            "overwrite"
+                        ],
+                        "type": "string",
+                        "description": "同じ食材があった場合の扱い (デフォルト: skip)",
+                        "name": "on_duplicate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "保存せずに結果だけを返す",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "食材名の列名。ほかのフィールドも mapping[quantity] のように指定できます",
+                        "name": "mapping[name]",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "行ごとの結果",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ImportIngredientsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "取り込み中に食材が他の更新により変更されました",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ingredients/parse-receipt": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "OCRで読み取ったレシートのテキストから、食品の品名・数量・価格と購入日を読み取り、保存前の下書きとして返します。\n小計・合計・税・支払いなどの行や日用品は除外されます。use_llm を指定すると設定済みのLLMでも読み取り、失敗した場合はルールによる結果と警告を返します。\n下書きはそのまま POST /ingredients/batch の create 操作として送信できます。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ingredients"
+                ],
+                "summary": "レシートから食材の下書きを作成",
+                "parameters": [
+                    {
+                        "description": "レシートのテキスト",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ParseReceiptRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "食材の下書き",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.IngredientDraftsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ingredients/quick-add": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "「卵10個と牛乳1本、昨日買った鶏もも300g」のような文章から、食材名・数量・購入日（「昨日」「3日前」などの相対的な日付も含む）を読み取ります。\nまずルールで読み取り、読み取れない部分や辞書にない食材名がある場合は設定済みのLLMで読み取ります（llm で auto / always / never を指定できます）。\nconfirm を指定しない場合は下書きだけを返します。確認後は下書きを drafts に入れ、confirm を true にして送ると、すべての下書きが 1 つのトランザクションで保存されます。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ingredients"
+                ],
+                "summary": "短い文章から食材を追加",
+                "parameters": [
+                    {
+                        "description": "文章、または確認済みの下書き",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.QuickAddRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "読み取った下書き",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.QuickAddResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "保存された食材",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.QuickAddResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ingredients/snapshot": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "変更履歴から指定した日時の在庫を復元します。日付のみを指定した場合はその日の終わりの在庫になります。履歴の記録を始める前の変更は反映されません。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "history"
+                ],
+                "summary": "過去の在庫を復元",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "復元する日時 (YYYY-MM-DD または RFC3339)",
+                        "name": "at",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "その時点の在庫",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.InventorySnapshotResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ingredients/trash": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "削除された食材のうち、まだ完全に削除されていないものを削除日時の新しい順に取得します。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ingredients"
+                ],
+                "summary": "ゴミ箱の食材を取得",
+                "responses": {
+                    "200": {
+                        "description": "ゴミ箱の食材のリスト",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Ingredient"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ingredients/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたIDの食材情報を取得します。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ingredients"
+                ],
+                "summary": "IDで食材を取得",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "食材ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "前回の ETag。食材に変更がなければ 304 を返します",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "食材の情報",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Ingredient"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "食材のバージョンを表すエンティティタグ"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "変更なし"
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "食材が見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたIDの食材情報をリクエストの内容で置き換えます。省略した項目はクリアされます（カテゴリ・保存場所は再判定されます）。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ingredients"
+                ],
+                "summary": "食材を置き換え",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "食材ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "取得時の ETag。食材が更新されていた場合は 412 を返します",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "置き換える食材の情報",
+                        "name": "ingredient",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.UpdateIngredientRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新された食材",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Ingredient"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "更新後の食材のエンティティタグ"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "食材が見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "食材が他の更新により変更されています",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたIDの食材をゴミ箱に移動します。ゴミ箱の食材は保持期間が過ぎるまで復元できます。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ingredients"
+                ],
+                "summary": "食材を削除",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "食材ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "取得時の ETag。食材が更新されていた場合は 412 を返します",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "削除成功"
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "食材が他の更新により変更されています",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたIDの食材情報を部分的に更新します。\nContent-Type が application/merge-patch+json（または application/json）の場合は RFC 7396 のマージパッチとして扱い、null を指定した項目はクリアされます。\nContent-Type が application/json-patch+json の場合は RFC 6902 の JSON Patch として扱います。",
+                "consumes": [
+                    "application/merge-patch+json",
+                    "application/json-patch+json",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ingredients"
+                ],
+                "summary": "食材を部分更新",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "食材ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "取得時の ETag。食材が更新されていた場合は 412 を返します",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "マージパッチまたは JSON Patch",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新された食材",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Ingredient"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "更新後の食材のエンティティタグ"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "食材が見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "食材が他の更新により変更されています",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "サポートされていない Content-Type です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ingredients/{id}/consume": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたIDの食材から amount の分だけ数量を減らし、理由とともに記録します。\namount を省略した場合や残りがなくなった場合は、食材をゴミ箱に移動します。数量の単位は g と kg のように換算され、単位のない amount は食材の単位で数えます。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ingredients"
+                ],
+                "summary": "食材を使う",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "食材ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "取得時の ETag。食材が更新されていた場合は 412 を返します",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "使った量と理由",
+                        "name": "consumption",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ConsumeIngredientRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "記録した消費と残りの食材",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ConsumeIngredientResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "残りの食材のエンティティタグ（使い切った場合はなし）"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "食材が見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "食材が他の更新により変更されています",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ingredients/{id}/consumptions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたIDの食材を使った記録を新しい順に取得します。使い切って削除された食材の記録も取得できます。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ingredients"
+                ],
+                "summary": "食材の消費記録を取得",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "食材ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "消費記録のリスト",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Consumption"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ingredients/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたIDの食材の追加・変更・削除の履歴を新しい順に取得します。完全に削除された食材の履歴も取得できます。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "history"
+                ],
+                "summary": "食材の変更履歴を取得",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "食材ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "変更した人",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "api",
+                            "import",
+                            "cook",
+                            "system"
+                        ],
+                        "type": "string",
+                        "description": "変更の経路",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "create",
+                            "update",
+                            "delete",
+                            "restore",
+                            "purge"
+                        ],
+                        "type": "string",
+                        "description": "操作",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "この日時以降の履歴 (YYYY-MM-DD または RFC3339)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "この日時より前の履歴。日付のみの場合はその日を含みます (YYYY-MM-DD または RFC3339)",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "前のページの next_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "1ページの件数 (デフォルト: 50、最大: 200)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "変更履歴",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.IngredientEventListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ingredients/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ゴミ箱にある指定されたIDの食材を元に戻します。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ingredients"
+                ],
+                "summary": "食材を復元",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "食材ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "復元された食材",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Ingredient"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "復元後の食材のエンティティタグ"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "ゴミ箱に食材が見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "CSV 形式の商品データベースを取り込みます。列名は自動で判別され、mapping で明示することもできます。\n利用者の登録から学習した商品は、overwrite_learned を指定しない限り上書きされません。\nJSON のリクエストボディのほか、Content-Type が text/csv の生データも受け付けます。その場合のオプションはクエリパラメータで指定します。\n商品データは全世帯で共有されるため、サーバー管理者だけが取り込めます。閲覧者として世帯を選んでいる場合も拒否されます。",
+                "consumes": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "商品データベースをインポート",
+                "parameters": [
+                    {
+                        "description": "インポートする内容",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ImportProductsRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "学習した商品も上書きする",
+                        "name": "overwrite_learned",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "バーコードの列名。ほかのフィールドも mapping[name] のように指定できます",
+                        "name": "mapping[barcode]",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "インポート結果",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ImportProductsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "サーバー管理者ではないか、世帯の閲覧者です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{barcode}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "JAN/EAN バーコードに対応する商品をローカルの商品データベースから取得します。ハイフンや空白、全角数字を含むバーコードも受け付けます。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "バーコードから商品を取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JAN/EAN バーコード (8桁または13桁)",
+                        "name": "barcode",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "商品",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "バーコードが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "商品が見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/recipes/suggestion": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "登録されている食材を基にAIが献立を提案します。AIが利用できない場合は同梱のレシピカタログから提案します（source: \"catalog\"）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "献立提案を取得",
+                "responses": {
+                    "200": {
+                        "description": "献立提案のリスト",
+                        "schema": {
+                            "$ref": "#/definitions/domain.RecipeResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "サービス利用不可（AI APIが利用できず、フォールバックも無効な場合）",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats/most-wasted": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "捨てた（discarded、expired）回数の多い食材を正規化した名前ごとに多い順で返します。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "よく捨てる食材を取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "この日時以降の記録 (YYYY-MM-DD または RFC3339)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "この日時より前の記録。日付のみの場合はその日を含みます (YYYY-MM-DD または RFC3339)",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "件数 (デフォルト: 10、最大: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "よく捨てる食材",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.WastedIngredient"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats/purchase-to-use": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "購入日が分かる食材について、購入してから食べる（cooked、eaten）までの平均日数を全体とカテゴリごとに返します。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "購入から消費までの日数を集計",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "この日時以降の記録 (YYYY-MM-DD または RFC3339)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "この日時より前の記録。日付のみの場合はその日を含みます (YYYY-MM-DD または RFC3339)",
+                        "name": "until",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "購入から消費までの平均日数",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.PurchaseToUseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats/recipe-costs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "cooked として使った食材の推定費用（使った分の価格）を料理名ごとに合計し、作った日数と 1 回あたりの平均費用を返します。価格の分からない食材は費用に含めず unpriced_items に数えます。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "料理ごとの費用を推定",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "この日時以降に作った料理 (YYYY-MM-DD または RFC3339)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "この日時より前に作った料理。日付のみの場合はその日を含みます (YYYY-MM-DD または RFC3339)",
+                        "name": "until",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "料理ごとの推定費用",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.RecipeCost"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats/spend": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "価格を登録した食材の購入金額を、週（月曜始まり）・カテゴリ・購入先のいずれかごとに集計します。期間は購入日で絞り込みます。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "食費を集計",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "この日以降の購入 (YYYY-MM-DD または RFC3339)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "この日より前の購入。日付のみの場合はその日を含みます (YYYY-MM-DD または RFC3339)",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "week",
+                            "category",
+                            "store"
+                        ],
+                        "type": "string",
+                        "description": "集計単位 (デフォルト: week)",
+                        "name": "group_by",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "集計単位ごとの購入金額",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.SpendReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats/suggestions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "期間中のレシピ提案の数と、そのうち実際に作られた（suggestion_id 付きで cooked として食材を使った）提案の数と割合を返します。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "レシピ提案の実施率を取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "この日時以降の提案 (YYYY-MM-DD または RFC3339)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "この日時より前の提案。日付のみの場合はその日を含みます (YYYY-MM-DD または RFC3339)",
+                        "name": "until",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "レシピ提案の実施率",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SuggestionStats"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats/usage": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "食材を使った記録を月・カテゴリごとに集計し、食べた件数（cooked、eaten）と捨てた件数（discarded、expired）、期間全体の廃棄率を返します。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "食材の消費と廃棄を集計",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "この日時以降の記録 (YYYY-MM-DD または RFC3339)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "この日時より前の記録。日付のみの場合はその日を含みます (YYYY-MM-DD または RFC3339)",
+                        "name": "until",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "月・カテゴリごとの消費と廃棄",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.UsageStatsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "現在の世帯のメンバーであるユーザーを取得します。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "ユーザー一覧を取得",
+                "responses": {
+                    "200": {
+                        "description": "ユーザーのリスト",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.User"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "世帯に所属していません",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "家族など他の人のアカウントを作成します。登録が開放されていない場合はサーバー管理者のみ利用できます。作成したユーザーは招待コードで世帯に参加します。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "ユーザーを作成",
+                "parameters": [
+                    {
+                        "description": "ユーザー名とパスワード",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "作成されたユーザー",
+                        "schema": {
+                            "$ref": "#/definitions/domain.User"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要です",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "サーバー管理者ではありません",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部エラー",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.APIKey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "household_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "description": "first characters of the key, to tell keys apart",
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.Allergen": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "mandatory": {
+                    "description": "true for the 特定原材料, whose labeling is mandatory",
+                    "type": "boolean"
+                }
+            }
+        },
+        "domain.CategoryPurchaseToUse": {
+            "type": "object",
+            "properties": {
+                "average_days": {
+                    "type": "number"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "count": {
+                    "description": "uses with a known purchase date",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.CategoryUsage": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "consumed": {
+                    "description": "uses cooked or eaten",
+                    "type": "integer"
+                },
+                "month": {
+                    "description": "YYYY-MM format",
+                    "type": "string"
+                },
+                "wasted": {
+                    "description": "uses discarded or expired",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.Consumption": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "type": "string"
+                },
+                "amount": {
+                    "description": "how much was used, such as 200g; the whole quantity when used up",
+                    "type": "string"
+                },
+                "canonical_name": {
+                    "type": "string"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "cost": {
+                    "description": "estimated yen of the amount used; nil when the price is unknown",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ingredient_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "purchase_date": {
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "\"cooked\", \"eaten\", \"discarded\" or \"expired\"",
+                    "type": "string"
+                },
+                "recipe": {
+                    "type": "string"
+                },
+                "remaining": {
+                    "description": "quantity left afterwards; empty when used up",
+                    "type": "string"
+                },
+                "suggestion_id": {
+                    "description": "the recipe suggestion that was cooked, if any",
+                    "type": "integer"
+                },
+                "used_up": {
+                    "description": "the ingredient was moved to the trash",
+                    "type": "boolean"
+                }
+            }
+        },
+        "domain.DictionaryEntry": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "description": "Aliases are other spellings and names that refer to the same ingredient",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "broader": {
+                    "description": "Broader is the canonical name of a more general ingredient, e.g. 豚肉 for 豚バラ肉",
+                    "type": "string"
+                },
+                "canonical": {
+                    "description": "Canonical is the preferred name of the ingredient",
+                    "type": "string"
+                },
+                "category": {
+                    "description": "Category is the ingredient category used for auto-categorization",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.DietaryProfile": {
+            "type": "object",
+            "properties": {
+                "allergens": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "dislikes": {
+                    "description": "ingredient names, matched with their more specific ingredients",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "household_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "household member the profile belongs to",
+                    "type": "string"
+                },
+                "owner_id": {
+                    "description": "user who created the profile",
+                    "type": "integer"
+                },
+                "restrictions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.DietaryRestriction": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "description": {
+                    "description": "what the restriction rules out, as told to the LLM",
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.DietaryViolation": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "allergen or restriction code, or the disliked ingredient",
+                    "type": "string"
+                },
+                "item": {
+                    "description": "dish name or ingredient that breaks the rule",
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "\"allergen\", \"restriction\" or \"dislike\"",
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "profile": {
+                    "description": "name of the profile",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ExcludedSuggestion": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "violations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.DietaryViolation"
+                    }
+                }
+            }
+        },
+        "domain.Household": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "description": "role of the signed-in user in the household",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.HouseholdInvitation": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "household_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.HouseholdMember": {
+            "type": "object",
+            "properties": {
+                "household_id": {
+                    "type": "integer"
+                },
+                "joined_at": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Ingredient": {
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "description": "JAN/EAN code of the packaged product, if any",
+                    "type": "string"
+                },
+                "canonical_name": {
+                    "type": "string"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "description": "set while the ingredient is in the trash",
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "household_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "nutrition": {
+                    "description": "looked up from the nutrition table when read",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.IngredientNutrition"
+                        }
+                    ]
+                },
+                "owner_id": {
+                    "description": "user who registered the ingredient",
+                    "type": "integer"
+                },
+                "price": {
+                    "description": "yen paid for the ingredient as bought",
+                    "type": "integer"
+                },
+                "purchase_date": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "string"
+                },
+                "store": {
+                    "description": "where the ingredient was bought",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.IngredientEvent": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "\"create\", \"update\", \"delete\", \"restore\" or \"purge\"",
+                    "type": "string"
+                },
+                "actor": {
+                    "description": "who made the change; empty when unknown",
+                    "type": "string"
+                },
+                "after": {
+                    "$ref": "#/definitions/domain.Ingredient"
+                },
+                "before": {
+                    "$ref": "#/definitions/domain.Ingredient"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ingredient_id": {
+                    "type": "integer"
+                },
+                "source": {
+                    "description": "\"api\", \"import\", \"cook\" or \"system\"",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.IngredientNutrition": {
+            "type": "object",
+            "properties": {
+                "per_100g": {
+                    "$ref": "#/definitions/domain.Nutrition"
+                },
+                "total": {
+                    "description": "nutrition of the whole quantity; nil when the quantity cannot be weighed",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.Nutrition"
+                        }
+                    ]
+                }
+            }
+        },
+        "domain.Nutrition": {
+            "type": "object",
+            "properties": {
+                "carbohydrate_g": {
+                    "type": "number"
+                },
+                "energy_kcal": {
+                    "type": "number"
+                },
+                "fat_g": {
+                    "type": "number"
+                },
+                "protein_g": {
+                    "type": "number"
+                },
+                "salt_g": {
+                    "description": "salt equivalent",
+                    "type": "number"
+                }
+            }
+        },
+        "domain.Product": {
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "type": "string"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "shelf_life_days": {
+                    "description": "days from purchase to expiry",
+                    "type": "integer"
+                },
+                "source": {
+                    "description": "\"import\" or \"learned\"",
+                    "type": "string"
+                },
+                "unit": {
+                    "description": "default quantity, e.g. 1本 or 300g",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Recipe": {
+            "type": "object",
+            "properties": {
+                "cook_time_minutes": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "household_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RecipeIngredient"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "owner_id": {
+                    "description": "user who imported the recipe",
+                    "type": "integer"
+                },
+                "prep_time_minutes": {
+                    "type": "integer"
+                },
+                "source_url": {
+                    "type": "string"
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "total_time_minutes": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "yield": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.RecipeCost": {
+            "type": "object",
+            "properties": {
+                "average_cost": {
+                    "description": "yen per time it was cooked",
+                    "type": "number"
+                },
+                "last_cooked_at": {
+                    "type": "string"
+                },
+                "recipe": {
+                    "type": "string"
+                },
+                "times_cooked": {
+                    "description": "days on which it was cooked",
+                    "type": "integer"
+                },
+                "total_cost": {
+                    "description": "yen over every time it was cooked",
+                    "type": "integer"
+                },
+                "unpriced_items": {
+                    "description": "ingredients used without a known price, left out of the cost",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.RecipeIngredient": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "string"
+                },
+                "raw_text": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.RecipeNutrition": {
+            "type": "object",
+            "properties": {
+                "counted": {
+                    "description": "ingredients included in the estimate",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "per_serving": {
+                    "$ref": "#/definitions/domain.Nutrition"
+                },
+                "servings": {
+                    "type": "integer"
+                },
+                "uncounted": {
+                    "description": "ingredients missing from the table or without a usable quantity",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "domain.RecipeResponse": {
+            "type": "object",
+            "properties": {
+                "excluded": {
+                    "description": "suggestions dropped for breaking an allergen or restriction",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ExcludedSuggestion"
+                    }
+                },
+                "source": {
+                    "type": "string"
+                },
+                "suggestions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RecipeSuggestion"
+                    }
+                }
+            }
+        },
+        "domain.RecipeSuggestion": {
+            "type": "object",
+            "properties": {
+                "available_items": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "dietary_warnings": {
+                    "description": "disliked ingredients the suggestion still uses",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.DietaryViolation"
+                    }
+                },
+                "id": {
+                    "description": "suggestion log ID; pass it as suggestion_id when cooking the dish",
+                    "type": "integer"
+                },
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RecipeIngredient"
+                    }
+                },
+                "missing_items": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "nutrition": {
+                    "description": "estimated from the ingredients found in the nutrition table",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.RecipeNutrition"
+                        }
+                    ]
+                },
+                "servings": {
+                    "type": "integer"
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "domain.SpendTotal": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "priced purchases",
+                    "type": "integer"
+                },
+                "key": {
+                    "description": "first day of the week (Monday, YYYY-MM-DD), category or store",
+                    "type": "string"
+                },
+                "total": {
+                    "description": "yen",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.SuggestionStats": {
+            "type": "object",
+            "properties": {
+                "cooked": {
+                    "description": "suggestions referenced by at least one cooked consumption",
+                    "type": "integer"
+                },
+                "cooked_rate": {
+                    "description": "cooked / suggested; 0 when nothing was suggested",
+                    "type": "number"
+                },
+                "suggested": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.User": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_admin": {
+                    "description": "administers what every household shares",
+                    "type": "boolean"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.WastedIngredient": {
+            "type": "object",
+            "properties": {
+                "canonical_name": {
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "last_wasted_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "description": "one of the names it was registered under",
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.BatchIngredientOperation": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "target of update and delete",
+                    "type": "integer"
+                },
+                "ingredient": {
+                    "description": "contents for create, full replacement for update",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/usecase.CreateIngredientRequest"
+                        }
+                    ]
+                },
+                "op": {
+                    "description": "\"create\", \"update\" or \"delete\"",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "expected version for update and delete; 0 means unconditional",
+                    "type": "integer"
+                }
+            }
+        },
+        "usecase.BatchIngredientResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/usecase.ErrorResponse"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "ingredient": {
+                    "$ref": "#/definitions/domain.Ingredient"
+                },
+                "op": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.BatchIngredientsRequest": {
+            "type": "object",
+            "required": [
+                "operations"
+            ],
+            "properties": {
+                "mode": {
+                    "description": "\"atomic\" (default) or \"best_effort\"",
+                    "type": "string"
+                },
+                "operations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.BatchIngredientOperation"
+                    }
+                }
+            }
+        },
+        "usecase.BatchIngredientsResponse": {
+            "type": "object",
+            "properties": {
+                "committed": {
+                    "type": "boolean"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "mode": {
+                    "type": "string"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.BatchIngredientResult"
+                    }
+                },
+                "succeeded": {
+                    "type": "integer"
+                }
+            }
+        },
+        "usecase.ChangePasswordRequest": {
+            "type": "object",
+            "required": [
+                "current_password",
+                "new_password"
+            ],
+            "properties": {
+                "current_password": {
+                    "type": "string"
+                },
+                "new_password": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.ConsumeIngredientRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "amount": {
+                    "description": "such as \"200g\" or \"1\"; the whole quantity when empty",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "\"cooked\", \"eaten\", \"discarded\" or \"expired\"",
+                    "type": "string"
+                },
+                "recipe": {
+                    "description": "name of the dish, for \"cooked\"",
+                    "type": "string"
+                },
+                "suggestion_id": {
+                    "description": "SuggestionID is the id of the recipe suggestion that was cooked; it requires the \"cooked\" reason and a suggestion made for the current household",
+                    "type": "integer"
+                }
+            }
+        },
+        "usecase.ConsumeIngredientResponse": {
+            "type": "object",
+            "properties": {
+                "consumption": {
+                    "$ref": "#/definitions/domain.Consumption"
+                },
+                "ingredient": {
+                    "description": "omitted when the ingredient was used up",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.Ingredient"
+                        }
+                    ]
+                }
+            }
+        },
+        "usecase.CreateAPIKeyRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "name": {
+                    "description": "what uses the key, such as 台所タブレット; up to 64 characters",
+                    "type": "string"
+                },
+                "scopes": {
+                    "description": "\"inventory:read\", \"inventory:write\", \"recipes:read\" and/or \"recipes:write\"",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "usecase.CreateAPIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "api_key": {
+                    "$ref": "#/definitions/domain.APIKey"
+                },
+                "key": {
+                    "description": "send as \"Authorization: Bearer \u003ckey\u003e\"",
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.CreateIngredientRequest": {
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "description": "JAN/EAN code of the packaged product",
+                    "type": "string"
+                },
+                "category": {
+                    "description": "detected from the name when empty",
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "YYYY-MM-DD format; derived from the product shelf life when empty",
+                    "type": "string"
+                },
+                "location": {
+                    "description": "\"fridge\", \"freezer\" or \"pantry\"; derived from the category when empty",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "filled in from the product table when a known barcode is given",
+                    "type": "string"
+                },
+                "price": {
+                    "description": "yen paid for the ingredient as bought",
+                    "type": "integer"
+                },
+                "purchase_date": {
+                    "description": "YYYY-MM-DD format",
+                    "type": "string"
+                },
+                "quantity": {
+                    "description": "defaults to the product unit",
+                    "type": "string"
+                },
+                "store": {
+                    "description": "where the ingredient was bought",
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.CreateInvitationRequest": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "description": "role given to the user who joins with the code; defaults to \"member\"",
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.DietaryRulesResponse": {
+            "type": "object",
+            "properties": {
+                "allergens": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Allergen"
+                    }
+                },
+                "restrictions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.DietaryRestriction"
+                    }
+                }
+            }
+        },
+        "usecase.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.ImportIngredientRow": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "\"create\", \"merge\", \"overwrite\", \"skip\" or \"error\"",
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "ingredient": {
+                    "$ref": "#/definitions/domain.Ingredient"
+                },
+                "line": {
+                    "description": "line number in a CSV file, or 1-based position in a JSON array",
+                    "type": "integer"
+                }
+            }
+        },
+        "usecase.ImportIngredientsRequest": {
+            "type": "object",
+            "required": [
+                "content"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "date_format": {
+                    "description": "e.g. \"YYYY/MM/DD\"; detected from the dates when empty",
+                    "type": "string"
+                },
+                "dry_run": {
+                    "description": "report what would happen without saving anything",
+                    "type": "boolean"
+                },
+                "format": {
+                    "description": "\"csv\" or \"json\"; detected from content when empty",
+                    "type": "string"
+                },
+                "mapping": {
+                    "description": "ingredient field -\u003e column name; detected from the column names when omitted",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "on_duplicate": {
+                    "description": "\"skip\" (default), \"merge\" or \"overwrite\"",
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.ImportIngredientsResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "date_format": {
+                    "description": "empty when the file has no dates",
+                    "type": "string"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "mapping": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "merged": {
+                    "type": "integer"
+                },
+                "overwritten": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.ImportIngredientRow"
+                    }
+                },
+                "skipped": {
+                    "type": "integer"
+                }
+            }
+        },
+        "usecase.ImportProductError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "line": {
+                    "type": "integer"
+                }
+            }
+        },
+        "usecase.ImportProductsRequest": {
+            "type": "object",
+            "required": [
+                "content"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "mapping": {
+                    "description": "product field to CSV column; detected from the header when omitted",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "overwrite_learned": {
+                    "description": "also replace products learned from user entries",
+                    "type": "boolean"
+                }
+            }
+        },
+        "usecase.ImportProductsResponse": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.ImportProductError"
+                    }
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "imported": {
+                    "type": "integer"
+                },
+                "mapping": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "usecase.ImportRecipesRequest": {
+            "type": "object",
+            "required": [
+                "content"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "format": {
+                    "description": "\"jsonld\" or \"html\"; detected from content when empty",
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.IngredientDraft": {
+            "type": "object",
             "properties": {
-                "missing_items": {
+                "barcode": {
+                    "description": "JAN/EAN code of the packaged product",
+                    "type": "string"
+                },
+                "category": {
+                    "description": "detected from the name when empty",
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "YYYY-MM-DD format; derived from the product shelf life when empty",
+                    "type": "string"
+                },
+                "line": {
+                    "description": "the text the draft was read from",
+                    "type": "string"
+                },
+                "location": {
+                    "description": "\"fridge\", \"freezer\" or \"pantry\"; derived from the category when empty",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "filled in from the product table when a known barcode is given",
+                    "type": "string"
+                },
+                "price": {
+                    "description": "yen paid for the ingredient as bought",
+                    "type": "integer"
+                },
+                "purchase_date": {
+                    "description": "YYYY-MM-DD format",
+                    "type": "string"
+                },
+                "quantity": {
+                    "description": "defaults to the product unit",
+                    "type": "string"
+                },
+                "store": {
+                    "description": "where the ingredient was bought",
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.IngredientDraftsResponse": {
+            "type": "object",
+            "properties": {
+                "drafts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.IngredientDraft"
+                    }
+                },
+                "ignored": {
+                    "description": "lines that were not read as food",
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "name": {
+                "purchase_date": {
+                    "description": "YYYY-MM-DD format; nil when no date was found",
                     "type": "string"
                 },
-                "steps": {
+                "used_llm": {
+                    "type": "boolean"
+                },
+                "warnings": {
                     "type": "array",
                     "items": {
                         "type": "string"
@@ -328,50 +4893,380 @@ const docTemplate = `{
                 }
             }
         },
-        "usecase.CreateIngredientRequest": {
+        "usecase.IngredientEventListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.IngredientEvent"
+                    }
+                },
+                "next_cursor": {
+                    "description": "empty on the last page",
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.IngredientListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Ingredient"
+                    }
+                },
+                "next_cursor": {
+                    "description": "empty on the last page",
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.InventorySnapshotResponse": {
+            "type": "object",
+            "properties": {
+                "at": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Ingredient"
+                    }
+                }
+            }
+        },
+        "usecase.JoinHouseholdRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.LoginRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "token": {
+                    "description": "send as \"Authorization: Bearer \u003ctoken\u003e\"",
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/domain.User"
+                }
+            }
+        },
+        "usecase.ParseReceiptRequest": {
+            "type": "object",
+            "required": [
+                "text"
+            ],
+            "properties": {
+                "text": {
+                    "description": "OCR'd receipt text",
+                    "type": "string"
+                },
+                "use_llm": {
+                    "description": "also ask the configured LLM to read the text",
+                    "type": "boolean"
+                }
+            }
+        },
+        "usecase.PurchaseToUseResponse": {
+            "type": "object",
+            "properties": {
+                "average_days": {
+                    "type": "number"
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.CategoryPurchaseToUse"
+                    }
+                },
+                "count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "usecase.QuickAddRequest": {
+            "type": "object",
+            "properties": {
+                "confirm": {
+                    "description": "save the drafts instead of only returning them",
+                    "type": "boolean"
+                },
+                "drafts": {
+                    "description": "drafts from an earlier preview, used instead of the text",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.CreateIngredientRequest"
+                    }
+                },
+                "llm": {
+                    "description": "\"auto\" (default), \"always\" or \"never\"",
+                    "type": "string"
+                },
+                "text": {
+                    "description": "e.g. \"卵10個と牛乳1本、昨日買った鶏もも300g\"; required unless drafts are given",
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.QuickAddResponse": {
+            "type": "object",
+            "properties": {
+                "committed": {
+                    "type": "boolean"
+                },
+                "drafts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.IngredientDraft"
+                    }
+                },
+                "ignored": {
+                    "description": "lines that were not read as food",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Ingredient"
+                    }
+                },
+                "purchase_date": {
+                    "description": "YYYY-MM-DD format; nil when no date was found",
+                    "type": "string"
+                },
+                "used_llm": {
+                    "type": "boolean"
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "usecase.RegisterRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "description": "8 to 72 bytes",
+                    "type": "string"
+                },
+                "username": {
+                    "description": "up to 64 characters without spaces",
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.ReindexResponse": {
+            "type": "object",
+            "properties": {
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "usecase.SaveDietaryProfileRequest": {
             "type": "object",
             "required": [
                 "name"
             ],
             "properties": {
-                "expiration_date": {
-                    "description": "YYYY-MM-DD format",
+                "allergens": {
+                    "description": "allergen codes or Japanese labels, such as \"egg\" or \"卵\"",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "dislikes": {
+                    "description": "ingredient names to avoid where possible",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "description": "household member the profile belongs to",
                     "type": "string"
                 },
+                "restrictions": {
+                    "description": "dietary restriction codes or Japanese labels",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "usecase.SaveHouseholdRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
                 "name": {
+                    "description": "up to 255 characters, such as 山田家",
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.SaveSynonymRequest": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "broader": {
+                    "description": "canonical name of a more general ingredient",
                     "type": "string"
                 },
-                "quantity": {
+                "category": {
+                    "description": "ingredient category used for auto-categorization",
                     "type": "string"
                 }
             }
         },
-        "usecase.ErrorResponse": {
+        "usecase.SpendReportResponse": {
             "type": "object",
             "properties": {
-                "error": {
+                "count": {
+                    "description": "priced purchases",
+                    "type": "integer"
+                },
+                "group_by": {
                     "type": "string"
                 },
-                "message": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.SpendTotal"
+                    }
+                },
+                "total": {
+                    "description": "yen",
+                    "type": "integer"
+                }
+            }
+        },
+        "usecase.UpdateHouseholdMemberRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "description": "\"owner\", \"member\" or \"viewer\"",
                     "type": "string"
                 }
             }
         },
         "usecase.UpdateIngredientRequest": {
             "type": "object",
+            "required": [
+                "name"
+            ],
             "properties": {
-                "expiration_date": {
+                "barcode": {
+                    "type": "string"
+                },
+                "category": {
+                    "description": "detected from the name when empty",
+                    "type": "string"
+                },
+                "expires_at": {
                     "description": "YYYY-MM-DD format",
                     "type": "string"
                 },
+                "location": {
+                    "description": "derived from the category when empty",
+                    "type": "string"
+                },
                 "name": {
                     "type": "string"
                 },
+                "price": {
+                    "description": "yen paid for the ingredient as bought",
+                    "type": "integer"
+                },
+                "purchase_date": {
+                    "description": "YYYY-MM-DD format",
+                    "type": "string"
+                },
                 "quantity": {
                     "type": "string"
+                },
+                "store": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.UsageStatsResponse": {
+            "type": "object",
+            "properties": {
+                "consumed": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.CategoryUsage"
+                    }
+                },
+                "waste_rate": {
+                    "description": "wasted / (consumed + wasted); 0 without any use",
+                    "type": "number"
+                },
+                "wasted": {
+                    "type": "integer"
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "\"Bearer \" followed by the token issued by POST /auth/login or an API key created with POST /admin/api-keys",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
     }
 }`
 
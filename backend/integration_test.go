@@ -89,18 +89,182 @@ func setupTestDB(t *testing.T) (*sqlx.DB, func()) {
 	CREATE TABLE IF NOT EXISTS ingredients (
 		id BIGINT AUTO_INCREMENT PRIMARY KEY,
 		name VARCHAR(255) NOT NULL,
+		canonical_name VARCHAR(255) NOT NULL DEFAULT '',
+		category VARCHAR(32) NOT NULL DEFAULT '',
+		location VARCHAR(32) NOT NULL DEFAULT 'fridge',
 		quantity VARCHAR(100),
-		expiration_date DATE,
+		barcode VARCHAR(14) NOT NULL DEFAULT '',
+		price INT NULL,
+		store VARCHAR(255) NOT NULL DEFAULT '',
+		purchase_date DATE,
+		expires_at DATE NULL,
+		version BIGINT NOT NULL DEFAULT 1,
 		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+		deleted_at TIMESTAMP NULL DEFAULT NULL,
+		owner_id BIGINT NULL,
+		household_id BIGINT NULL,
 		INDEX idx_name (name),
-		INDEX idx_expiration_date (expiration_date)
+		INDEX idx_canonical_name (canonical_name),
+		INDEX idx_purchase_date (purchase_date),
+		INDEX idx_barcode (barcode),
+		INDEX idx_deleted_at (deleted_at)
 	);`
 
-	if _, err := db.Exec(schema); err != nil {
-		database.Close(db)
-		mysqlContainer.Terminate(ctx)
-		t.Fatalf("Failed to create schema: %v", err)
+	productSchema := `
+	CREATE TABLE IF NOT EXISTS products (
+		barcode VARCHAR(14) PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		category VARCHAR(32) NOT NULL DEFAULT 'other',
+		unit VARCHAR(100) NOT NULL DEFAULT '',
+		shelf_life_days INT NULL,
+		source VARCHAR(16) NOT NULL DEFAULT 'import',
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+		INDEX idx_name (name)
+	);`
+
+	eventSchema := `
+	CREATE TABLE IF NOT EXISTS ingredient_events (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		ingredient_id BIGINT NOT NULL,
+		action VARCHAR(16) NOT NULL,
+		actor VARCHAR(255) NOT NULL DEFAULT '',
+		source VARCHAR(16) NOT NULL DEFAULT 'api',
+		before_value JSON NULL,
+		after_value JSON NULL,
+		created_at TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		household_id BIGINT NULL,
+		INDEX idx_ingredient_id (ingredient_id, id),
+		INDEX idx_created_at (created_at),
+		INDEX idx_actor (actor)
+	);`
+
+	consumptionSchema := `
+	CREATE TABLE IF NOT EXISTS ingredient_consumptions (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		ingredient_id BIGINT NOT NULL,
+		name VARCHAR(255) NOT NULL,
+		canonical_name VARCHAR(255) NOT NULL DEFAULT '',
+		category VARCHAR(32) NOT NULL DEFAULT 'other',
+		amount VARCHAR(100) NOT NULL DEFAULT '',
+		remaining VARCHAR(100) NOT NULL DEFAULT '',
+		used_up BOOLEAN NOT NULL DEFAULT FALSE,
+		reason VARCHAR(16) NOT NULL,
+		recipe VARCHAR(255) NOT NULL DEFAULT '',
+		suggestion_id BIGINT NULL,
+		cost INT NULL,
+		purchase_date DATE NULL,
+		actor VARCHAR(255) NOT NULL DEFAULT '',
+		created_at TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		household_id BIGINT NULL,
+		INDEX idx_ingredient_id (ingredient_id, id),
+		INDEX idx_reason_created_at (reason, created_at),
+		INDEX idx_canonical_name (canonical_name),
+		INDEX idx_suggestion_id (suggestion_id)
+	);`
+
+	suggestionSchema := `
+	CREATE TABLE IF NOT EXISTS recipe_suggestions (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		source VARCHAR(16) NOT NULL,
+		created_at TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		household_id BIGINT NULL,
+		INDEX idx_created_at (created_at)
+	);`
+
+	purchaseSchema := `
+	CREATE TABLE IF NOT EXISTS ingredient_purchases (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		ingredient_id BIGINT NOT NULL,
+		name VARCHAR(255) NOT NULL,
+		category VARCHAR(32) NOT NULL DEFAULT 'other',
+		store VARCHAR(255) NOT NULL DEFAULT '',
+		price INT NOT NULL,
+		purchased_on DATE NOT NULL,
+		household_id BIGINT NULL,
+		UNIQUE KEY uk_ingredient_id (ingredient_id),
+		INDEX idx_purchased_on (purchased_on)
+	);`
+
+	dietarySchema := `
+	CREATE TABLE IF NOT EXISTS dietary_profiles (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		allergens JSON NOT NULL,
+		restrictions JSON NOT NULL,
+		dislikes JSON NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+		owner_id BIGINT NULL,
+		household_id BIGINT NULL
+	);`
+
+	userSchema := `
+	CREATE TABLE IF NOT EXISTS users (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		username VARCHAR(64) NOT NULL,
+		password_hash VARCHAR(255) NOT NULL,
+		is_admin BOOLEAN NOT NULL DEFAULT FALSE,
+		session_version INT NOT NULL DEFAULT 0,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+		UNIQUE KEY uk_username (username)
+	);`
+
+	householdSchema := `
+	CREATE TABLE IF NOT EXISTS households (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+	);`
+
+	householdMemberSchema := `
+	CREATE TABLE IF NOT EXISTS household_members (
+		household_id BIGINT NOT NULL,
+		user_id BIGINT NOT NULL,
+		role VARCHAR(16) NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (household_id, user_id),
+		INDEX idx_user_id (user_id)
+	);`
+
+	invitationSchema := `
+	CREATE TABLE IF NOT EXISTS household_invitations (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		household_id BIGINT NOT NULL,
+		code VARCHAR(32) NOT NULL,
+		role VARCHAR(16) NOT NULL,
+		created_by BIGINT NULL,
+		expires_at TIMESTAMP NOT NULL,
+		used_by BIGINT NULL,
+		used_at TIMESTAMP NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE KEY uk_code (code)
+	);`
+
+	apiKeySchema := `
+	CREATE TABLE IF NOT EXISTS api_keys (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		name VARCHAR(64) NOT NULL,
+		prefix VARCHAR(16) NOT NULL,
+		key_hash CHAR(64) NOT NULL,
+		scopes JSON NOT NULL,
+		user_id BIGINT NOT NULL,
+		household_id BIGINT NOT NULL,
+		last_used_at TIMESTAMP NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE KEY uk_key_hash (key_hash)
+	);`
+
+	for _, statement := range []string{schema, productSchema, eventSchema, consumptionSchema, suggestionSchema, purchaseSchema, dietarySchema, userSchema, householdSchema, householdMemberSchema, invitationSchema, apiKeySchema} {
+		if _, err := db.Exec(statement); err != nil {
+			database.Close(db)
+			mysqlContainer.Terminate(ctx)
+			t.Fatalf("Failed to create schema: %v", err)
+		}
 	}
 
 	// Return cleanup function
@@ -120,6 +284,15 @@ func setupTestRouter(db *sqlx.DB) *gin.Engine {
 
 	// Initialize dependencies
 	ingredientRepo := repository.NewIngredientRepository(db)
+	productRepo := repository.NewProductRepository(db)
+	ingredientEventRepo := repository.NewIngredientEventRepository(db)
+	consumptionRepo := repository.NewConsumptionRepository(db)
+	suggestionRepo := repository.NewSuggestionRepository(db)
+	statsRepo := repository.NewStatsRepository(db)
+	dietaryProfileRepo := repository.NewDietaryProfileRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	householdRepo := repository.NewHouseholdRepository(db)
+	apiKeyRepo := repository.NewAPIKeyRepository(db)
 
 	// Mock Ollama service for testing
 	timeout, _ := time.ParseDuration("30s")
@@ -130,35 +303,159 @@ func setupTestRouter(db *sqlx.DB) *gin.Engine {
 	}
 	ollamaService := service.NewOllamaService(ollamaConfig)
 
-	ingredientUsecase := usecase.NewIngredientUsecase(ingredientRepo)
-	recipeUsecase := usecase.NewRecipeUsecase(ingredientRepo, ollamaService)
+	dictionary, _ := service.DefaultDictionary()
+	ingredientNormalizer := service.NewIngredientNormalizer(dictionary)
+	ingredientMatcher := service.NewIngredientMatcher(ingredientNormalizer)
+	nutritionTable, _ := service.NewNutritionTable(&config.NutritionConfig{}, ingredientNormalizer)
+	dietaryChecker, _ := service.NewDietaryChecker(&config.DietaryConfig{}, ingredientNormalizer)
+	tokenService, _ := service.NewTokenService(&config.AuthConfig{})
+
+	ingredientUsecase := usecase.NewIngredientUsecase(ingredientRepo, productRepo, ingredientNormalizer, service.NewInventoryCodec(), nutritionTable)
+	recipeUsecase := usecase.NewRecipeUsecase(ingredientRepo, nil, suggestionRepo, dietaryProfileRepo, ollamaService, nil, ingredientMatcher, dietaryChecker, nutritionTable)
+	intakeUsecase := usecase.NewIntakeUsecase(ingredientUsecase, ingredientNormalizer, service.NewIngredientTextParser(), ollamaService)
+	productUsecase := usecase.NewProductUsecase(productRepo, ingredientNormalizer, service.NewInventoryCodec())
+	historyUsecase := usecase.NewHistoryUsecase(ingredientEventRepo)
+	consumptionUsecase := usecase.NewConsumptionUsecase(ingredientRepo, consumptionRepo, suggestionRepo)
+	statsUsecase := usecase.NewStatsUsecase(statsRepo)
+	dietaryUsecase := usecase.NewDietaryUsecase(dietaryProfileRepo)
+	householdUsecase := usecase.NewHouseholdUsecase(householdRepo)
+	userUsecase := usecase.NewUserUsecase(userRepo, householdRepo, tokenService, false)
+	apiKeyUsecase := usecase.NewAPIKeyUsecase(apiKeyRepo, userRepo)
 
 	ingredientHandler := handler.NewIngredientHandler(ingredientUsecase)
 	recipeHandler := handler.NewRecipeHandler(recipeUsecase)
+	intakeHandler := handler.NewIntakeHandler(intakeUsecase)
+	productHandler := handler.NewProductHandler(productUsecase)
+	historyHandler := handler.NewHistoryHandler(historyUsecase)
+	consumptionHandler := handler.NewConsumptionHandler(consumptionUsecase)
+	statsHandler := handler.NewStatsHandler(statsUsecase)
+	dietaryHandler := handler.NewDietaryHandler(dietaryUsecase)
+	userHandler := handler.NewUserHandler(userUsecase)
+	householdHandler := handler.NewHouseholdHandler(householdUsecase)
+	apiKeyHandler := handler.NewAPIKeyHandler(apiKeyUsecase)
+
+	// The first user registers freely; requests without credentials are signed in as this user
+	session, err := userUsecase.Register(context.Background(), usecase.RegisterRequest{Username: "tester", Password: "tester-password"})
+	if err != nil {
+		panic(fmt.Sprintf("failed to register test user: %v", err))
+	}
+	if _, err := householdUsecase.CreateHousehold(domain.WithUser(context.Background(), session.User), usecase.SaveHouseholdRequest{Name: "テスト世帯"}); err != nil {
+		panic(fmt.Sprintf("failed to create test household: %v", err))
+	}
+	requireHousehold := handler.RequireHousehold(householdUsecase)
 	healthHandler := handler.NewHealthHandler(db, ollamaService)
 
 	// Setup router
 	router := gin.New()
 	router.Use(gin.Recovery())
+	router.Use(handler.EventOrigin())
 
 	router.GET("/health", healthHandler.Health)
 	router.GET("/health/db", healthHandler.HealthDB)
 
-	api := router.Group("/api")
+	router.Use(func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			c.Request.Header.Set("Authorization", "Bearer "+session.Token)
+		}
+	})
+
+	auth := router.Group("/api/auth")
+	{
+		auth.POST("/register", userHandler.Register)
+		auth.POST("/login", userHandler.Login)
+	}
+
+	inventoryScope := handler.RequireScope(domain.APIKeyScopeInventoryRead, domain.APIKeyScopeInventoryWrite)
+	suggestionScope := handler.RequireScope(domain.APIKeyScopeRecipesRead, domain.APIKeyScopeRecipesRead)
+	requireSession := handler.RequireSession()
+
+	api := router.Group("/api", handler.RequireUser(userUsecase, apiKeyUsecase))
 	{
-		ingredients := api.Group("/ingredients")
+		account := api.Group("/auth", requireSession)
+		{
+			account.GET("/me", userHandler.Me)
+			account.PUT("/password", userHandler.ChangePassword)
+		}
+
+		users := api.Group("/users", requireSession, requireHousehold)
+		{
+			users.GET("", userHandler.ListUsers)
+			users.POST("", userHandler.CreateUser)
+		}
+
+		households := api.Group("/households", requireSession)
+		{
+			households.GET("", householdHandler.ListHouseholds)
+			households.POST("", householdHandler.CreateHousehold)
+			households.POST("/join", householdHandler.JoinHousehold)
+			households.PUT("/:id", householdHandler.UpdateHousehold)
+			households.GET("/:id/members", householdHandler.ListMembers)
+			households.PUT("/:id/members/:user_id", householdHandler.UpdateMember)
+			households.DELETE("/:id/members/:user_id", householdHandler.RemoveMember)
+			households.GET("/:id/invitations", householdHandler.ListInvitations)
+			households.POST("/:id/invitations", householdHandler.CreateInvitation)
+			households.DELETE("/:id/invitations/:invitation_id", householdHandler.RevokeInvitation)
+		}
+
+		ingredients := api.Group("/ingredients", inventoryScope, requireHousehold)
 		{
 			ingredients.POST("", ingredientHandler.CreateIngredient)
+			ingredients.POST("/batch", ingredientHandler.BatchIngredients)
+			ingredients.POST("/import", ingredientHandler.ImportIngredients)
+			ingredients.GET("/export", ingredientHandler.ExportIngredients)
+			ingredients.POST("/parse-receipt", intakeHandler.ParseReceipt)
+			ingredients.POST("/quick-add", intakeHandler.QuickAdd)
+			ingredients.GET("/trash", ingredientHandler.GetTrash)
+			ingredients.GET("/activity", historyHandler.GetActivity)
+			ingredients.GET("/snapshot", historyHandler.GetInventorySnapshot)
 			ingredients.GET("", ingredientHandler.GetAllIngredients)
 			ingredients.GET("/:id", ingredientHandler.GetIngredientByID)
 			ingredients.PUT("/:id", ingredientHandler.UpdateIngredient)
+			ingredients.PATCH("/:id", ingredientHandler.PatchIngredient)
 			ingredients.DELETE("/:id", ingredientHandler.DeleteIngredient)
+			ingredients.POST("/:id/restore", ingredientHandler.RestoreIngredient)
+			ingredients.GET("/:id/history", historyHandler.GetIngredientHistory)
+			ingredients.POST("/:id/consume", consumptionHandler.ConsumeIngredient)
+			ingredients.GET("/:id/consumptions", consumptionHandler.GetConsumptions)
+		}
+
+		products := api.Group("/products", inventoryScope, requireHousehold)
+		{
+			products.POST("/import", productHandler.ImportProducts)
+			products.GET("/:barcode", productHandler.GetProduct)
 		}
 
-		recipes := api.Group("/recipes")
+		recipes := api.Group("/recipes", suggestionScope, requireHousehold)
 		{
 			recipes.POST("/suggestion", recipeHandler.GetRecipeSuggestion)
 		}
+
+		stats := api.Group("/stats", inventoryScope, requireHousehold)
+		{
+			stats.GET("/usage", statsHandler.GetUsage)
+			stats.GET("/purchase-to-use", statsHandler.GetPurchaseToUse)
+			stats.GET("/most-wasted", statsHandler.GetMostWasted)
+			stats.GET("/suggestions", statsHandler.GetSuggestionStats)
+			stats.GET("/spend", statsHandler.GetSpend)
+			stats.GET("/recipe-costs", statsHandler.GetRecipeCosts)
+		}
+
+		dietary := api.Group("/dietary", requireSession, requireHousehold)
+		{
+			dietary.GET("/rules", dietaryHandler.ListRules)
+			dietary.GET("/profiles", dietaryHandler.ListProfiles)
+			dietary.POST("/profiles", dietaryHandler.CreateProfile)
+			dietary.GET("/profiles/:id", dietaryHandler.GetProfile)
+			dietary.PUT("/profiles/:id", dietaryHandler.UpdateProfile)
+			dietary.DELETE("/profiles/:id", dietaryHandler.DeleteProfile)
+		}
+
+		apiKeys := api.Group("/admin/api-keys", requireSession, requireHousehold)
+		{
+			apiKeys.GET("", apiKeyHandler.ListAPIKeys)
+			apiKeys.POST("", apiKeyHandler.CreateAPIKey)
+			apiKeys.DELETE("/:id", apiKeyHandler.RevokeAPIKey)
+		}
 	}
 
 	return router
@@ -206,13 +503,16 @@ func TestIngredientCRUDFlow(t *testing.T) {
 		}
 		assert.Equal(t, http.StatusOK, w.Code)
 
-		var ingredients []*domain.Ingredient
-		if err := json.Unmarshal(w.Body.Bytes(), &ingredients); err != nil {
+		var page usecase.IngredientListResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
 			t.Fatalf("Failed to unmarshal response: %v", err)
 		}
 
-		if len(ingredients) == 0 {
-			t.Error("Expected at least one ingredient")
+		if len(page.Items) == 0 {
+			t.Fatal("Expected at least one ingredient")
+		}
+		if page.Items[0].Nutrition == nil || page.Items[0].Nutrition.Total == nil {
+			t.Errorf("Expected nutrition for 2本 of にんじん, got %+v", page.Items[0].Nutrition)
 		}
 	})
 
@@ -233,8 +533,9 @@ func TestIngredientCRUDFlow(t *testing.T) {
 		err := json.Unmarshal(w.Body.Bytes(), &created)
 		assert.NoError(t, err)
 
-		// Update the ingredient
+		// Replace the ingredient
 		updateBody := map[string]interface{}{
+			"name":     "豚バラ肉",
 			"quantity": "300g",
 		}
 		body, _ = json.Marshal(updateBody)
@@ -250,6 +551,31 @@ func TestIngredientCRUDFlow(t *testing.T) {
 		err = json.Unmarshal(w.Body.Bytes(), &updated)
 		assert.NoError(t, err)
 		assert.Equal(t, "300g", updated.Quantity)
+
+		// Patch the ingredient, clearing the quantity with an explicit null
+		req = httptest.NewRequest(http.MethodPatch, fmt.Sprintf("/api/ingredients/%d", created.ID),
+			bytes.NewBufferString(`{"quantity": null, "purchase_date": "2025-12-01"}`))
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+		w = httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code, "Body: %s", w.Body.String())
+
+		var patched domain.Ingredient
+		err = json.Unmarshal(w.Body.Bytes(), &patched)
+		assert.NoError(t, err)
+		assert.Equal(t, "豚バラ肉", patched.Name)
+		assert.Empty(t, patched.Quantity)
+		assert.NotNil(t, patched.PurchaseDate)
+
+		// Read it back through the single-item endpoint
+		req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/ingredients/%d", created.ID), nil)
+		w = httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
 	})
 
 	// Test 4: Delete ingredient
@@ -280,6 +606,691 @@ func TestIngredientCRUDFlow(t *testing.T) {
 		w = httptest.NewRecorder()
 		router.ServeHTTP(w, req)
 		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		// It waits in the trash
+		req = httptest.NewRequest(http.MethodGet, "/api/ingredients/trash", nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var trash []domain.Ingredient
+		err = json.Unmarshal(w.Body.Bytes(), &trash)
+		assert.NoError(t, err)
+		assert.Len(t, trash, 1)
+		assert.Equal(t, created.ID, trash[0].ID)
+		assert.NotNil(t, trash[0].DeletedAt)
+
+		// Restore it and read it back
+		req = httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/ingredients/%d/restore", created.ID), nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/ingredients/%d", created.ID), nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		// Restoring twice finds nothing in the trash
+		req = httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/ingredients/%d/restore", created.ID), nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	// Test 4b: Change history
+	t.Run("Ingredient History", func(t *testing.T) {
+		// The test user buys milk and finishes it
+		body := []byte(`{"name": "牛乳", "quantity": "1本"}`)
+		req := httptest.NewRequest(http.MethodPost, "/api/ingredients", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var created domain.Ingredient
+		err := json.Unmarshal(w.Body.Bytes(), &created)
+		assert.NoError(t, err)
+
+		// The X-Actor header cannot claim someone else made the change
+		req = httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/ingredients/%d", created.ID), nil)
+		req.Header.Set("X-Actor", "bob")
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		// The history lists both changes, newest first
+		req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/ingredients/%d/history", created.ID), nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var history usecase.IngredientEventListResponse
+		err = json.Unmarshal(w.Body.Bytes(), &history)
+		assert.NoError(t, err)
+		if assert.Len(t, history.Items, 2) {
+			assert.Equal(t, domain.EventActionDelete, history.Items[0].Action)
+			assert.Equal(t, "tester", history.Items[0].Actor)
+			assert.Equal(t, "1本", history.Items[0].Before.Quantity)
+			assert.Nil(t, history.Items[0].After)
+			assert.Equal(t, domain.EventActionCreate, history.Items[1].Action)
+			assert.Equal(t, "tester", history.Items[1].Actor)
+			assert.Equal(t, domain.EventSourceAPI, history.Items[1].Source)
+		}
+
+		// The activity feed answers who finished the milk
+		req = httptest.NewRequest(http.MethodGet, "/api/ingredients/activity?action=delete&actor=tester", nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var activity usecase.IngredientEventListResponse
+		err = json.Unmarshal(w.Body.Bytes(), &activity)
+		assert.NoError(t, err)
+		if assert.NotEmpty(t, activity.Items) {
+			assert.Equal(t, created.ID, activity.Items[0].IngredientID)
+		}
+
+		// The milk is no longer in today's reconstructed inventory
+		req = httptest.NewRequest(http.MethodGet, "/api/ingredients/snapshot?at="+time.Now().UTC().Add(time.Minute).Format(time.RFC3339), nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var snapshot usecase.InventorySnapshotResponse
+		err = json.Unmarshal(w.Body.Bytes(), &snapshot)
+		assert.NoError(t, err)
+		for _, ingredient := range snapshot.Items {
+			assert.NotEqual(t, created.ID, ingredient.ID)
+		}
+	})
+
+	// Test 4c: Consumption
+	t.Run("Consume Ingredient", func(t *testing.T) {
+		body := []byte(`{"name": "豚バラ肉", "quantity": "1kg", "price": 1000, "store": "スーパーA"}`)
+		req := httptest.NewRequest(http.MethodPost, "/api/ingredients", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var created domain.Ingredient
+		err := json.Unmarshal(w.Body.Bytes(), &created)
+		assert.NoError(t, err)
+
+		// Using part of it keeps the rest
+		body = []byte(`{"amount": "200g", "reason": "cooked", "recipe": "豚汁"}`)
+		req = httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/ingredients/%d/consume", created.ID), bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var partial usecase.ConsumeIngredientResponse
+		err = json.Unmarshal(w.Body.Bytes(), &partial)
+		assert.NoError(t, err)
+		assert.Equal(t, "0.8kg", partial.Ingredient.Quantity)
+		if assert.NotNil(t, partial.Consumption.Cost) {
+			assert.Equal(t, 200, *partial.Consumption.Cost)
+		}
+
+		// Throwing away the rest removes it
+		body = []byte(`{"reason": "expired"}`)
+		req = httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/ingredients/%d/consume", created.ID), bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/ingredients/%d", created.ID), nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/ingredients/%d/consumptions", created.ID), nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var consumptions []domain.Consumption
+		err = json.Unmarshal(w.Body.Bytes(), &consumptions)
+		assert.NoError(t, err)
+		if assert.Len(t, consumptions, 2) {
+			assert.Equal(t, domain.ConsumptionReasonExpired, consumptions[0].Reason)
+			assert.Equal(t, "0.8kg", consumptions[0].Amount)
+			assert.True(t, consumptions[0].UsedUp)
+			assert.Equal(t, "豚汁", consumptions[1].Recipe)
+		}
+
+		// The thrown away pork shows up in the waste statistics
+		req = httptest.NewRequest(http.MethodGet, "/api/stats/usage", nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var usage usecase.UsageStatsResponse
+		err = json.Unmarshal(w.Body.Bytes(), &usage)
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, usage.Consumed, 1)
+		assert.GreaterOrEqual(t, usage.Wasted, 1)
+
+		req = httptest.NewRequest(http.MethodGet, "/api/stats/most-wasted", nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var wasted []domain.WastedIngredient
+		err = json.Unmarshal(w.Body.Bytes(), &wasted)
+		assert.NoError(t, err)
+		var names []string
+		for _, ingredient := range wasted {
+			names = append(names, ingredient.CanonicalName)
+		}
+		assert.Contains(t, names, "豚バラ肉")
+
+		// The price counts towards the store's spend and the cooked share towards the recipe
+		req = httptest.NewRequest(http.MethodGet, "/api/stats/spend?group_by=store", nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var spend usecase.SpendReportResponse
+		err = json.Unmarshal(w.Body.Bytes(), &spend)
+		assert.NoError(t, err)
+		assert.Contains(t, spend.Items, &domain.SpendTotal{Key: "スーパーA", Total: 1000, Count: 1})
+
+		req = httptest.NewRequest(http.MethodGet, "/api/stats/recipe-costs", nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var costs []domain.RecipeCost
+		err = json.Unmarshal(w.Body.Bytes(), &costs)
+		assert.NoError(t, err)
+		if assert.NotEmpty(t, costs) {
+			assert.Equal(t, "豚汁", costs[0].Recipe)
+			assert.Equal(t, 200, costs[0].TotalCost)
+		}
+	})
+
+	// Test 5: Batch operations
+	t.Run("Batch Ingredients", func(t *testing.T) {
+		// An atomic batch with a failing operation writes nothing
+		body := []byte(`{"operations": [{"op": "create", "ingredient": {"name": "ごぼう"}}, {"op": "delete", "id": 99999}]}`)
+		req := httptest.NewRequest(http.MethodPost, "/api/ingredients/batch", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		var result usecase.BatchIngredientsResponse
+		err := json.Unmarshal(w.Body.Bytes(), &result)
+		assert.NoError(t, err)
+		assert.False(t, result.Committed)
+		assert.Equal(t, usecase.BatchStatusRolledBack, result.Results[0].Status)
+
+		// The same batch in best-effort mode keeps the create
+		body = []byte(`{"mode": "best_effort", "operations": [{"op": "create", "ingredient": {"name": "ごぼう"}}, {"op": "delete", "id": 99999}]}`)
+		req = httptest.NewRequest(http.MethodPost, "/api/ingredients/batch", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w = httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		result = usecase.BatchIngredientsResponse{}
+		err = json.Unmarshal(w.Body.Bytes(), &result)
+		assert.NoError(t, err)
+		assert.True(t, result.Committed)
+		assert.Equal(t, usecase.BatchStatusSucceeded, result.Results[0].Status)
+		assert.Equal(t, usecase.BatchStatusFailed, result.Results[1].Status)
+
+		req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/ingredients/%d", result.Results[0].ID), nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	// Test 6: Import and export
+	t.Run("Import And Export Ingredients", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/ingredients/import?on_duplicate=merge",
+			bytes.NewBufferString("品名,数量,賞味期限\nれんこん,1節,2026/01/15\nレンコン,2節,\n"))
+		req.Header.Set("Content-Type", "text/csv")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var result usecase.ImportIngredientsResponse
+		err := json.Unmarshal(w.Body.Bytes(), &result)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result.Created)
+		assert.Equal(t, 1, result.Merged)
+
+		req = httptest.NewRequest(http.MethodGet, "/api/ingredients/export?format=csv", nil)
+		w = httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "3節,,2026-01-15")
+	})
+
+	// Test 7: Parse receipt
+	t.Run("Parse Receipt", func(t *testing.T) {
+		reqBody := map[string]interface{}{
+			"text": "2026年10月17日\n*ﾀﾏﾈｷﾞ 3ｺ入 ¥298\nﾃｨｯｼｭ ¥198\n合計 ¥496",
+		}
+		body, _ := json.Marshal(reqBody)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/ingredients/parse-receipt", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var result usecase.IngredientDraftsResponse
+		err := json.Unmarshal(w.Body.Bytes(), &result)
+		assert.NoError(t, err)
+		assert.Equal(t, "2026-10-17", *result.PurchaseDate)
+		assert.Len(t, result.Drafts, 1)
+		assert.Equal(t, "タマネギ", result.Drafts[0].Name)
+		assert.Equal(t, "3個", result.Drafts[0].Quantity)
+	})
+
+	// Test 8: Quick add
+	t.Run("Quick Add Ingredients", func(t *testing.T) {
+		reqBody := map[string]interface{}{
+			"text":    "卵10個と牛乳1本、昨日買った鶏もも300g",
+			"llm":     "never",
+			"confirm": true,
+		}
+		body, _ := json.Marshal(reqBody)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/ingredients/quick-add", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var result usecase.QuickAddResponse
+		err := json.Unmarshal(w.Body.Bytes(), &result)
+		assert.NoError(t, err)
+		assert.True(t, result.Committed)
+		assert.Len(t, result.Ingredients, 3)
+		assert.Equal(t, "鶏もも", result.Ingredients[2].Name)
+		assert.Equal(t, "300g", result.Ingredients[2].Quantity)
+		assert.NotNil(t, result.Ingredients[2].PurchaseDate)
+	})
+
+	t.Run("Scan Barcodes", func(t *testing.T) {
+		// First scan of an unknown product: the user names it and the product is learned
+		body, _ := json.Marshal(map[string]interface{}{
+			"name":     "納豆",
+			"barcode":  "4901234567894",
+			"quantity": "3パック",
+		})
+		req := httptest.NewRequest(http.MethodPost, "/api/ingredients", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		req = httptest.NewRequest(http.MethodGet, "/api/products/4901234567894", nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var product domain.Product
+		err := json.Unmarshal(w.Body.Bytes(), &product)
+		assert.NoError(t, err)
+		assert.Equal(t, "納豆", product.Name)
+		assert.Equal(t, domain.ProductSourceLearned, product.Source)
+
+		// Later scans fill in the ingredient from the barcode alone
+		body, _ = json.Marshal(map[string]interface{}{"barcode": "4901234567894"})
+		req = httptest.NewRequest(http.MethodPost, "/api/ingredients", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var ingredient domain.Ingredient
+		err = json.Unmarshal(w.Body.Bytes(), &ingredient)
+		assert.NoError(t, err)
+		assert.Equal(t, "納豆", ingredient.Name)
+		assert.Equal(t, "3パック", ingredient.Quantity)
+
+		// Imported products are found as well
+		req = httptest.NewRequest(http.MethodPost, "/api/products/import", bytes.NewBufferString("JANコード,商品名,内容量,賞味期間\n49021028,牛乳,1000ml,10\n"))
+		req.Header.Set("Content-Type", "text/csv")
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		req = httptest.NewRequest(http.MethodGet, "/api/products/49021028", nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Dietary Profiles", func(t *testing.T) {
+		// Japanese labels are stored as codes
+		body, _ := json.Marshal(map[string]interface{}{
+			"name":         "花子",
+			"allergens":    []string{"卵", "shrimp"},
+			"restrictions": []string{"vegetarian"},
+			"dislikes":     []string{"セロリ"},
+		})
+		req := httptest.NewRequest(http.MethodPost, "/api/dietary/profiles", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var created domain.DietaryProfile
+		err := json.Unmarshal(w.Body.Bytes(), &created)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{domain.AllergenEgg, domain.AllergenShrimp}, created.Allergens)
+
+		req = httptest.NewRequest(http.MethodGet, "/api/dietary/profiles", nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var profiles []domain.DietaryProfile
+		err = json.Unmarshal(w.Body.Bytes(), &profiles)
+		assert.NoError(t, err)
+		if assert.Len(t, profiles, 1) {
+			assert.Equal(t, []string{domain.RestrictionVegetarian}, profiles[0].Restrictions)
+			assert.Equal(t, []string{"セロリ"}, profiles[0].Dislikes)
+		}
+
+		// Unknown allergens are rejected
+		body, _ = json.Marshal(map[string]interface{}{"name": "太郎", "allergens": []string{"chocolate"}})
+		req = httptest.NewRequest(http.MethodPost, "/api/dietary/profiles", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Users and Ownership", func(t *testing.T) {
+		// Invalid tokens are rejected
+		req := httptest.NewRequest(http.MethodGet, "/api/ingredients", nil)
+		req.Header.Set("Authorization", "Bearer invalid")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		// Registration is closed once the first user exists
+		body, _ := json.Marshal(map[string]string{"username": "taro", "password": "taro-password"})
+		req = httptest.NewRequest(http.MethodPost, "/api/auth/register", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		// The administrator (the first user) creates the account instead
+		req = httptest.NewRequest(http.MethodPost, "/api/users", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		req = httptest.NewRequest(http.MethodPost, "/api/auth/login", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var session usecase.LoginResponse
+		err := json.Unmarshal(w.Body.Bytes(), &session)
+		assert.NoError(t, err)
+		assert.Equal(t, "taro", session.User.Username)
+
+		// Changing the password revokes the tokens issued before and returns a fresh one
+		body, _ = json.Marshal(map[string]string{"current_password": "taro-password", "new_password": "taro-new-password"})
+		req = httptest.NewRequest(http.MethodPut, "/api/auth/password", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+session.Token)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		revoked := session.Token
+		err = json.Unmarshal(w.Body.Bytes(), &session)
+		assert.NoError(t, err)
+
+		req = httptest.NewRequest(http.MethodGet, "/api/auth/me", nil)
+		req.Header.Set("Authorization", "Bearer "+revoked)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		req = httptest.NewRequest(http.MethodGet, "/api/auth/me", nil)
+		req.Header.Set("Authorization", "Bearer "+session.Token)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		// The test user invites the new account into their household as a member
+		req = httptest.NewRequest(http.MethodGet, "/api/households", nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var households []domain.Household
+		err = json.Unmarshal(w.Body.Bytes(), &households)
+		assert.NoError(t, err)
+		assert.Len(t, households, 1)
+
+		req = httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/households/%d/invitations", households[0].ID), nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var invitation domain.HouseholdInvitation
+		err = json.Unmarshal(w.Body.Bytes(), &invitation)
+		assert.NoError(t, err)
+
+		body, _ = json.Marshal(map[string]string{"code": invitation.Code})
+		req = httptest.NewRequest(http.MethodPost, "/api/households/join", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+session.Token)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		// The invitation code is used up
+		req = httptest.NewRequest(http.MethodPost, "/api/households/join", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+session.Token)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		// The test user registers an ingredient and owns it
+		body, _ = json.Marshal(map[string]string{"name": "かぼちゃ"})
+		req = httptest.NewRequest(http.MethodPost, "/api/ingredients", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var created domain.Ingredient
+		err = json.Unmarshal(w.Body.Bytes(), &created)
+		assert.NoError(t, err)
+		assert.NotNil(t, created.OwnerID)
+
+		// Another member of the household can read it but not delete it
+		req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/ingredients/%d", created.ID), nil)
+		req.Header.Set("Authorization", "Bearer "+session.Token)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		req = httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/ingredients/%d", created.ID), nil)
+		req.Header.Set("Authorization", "Bearer "+session.Token)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		// Changes are recorded as made by the signed-in user
+		req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/ingredients/%d/history", created.ID), nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"actor":"tester"`)
+	})
+
+	t.Run("API Keys", func(t *testing.T) {
+		body, _ := json.Marshal(map[string]interface{}{"name": "台所タブレット", "scopes": []string{"inventory:read"}})
+		req := httptest.NewRequest(http.MethodPost, "/api/admin/api-keys", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var created usecase.CreateAPIKeyResponse
+		err := json.Unmarshal(w.Body.Bytes(), &created)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, created.Key)
+
+		// The key reads the inventory of its household
+		req = httptest.NewRequest(http.MethodGet, "/api/ingredients", nil)
+		req.Header.Set("Authorization", "Bearer "+created.Key)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		// but cannot change it, ask for recipes or manage accounts
+		body, _ = json.Marshal(map[string]string{"name": "れんこん"})
+		req = httptest.NewRequest(http.MethodPost, "/api/ingredients", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+created.Key)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		req = httptest.NewRequest(http.MethodGet, "/api/dietary/profiles", nil)
+		req.Header.Set("Authorization", "Bearer "+created.Key)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		req = httptest.NewRequest(http.MethodGet, "/api/admin/api-keys", nil)
+		req.Header.Set("Authorization", "Bearer "+created.Key)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		// The list shows when the key was last used but never the key itself
+		req = httptest.NewRequest(http.MethodGet, "/api/admin/api-keys", nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), created.Key)
+
+		var keys []domain.APIKey
+		err = json.Unmarshal(w.Body.Bytes(), &keys)
+		assert.NoError(t, err)
+		if assert.Len(t, keys, 1) {
+			assert.NotNil(t, keys[0].LastUsedAt)
+		}
+
+		// Revoked keys stop working
+		req = httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/admin/api-keys/%d", created.APIKey.ID), nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		req = httptest.NewRequest(http.MethodGet, "/api/ingredients", nil)
+		req.Header.Set("Authorization", "Bearer "+created.Key)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("Household Isolation", func(t *testing.T) {
+		body, _ := json.Marshal(map[string]string{"username": "jiro", "password": "jiro-password"})
+		req := httptest.NewRequest(http.MethodPost, "/api/users", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		req = httptest.NewRequest(http.MethodPost, "/api/auth/login", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var session usecase.LoginResponse
+		err := json.Unmarshal(w.Body.Bytes(), &session)
+		assert.NoError(t, err)
+
+		// Accounts show up in the user list only once they join the household
+		req = httptest.NewRequest(http.MethodGet, "/api/users", nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), `"username":"jiro"`)
+
+		// Users outside of every household are asked to create or join one
+		req = httptest.NewRequest(http.MethodGet, "/api/ingredients", nil)
+		req.Header.Set("Authorization", "Bearer "+session.Token)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		body, _ = json.Marshal(map[string]string{"name": "次郎の家"})
+		req = httptest.NewRequest(http.MethodPost, "/api/households", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+session.Token)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var household domain.Household
+		err = json.Unmarshal(w.Body.Bytes(), &household)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.HouseholdRoleOwner, household.Role)
+
+		// The new household starts empty and its records stay in it
+		req = httptest.NewRequest(http.MethodGet, "/api/ingredients", nil)
+		req.Header.Set("Authorization", "Bearer "+session.Token)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, fmt.Sprint(household.ID), w.Header().Get(handler.HouseholdHeader))
+		assert.Equal(t, "[]", w.Body.String())
+
+		body, _ = json.Marshal(map[string]string{"name": "ごぼう"})
+		req = httptest.NewRequest(http.MethodPost, "/api/ingredients", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+session.Token)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var created domain.Ingredient
+		err = json.Unmarshal(w.Body.Bytes(), &created)
+		assert.NoError(t, err)
+
+		req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/ingredients/%d", created.ID), nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		// Households the user does not belong to cannot be selected
+		req = httptest.NewRequest(http.MethodGet, "/api/ingredients", nil)
+		req.Header.Set(handler.HouseholdHeader, fmt.Sprint(household.ID))
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
 	})
 }
 
@@ -402,13 +1413,13 @@ func TestEmptyIngredientsFlow(t *testing.T) {
 		}
 		assert.Equal(t, http.StatusOK, w.Code)
 
-		var ingredients []*domain.Ingredient
-		if err := json.Unmarshal(w.Body.Bytes(), &ingredients); err != nil {
+		var page usecase.IngredientListResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
 			t.Fatalf("Failed to unmarshal response: %v", err)
 		}
 
-		if len(ingredients) != 0 {
-			t.Errorf("Expected empty array, got %d ingredients", len(ingredients))
+		if len(page.Items) != 0 {
+			t.Errorf("Expected empty array, got %d ingredients", len(page.Items))
 		}
 	})
 }
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// Consumption reasons
+const (
+	// ConsumptionReasonCooked marks an ingredient used in cooking
+	ConsumptionReasonCooked = "cooked"
+
+	// ConsumptionReasonEaten marks an ingredient eaten as is
+	ConsumptionReasonEaten = "eaten"
+
+	// ConsumptionReasonDiscarded marks an ingredient thrown away before its date
+	ConsumptionReasonDiscarded = "discarded"
+
+	// ConsumptionReasonExpired marks an ingredient thrown away because it went past its date
+	ConsumptionReasonExpired = "expired"
+)
+
+// consumptionReasons lists the known consumption reasons
+var consumptionReasons = []string{
+	ConsumptionReasonCooked,
+	ConsumptionReasonEaten,
+	ConsumptionReasonDiscarded,
+	ConsumptionReasonExpired,
+}
+
+// UsedReasons and WasteReasons split the consumption reasons into food that was eaten and food that was thrown away
+var (
+	UsedReasons  = []string{ConsumptionReasonCooked, ConsumptionReasonEaten}
+	WasteReasons = []string{ConsumptionReasonDiscarded, ConsumptionReasonExpired}
+)
+
+// IsValidConsumptionReason reports whether r is a known consumption reason
+func IsValidConsumptionReason(r string) bool {
+	return slices.Contains(consumptionReasons, r)
+}
+
+// Consumption represents one use of an ingredient.
+// The name, category and purchase date are copied from the ingredient so the record outlives it.
+type Consumption struct {
+	ID            int64      `json:"id" db:"id"`
+	IngredientID  int64      `json:"ingredient_id" db:"ingredient_id"`
+	Name          string     `json:"name" db:"name"`
+	CanonicalName string     `json:"canonical_name" db:"canonical_name"`
+	Category      string     `json:"category" db:"category"`
+	Amount        string     `json:"amount" db:"amount"`       // how much was used, such as 200g; the whole quantity when used up
+	Remaining     string     `json:"remaining" db:"remaining"` // quantity left afterwards; empty when used up
+	UsedUp        bool       `json:"used_up" db:"used_up"`     // the ingredient was moved to the trash
+	Reason        string     `json:"reason" db:"reason"`       // "cooked", "eaten", "discarded" or "expired"
+	Recipe        string     `json:"recipe,omitempty" db:"recipe"`
+	SuggestionID  *int64     `json:"suggestion_id,omitempty" db:"suggestion_id"` // the recipe suggestion that was cooked, if any
+	Cost          *int       `json:"cost,omitempty" db:"cost"`                   // estimated yen of the amount used; nil when the price is unknown
+	PurchaseDate  *time.Time `json:"purchase_date,omitempty" db:"purchase_date"`
+	Actor         string     `json:"actor" db:"actor"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+}
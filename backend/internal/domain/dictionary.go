@@ -0,0 +1,21 @@
+package domain
+
+// DictionaryEntry represents an ingredient in the synonym dictionary
+type DictionaryEntry struct {
+	// Canonical is the preferred name of the ingredient
+	Canonical string `json:"canonical"`
+
+	// Aliases are other spellings and names that refer to the same ingredient
+	Aliases []string `json:"aliases"`
+
+	// Broader is the canonical name of a more general ingredient, e.g. 豚肉 for 豚バラ肉
+	Broader string `json:"broader,omitempty"`
+
+	// Category is the ingredient category used for auto-categorization
+	Category string `json:"category,omitempty"`
+}
+
+// Dictionary represents the structure of a synonym dictionary file
+type Dictionary struct {
+	Entries []DictionaryEntry `json:"entries"`
+}
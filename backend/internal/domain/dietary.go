@@ -0,0 +1,172 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// Allergens of the Japanese food labeling standard. The first eight are 特定原材料, which must be labeled;
+// the rest are 特定原材料に準ずるもの, whose labeling is recommended.
+const (
+	AllergenShrimp    = "shrimp"    // えび
+	AllergenCrab      = "crab"      // かに
+	AllergenWalnut    = "walnut"    // くるみ
+	AllergenWheat     = "wheat"     // 小麦
+	AllergenBuckwheat = "buckwheat" // そば
+	AllergenEgg       = "egg"       // 卵
+	AllergenMilk      = "milk"      // 乳
+	AllergenPeanut    = "peanut"    // 落花生
+
+	AllergenAlmond    = "almond"     // アーモンド
+	AllergenAbalone   = "abalone"    // あわび
+	AllergenSquid     = "squid"      // いか
+	AllergenSalmonRoe = "salmon_roe" // いくら
+	AllergenOrange    = "orange"     // オレンジ
+	AllergenCashew    = "cashew"     // カシューナッツ
+	AllergenKiwi      = "kiwi"       // キウイフルーツ
+	AllergenBeef      = "beef"       // 牛肉
+	AllergenSesame    = "sesame"     // ごま
+	AllergenSalmon    = "salmon"     // さけ
+	AllergenMackerel  = "mackerel"   // さば
+	AllergenSoybean   = "soybean"    // 大豆
+	AllergenChicken   = "chicken"    // 鶏肉
+	AllergenBanana    = "banana"     // バナナ
+	AllergenPork      = "pork"       // 豚肉
+	AllergenMacadamia = "macadamia"  // マカダミアナッツ
+	AllergenPeach     = "peach"      // もも
+	AllergenYam       = "yam"        // やまいも
+	AllergenApple     = "apple"      // りんご
+	AllergenGelatin   = "gelatin"    // ゼラチン
+)
+
+// Dietary restrictions
+const (
+	RestrictionVegetarian  = "vegetarian"  // ベジタリアン
+	RestrictionVegan       = "vegan"       // ヴィーガン
+	RestrictionPescatarian = "pescatarian" // ペスカタリアン
+	RestrictionHalal       = "halal"       // ハラール
+	RestrictionNoPork      = "no_pork"     // 豚肉を食べない
+	RestrictionNoBeef      = "no_beef"     // 牛肉を食べない
+	RestrictionNoAlcohol   = "no_alcohol"  // アルコールを摂らない
+)
+
+// Dietary violation kinds
+const (
+	// ViolationAllergen marks an ingredient containing an allergen of the profile
+	ViolationAllergen = "allergen"
+
+	// ViolationRestriction marks an ingredient a dietary restriction of the profile rules out
+	ViolationRestriction = "restriction"
+
+	// ViolationDislike marks an ingredient the profile dislikes
+	ViolationDislike = "dislike"
+)
+
+// Allergen describes an allergen that dietary profiles can list
+type Allergen struct {
+	Code      string `json:"code"`
+	Label     string `json:"label"`
+	Mandatory bool   `json:"mandatory"` // true for the 特定原材料, whose labeling is mandatory
+}
+
+// DietaryRestriction describes a dietary restriction that dietary profiles can list
+type DietaryRestriction struct {
+	Code        string `json:"code"`
+	Label       string `json:"label"`
+	Description string `json:"description"` // what the restriction rules out, as told to the LLM
+}
+
+// Allergens lists all allergens in display order
+var Allergens = []Allergen{
+	{AllergenShrimp, "えび", true},
+	{AllergenCrab, "かに", true},
+	{AllergenWalnut, "くるみ", true},
+	{AllergenWheat, "小麦", true},
+	{AllergenBuckwheat, "そば", true},
+	{AllergenEgg, "卵", true},
+	{AllergenMilk, "乳", true},
+	{AllergenPeanut, "落花生", true},
+	{AllergenAlmond, "アーモンド", false},
+	{AllergenAbalone, "あわび", false},
+	{AllergenSquid, "いか", false},
+	{AllergenSalmonRoe, "いくら", false},
+	{AllergenOrange, "オレンジ", false},
+	{AllergenCashew, "カシューナッツ", false},
+	{AllergenKiwi, "キウイフルーツ", false},
+	{AllergenBeef, "牛肉", false},
+	{AllergenSesame, "ごま", false},
+	{AllergenSalmon, "さけ", false},
+	{AllergenMackerel, "さば", false},
+	{AllergenSoybean, "大豆", false},
+	{AllergenChicken, "鶏肉", false},
+	{AllergenBanana, "バナナ", false},
+	{AllergenPork, "豚肉", false},
+	{AllergenMacadamia, "マカダミアナッツ", false},
+	{AllergenPeach, "もも", false},
+	{AllergenYam, "やまいも", false},
+	{AllergenApple, "りんご", false},
+	{AllergenGelatin, "ゼラチン", false},
+}
+
+// DietaryRestrictions lists all dietary restrictions in display order
+var DietaryRestrictions = []DietaryRestriction{
+	{RestrictionVegetarian, "ベジタリアン", "肉・魚介類・ゼラチンを使わない"},
+	{RestrictionVegan, "ヴィーガン", "肉・魚介類・卵・乳製品・はちみつ・ゼラチンなど動物性の食材を使わない"},
+	{RestrictionPescatarian, "ペスカタリアン", "肉・ゼラチンを使わない（魚介類は可）"},
+	{RestrictionHalal, "ハラール", "豚肉とその加工品・ゼラチン・酒やみりんなどアルコールを含む調味料を使わない"},
+	{RestrictionNoPork, "豚肉なし", "豚肉とその加工品（ベーコン・ハム・ソーセージなど）を使わない"},
+	{RestrictionNoBeef, "牛肉なし", "牛肉とその加工品を使わない"},
+	{RestrictionNoAlcohol, "アルコールなし", "酒・みりん・ワインなどアルコールを含む食材や調味料を使わない"},
+}
+
+// DietaryProfile holds the allergies, restrictions and dislikes of one household member.
+// Allergens and restrictions are hard constraints; dislikes are only avoided where possible.
+type DietaryProfile struct {
+	ID           int64     `json:"id" db:"id"`
+	Name         string    `json:"name" db:"name"` // household member the profile belongs to
+	Allergens    []string  `json:"allergens" db:"-"`
+	Restrictions []string  `json:"restrictions" db:"-"`
+	Dislikes     []string  `json:"dislikes" db:"-"` // ingredient names, matched with their more specific ingredients
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+	OwnerID      *int64    `json:"owner_id,omitempty" db:"owner_id"` // user who created the profile
+	HouseholdID  *int64    `json:"household_id,omitempty" db:"household_id"`
+}
+
+// DietaryViolation reports an item of a recipe suggestion that breaks a rule of a dietary profile
+type DietaryViolation struct {
+	Profile string `json:"profile"` // name of the profile
+	Item    string `json:"item"`    // dish name or ingredient that breaks the rule
+	Kind    string `json:"kind"`    // "allergen", "restriction" or "dislike"
+	Code    string `json:"code"`    // allergen or restriction code, or the disliked ingredient
+	Label   string `json:"label"`
+}
+
+// IsHard reports whether the violation rules the suggestion out rather than only warning about it
+func (v DietaryViolation) IsHard() bool {
+	return v.Kind == ViolationAllergen || v.Kind == ViolationRestriction
+}
+
+// ExcludedSuggestion is a recipe suggestion dropped because it breaks an allergen or restriction
+type ExcludedSuggestion struct {
+	Name       string             `json:"name"`
+	Violations []DietaryViolation `json:"violations"`
+}
+
+// LookupAllergen returns the allergen with the given code or Japanese label
+func LookupAllergen(s string) (Allergen, bool) {
+	i := slices.IndexFunc(Allergens, func(a Allergen) bool { return a.Code == s || a.Label == s })
+	if i < 0 {
+		return Allergen{}, false
+	}
+	return Allergens[i], true
+}
+
+// LookupDietaryRestriction returns the dietary restriction with the given code or Japanese label
+func LookupDietaryRestriction(s string) (DietaryRestriction, bool) {
+	i := slices.IndexFunc(DietaryRestrictions, func(r DietaryRestriction) bool { return r.Code == s || r.Label == s })
+	if i < 0 {
+		return DietaryRestriction{}, false
+	}
+	return DietaryRestrictions[i], true
+}
@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidInput indicates that a request was rejected by business validation
+var ErrInvalidInput = errors.New("invalid input")
+
+// ErrVersionConflict indicates that a write was based on an outdated version of the resource
+var ErrVersionConflict = errors.New("version conflict")
+
+// ErrUnauthorized indicates that a request carried no valid credentials
+var ErrUnauthorized = errors.New("unauthorized")
+
+// ErrForbidden indicates that the signed-in user may not change the resource
+var ErrForbidden = errors.New("forbidden")
+
+// ErrNoHousehold indicates that household records were accessed outside of any household
+var ErrNoHousehold = fmt.Errorf("%w: no household selected", ErrForbidden)
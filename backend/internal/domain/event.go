@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// Ingredient event actions
+const (
+	// EventActionCreate records a newly registered ingredient
+	EventActionCreate = "create"
+
+	// EventActionUpdate records a change to an ingredient
+	EventActionUpdate = "update"
+
+	// EventActionDelete records an ingredient moved to the trash
+	EventActionDelete = "delete"
+
+	// EventActionRestore records an ingredient taken out of the trash
+	EventActionRestore = "restore"
+
+	// EventActionPurge records an ingredient permanently removed from the trash
+	EventActionPurge = "purge"
+)
+
+// Ingredient event sources
+const (
+	// EventSourceAPI marks changes requested through the HTTP API
+	EventSourceAPI = "api"
+
+	// EventSourceImport marks changes made by an inventory import
+	EventSourceImport = "import"
+
+	// EventSourceCook marks ingredients used up by cooking
+	EventSourceCook = "cook"
+
+	// EventSourceSystem marks changes made by the server itself, such as reindexing and purging the trash
+	EventSourceSystem = "system"
+)
+
+// eventActions and eventSources list the known event actions and sources
+var (
+	eventActions = []string{EventActionCreate, EventActionUpdate, EventActionDelete, EventActionRestore, EventActionPurge}
+	eventSources = []string{EventSourceAPI, EventSourceImport, EventSourceCook, EventSourceSystem}
+)
+
+// IsValidEventAction reports whether a is a known ingredient event action
+func IsValidEventAction(a string) bool {
+	return slices.Contains(eventActions, a)
+}
+
+// IsValidEventSource reports whether s is a known ingredient event source
+func IsValidEventSource(s string) bool {
+	return slices.Contains(eventSources, s)
+}
+
+// IngredientEvent represents one entry of the append-only ingredient history.
+// Before is nil for ingredients entering the inventory and After is nil for ingredients leaving it.
+type IngredientEvent struct {
+	ID           int64       `json:"id"`
+	IngredientID int64       `json:"ingredient_id"`
+	Action       string      `json:"action"` // "create", "update", "delete", "restore" or "purge"
+	Actor        string      `json:"actor"`  // who made the change; empty when unknown
+	Source       string      `json:"source"` // "api", "import", "cook" or "system"
+	Before       *Ingredient `json:"before"`
+	After        *Ingredient `json:"after"`
+	CreatedAt    time.Time   `json:"created_at"`
+}
+
+// eventActorKey and eventSourceKey are the context keys of the event origin
+type (
+	eventActorKey  struct{}
+	eventSourceKey struct{}
+)
+
+// WithEventActor returns a context whose ingredient changes are recorded as made by actor
+func WithEventActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, eventActorKey{}, actor)
+}
+
+// WithEventSource returns a context whose ingredient changes are recorded as coming from source
+func WithEventSource(ctx context.Context, source string) context.Context {
+	return context.WithValue(ctx, eventSourceKey{}, source)
+}
+
+// EventActor returns the actor recorded for ingredient changes made with ctx, or an empty string
+func EventActor(ctx context.Context) string {
+	actor, _ := ctx.Value(eventActorKey{}).(string)
+	return actor
+}
+
+// EventSource returns the source recorded for ingredient changes made with ctx; it defaults to EventSourceSystem
+func EventSource(ctx context.Context) string {
+	if source, ok := ctx.Value(eventSourceKey{}).(string); ok && source != "" {
+		return source
+	}
+	return EventSourceSystem
+}
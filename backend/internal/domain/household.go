@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// Household member roles
+const (
+	// HouseholdRoleOwner manages the household, its members and invitations, and can change every record in it
+	HouseholdRoleOwner = "owner"
+
+	// HouseholdRoleMember reads the household's records and changes the ones they created
+	HouseholdRoleMember = "member"
+
+	// HouseholdRoleViewer only reads the household's records
+	HouseholdRoleViewer = "viewer"
+)
+
+// HouseholdRoles lists the household member roles, from the most to the least privileged
+var HouseholdRoles = []string{HouseholdRoleOwner, HouseholdRoleMember, HouseholdRoleViewer}
+
+// IsValidHouseholdRole reports whether role is a known household member role
+func IsValidHouseholdRole(role string) bool {
+	return slices.Contains(HouseholdRoles, role)
+}
+
+// Household is a group of users sharing a fridge. Ingredients, recipes and dietary profiles belong to a household
+// and are only visible to its members.
+type Household struct {
+	ID        int64     `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	Role      string    `json:"role,omitempty" db:"role"` // role of the signed-in user in the household
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// CanWrite reports whether the signed-in user may change the household's records
+func (h *Household) CanWrite() bool {
+	return h.Role != HouseholdRoleViewer
+}
+
+// HouseholdMember is a user belonging to a household
+type HouseholdMember struct {
+	HouseholdID int64     `json:"household_id" db:"household_id"`
+	UserID      int64     `json:"user_id" db:"user_id"`
+	Username    string    `json:"username" db:"username"`
+	Role        string    `json:"role" db:"role"`
+	JoinedAt    time.Time `json:"joined_at" db:"created_at"`
+}
+
+// HouseholdInvitation is a single-use code that lets a user join a household with a given role
+type HouseholdInvitation struct {
+	ID          int64     `json:"id" db:"id"`
+	HouseholdID int64     `json:"household_id" db:"household_id"`
+	Code        string    `json:"code" db:"code"`
+	Role        string    `json:"role" db:"role"`
+	CreatedBy   *int64    `json:"created_by,omitempty" db:"created_by"`
+	ExpiresAt   time.Time `json:"expires_at" db:"expires_at"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// householdKey is the context key of the household requests are made in
+type householdKey struct{}
+
+// WithHousehold returns a context whose requests read and write the records of household
+func WithHousehold(ctx context.Context, household *Household) context.Context {
+	return context.WithValue(ctx, householdKey{}, household)
+}
+
+// CurrentHousehold returns the household requests with ctx are made in, or nil outside of any household
+func CurrentHousehold(ctx context.Context) *Household {
+	household, _ := ctx.Value(householdKey{}).(*Household)
+	return household
+}
+
+// HouseholdID returns the ID of the household in ctx, or nil outside of any household.
+// Repositories refuse to read household records outside of a household unless ctx comes from WithAllHouseholds.
+func HouseholdID(ctx context.Context) *int64 {
+	household := CurrentHousehold(ctx)
+	if household == nil {
+		return nil
+	}
+	id := household.ID
+	return &id
+}
+
+// allHouseholdsKey is the context key marking background jobs that work across every household
+type allHouseholdsKey struct{}
+
+// WithAllHouseholds returns a context whose queries cover the records of every household, dropping the
+// household of ctx. It is meant for background jobs such as the trash purge and the synonym reindex.
+func WithAllHouseholds(ctx context.Context) context.Context {
+	ctx = context.WithValue(ctx, householdKey{}, (*Household)(nil))
+	return context.WithValue(ctx, allHouseholdsKey{}, true)
+}
+
+// AllHouseholds reports whether ctx was made by WithAllHouseholds
+func AllHouseholds(ctx context.Context) bool {
+	all, _ := ctx.Value(allHouseholdsKey{}).(bool)
+	return all
+}
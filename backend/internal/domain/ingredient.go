@@ -5,14 +5,125 @@ import (
 	"time"
 )
 
+// Ingredient categories
+const (
+	CategoryVegetable = "vegetable" // 野菜
+	CategoryFruit     = "fruit"     // 果物
+	CategoryMeat      = "meat"      // 肉
+	CategorySeafood   = "seafood"   // 魚介
+	CategoryDairy     = "dairy"     // 乳製品・卵
+	CategorySoy       = "soy"       // 大豆製品
+	CategoryGrain     = "grain"     // 主食・穀物
+	CategorySeasoning = "seasoning" // 調味料
+	CategoryOther     = "other"     // その他
+)
+
+// Storage locations
+const (
+	LocationFridge  = "fridge"  // 冷蔵
+	LocationFreezer = "freezer" // 冷凍
+	LocationPantry  = "pantry"  // 常温
+)
+
+// Categories lists all ingredient categories in display order
+var Categories = []string{
+	CategoryVegetable,
+	CategoryFruit,
+	CategoryMeat,
+	CategorySeafood,
+	CategoryDairy,
+	CategorySoy,
+	CategoryGrain,
+	CategorySeasoning,
+	CategoryOther,
+}
+
+// Locations lists all storage locations in display order
+var Locations = []string{
+	LocationFridge,
+	LocationFreezer,
+	LocationPantry,
+}
+
+// categoryLabels maps categories to their Japanese labels
+var categoryLabels = map[string]string{
+	CategoryVegetable: "野菜",
+	CategoryFruit:     "果物",
+	CategoryMeat:      "肉",
+	CategorySeafood:   "魚介",
+	CategoryDairy:     "乳製品・卵",
+	CategorySoy:       "大豆製品",
+	CategoryGrain:     "主食",
+	CategorySeasoning: "調味料",
+	CategoryOther:     "その他",
+}
+
+// locationLabels maps storage locations to their Japanese labels
+var locationLabels = map[string]string{
+	LocationFridge:  "冷蔵",
+	LocationFreezer: "冷凍",
+	LocationPantry:  "常温",
+}
+
 // Ingredient represents a food item in the refrigerator
 type Ingredient struct {
-	ID           int64      `json:"id" db:"id"`
-	Name         string     `json:"name" db:"name"`
-	Quantity     string     `json:"quantity" db:"quantity"`
-	PurchaseDate *time.Time `json:"purchase_date,omitempty" db:"purchase_date"`
-	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
-	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
+	ID            int64      `json:"id" db:"id"`
+	Name          string     `json:"name" db:"name"`
+	CanonicalName string     `json:"canonical_name" db:"canonical_name"`
+	Category      string     `json:"category" db:"category"`
+	Location      string     `json:"location" db:"location"`
+	Quantity      string     `json:"quantity" db:"quantity"`
+	Barcode       string     `json:"barcode,omitempty" db:"barcode"` // JAN/EAN code of the packaged product, if any
+	Price         *int       `json:"price,omitempty" db:"price"`     // yen paid for the ingredient as bought
+	Store         string     `json:"store,omitempty" db:"store"`     // where the ingredient was bought
+	PurchaseDate  *time.Time `json:"purchase_date,omitempty" db:"purchase_date"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+	Version       int64      `json:"version" db:"version"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at" db:"updated_at"`
+	DeletedAt     *time.Time `json:"deleted_at,omitempty" db:"deleted_at"` // set while the ingredient is in the trash
+	OwnerID       *int64     `json:"owner_id,omitempty" db:"owner_id"`     // user who registered the ingredient
+	HouseholdID   *int64     `json:"household_id,omitempty" db:"household_id"`
+
+	Nutrition *IngredientNutrition `json:"nutrition,omitempty" db:"-"` // looked up from the nutrition table when read
+}
+
+// IsValidCategory reports whether c is a known ingredient category
+func IsValidCategory(c string) bool {
+	_, ok := categoryLabels[c]
+	return ok
+}
+
+// IsValidLocation reports whether l is a known storage location
+func IsValidLocation(l string) bool {
+	_, ok := locationLabels[l]
+	return ok
+}
+
+// CategoryLabel returns the Japanese label of a category
+func CategoryLabel(c string) string {
+	if label, ok := categoryLabels[c]; ok {
+		return label
+	}
+	return categoryLabels[CategoryOther]
+}
+
+// LocationLabel returns the Japanese label of a storage location
+func LocationLabel(l string) string {
+	if label, ok := locationLabels[l]; ok {
+		return label
+	}
+	return l
+}
+
+// DefaultLocation returns where an ingredient of the given category is usually stored
+func DefaultLocation(category string) string {
+	switch category {
+	case CategoryGrain, CategorySeasoning:
+		return LocationPantry
+	default:
+		return LocationFridge
+	}
 }
 
 // NullableTime is a helper type for handling nullable time fields in database
@@ -0,0 +1,45 @@
+package domain
+
+// Inventory columns, named after the ingredient fields they hold
+const (
+	InventoryColumnName         = "name"
+	InventoryColumnCategory     = "category"
+	InventoryColumnLocation     = "location"
+	InventoryColumnQuantity     = "quantity"
+	InventoryColumnBarcode      = "barcode"
+	InventoryColumnPrice        = "price"
+	InventoryColumnStore        = "store"
+	InventoryColumnPurchaseDate = "purchase_date"
+	InventoryColumnExpiresAt    = "expires_at"
+)
+
+// InventoryColumns lists the inventory columns in export order
+var InventoryColumns = []string{
+	InventoryColumnName,
+	InventoryColumnCategory,
+	InventoryColumnLocation,
+	InventoryColumnQuantity,
+	InventoryColumnBarcode,
+	InventoryColumnPrice,
+	InventoryColumnStore,
+	InventoryColumnPurchaseDate,
+	InventoryColumnExpiresAt,
+}
+
+// InventoryTable represents an imported inventory file as rows of raw text values
+type InventoryTable struct {
+	// Columns are the column names found in the file, in file order for CSV and sorted for JSON
+	Columns []string
+
+	// Rows are the data rows of the file
+	Rows []InventoryRow
+}
+
+// InventoryRow represents one data row of an inventory file
+type InventoryRow struct {
+	// Line is the line number of the row in a CSV file, or its 1-based position in a JSON array
+	Line int
+
+	// Values maps column names to the text of their cells
+	Values map[string]string
+}
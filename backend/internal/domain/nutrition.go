@@ -0,0 +1,32 @@
+package domain
+
+// Nutrition holds the energy and macronutrients of an amount of food
+type Nutrition struct {
+	EnergyKcal    float64 `json:"energy_kcal"`
+	ProteinG      float64 `json:"protein_g"`
+	FatG          float64 `json:"fat_g"`
+	CarbohydrateG float64 `json:"carbohydrate_g"`
+	SaltG         float64 `json:"salt_g"` // salt equivalent
+}
+
+// NutritionFacts is one entry of the nutrition table
+type NutritionFacts struct {
+	Name       string
+	Per100g    Nutrition
+	PieceGrams float64 // grams of one piece, such as one egg; 0 when the food is not counted in pieces
+	GramsPerML float64 // density used to weigh spoons and cups; 0 means 1
+}
+
+// IngredientNutrition is the nutrition attached to an inventory item
+type IngredientNutrition struct {
+	Per100g Nutrition  `json:"per_100g"`
+	Total   *Nutrition `json:"total,omitempty"` // nutrition of the whole quantity; nil when the quantity cannot be weighed
+}
+
+// RecipeNutrition is the estimated nutrition of a recipe suggestion
+type RecipeNutrition struct {
+	Servings   int       `json:"servings"`
+	PerServing Nutrition `json:"per_serving"`
+	Counted    []string  `json:"counted"`             // ingredients included in the estimate
+	Uncounted  []string  `json:"uncounted,omitempty"` // ingredients missing from the table or without a usable quantity
+}
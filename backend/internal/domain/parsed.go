@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// ParsedIngredient is a food item read from free text such as a receipt, not yet saved
+type ParsedIngredient struct {
+	Name         string     `json:"name"`
+	Quantity     string     `json:"quantity,omitempty"`
+	PurchaseDate *time.Time `json:"purchase_date,omitempty"`
+	Price        int        `json:"price,omitempty"` // yen, after line discounts
+	Line         string     `json:"line,omitempty"`  // the text the item was read from
+}
+
+// ParsedIngredients represents the food items found in a piece of free text
+type ParsedIngredients struct {
+	// PurchaseDate is the date the whole text refers to, such as the date printed on a receipt
+	PurchaseDate *time.Time
+
+	// Items are the food items in the order they appear
+	Items []ParsedIngredient
+
+	// Ignored are the lines that were not read as food, such as totals, taxes and household goods
+	Ignored []string
+}
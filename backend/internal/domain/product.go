@@ -0,0 +1,42 @@
+package domain
+
+import "time"
+
+// Product sources
+const (
+	// ProductSourceImport marks products loaded from a product database dump
+	ProductSourceImport = "import"
+
+	// ProductSourceLearned marks products learned from ingredients registered with a barcode
+	ProductSourceLearned = "learned"
+)
+
+// Product columns of a product database dump, named after the product fields they hold
+const (
+	ProductColumnBarcode       = "barcode"
+	ProductColumnName          = "name"
+	ProductColumnCategory      = "category"
+	ProductColumnUnit          = "unit"
+	ProductColumnShelfLifeDays = "shelf_life_days"
+)
+
+// ProductColumns lists the product columns in the order they are matched against a dump
+var ProductColumns = []string{
+	ProductColumnBarcode,
+	ProductColumnName,
+	ProductColumnCategory,
+	ProductColumnUnit,
+	ProductColumnShelfLifeDays,
+}
+
+// Product represents a packaged product identified by its JAN/EAN barcode
+type Product struct {
+	Barcode       string    `json:"barcode" db:"barcode"`
+	Name          string    `json:"name" db:"name"`
+	Category      string    `json:"category" db:"category"`
+	Unit          string    `json:"unit" db:"unit"`                                 // default quantity, e.g. 1本 or 300g
+	ShelfLifeDays *int      `json:"shelf_life_days,omitempty" db:"shelf_life_days"` // days from purchase to expiry
+	Source        string    `json:"source" db:"source"`                             // "import" or "learned"
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
+}
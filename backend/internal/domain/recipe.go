@@ -1,13 +1,58 @@
 package domain
 
+import "time"
+
+// Recipe suggestion sources
+const (
+	// RecipeSourceLLM marks suggestions generated by the LLM
+	RecipeSourceLLM = "llm"
+
+	// RecipeSourceCatalog marks suggestions produced by the offline catalog recommender
+	RecipeSourceCatalog = "catalog"
+)
+
 // RecipeSuggestion represents a recipe suggestion from LLM
 type RecipeSuggestion struct {
-	Name         string   `json:"name"`
-	Steps        []string `json:"steps"`
-	MissingItems []string `json:"missing_items"`
+	ID             int64              `json:"id,omitempty"` // suggestion log ID; pass it as suggestion_id when cooking the dish
+	Name           string             `json:"name"`
+	Ingredients    []RecipeIngredient `json:"ingredients,omitempty"`
+	Steps          []string           `json:"steps"`
+	AvailableItems []string           `json:"available_items,omitempty"`
+	MissingItems   []string           `json:"missing_items"`
+	Servings       int                `json:"servings,omitempty"`
+	Nutrition      *RecipeNutrition   `json:"nutrition,omitempty"` // estimated from the ingredients found in the nutrition table
+
+	DietaryWarnings []DietaryViolation `json:"dietary_warnings,omitempty"` // disliked ingredients the suggestion still uses
 }
 
 // RecipeResponse represents the response containing multiple suggestions
 type RecipeResponse struct {
-	Suggestions []RecipeSuggestion `json:"suggestions"`
+	Suggestions []RecipeSuggestion   `json:"suggestions"`
+	Source      string               `json:"source,omitempty"`
+	Excluded    []ExcludedSuggestion `json:"excluded,omitempty"` // suggestions dropped for breaking an allergen or restriction
+}
+
+// Recipe represents a recipe stored in the local catalog
+type Recipe struct {
+	ID               int64              `json:"id" db:"id"`
+	Name             string             `json:"name" db:"name"`
+	Description      string             `json:"description" db:"description"`
+	Yield            string             `json:"yield" db:"yield"`
+	PrepTimeMinutes  *int               `json:"prep_time_minutes,omitempty" db:"prep_time_minutes"`
+	CookTimeMinutes  *int               `json:"cook_time_minutes,omitempty" db:"cook_time_minutes"`
+	TotalTimeMinutes *int               `json:"total_time_minutes,omitempty" db:"total_time_minutes"`
+	SourceURL        string             `json:"source_url" db:"source_url"`
+	Ingredients      []RecipeIngredient `json:"ingredients" db:"-"`
+	Steps            []string           `json:"steps" db:"-"`
+	CreatedAt        time.Time          `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time          `json:"updated_at" db:"updated_at"`
+	OwnerID          *int64             `json:"owner_id,omitempty" db:"owner_id"` // user who imported the recipe
+	HouseholdID      *int64             `json:"household_id,omitempty" db:"household_id"`
+}
+
+// RecipeIngredient represents a single ingredient line of a catalog recipe
+type RecipeIngredient struct {
+	Name     string `json:"name" db:"name"`
+	Quantity string `json:"quantity" db:"quantity"`
+	RawText  string `json:"raw_text,omitempty" db:"raw_text"`
 }
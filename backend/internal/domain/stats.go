@@ -0,0 +1,50 @@
+package domain
+
+import "time"
+
+// CategoryUsage counts the recorded uses of one category in one month
+type CategoryUsage struct {
+	Month    string `json:"month" db:"month"` // YYYY-MM format
+	Category string `json:"category" db:"category"`
+	Consumed int    `json:"consumed" db:"consumed"` // uses cooked or eaten
+	Wasted   int    `json:"wasted" db:"wasted"`     // uses discarded or expired
+}
+
+// CategoryPurchaseToUse is the average number of days between buying and eating the ingredients of one category
+type CategoryPurchaseToUse struct {
+	Category    string  `json:"category" db:"category"`
+	AverageDays float64 `json:"average_days" db:"average_days"`
+	Count       int     `json:"count" db:"count"` // uses with a known purchase date
+}
+
+// WastedIngredient counts how often an ingredient was thrown away
+type WastedIngredient struct {
+	CanonicalName string    `json:"canonical_name" db:"canonical_name"`
+	Name          string    `json:"name" db:"name"` // one of the names it was registered under
+	Count         int       `json:"count" db:"count"`
+	LastWastedAt  time.Time `json:"last_wasted_at" db:"last_wasted_at"`
+}
+
+// SpendTotal is the money spent on the purchases of one week, category or store
+type SpendTotal struct {
+	Key   string `json:"key" db:"spend_key"` // first day of the week (Monday, YYYY-MM-DD), category or store
+	Total int    `json:"total" db:"total"`   // yen
+	Count int    `json:"count" db:"count"`   // priced purchases
+}
+
+// RecipeCost estimates what a recipe costs from the prices of the ingredients cooked for it
+type RecipeCost struct {
+	Recipe        string    `json:"recipe" db:"recipe"`
+	TimesCooked   int       `json:"times_cooked" db:"times_cooked"`     // days on which it was cooked
+	TotalCost     int       `json:"total_cost" db:"total_cost"`         // yen over every time it was cooked
+	AverageCost   float64   `json:"average_cost" db:"-"`                // yen per time it was cooked
+	UnpricedItems int       `json:"unpriced_items" db:"unpriced_items"` // ingredients used without a known price, left out of the cost
+	LastCookedAt  time.Time `json:"last_cooked_at" db:"last_cooked_at"`
+}
+
+// SuggestionStats measures how many recipe suggestions were actually cooked
+type SuggestionStats struct {
+	Suggested  int     `json:"suggested" db:"suggested"`
+	Cooked     int     `json:"cooked" db:"cooked"` // suggestions referenced by at least one cooked consumption
+	CookedRate float64 `json:"cooked_rate" db:"-"` // cooked / suggested; 0 when nothing was suggested
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// User represents an account that can sign in to the API
+type User struct {
+	ID             int64     `json:"id" db:"id"`
+	Username       string    `json:"username" db:"username"`
+	PasswordHash   string    `json:"-" db:"password_hash"`   // bcrypt hash; never leaves the server
+	IsAdmin        bool      `json:"is_admin" db:"is_admin"` // administers what every household shares
+	SessionVersion int       `json:"-" db:"session_version"` // raised on every password change; older tokens are rejected
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// userKey is the context key of the signed-in user
+type userKey struct{}
+
+// WithUser returns a context whose requests are made by user
+func WithUser(ctx context.Context, user *User) context.Context {
+	return context.WithValue(ctx, userKey{}, user)
+}
+
+// CurrentUser returns the user making requests with ctx, or nil when nobody is signed in
+func CurrentUser(ctx context.Context) *User {
+	user, _ := ctx.Value(userKey{}).(*User)
+	return user
+}
+
+// OwnerID returns the ID of the user in ctx as the owner of records created with it, or nil when nobody is signed in
+func OwnerID(ctx context.Context) *int64 {
+	user := CurrentUser(ctx)
+	if user == nil {
+		return nil
+	}
+	id := user.ID
+	return &id
+}
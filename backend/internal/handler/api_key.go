@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// RequireScope returns a middleware that limits requests made with an API key to keys granting readScope for
+// GET and HEAD requests and writeScope for every other method. Signed-in users are not limited.
+// It must run after RequireUser.
+func RequireScope(readScope string, writeScope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := domain.CurrentAPIKey(c.Request.Context())
+		if key == nil {
+			c.Next()
+			return
+		}
+
+		scope := writeScope
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			scope = readScope
+		}
+
+		if !key.HasScope(scope) {
+			c.AbortWithStatusJSON(http.StatusForbidden, usecase.ErrorResponse{
+				Error:   "forbidden",
+				Message: "This API key lacks the " + scope + " scope",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireSession returns a middleware that rejects requests made with an API key, for routes such as
+// account and household management that only signed-in users may use. It must run after RequireUser.
+func RequireSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if domain.CurrentAPIKey(c.Request.Context()) != nil {
+			c.AbortWithStatusJSON(http.StatusForbidden, usecase.ErrorResponse{
+				Error:   "forbidden",
+				Message: "API keys cannot be used here; sign in instead",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// APIKeyHandler handles HTTP requests for managing API keys
+type APIKeyHandler struct {
+	apiKeyUsecase usecase.APIKeyUsecase
+}
+
+// NewAPIKeyHandler creates a new APIKeyHandler instance
+func NewAPIKeyHandler(apiKeyUsecase usecase.APIKeyUsecase) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyUsecase: apiKeyUsecase,
+	}
+}
+
+// @Summary      APIキーの一覧を取得
+// @Description  世帯のAPIキーを新しい順に返します。キー本体は含まれません。世帯のオーナーのみ利用できます。
+// @Tags         api-keys
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {array} domain.APIKey "APIキーのリスト"
+// @Failure      401 {object} usecase.ErrorResponse "認証が必要です"
+// @Failure      403 {object} usecase.ErrorResponse "世帯のオーナーではありません"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /admin/api-keys [get]
+// ListAPIKeys handles GET /admin/api-keys
+func (h *APIKeyHandler) ListAPIKeys(c *gin.Context) {
+	// Call usecase
+	keys, err := h.apiKeyUsecase.ListKeys(c.Request.Context())
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, keys)
+}
+
+// @Summary      APIキーを作成
+// @Description  タブレットやスクリプトから使うAPIキーを作成します。キー本体はこのレスポンスでのみ返され、サーバーにはハッシュだけが保存されます。
+// @Description  キーは作成したユーザーとして、作成した世帯の中で、scopes で許可された操作だけを行えます。世帯のオーナーのみ利用できます。
+// @Tags         api-keys
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body usecase.CreateAPIKeyRequest true "キーの名前とスコープ"
+// @Success      201 {object} usecase.CreateAPIKeyResponse "作成されたAPIキー"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      401 {object} usecase.ErrorResponse "認証が必要です"
+// @Failure      403 {object} usecase.ErrorResponse "世帯のオーナーではありません"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /admin/api-keys [post]
+// CreateAPIKey handles POST /admin/api-keys
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	var req usecase.CreateAPIKeyRequest
+
+	// Bind and validate request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, err.Error())
+		return
+	}
+
+	// Call usecase
+	response, err := h.apiKeyUsecase.CreateKey(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// @Summary      APIキーを取り消す
+// @Description  APIキーを削除し、以降のリクエストで使えなくします。世帯のオーナーのみ利用できます。
+// @Tags         api-keys
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "APIキーID"
+// @Success      204 "削除成功"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      403 {object} usecase.ErrorResponse "世帯のオーナーではありません"
+// @Failure      404 {object} usecase.ErrorResponse "APIキーが見つかりません"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /admin/api-keys/{id} [delete]
+// RevokeAPIKey handles DELETE /admin/api-keys/:id
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	// Parse ID from URL parameter
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondBadRequest(c, "Invalid API key ID")
+		return
+	}
+
+	// Call usecase
+	if err := h.apiKeyUsecase.RevokeKey(c.Request.Context(), id); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
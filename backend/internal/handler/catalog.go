@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// CatalogHandler handles HTTP requests for the local recipe catalog
+type CatalogHandler struct {
+	catalogUsecase usecase.CatalogUsecase
+}
+
+// NewCatalogHandler creates a new CatalogHandler instance
+func NewCatalogHandler(catalogUsecase usecase.CatalogUsecase) *CatalogHandler {
+	return &CatalogHandler{
+		catalogUsecase: catalogUsecase,
+	}
+}
+
+// @Summary      レシピをインポート
+// @Description  schema.org Recipe の JSON-LD、またはそれを含む HTML からレシピを取り込みます。ネットワークからの取得は行いません。
+// @Description  JSON のリクエストボディのほか、Content-Type が application/ld+json または text/html の生データも受け付けます。
+// @Tags         catalog
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body usecase.ImportRecipesRequest true "インポートする内容"
+// @Success      201 {array} domain.Recipe "取り込まれたレシピ"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /catalog/recipes/import [post]
+// ImportRecipes handles POST /catalog/recipes/import
+func (h *CatalogHandler) ImportRecipes(c *gin.Context) {
+	var req usecase.ImportRecipesRequest
+
+	switch c.ContentType() {
+	case "application/ld+json", "text/html":
+		// Accept the raw document as the request body
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			respondBadRequest(c, err.Error())
+			return
+		}
+		req.Content = string(body)
+		req.Format = usecase.ImportFormatJSONLD
+		if c.ContentType() == "text/html" {
+			req.Format = usecase.ImportFormatHTML
+		}
+	default:
+		// Bind and validate request body
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondBadRequest(c, err.Error())
+			return
+		}
+	}
+
+	// Call usecase
+	recipes, err := h.catalogUsecase.ImportRecipes(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, recipes)
+}
+
+// @Summary      レシピを検索
+// @Description  料理名または材料名でカタログのレシピを検索します。条件を指定しない場合はすべてのレシピを返します。
+// @Tags         catalog
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        q           query  string  false  "料理名（部分一致）"
+// @Param        ingredient  query  string  false  "材料名（部分一致）"
+// @Success      200 {array} domain.Recipe "レシピのリスト"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /catalog/recipes [get]
+// SearchRecipes handles GET /catalog/recipes
+func (h *CatalogHandler) SearchRecipes(c *gin.Context) {
+	var req usecase.SearchRecipesRequest
+
+	// Bind query parameters
+	if err := c.ShouldBindQuery(&req); err != nil {
+		respondBadRequest(c, err.Error())
+		return
+	}
+
+	// Call usecase
+	recipes, err := h.catalogUsecase.SearchRecipes(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, recipes)
+}
+
+// @Summary      IDでレシピを取得
+// @Description  指定されたIDのカタログレシピを取得します。
+// @Tags         catalog
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "レシピID"
+// @Success      200 {object} domain.Recipe "レシピ"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      404 {object} usecase.ErrorResponse "レシピが見つかりません"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /catalog/recipes/{id} [get]
+// GetRecipe handles GET /catalog/recipes/:id
+func (h *CatalogHandler) GetRecipe(c *gin.Context) {
+	// Parse ID from URL parameter
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondBadRequest(c, "Invalid recipe ID")
+		return
+	}
+
+	// Call usecase
+	recipe, err := h.catalogUsecase.GetRecipe(c.Request.Context(), id)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, recipe)
+}
+
+// @Summary      レシピを削除
+// @Description  指定されたIDのカタログレシピを削除します。
+// @Tags         catalog
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "レシピID"
+// @Success      204 "削除成功"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      404 {object} usecase.ErrorResponse "レシピが見つかりません"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /catalog/recipes/{id} [delete]
+// DeleteRecipe handles DELETE /catalog/recipes/:id
+func (h *CatalogHandler) DeleteRecipe(c *gin.Context) {
+	// Parse ID from URL parameter
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondBadRequest(c, "Invalid recipe ID")
+		return
+	}
+
+	// Call usecase
+	if err := h.catalogUsecase.DeleteRecipe(c.Request.Context(), id); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCatalogUsecase is a mock implementation of CatalogUsecase
+type MockCatalogUsecase struct {
+	mock.Mock
+}
+
+func (m *MockCatalogUsecase) ImportRecipes(ctx context.Context, req usecase.ImportRecipesRequest) ([]*domain.Recipe, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Recipe), args.Error(1)
+}
+
+func (m *MockCatalogUsecase) SearchRecipes(ctx context.Context, req usecase.SearchRecipesRequest) ([]*domain.Recipe, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Recipe), args.Error(1)
+}
+
+func (m *MockCatalogUsecase) GetRecipe(ctx context.Context, id int64) (*domain.Recipe, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Recipe), args.Error(1)
+}
+
+func (m *MockCatalogUsecase) DeleteRecipe(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// TestImportRecipes_JSONBody tests importing recipes from a JSON request body
+func TestImportRecipes_JSONBody(t *testing.T) {
+	mockUsecase := new(MockCatalogUsecase)
+	handler := NewCatalogHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/catalog/recipes/import", handler.ImportRecipes)
+
+	reqBody := usecase.ImportRecipesRequest{Format: "jsonld", Content: `{"@type":"Recipe","name":"肉じゃが"}`}
+	mockUsecase.On("ImportRecipes", mock.Anything, reqBody).
+		Return([]*domain.Recipe{{ID: 1, Name: "肉じゃが"}}, nil)
+
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPost, "/catalog/recipes/import", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response []domain.Recipe
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Len(t, response, 1)
+	assert.Equal(t, "肉じゃが", response[0].Name)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestImportRecipes_RawHTML tests importing recipes from a raw HTML body
+func TestImportRecipes_RawHTML(t *testing.T) {
+	mockUsecase := new(MockCatalogUsecase)
+	handler := NewCatalogHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/catalog/recipes/import", handler.ImportRecipes)
+
+	page := `<html><script type="application/ld+json">{}</script></html>`
+	mockUsecase.On("ImportRecipes", mock.Anything, usecase.ImportRecipesRequest{Format: "html", Content: page}).
+		Return([]*domain.Recipe{{ID: 1, Name: "親子丼"}}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/catalog/recipes/import", bytes.NewBufferString(page))
+	req.Header.Set("Content-Type", "text/html; charset=utf-8")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestImportRecipes_InvalidContent tests that validation errors map to 400
+func TestImportRecipes_InvalidContent(t *testing.T) {
+	mockUsecase := new(MockCatalogUsecase)
+	handler := NewCatalogHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/catalog/recipes/import", handler.ImportRecipes)
+
+	reqBody := usecase.ImportRecipesRequest{Content: "<html></html>"}
+	mockUsecase.On("ImportRecipes", mock.Anything, reqBody).
+		Return(nil, fmt.Errorf("%w: no schema.org Recipe found", domain.ErrInvalidInput))
+
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPost, "/catalog/recipes/import", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response usecase.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "validation_error", response.Error)
+}
+
+// TestImportRecipes_MissingContent tests binding validation of the request body
+func TestImportRecipes_MissingContent(t *testing.T) {
+	mockUsecase := new(MockCatalogUsecase)
+	handler := NewCatalogHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/catalog/recipes/import", handler.ImportRecipes)
+
+	req := httptest.NewRequest(http.MethodPost, "/catalog/recipes/import", bytes.NewBufferString(`{"format":"html"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUsecase.AssertNotCalled(t, "ImportRecipes")
+}
+
+// TestSearchRecipes_Success tests searching recipes with query parameters
+func TestSearchRecipes_Success(t *testing.T) {
+	mockUsecase := new(MockCatalogUsecase)
+	handler := NewCatalogHandler(mockUsecase)
+	router := setupTestRouter()
+	router.GET("/catalog/recipes", handler.SearchRecipes)
+
+	mockUsecase.On("SearchRecipes", mock.Anything, usecase.SearchRecipesRequest{Query: "丼", Ingredient: "卵"}).
+		Return([]*domain.Recipe{{ID: 1, Name: "親子丼"}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/catalog/recipes?q=%E4%B8%BC&ingredient=%E5%8D%B5", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestGetRecipe_NotFound tests 404 handling for unknown recipes
+func TestGetRecipe_NotFound(t *testing.T) {
+	mockUsecase := new(MockCatalogUsecase)
+	handler := NewCatalogHandler(mockUsecase)
+	router := setupTestRouter()
+	router.GET("/catalog/recipes/:id", handler.GetRecipe)
+
+	mockUsecase.On("GetRecipe", mock.Anything, int64(999)).Return(nil, sql.ErrNoRows)
+
+	req := httptest.NewRequest(http.MethodGet, "/catalog/recipes/999", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestDeleteRecipe_InvalidID tests error handling for invalid IDs
+func TestDeleteRecipe_InvalidID(t *testing.T) {
+	mockUsecase := new(MockCatalogUsecase)
+	handler := NewCatalogHandler(mockUsecase)
+	router := setupTestRouter()
+	router.DELETE("/catalog/recipes/:id", handler.DeleteRecipe)
+
+	req := httptest.NewRequest(http.MethodDelete, "/catalog/recipes/abc", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUsecase.AssertNotCalled(t, "DeleteRecipe")
+}
+
+// TestDeleteRecipe_Success tests successful recipe deletion
+func TestDeleteRecipe_Success(t *testing.T) {
+	mockUsecase := new(MockCatalogUsecase)
+	handler := NewCatalogHandler(mockUsecase)
+	router := setupTestRouter()
+	router.DELETE("/catalog/recipes/:id", handler.DeleteRecipe)
+
+	mockUsecase.On("DeleteRecipe", mock.Anything, int64(1)).Return(nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/catalog/recipes/1", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
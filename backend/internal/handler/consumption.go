@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// ConsumptionHandler handles HTTP requests for using up ingredients
+type ConsumptionHandler struct {
+	consumptionUsecase usecase.ConsumptionUsecase
+}
+
+// NewConsumptionHandler creates a new ConsumptionHandler instance
+func NewConsumptionHandler(consumptionUsecase usecase.ConsumptionUsecase) *ConsumptionHandler {
+	return &ConsumptionHandler{
+		consumptionUsecase: consumptionUsecase,
+	}
+}
+
+// @Summary      食材を使う
+// @Description  指定されたIDの食材から amount の分だけ数量を減らし、理由とともに記録します。
+// @Description  amount を省略した場合や残りがなくなった場合は、食材をゴミ箱に移動します。数量の単位は g と kg のように換算され、単位のない amount は食材の単位で数えます。
+// @Tags         ingredients
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id           path    int                                true   "食材ID"
+// @Param        If-Match     header  string                             false  "取得時の ETag。食材が更新されていた場合は 412 を返します"
+// @Param        consumption  body    usecase.ConsumeIngredientRequest  true   "使った量と理由"
+// @Success      200 {object} usecase.ConsumeIngredientResponse "記録した消費と残りの食材"
+// @Header       200 {string} ETag "残りの食材のエンティティタグ（使い切った場合はなし）"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      404 {object} usecase.ErrorResponse "食材が見つかりません"
+// @Failure      412 {object} usecase.ErrorResponse "食材が他の更新により変更されています"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /ingredients/{id}/consume [post]
+// ConsumeIngredient handles POST /ingredients/:id/consume
+func (h *ConsumptionHandler) ConsumeIngredient(c *gin.Context) {
+	// Parse ID from URL parameter
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		respondBadRequest(c, "Invalid ingredient ID")
+		return
+	}
+
+	versions, ok := ifMatchVersions(c)
+	if !ok {
+		respondPreconditionFailed(c, "If-Match does not match the ingredient")
+		return
+	}
+
+	var req usecase.ConsumeIngredientRequest
+
+	// Bind and validate request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, err.Error())
+		return
+	}
+	req.ExpectedVersions = versions
+
+	// Call usecase
+	result, err := h.consumptionUsecase.ConsumeIngredient(c.Request.Context(), id, req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	if result.Ingredient != nil {
+		c.Header("ETag", ingredientETag(result.Ingredient))
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// @Summary      食材の消費記録を取得
+// @Description  指定されたIDの食材を使った記録を新しい順に取得します。使い切って削除された食材の記録も取得できます。
+// @Tags         ingredients
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path  int  true  "食材ID"
+// @Success      200 {array} domain.Consumption "消費記録のリスト"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /ingredients/{id}/consumptions [get]
+// GetConsumptions handles GET /ingredients/:id/consumptions
+func (h *ConsumptionHandler) GetConsumptions(c *gin.Context) {
+	// Parse ID from URL parameter
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		respondBadRequest(c, "Invalid ingredient ID")
+		return
+	}
+
+	// Call usecase
+	consumptions, err := h.consumptionUsecase.GetConsumptions(c.Request.Context(), id)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, consumptions)
+}
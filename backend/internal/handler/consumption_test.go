@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockConsumptionUsecase is a mock implementation of ConsumptionUsecase
+type MockConsumptionUsecase struct {
+	mock.Mock
+}
+
+func (m *MockConsumptionUsecase) ConsumeIngredient(ctx context.Context, id int64, req usecase.ConsumeIngredientRequest) (*usecase.ConsumeIngredientResponse, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.ConsumeIngredientResponse), args.Error(1)
+}
+
+func (m *MockConsumptionUsecase) GetConsumptions(ctx context.Context, id int64) ([]*domain.Consumption, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Consumption), args.Error(1)
+}
+
+// TestConsumeIngredient_Partial tests that a partial use returns the rest of the ingredient with its ETag
+func TestConsumeIngredient_Partial(t *testing.T) {
+	mockUsecase := new(MockConsumptionUsecase)
+	handler := NewConsumptionHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/ingredients/:id/consume", handler.ConsumeIngredient)
+
+	expected := &usecase.ConsumeIngredientResponse{
+		Consumption: &domain.Consumption{ID: 1, IngredientID: 1, Amount: "200g", Remaining: "0.8kg", Reason: domain.ConsumptionReasonCooked},
+		Ingredient:  &domain.Ingredient{ID: 1, Name: "豚バラ肉", Quantity: "0.8kg", Version: 3},
+	}
+	mockUsecase.On("ConsumeIngredient", mock.Anything, int64(1), usecase.ConsumeIngredientRequest{
+		Amount:           "200g",
+		Reason:           domain.ConsumptionReasonCooked,
+		ExpectedVersions: []int64{2},
+	}).Return(expected, nil)
+
+	body := []byte(`{"amount": "200g", "reason": "cooked"}`)
+	req := httptest.NewRequest(http.MethodPost, "/ingredients/1/consume", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"2"`)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+
+	var response usecase.ConsumeIngredientResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.8kg", response.Ingredient.Quantity)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestConsumeIngredient_UsedUp tests that using up an ingredient returns no ingredient and no ETag
+func TestConsumeIngredient_UsedUp(t *testing.T) {
+	mockUsecase := new(MockConsumptionUsecase)
+	handler := NewConsumptionHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/ingredients/:id/consume", handler.ConsumeIngredient)
+
+	expected := &usecase.ConsumeIngredientResponse{
+		Consumption: &domain.Consumption{ID: 1, IngredientID: 1, Amount: "1本", UsedUp: true, Reason: domain.ConsumptionReasonExpired},
+	}
+	mockUsecase.On("ConsumeIngredient", mock.Anything, int64(1), mock.Anything).Return(expected, nil)
+
+	body := []byte(`{"reason": "expired"}`)
+	req := httptest.NewRequest(http.MethodPost, "/ingredients/1/consume", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("ETag"))
+	assert.NotContains(t, w.Body.String(), `"ingredient"`)
+}
+
+// TestConsumeIngredient_MissingReason tests that the reason is required
+func TestConsumeIngredient_MissingReason(t *testing.T) {
+	mockUsecase := new(MockConsumptionUsecase)
+	handler := NewConsumptionHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/ingredients/:id/consume", handler.ConsumeIngredient)
+
+	body := []byte(`{"amount": "1本"}`)
+	req := httptest.NewRequest(http.MethodPost, "/ingredients/1/consume", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUsecase.AssertNotCalled(t, "ConsumeIngredient", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestGetConsumptions_Success tests listing the recorded uses of an ingredient
+func TestGetConsumptions_Success(t *testing.T) {
+	mockUsecase := new(MockConsumptionUsecase)
+	handler := NewConsumptionHandler(mockUsecase)
+	router := setupTestRouter()
+	router.GET("/ingredients/:id/consumptions", handler.GetConsumptions)
+
+	expected := []*domain.Consumption{{ID: 1, IngredientID: 1, Reason: domain.ConsumptionReasonCooked, Recipe: "カレー"}}
+	mockUsecase.On("GetConsumptions", mock.Anything, int64(1)).Return(expected, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/ingredients/1/consumptions", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response []domain.Consumption
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Len(t, response, 1)
+	assert.Equal(t, "カレー", response[0].Recipe)
+}
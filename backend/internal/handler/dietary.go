@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// DietaryHandler handles HTTP requests for dietary profiles
+type DietaryHandler struct {
+	dietaryUsecase usecase.DietaryUsecase
+}
+
+// NewDietaryHandler creates a new DietaryHandler instance
+func NewDietaryHandler(dietaryUsecase usecase.DietaryUsecase) *DietaryHandler {
+	return &DietaryHandler{
+		dietaryUsecase: dietaryUsecase,
+	}
+}
+
+// @Summary      食事制限の一覧を取得
+// @Description  プロフィールに指定できるアレルゲン（特定原材料・準ずるもの）と食事制限の一覧を返します。
+// @Tags         dietary
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} usecase.DietaryRulesResponse "アレルゲンと食事制限の一覧"
+// @Router       /dietary/rules [get]
+// ListRules handles GET /dietary/rules
+func (h *DietaryHandler) ListRules(c *gin.Context) {
+	c.JSON(http.StatusOK, h.dietaryUsecase.ListRules(c.Request.Context()))
+}
+
+// @Summary      食事プロフィール一覧を取得
+// @Description  登録されているすべての食事プロフィールを取得します。
+// @Tags         dietary
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {array} domain.DietaryProfile "食事プロフィールのリスト"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /dietary/profiles [get]
+// ListProfiles handles GET /dietary/profiles
+func (h *DietaryHandler) ListProfiles(c *gin.Context) {
+	// Call usecase
+	profiles, err := h.dietaryUsecase.ListProfiles(c.Request.Context())
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, profiles)
+}
+
+// @Summary      食事プロフィールを作成
+// @Description  家族それぞれのアレルギー・食事制限・苦手な食材を登録します。アレルゲンと食事制限はコードまたは日本語名で指定できます。
+// @Tags         dietary
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body usecase.SaveDietaryProfileRequest true "食事プロフィール"
+// @Success      201 {object} domain.DietaryProfile "作成された食事プロフィール"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /dietary/profiles [post]
+// CreateProfile handles POST /dietary/profiles
+func (h *DietaryHandler) CreateProfile(c *gin.Context) {
+	var req usecase.SaveDietaryProfileRequest
+
+	// Bind and validate request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, err.Error())
+		return
+	}
+
+	// Call usecase
+	profile, err := h.dietaryUsecase.CreateProfile(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, profile)
+}
+
+// @Summary      IDで食事プロフィールを取得
+// @Description  指定されたIDの食事プロフィールを取得します。
+// @Tags         dietary
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "食事プロフィールID"
+// @Success      200 {object} domain.DietaryProfile "食事プロフィール"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      404 {object} usecase.ErrorResponse "食事プロフィールが見つかりません"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /dietary/profiles/{id} [get]
+// GetProfile handles GET /dietary/profiles/:id
+func (h *DietaryHandler) GetProfile(c *gin.Context) {
+	// Parse ID from URL parameter
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondBadRequest(c, "Invalid profile ID")
+		return
+	}
+
+	// Call usecase
+	profile, err := h.dietaryUsecase.GetProfile(c.Request.Context(), id)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// @Summary      食事プロフィールを更新
+// @Description  指定されたIDの食事プロフィールを置き換えます。
+// @Tags         dietary
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path  int                                true  "食事プロフィールID"
+// @Param        request body  usecase.SaveDietaryProfileRequest  true  "食事プロフィール"
+// @Success      200 {object} domain.DietaryProfile "更新された食事プロフィール"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      404 {object} usecase.ErrorResponse "食事プロフィールが見つかりません"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /dietary/profiles/{id} [put]
+// UpdateProfile handles PUT /dietary/profiles/:id
+func (h *DietaryHandler) UpdateProfile(c *gin.Context) {
+	// Parse ID from URL parameter
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondBadRequest(c, "Invalid profile ID")
+		return
+	}
+
+	var req usecase.SaveDietaryProfileRequest
+
+	// Bind and validate request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, err.Error())
+		return
+	}
+
+	// Call usecase
+	profile, err := h.dietaryUsecase.UpdateProfile(c.Request.Context(), id, req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// @Summary      食事プロフィールを削除
+// @Description  指定されたIDの食事プロフィールを削除します。
+// @Tags         dietary
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "食事プロフィールID"
+// @Success      204 "削除成功"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      404 {object} usecase.ErrorResponse "食事プロフィールが見つかりません"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /dietary/profiles/{id} [delete]
+// DeleteProfile handles DELETE /dietary/profiles/:id
+func (h *DietaryHandler) DeleteProfile(c *gin.Context) {
+	// Parse ID from URL parameter
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondBadRequest(c, "Invalid profile ID")
+		return
+	}
+
+	// Call usecase
+	if err := h.dietaryUsecase.DeleteProfile(c.Request.Context(), id); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,208 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockDietaryUsecase is a mock implementation of DietaryUsecase
+type MockDietaryUsecase struct {
+	mock.Mock
+}
+
+func (m *MockDietaryUsecase) ListRules(ctx context.Context) *usecase.DietaryRulesResponse {
+	args := m.Called(ctx)
+	return args.Get(0).(*usecase.DietaryRulesResponse)
+}
+
+func (m *MockDietaryUsecase) ListProfiles(ctx context.Context) ([]*domain.DietaryProfile, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.DietaryProfile), args.Error(1)
+}
+
+func (m *MockDietaryUsecase) GetProfile(ctx context.Context, id int64) (*domain.DietaryProfile, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DietaryProfile), args.Error(1)
+}
+
+func (m *MockDietaryUsecase) CreateProfile(ctx context.Context, req usecase.SaveDietaryProfileRequest) (*domain.DietaryProfile, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DietaryProfile), args.Error(1)
+}
+
+func (m *MockDietaryUsecase) UpdateProfile(ctx context.Context, id int64, req usecase.SaveDietaryProfileRequest) (*domain.DietaryProfile, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DietaryProfile), args.Error(1)
+}
+
+func (m *MockDietaryUsecase) DeleteProfile(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// TestListDietaryRules_Success tests listing the allergens and restrictions
+func TestListDietaryRules_Success(t *testing.T) {
+	mockUsecase := new(MockDietaryUsecase)
+	handler := NewDietaryHandler(mockUsecase)
+	router := setupTestRouter()
+	router.GET("/dietary/rules", handler.ListRules)
+
+	mockUsecase.On("ListRules", mock.Anything).
+		Return(&usecase.DietaryRulesResponse{Allergens: domain.Allergens, Restrictions: domain.DietaryRestrictions})
+
+	req := httptest.NewRequest(http.MethodGet, "/dietary/rules", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response usecase.DietaryRulesResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Len(t, response.Allergens, len(domain.Allergens))
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestCreateDietaryProfile_Success tests creating a dietary profile
+func TestCreateDietaryProfile_Success(t *testing.T) {
+	mockUsecase := new(MockDietaryUsecase)
+	handler := NewDietaryHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/dietary/profiles", handler.CreateProfile)
+
+	reqBody := usecase.SaveDietaryProfileRequest{Name: "花子", Allergens: []string{"卵"}}
+	mockUsecase.On("CreateProfile", mock.Anything, reqBody).
+		Return(&domain.DietaryProfile{ID: 1, Name: "花子", Allergens: []string{domain.AllergenEgg}}, nil)
+
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPost, "/dietary/profiles", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response domain.DietaryProfile
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{domain.AllergenEgg}, response.Allergens)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestCreateDietaryProfile_MissingName tests that the profile name is required
+func TestCreateDietaryProfile_MissingName(t *testing.T) {
+	mockUsecase := new(MockDietaryUsecase)
+	handler := NewDietaryHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/dietary/profiles", handler.CreateProfile)
+
+	req := httptest.NewRequest(http.MethodPost, "/dietary/profiles", bytes.NewBufferString(`{"allergens":["egg"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUsecase.AssertNotCalled(t, "CreateProfile")
+}
+
+// TestCreateDietaryProfile_UnknownAllergen tests that usecase validation errors map to 400
+func TestCreateDietaryProfile_UnknownAllergen(t *testing.T) {
+	mockUsecase := new(MockDietaryUsecase)
+	handler := NewDietaryHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/dietary/profiles", handler.CreateProfile)
+
+	reqBody := usecase.SaveDietaryProfileRequest{Name: "太郎", Allergens: []string{"chocolate"}}
+	mockUsecase.On("CreateProfile", mock.Anything, reqBody).
+		Return(nil, fmt.Errorf("%w: unknown allergen \"chocolate\"", domain.ErrInvalidInput))
+
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPost, "/dietary/profiles", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestGetDietaryProfile_NotFound tests fetching a missing profile
+func TestGetDietaryProfile_NotFound(t *testing.T) {
+	mockUsecase := new(MockDietaryUsecase)
+	handler := NewDietaryHandler(mockUsecase)
+	router := setupTestRouter()
+	router.GET("/dietary/profiles/:id", handler.GetProfile)
+
+	mockUsecase.On("GetProfile", mock.Anything, int64(99)).
+		Return(nil, fmt.Errorf("dietary profile not found: %w", sql.ErrNoRows))
+
+	req := httptest.NewRequest(http.MethodGet, "/dietary/profiles/99", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestUpdateDietaryProfile_InvalidID tests that a non-numeric ID is rejected
+func TestUpdateDietaryProfile_InvalidID(t *testing.T) {
+	mockUsecase := new(MockDietaryUsecase)
+	handler := NewDietaryHandler(mockUsecase)
+	router := setupTestRouter()
+	router.PUT("/dietary/profiles/:id", handler.UpdateProfile)
+
+	req := httptest.NewRequest(http.MethodPut, "/dietary/profiles/abc", bytes.NewBufferString(`{"name":"花子"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUsecase.AssertNotCalled(t, "UpdateProfile")
+}
+
+// TestDeleteDietaryProfile_Success tests deleting a profile
+func TestDeleteDietaryProfile_Success(t *testing.T) {
+	mockUsecase := new(MockDietaryUsecase)
+	handler := NewDietaryHandler(mockUsecase)
+	router := setupTestRouter()
+	router.DELETE("/dietary/profiles/:id", handler.DeleteProfile)
+
+	mockUsecase.On("DeleteProfile", mock.Anything, int64(1)).Return(nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/dietary/profiles/1", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
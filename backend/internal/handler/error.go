@@ -5,6 +5,7 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
 	"github.com/Rin0530/DinnerDecider/backend/internal/usecase"
 	"github.com/gin-gonic/gin"
 )
@@ -23,14 +24,39 @@ func handleError(c *gin.Context, err error) {
 		return
 	}
 
+	statusCode, response := errorResponse(err)
+	c.JSON(statusCode, response)
+}
+
+// errorResponse maps an error to its HTTP status code and standardized error body
+func errorResponse(err error) (int, usecase.ErrorResponse) {
 	// Handle sql.ErrNoRows (resource not found)
 	if errors.Is(err, sql.ErrNoRows) {
-		respondWithError(c, http.StatusNotFound, "not_found", "Resource not found")
-		return
+		return http.StatusNotFound, usecase.ErrorResponse{Error: "not_found", Message: "Resource not found"}
+	}
+
+	// Handle writes based on an outdated version (If-Match mismatch or a concurrent update)
+	if errors.Is(err, domain.ErrVersionConflict) {
+		return http.StatusPreconditionFailed, usecase.ErrorResponse{Error: "precondition_failed", Message: err.Error()}
+	}
+
+	// Handle missing or invalid credentials
+	if errors.Is(err, domain.ErrUnauthorized) {
+		return http.StatusUnauthorized, usecase.ErrorResponse{Error: "unauthorized", Message: err.Error()}
+	}
+
+	// Handle changes the signed-in user is not allowed to make
+	if errors.Is(err, domain.ErrForbidden) {
+		return http.StatusForbidden, usecase.ErrorResponse{Error: "forbidden", Message: err.Error()}
+	}
+
+	// Handle business validation errors
+	if errors.Is(err, domain.ErrInvalidInput) {
+		return http.StatusBadRequest, usecase.ErrorResponse{Error: "validation_error", Message: err.Error()}
 	}
 
 	// Default to internal server error
-	respondWithError(c, http.StatusInternalServerError, "internal_error", err.Error())
+	return http.StatusInternalServerError, usecase.ErrorResponse{Error: "internal_error", Message: err.Error()}
 }
 
 // respondBadRequest sends a 400 Bad Request response
@@ -43,6 +69,11 @@ func respondNotFound(c *gin.Context, message string) {
 	respondWithError(c, http.StatusNotFound, "not_found", message)
 }
 
+// respondPreconditionFailed sends a 412 Precondition Failed response
+func respondPreconditionFailed(c *gin.Context, message string) {
+	respondWithError(c, http.StatusPreconditionFailed, "precondition_failed", message)
+}
+
 // respondInternalError sends a 500 Internal Server Error response
 func respondInternalError(c *gin.Context, message string) {
 	respondWithError(c, http.StatusInternalServerError, "internal_error", message)
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// ingredientETag returns the strong entity tag of an ingredient, derived from its row version
+func ingredientETag(ingredient *domain.Ingredient) string {
+	return fmt.Sprintf(`"%d"`, ingredient.Version)
+}
+
+// ingredientListETag returns a weak entity tag for a page of the ingredient list.
+// It changes whenever an ingredient on the page is added, removed or modified.
+func ingredientListETag(page *usecase.IngredientListResponse) string {
+	hash := sha256.New()
+	for _, ingredient := range page.Items {
+		fmt.Fprintf(hash, "%d:%d;", ingredient.ID, ingredient.Version)
+	}
+	fmt.Fprintf(hash, "next:%s", page.NextCursor)
+
+	return fmt.Sprintf(`W/"%x"`, hash.Sum(nil)[:16])
+}
+
+// notModified sets the ETag header and answers 304 Not Modified when If-None-Match matches it.
+// It reports whether the response has been written.
+func notModified(c *gin.Context, etag string) bool {
+	c.Header("ETag", etag)
+
+	header := c.GetHeader("If-None-Match")
+	if header == "" {
+		return false
+	}
+
+	// If-None-Match uses the weak comparison, so W/ prefixes are ignored
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == strings.TrimPrefix(etag, "W/") {
+			c.Status(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
+
+// ifMatchVersions extracts the ingredient versions listed in If-Match.
+// A missing header or "*" yields no versions, meaning the write is unconditional.
+// ok is false when the header lists no tag that could ever match, in which case the precondition has already failed.
+func ifMatchVersions(c *gin.Context) (versions []int64, ok bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return nil, true
+	}
+
+	// If-Match uses the strong comparison, so weak tags never match
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if !strings.HasPrefix(tag, `"`) || !strings.HasSuffix(tag, `"`) || len(tag) < 2 {
+			continue
+		}
+		if version, err := strconv.ParseInt(tag[1:len(tag)-1], 10, 64); err == nil {
+			versions = append(versions, version)
+		}
+	}
+
+	return versions, len(versions) > 0
+}
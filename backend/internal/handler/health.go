@@ -66,7 +66,7 @@ func (h *HealthHandler) HealthOllama(c *gin.Context) {
 
 	// Try to generate a simple recipe suggestion with empty ingredients
 	// This will test if Ollama API is reachable and responding
-	_, err := h.ollamaService.GenerateRecipeSuggestion(ctx, nil)
+	_, err := h.ollamaService.GenerateRecipeSuggestion(ctx, nil, service.SuggestionOptions{})
 	if err != nil {
 		c.JSON(http.StatusServiceUnavailable, HealthResponse{
 			Status:  "error",
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// actorHeader names the request header identifying who makes a change
+const actorHeader = "X-Actor"
+
+// EventOrigin returns a middleware that records ingredient changes made by a request
+// as coming from the API, made by the actor named in the X-Actor header.
+// RequireUser replaces the actor with the signed-in user on authenticated routes.
+func EventOrigin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := domain.WithEventSource(c.Request.Context(), domain.EventSourceAPI)
+		if actor := strings.TrimSpace(c.GetHeader(actorHeader)); actor != "" {
+			ctx = domain.WithEventActor(ctx, actor)
+		}
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// HistoryHandler handles HTTP requests for the ingredient change history
+type HistoryHandler struct {
+	historyUsecase usecase.HistoryUsecase
+}
+
+// NewHistoryHandler creates a new HistoryHandler instance
+func NewHistoryHandler(historyUsecase usecase.HistoryUsecase) *HistoryHandler {
+	return &HistoryHandler{
+		historyUsecase: historyUsecase,
+	}
+}
+
+// @Summary      食材の変更履歴を取得
+// @Description  指定されたIDの食材の追加・変更・削除の履歴を新しい順に取得します。完全に削除された食材の履歴も取得できます。
+// @Tags         history
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path   int     true   "食材ID"
+// @Param        actor   query  string  false  "変更した人"
+// @Param        source  query  string  false  "変更の経路"  Enums(api, import, cook, system)
+// @Param        action  query  string  false  "操作"  Enums(create, update, delete, restore, purge)
+// @Param        since   query  string  false  "この日時以降の履歴 (YYYY-MM-DD または RFC3339)"
+// @Param        until   query  string  false  "この日時より前の履歴。日付のみの場合はその日を含みます (YYYY-MM-DD または RFC3339)"
+// @Param        cursor  query  string  false  "前のページの next_cursor"
+// @Param        limit   query  int     false  "1ページの件数 (デフォルト: 50、最大: 200)"
+// @Success      200 {object} usecase.IngredientEventListResponse "変更履歴"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /ingredients/{id}/history [get]
+// GetIngredientHistory handles GET /ingredients/:id/history
+func (h *HistoryHandler) GetIngredientHistory(c *gin.Context) {
+	// Parse ID from URL parameter
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		respondBadRequest(c, "Invalid ingredient ID")
+		return
+	}
+
+	var req usecase.ListIngredientEventsRequest
+
+	// Bind query parameters
+	if err := c.ShouldBindQuery(&req); err != nil {
+		respondBadRequest(c, err.Error())
+		return
+	}
+
+	// Call usecase
+	page, err := h.historyUsecase.GetIngredientHistory(c.Request.Context(), id, req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// @Summary      食材のアクティビティを取得
+// @Description  すべての食材の追加・変更・削除の履歴を新しい順に取得します。「誰が牛乳を使い切ったか」は action=update や action=delete と actor で絞り込めます。
+// @Tags         history
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        actor   query  string  false  "変更した人"
+// @Param        source  query  string  false  "変更の経路"  Enums(api, import, cook, system)
+// @Param        action  query  string  false  "操作"  Enums(create, update, delete, restore, purge)
+// @Param        since   query  string  false  "この日時以降の履歴 (YYYY-MM-DD または RFC3339)"
+// @Param        until   query  string  false  "この日時より前の履歴。日付のみの場合はその日を含みます (YYYY-MM-DD または RFC3339)"
+// @Param        cursor  query  string  false  "前のページの next_cursor"
+// @Param        limit   query  int     false  "1ページの件数 (デフォルト: 50、最大: 200)"
+// @Success      200 {object} usecase.IngredientEventListResponse "変更履歴"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /ingredients/activity [get]
+// GetActivity handles GET /ingredients/activity
+func (h *HistoryHandler) GetActivity(c *gin.Context) {
+	var req usecase.ListIngredientEventsRequest
+
+	// Bind query parameters
+	if err := c.ShouldBindQuery(&req); err != nil {
+		respondBadRequest(c, err.Error())
+		return
+	}
+
+	// Call usecase
+	page, err := h.historyUsecase.GetActivity(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// @Summary      過去の在庫を復元
+// @Description  変更履歴から指定した日時の在庫を復元します。日付のみを指定した場合はその日の終わりの在庫になります。履歴の記録を始める前の変更は反映されません。
+// @Tags         history
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        at  query  string  true  "復元する日時 (YYYY-MM-DD または RFC3339)"
+// @Success      200 {object} usecase.InventorySnapshotResponse "その時点の在庫"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /ingredients/snapshot [get]
+// GetInventorySnapshot handles GET /ingredients/snapshot
+func (h *HistoryHandler) GetInventorySnapshot(c *gin.Context) {
+	// Call usecase
+	snapshot, err := h.historyUsecase.GetInventoryAt(c.Request.Context(), c.Query("at"))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, snapshot)
+}
@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockHistoryUsecase is a mock implementation of HistoryUsecase
+type MockHistoryUsecase struct {
+	mock.Mock
+}
+
+func (m *MockHistoryUsecase) GetIngredientHistory(ctx context.Context, id int64, req usecase.ListIngredientEventsRequest) (*usecase.IngredientEventListResponse, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.IngredientEventListResponse), args.Error(1)
+}
+
+func (m *MockHistoryUsecase) GetActivity(ctx context.Context, req usecase.ListIngredientEventsRequest) (*usecase.IngredientEventListResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.IngredientEventListResponse), args.Error(1)
+}
+
+func (m *MockHistoryUsecase) GetInventoryAt(ctx context.Context, at string) (*usecase.InventorySnapshotResponse, error) {
+	args := m.Called(ctx, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.InventorySnapshotResponse), args.Error(1)
+}
+
+// TestEventOrigin tests that the middleware records API changes with the actor from the header
+func TestEventOrigin(t *testing.T) {
+	router := setupTestRouter()
+	router.Use(EventOrigin())
+
+	var actor, source string
+	router.GET("/origin", func(c *gin.Context) {
+		actor = domain.EventActor(c.Request.Context())
+		source = domain.EventSource(c.Request.Context())
+		c.Status(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/origin", nil)
+	req.Header.Set("X-Actor", " alice ")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "alice", actor)
+	assert.Equal(t, domain.EventSourceAPI, source)
+}
+
+// TestGetIngredientHistory_Success tests retrieving the history of an ingredient with filters
+func TestGetIngredientHistory_Success(t *testing.T) {
+	mockUsecase := new(MockHistoryUsecase)
+	handler := NewHistoryHandler(mockUsecase)
+	router := setupTestRouter()
+	router.GET("/ingredients/:id/history", handler.GetIngredientHistory)
+
+	expected := &usecase.IngredientEventListResponse{
+		Items: []*domain.IngredientEvent{
+			{ID: 2, IngredientID: 1, Action: domain.EventActionDelete, Actor: "alice", Source: domain.EventSourceAPI, Before: &domain.Ingredient{ID: 1, Name: "牛乳"}},
+		},
+		NextCursor: "2",
+	}
+	mockUsecase.On("GetIngredientHistory", mock.Anything, int64(1), usecase.ListIngredientEventsRequest{Action: domain.EventActionDelete, Limit: 1}).
+		Return(expected, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/ingredients/1/history?action=delete&limit=1", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response usecase.IngredientEventListResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Len(t, response.Items, 1)
+	assert.Equal(t, "alice", response.Items[0].Actor)
+	assert.Equal(t, "牛乳", response.Items[0].Before.Name)
+	assert.Nil(t, response.Items[0].After)
+	assert.Equal(t, "2", response.NextCursor)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestGetIngredientHistory_InvalidID tests that a non-numeric ID is rejected
+func TestGetIngredientHistory_InvalidID(t *testing.T) {
+	mockUsecase := new(MockHistoryUsecase)
+	handler := NewHistoryHandler(mockUsecase)
+	router := setupTestRouter()
+	router.GET("/ingredients/:id/history", handler.GetIngredientHistory)
+
+	req := httptest.NewRequest(http.MethodGet, "/ingredients/abc/history", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUsecase.AssertNotCalled(t, "GetIngredientHistory", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestGetActivity_InvalidInput tests that usecase validation errors map to 400
+func TestGetActivity_InvalidInput(t *testing.T) {
+	mockUsecase := new(MockHistoryUsecase)
+	handler := NewHistoryHandler(mockUsecase)
+	router := setupTestRouter()
+	router.GET("/ingredients/activity", handler.GetActivity)
+
+	mockUsecase.On("GetActivity", mock.Anything, usecase.ListIngredientEventsRequest{Source: "fax"}).
+		Return(nil, fmt.Errorf("%w: unknown source %q", domain.ErrInvalidInput, "fax"))
+
+	req := httptest.NewRequest(http.MethodGet, "/ingredients/activity?source=fax", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestGetInventorySnapshot_Success tests reconstructing the inventory at a past date
+func TestGetInventorySnapshot_Success(t *testing.T) {
+	mockUsecase := new(MockHistoryUsecase)
+	handler := NewHistoryHandler(mockUsecase)
+	router := setupTestRouter()
+	router.GET("/ingredients/snapshot", handler.GetInventorySnapshot)
+
+	expected := &usecase.InventorySnapshotResponse{Items: []*domain.Ingredient{{ID: 1, Name: "牛乳"}}}
+	mockUsecase.On("GetInventoryAt", mock.Anything, "2026-10-01").Return(expected, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/ingredients/snapshot?at=2026-10-01", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response usecase.InventorySnapshotResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Len(t, response.Items, 1)
+	mockUsecase.AssertExpectations(t)
+}
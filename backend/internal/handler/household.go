@@ -0,0 +1,409 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// HouseholdHeader selects the household a request works in; without it the user's first household is used
+const HouseholdHeader = "X-Household-ID"
+
+// RequireHousehold returns a middleware that limits the request to the household selected by the X-Household-ID
+// header, rejecting households the signed-in user does not belong to. It must run after RequireUser.
+// The household used is echoed in the X-Household-ID response header.
+func RequireHousehold(householdUsecase usecase.HouseholdUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var householdID int64
+		if header := c.GetHeader(HouseholdHeader); header != "" {
+			id, err := strconv.ParseInt(header, 10, 64)
+			if err != nil || id <= 0 {
+				c.AbortWithStatusJSON(http.StatusBadRequest, usecase.ErrorResponse{
+					Error:   "validation_error",
+					Message: "Invalid " + HouseholdHeader + " header",
+				})
+				return
+			}
+			householdID = id
+		}
+
+		household, err := householdUsecase.Resolve(c.Request.Context(), householdID)
+		if err != nil {
+			statusCode, response := errorResponse(err)
+			c.AbortWithStatusJSON(statusCode, response)
+			return
+		}
+
+		c.Header(HouseholdHeader, strconv.FormatInt(household.ID, 10))
+		c.Request = c.Request.WithContext(domain.WithHousehold(c.Request.Context(), household))
+		c.Next()
+	}
+}
+
+// HouseholdHandler handles HTTP requests for households, their members and invitation codes
+type HouseholdHandler struct {
+	householdUsecase usecase.HouseholdUsecase
+}
+
+// NewHouseholdHandler creates a new HouseholdHandler instance
+func NewHouseholdHandler(householdUsecase usecase.HouseholdUsecase) *HouseholdHandler {
+	return &HouseholdHandler{
+		householdUsecase: householdUsecase,
+	}
+}
+
+// @Summary      所属する世帯の一覧を取得
+// @Description  ログイン中のユーザーが所属する世帯と、それぞれでの役割を返します。
+// @Tags         households
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {array} domain.Household "世帯のリスト"
+// @Failure      401 {object} usecase.ErrorResponse "認証が必要です"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /households [get]
+// ListHouseholds handles GET /households
+func (h *HouseholdHandler) ListHouseholds(c *gin.Context) {
+	// Call usecase
+	households, err := h.householdUsecase.ListHouseholds(c.Request.Context())
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, households)
+}
+
+// @Summary      世帯を作成
+// @Description  新しい世帯を作成し、ログイン中のユーザーをオーナーにします。
+// @Tags         households
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body usecase.SaveHouseholdRequest true "世帯名"
+// @Success      201 {object} domain.Household "作成された世帯"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      401 {object} usecase.ErrorResponse "認証が必要です"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /households [post]
+// CreateHousehold handles POST /households
+func (h *HouseholdHandler) CreateHousehold(c *gin.Context) {
+	var req usecase.SaveHouseholdRequest
+
+	// Bind and validate request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, err.Error())
+		return
+	}
+
+	// Call usecase
+	household, err := h.householdUsecase.CreateHousehold(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, household)
+}
+
+// @Summary      招待コードで世帯に参加
+// @Description  世帯のオーナーから受け取った招待コードで世帯に参加します。招待コードは一度だけ使えます。
+// @Tags         households
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body usecase.JoinHouseholdRequest true "招待コード"
+// @Success      200 {object} domain.Household "参加した世帯"
+// @Failure      400 {object} usecase.ErrorResponse "招待コードが無効です"
+// @Failure      401 {object} usecase.ErrorResponse "認証が必要です"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /households/join [post]
+// JoinHousehold handles POST /households/join
+func (h *HouseholdHandler) JoinHousehold(c *gin.Context) {
+	var req usecase.JoinHouseholdRequest
+
+	// Bind and validate request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, err.Error())
+		return
+	}
+
+	// Call usecase
+	household, err := h.householdUsecase.Join(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, household)
+}
+
+// @Summary      世帯名を変更
+// @Description  世帯の名前を変更します。世帯のオーナーのみ利用できます。
+// @Tags         households
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path  int                           true  "世帯ID"
+// @Param        request body  usecase.SaveHouseholdRequest  true  "世帯名"
+// @Success      200 {object} domain.Household "更新された世帯"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      403 {object} usecase.ErrorResponse "世帯のオーナーではありません"
+// @Failure      404 {object} usecase.ErrorResponse "世帯が見つかりません"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /households/{id} [put]
+// UpdateHousehold handles PUT /households/:id
+func (h *HouseholdHandler) UpdateHousehold(c *gin.Context) {
+	// Parse ID from URL parameter
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondBadRequest(c, "Invalid household ID")
+		return
+	}
+
+	var req usecase.SaveHouseholdRequest
+
+	// Bind and validate request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, err.Error())
+		return
+	}
+
+	// Call usecase
+	household, err := h.householdUsecase.UpdateHousehold(c.Request.Context(), id, req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, household)
+}
+
+// @Summary      世帯のメンバー一覧を取得
+// @Description  世帯に所属するユーザーと役割を参加順に返します。
+// @Tags         households
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "世帯ID"
+// @Success      200 {array} domain.HouseholdMember "メンバーのリスト"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      404 {object} usecase.ErrorResponse "世帯が見つかりません"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /households/{id}/members [get]
+// ListMembers handles GET /households/:id/members
+func (h *HouseholdHandler) ListMembers(c *gin.Context) {
+	// Parse ID from URL parameter
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondBadRequest(c, "Invalid household ID")
+		return
+	}
+
+	// Call usecase
+	members, err := h.householdUsecase.ListMembers(c.Request.Context(), id)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, members)
+}
+
+// @Summary      メンバーの役割を変更
+// @Description  メンバーの役割を owner、member、viewer のいずれかに変更します。世帯のオーナーのみ利用できます。最後のオーナーは役割を変更できません。
+// @Tags         households
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  int                                   true  "世帯ID"
+// @Param        user_id  path  int                                   true  "ユーザーID"
+// @Param        request  body  usecase.UpdateHouseholdMemberRequest  true  "役割"
+// @Success      204 "変更成功"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      403 {object} usecase.ErrorResponse "世帯のオーナーではありません"
+// @Failure      404 {object} usecase.ErrorResponse "世帯またはメンバーが見つかりません"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /households/{id}/members/{user_id} [put]
+// UpdateMember handles PUT /households/:id/members/:user_id
+func (h *HouseholdHandler) UpdateMember(c *gin.Context) {
+	// Parse IDs from URL parameters
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondBadRequest(c, "Invalid household ID")
+		return
+	}
+	userID, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
+	if err != nil {
+		respondBadRequest(c, "Invalid user ID")
+		return
+	}
+
+	var req usecase.UpdateHouseholdMemberRequest
+
+	// Bind and validate request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, err.Error())
+		return
+	}
+
+	// Call usecase
+	if err := h.householdUsecase.UpdateMember(c.Request.Context(), id, userID, req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary      メンバーを外す・世帯から抜ける
+// @Description  メンバーを世帯から外します。オーナーは誰でも外せ、それ以外のメンバーは自分自身のみ外せます。最後のオーナーは抜けられません。
+// @Tags         households
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  int  true  "世帯ID"
+// @Param        user_id  path  int  true  "ユーザーID"
+// @Success      204 "削除成功"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      403 {object} usecase.ErrorResponse "世帯のオーナーではありません"
+// @Failure      404 {object} usecase.ErrorResponse "世帯またはメンバーが見つかりません"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /households/{id}/members/{user_id} [delete]
+// RemoveMember handles DELETE /households/:id/members/:user_id
+func (h *HouseholdHandler) RemoveMember(c *gin.Context) {
+	// Parse IDs from URL parameters
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondBadRequest(c, "Invalid household ID")
+		return
+	}
+	userID, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
+	if err != nil {
+		respondBadRequest(c, "Invalid user ID")
+		return
+	}
+
+	// Call usecase
+	if err := h.householdUsecase.RemoveMember(c.Request.Context(), id, userID); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary      招待コードの一覧を取得
+// @Description  未使用で有効期限内の招待コードを新しい順に返します。世帯のオーナーのみ利用できます。
+// @Tags         households
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "世帯ID"
+// @Success      200 {array} domain.HouseholdInvitation "招待コードのリスト"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      403 {object} usecase.ErrorResponse "世帯のオーナーではありません"
+// @Failure      404 {object} usecase.ErrorResponse "世帯が見つかりません"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /households/{id}/invitations [get]
+// ListInvitations handles GET /households/:id/invitations
+func (h *HouseholdHandler) ListInvitations(c *gin.Context) {
+	// Parse ID from URL parameter
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondBadRequest(c, "Invalid household ID")
+		return
+	}
+
+	// Call usecase
+	invitations, err := h.householdUsecase.ListInvitations(c.Request.Context(), id)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invitations)
+}
+
+// @Summary      招待コードを発行
+// @Description  世帯に参加するための招待コードを発行します。コードは一度だけ使え、7日で失効します。世帯のオーナーのみ利用できます。
+// @Tags         households
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  int                              true   "世帯ID"
+// @Param        request  body  usecase.CreateInvitationRequest  false  "参加者の役割（省略時は member）"
+// @Success      201 {object} domain.HouseholdInvitation "発行された招待コード"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      403 {object} usecase.ErrorResponse "世帯のオーナーではありません"
+// @Failure      404 {object} usecase.ErrorResponse "世帯が見つかりません"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /households/{id}/invitations [post]
+// CreateInvitation handles POST /households/:id/invitations
+func (h *HouseholdHandler) CreateInvitation(c *gin.Context) {
+	// Parse ID from URL parameter
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondBadRequest(c, "Invalid household ID")
+		return
+	}
+
+	var req usecase.CreateInvitationRequest
+
+	// The body is optional; without it the code invites a member
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondBadRequest(c, err.Error())
+			return
+		}
+	}
+
+	// Call usecase
+	invitation, err := h.householdUsecase.CreateInvitation(c.Request.Context(), id, req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, invitation)
+}
+
+// @Summary      招待コードを取り消す
+// @Description  未使用の招待コードを無効にします。世帯のオーナーのみ利用できます。
+// @Tags         households
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id             path  int  true  "世帯ID"
+// @Param        invitation_id  path  int  true  "招待コードID"
+// @Success      204 "削除成功"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      403 {object} usecase.ErrorResponse "世帯のオーナーではありません"
+// @Failure      404 {object} usecase.ErrorResponse "世帯または招待コードが見つかりません"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /households/{id}/invitations/{invitation_id} [delete]
+// RevokeInvitation handles DELETE /households/:id/invitations/:invitation_id
+func (h *HouseholdHandler) RevokeInvitation(c *gin.Context) {
+	// Parse IDs from URL parameters
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondBadRequest(c, "Invalid household ID")
+		return
+	}
+	invitationID, err := strconv.ParseInt(c.Param("invitation_id"), 10, 64)
+	if err != nil {
+		respondBadRequest(c, "Invalid invitation ID")
+		return
+	}
+
+	// Call usecase
+	if err := h.householdUsecase.RevokeInvitation(c.Request.Context(), id, invitationID); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
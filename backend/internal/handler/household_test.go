@@ -0,0 +1,229 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockHouseholdUsecase is a mock implementation of HouseholdUsecase
+type MockHouseholdUsecase struct {
+	mock.Mock
+}
+
+func (m *MockHouseholdUsecase) Resolve(ctx context.Context, householdID int64) (*domain.Household, error) {
+	args := m.Called(ctx, householdID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Household), args.Error(1)
+}
+
+func (m *MockHouseholdUsecase) ListHouseholds(ctx context.Context) ([]*domain.Household, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Household), args.Error(1)
+}
+
+func (m *MockHouseholdUsecase) CreateHousehold(ctx context.Context, req usecase.SaveHouseholdRequest) (*domain.Household, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Household), args.Error(1)
+}
+
+func (m *MockHouseholdUsecase) UpdateHousehold(ctx context.Context, id int64, req usecase.SaveHouseholdRequest) (*domain.Household, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Household), args.Error(1)
+}
+
+func (m *MockHouseholdUsecase) ListMembers(ctx context.Context, id int64) ([]*domain.HouseholdMember, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.HouseholdMember), args.Error(1)
+}
+
+func (m *MockHouseholdUsecase) UpdateMember(ctx context.Context, id int64, userID int64, req usecase.UpdateHouseholdMemberRequest) error {
+	args := m.Called(ctx, id, userID, req)
+	return args.Error(0)
+}
+
+func (m *MockHouseholdUsecase) RemoveMember(ctx context.Context, id int64, userID int64) error {
+	args := m.Called(ctx, id, userID)
+	return args.Error(0)
+}
+
+func (m *MockHouseholdUsecase) ListInvitations(ctx context.Context, id int64) ([]*domain.HouseholdInvitation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.HouseholdInvitation), args.Error(1)
+}
+
+func (m *MockHouseholdUsecase) CreateInvitation(ctx context.Context, id int64, req usecase.CreateInvitationRequest) (*domain.HouseholdInvitation, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.HouseholdInvitation), args.Error(1)
+}
+
+func (m *MockHouseholdUsecase) RevokeInvitation(ctx context.Context, id int64, invitationID int64) error {
+	args := m.Called(ctx, id, invitationID)
+	return args.Error(0)
+}
+
+func (m *MockHouseholdUsecase) Join(ctx context.Context, req usecase.JoinHouseholdRequest) (*domain.Household, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Household), args.Error(1)
+}
+
+// TestRequireHousehold_Default tests that requests without the header work in the resolved household
+func TestRequireHousehold_Default(t *testing.T) {
+	mockUsecase := new(MockHouseholdUsecase)
+	router := setupTestRouter()
+
+	var householdID *int64
+	router.GET("/protected", RequireHousehold(mockUsecase), func(c *gin.Context) {
+		householdID = domain.HouseholdID(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+
+	mockUsecase.On("Resolve", mock.Anything, int64(0)).
+		Return(&domain.Household{ID: 3, Name: "山田家", Role: domain.HouseholdRoleMember}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "3", w.Header().Get(HouseholdHeader))
+	if assert.NotNil(t, householdID) {
+		assert.Equal(t, int64(3), *householdID)
+	}
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestRequireHousehold_InvalidHeader tests that malformed household IDs are rejected
+func TestRequireHousehold_InvalidHeader(t *testing.T) {
+	mockUsecase := new(MockHouseholdUsecase)
+	router := setupTestRouter()
+	router.GET("/protected", RequireHousehold(mockUsecase), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set(HouseholdHeader, "abc")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUsecase.AssertNotCalled(t, "Resolve", mock.Anything, mock.Anything)
+}
+
+// TestRequireHousehold_NotMember tests that households the user does not belong to are answered with 403
+func TestRequireHousehold_NotMember(t *testing.T) {
+	mockUsecase := new(MockHouseholdUsecase)
+	router := setupTestRouter()
+	router.GET("/protected", RequireHousehold(mockUsecase), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	mockUsecase.On("Resolve", mock.Anything, int64(5)).
+		Return(nil, fmt.Errorf("%w: you do not belong to household 5", domain.ErrForbidden))
+
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set(HouseholdHeader, "5")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	var response usecase.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "forbidden", response.Error)
+}
+
+// TestCreateInvitation_NoBody tests that invitations can be issued without a request body
+func TestCreateInvitation_NoBody(t *testing.T) {
+	mockUsecase := new(MockHouseholdUsecase)
+	handler := NewHouseholdHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/households/:id/invitations", handler.CreateInvitation)
+
+	mockUsecase.On("CreateInvitation", mock.Anything, int64(3), usecase.CreateInvitationRequest{}).
+		Return(&domain.HouseholdInvitation{ID: 1, HouseholdID: 3, Code: "ABCD2345EFGH", Role: domain.HouseholdRoleMember}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/households/3/invitations", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"ABCD2345EFGH"`)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestJoinHousehold_InvalidCode tests that unusable invitation codes are answered with 400
+func TestJoinHousehold_InvalidCode(t *testing.T) {
+	mockUsecase := new(MockHouseholdUsecase)
+	handler := NewHouseholdHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/households/join", handler.JoinHousehold)
+
+	reqBody := usecase.JoinHouseholdRequest{Code: "USED2345CODE"}
+	mockUsecase.On("Join", mock.Anything, reqBody).
+		Return(nil, fmt.Errorf("%w: the invitation code is unknown, already used or expired", domain.ErrInvalidInput))
+
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPost, "/households/join", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestRemoveMember_InvalidUserID tests that malformed user IDs are rejected
+func TestRemoveMember_InvalidUserID(t *testing.T) {
+	mockUsecase := new(MockHouseholdUsecase)
+	handler := NewHouseholdHandler(mockUsecase)
+	router := setupTestRouter()
+	router.DELETE("/households/:id/members/:user_id", handler.RemoveMember)
+
+	req := httptest.NewRequest(http.MethodDelete, "/households/3/members/abc", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUsecase.AssertNotCalled(t, "RemoveMember", mock.Anything, mock.Anything, mock.Anything)
+}
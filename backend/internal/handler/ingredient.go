@@ -1,8 +1,11 @@
 package handler
 
 import (
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/Rin0530/DinnerDecider/backend/internal/usecase"
 	"github.com/gin-gonic/gin"
@@ -25,8 +28,10 @@ func NewIngredientHandler(ingredientUsecase usecase.IngredientUsecase) *Ingredie
 // @Tags         ingredients
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param        ingredient body usecase.CreateIngredientRequest true "作成する食材の情報"
 // @Success      201 {object} domain.Ingredient "作成された食材"
+// @Header       201 {string} ETag "食材のバージョンを表すエンティティタグ"
 // @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
 // @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
 // @Router       /ingredients [post]
@@ -48,28 +53,55 @@ func (h *IngredientHandler) CreateIngredient(c *gin.Context) {
 	}
 
 	// Return created ingredient with 201 status
+	c.Header("ETag", ingredientETag(ingredient))
 	c.JSON(http.StatusCreated, ingredient)
 }
 
 // @Summary      すべての食材を取得
-// @Description  冷蔵庫にあるすべての食材のリストを取得します。
+// @Description  冷蔵庫にある食材のリストを取得します。名前での検索、カテゴリ・保存場所・購入日での絞り込み、並び替えができます。
+// @Description  結果はページ単位で返され、次のページがある場合は next_cursor を cursor に指定して続きを取得します。
 // @Tags         ingredients
 // @Accept       json
 // @Produce      json
-// @Success      200 {array} domain.Ingredient "食材のリスト"
+// @Security     BearerAuth
+// @Param        q                 query  string  false  "食材名の部分一致検索"
+// @Param        category          query  string  false  "カテゴリ"  Enums(vegetable, fruit, meat, seafood, dairy, soy, grain, seasoning, other)
+// @Param        location          query  string  false  "保存場所"  Enums(fridge, freezer, pantry)
+// @Param        purchased_before  query  string  false  "この日付より前に購入した食材 (YYYY-MM-DD)"
+// @Param        sort              query  string  false  "並び替えの基準 (デフォルト: created_at)"  Enums(created_at, name, purchase_date, expires_at)
+// @Param        order             query  string  false  "並び順 (デフォルト: created_at は desc、それ以外は asc)"  Enums(asc, desc)
+// @Param        cursor            query  string  false  "前のページの next_cursor"
+// @Param        limit             query  int     false  "1ページの件数 (デフォルト: 50、最大: 200)"
+// @Param        If-None-Match     header  string  false  "前回の ETag。一覧に変更がなければ 304 を返します"
+// @Success      200 {object} usecase.IngredientListResponse "食材のリスト"
+// @Header       200 {string} ETag "一覧の内容を表すエンティティタグ"
+// @Success      304 "変更なし"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
 // @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
 // @Router       /ingredients [get]
 // GetAllIngredients handles GET /ingredients
 func (h *IngredientHandler) GetAllIngredients(c *gin.Context) {
+	var req usecase.ListIngredientsRequest
+
+	// Bind query parameters
+	if err := c.ShouldBindQuery(&req); err != nil {
+		respondBadRequest(c, err.Error())
+		return
+	}
+
 	// Call usecase
-	ingredients, err := h.ingredientUsecase.GetAllIngredients(c.Request.Context())
+	page, err := h.ingredientUsecase.GetAllIngredients(c.Request.Context(), req)
 	if err != nil {
 		handleError(c, err)
 		return
 	}
 
-	// Return ingredients array (empty array if no ingredients)
-	c.JSON(http.StatusOK, ingredients)
+	if notModified(c, ingredientListETag(page)) {
+		return
+	}
+
+	// Return the page (items is an empty array if no ingredients)
+	c.JSON(http.StatusOK, page)
 }
 
 // @Summary      IDで食材を取得
@@ -77,8 +109,12 @@ func (h *IngredientHandler) GetAllIngredients(c *gin.Context) {
 // @Tags         ingredients
 // @Accept       json
 // @Produce      json
-// @Param        id   path      int  true  "食材ID"
+// @Security     BearerAuth
+// @Param        id             path    int     true   "食材ID"
+// @Param        If-None-Match  header  string  false  "前回の ETag。食材に変更がなければ 304 を返します"
 // @Success      200 {object} domain.Ingredient "食材の情報"
+// @Header       200 {string} ETag "食材のバージョンを表すエンティティタグ"
+// @Success      304 "変更なし"
 // @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
 // @Failure      404 {object} usecase.ErrorResponse "食材が見つかりません"
 // @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
@@ -100,19 +136,27 @@ func (h *IngredientHandler) GetIngredientByID(c *gin.Context) {
 		return
 	}
 
+	if notModified(c, ingredientETag(ingredient)) {
+		return
+	}
+
 	c.JSON(http.StatusOK, ingredient)
 }
 
-// @Summary      食材を更新
-// @Description  指定されたIDの食材情報を更新します。
+// @Summary      食材を置き換え
+// @Description  指定されたIDの食材情報をリクエストの内容で置き換えます。省略した項目はクリアされます（カテゴリ・保存場所は再判定されます）。
 // @Tags         ingredients
 // @Accept       json
 // @Produce      json
-// @Param        id   path      int  true  "食材ID"
-// @Param        ingredient body usecase.UpdateIngredientRequest true "更新する食材の情報"
+// @Security     BearerAuth
+// @Param        id          path    int                              true   "食材ID"
+// @Param        If-Match    header  string                           false  "取得時の ETag。食材が更新されていた場合は 412 を返します"
+// @Param        ingredient  body    usecase.UpdateIngredientRequest  true   "置き換える食材の情報"
 // @Success      200 {object} domain.Ingredient "更新された食材"
+// @Header       200 {string} ETag "更新後の食材のエンティティタグ"
 // @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
 // @Failure      404 {object} usecase.ErrorResponse "食材が見つかりません"
+// @Failure      412 {object} usecase.ErrorResponse "食材が他の更新により変更されています"
 // @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
 // @Router       /ingredients/{id} [put]
 // UpdateIngredient handles PUT /ingredients/:id
@@ -125,6 +169,12 @@ func (h *IngredientHandler) UpdateIngredient(c *gin.Context) {
 		return
 	}
 
+	versions, ok := ifMatchVersions(c)
+	if !ok {
+		respondPreconditionFailed(c, "If-Match does not match the ingredient")
+		return
+	}
+
 	var req usecase.UpdateIngredientRequest
 
 	// Bind and validate request body
@@ -132,6 +182,7 @@ func (h *IngredientHandler) UpdateIngredient(c *gin.Context) {
 		respondBadRequest(c, err.Error())
 		return
 	}
+	req.ExpectedVersions = versions
 
 	// Call usecase
 	ingredient, err := h.ingredientUsecase.UpdateIngredient(c.Request.Context(), id, req)
@@ -141,17 +192,93 @@ func (h *IngredientHandler) UpdateIngredient(c *gin.Context) {
 	}
 
 	// Return updated ingredient
+	c.Header("ETag", ingredientETag(ingredient))
+	c.JSON(http.StatusOK, ingredient)
+}
+
+// @Summary      食材を部分更新
+// @Description  指定されたIDの食材情報を部分的に更新します。
+// @Description  Content-Type が application/merge-patch+json（または application/json）の場合は RFC 7396 のマージパッチとして扱い、null を指定した項目はクリアされます。
+// @Description  Content-Type が application/json-patch+json の場合は RFC 6902 の JSON Patch として扱います。
+// @Tags         ingredients
+// @Accept       application/merge-patch+json
+// @Accept       application/json-patch+json
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id        path    int     true   "食材ID"
+// @Param        If-Match  header  string  false  "取得時の ETag。食材が更新されていた場合は 412 を返します"
+// @Param        patch     body    object  true   "マージパッチまたは JSON Patch"
+// @Success      200 {object} domain.Ingredient "更新された食材"
+// @Header       200 {string} ETag "更新後の食材のエンティティタグ"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      404 {object} usecase.ErrorResponse "食材が見つかりません"
+// @Failure      412 {object} usecase.ErrorResponse "食材が他の更新により変更されています"
+// @Failure      415 {object} usecase.ErrorResponse "サポートされていない Content-Type です"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /ingredients/{id} [patch]
+// PatchIngredient handles PATCH /ingredients/:id
+func (h *IngredientHandler) PatchIngredient(c *gin.Context) {
+	// Parse ID from URL parameter
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		respondBadRequest(c, "Invalid ingredient ID")
+		return
+	}
+
+	// Choose the patch format from the content type
+	var format string
+	switch c.ContentType() {
+	case "application/merge-patch+json", "application/json", "":
+		format = usecase.PatchFormatMerge
+	case "application/json-patch+json":
+		format = usecase.PatchFormatJSONPatch
+	default:
+		respondWithError(c, http.StatusUnsupportedMediaType, "unsupported_media_type",
+			"Content-Type must be application/merge-patch+json or application/json-patch+json")
+		return
+	}
+
+	versions, ok := ifMatchVersions(c)
+	if !ok {
+		respondPreconditionFailed(c, "If-Match does not match the ingredient")
+		return
+	}
+
+	patch, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		respondBadRequest(c, err.Error())
+		return
+	}
+
+	// Call usecase
+	ingredient, err := h.ingredientUsecase.PatchIngredient(c.Request.Context(), id, usecase.PatchIngredientRequest{
+		Format:           format,
+		Patch:            patch,
+		ExpectedVersions: versions,
+	})
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	// Return updated ingredient
+	c.Header("ETag", ingredientETag(ingredient))
 	c.JSON(http.StatusOK, ingredient)
 }
 
 // @Summary      食材を削除
-// @Description  指定されたIDの食材を削除します。
+// @Description  指定されたIDの食材をゴミ箱に移動します。ゴミ箱の食材は保持期間が過ぎるまで復元できます。
 // @Tags         ingredients
 // @Accept       json
 // @Produce      json
-// @Param        id   path      int  true  "食材ID"
+// @Security     BearerAuth
+// @Param        id        path    int     true   "食材ID"
+// @Param        If-Match  header  string  false  "取得時の ETag。食材が更新されていた場合は 412 を返します"
 // @Success      204 "削除成功"
 // @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      412 {object} usecase.ErrorResponse "食材が他の更新により変更されています"
 // @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
 // @Router       /ingredients/{id} [delete]
 // DeleteIngredient handles DELETE /ingredients/:id
@@ -164,8 +291,14 @@ func (h *IngredientHandler) DeleteIngredient(c *gin.Context) {
 		return
 	}
 
+	versions, ok := ifMatchVersions(c)
+	if !ok {
+		respondPreconditionFailed(c, "If-Match does not match the ingredient")
+		return
+	}
+
 	// Call usecase
-	err = h.ingredientUsecase.DeleteIngredient(c.Request.Context(), id)
+	err = h.ingredientUsecase.DeleteIngredient(c.Request.Context(), id, versions)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -174,3 +307,202 @@ func (h *IngredientHandler) DeleteIngredient(c *gin.Context) {
 	// Return 204 No Content on successful deletion
 	c.Status(http.StatusNoContent)
 }
+
+// @Summary      ゴミ箱の食材を取得
+// @Description  削除された食材のうち、まだ完全に削除されていないものを削除日時の新しい順に取得します。
+// @Tags         ingredients
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {array} domain.Ingredient "ゴミ箱の食材のリスト"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /ingredients/trash [get]
+// GetTrash handles GET /ingredients/trash
+func (h *IngredientHandler) GetTrash(c *gin.Context) {
+	// Call usecase
+	ingredients, err := h.ingredientUsecase.GetTrash(c.Request.Context())
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ingredients)
+}
+
+// @Summary      食材を復元
+// @Description  ゴミ箱にある指定されたIDの食材を元に戻します。
+// @Tags         ingredients
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path  int  true  "食材ID"
+// @Success      200 {object} domain.Ingredient "復元された食材"
+// @Header       200 {string} ETag "復元後の食材のエンティティタグ"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      404 {object} usecase.ErrorResponse "ゴミ箱に食材が見つかりません"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /ingredients/{id}/restore [post]
+// RestoreIngredient handles POST /ingredients/:id/restore
+func (h *IngredientHandler) RestoreIngredient(c *gin.Context) {
+	// Parse ID from URL parameter
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		respondBadRequest(c, "Invalid ingredient ID")
+		return
+	}
+
+	// Call usecase
+	ingredient, err := h.ingredientUsecase.RestoreIngredient(c.Request.Context(), id)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Header("ETag", ingredientETag(ingredient))
+	c.JSON(http.StatusOK, ingredient)
+}
+
+// @Summary      食材を一括で作成・更新・削除
+// @Description  複数の食材の作成・更新・削除を 1 つのトランザクションでまとめて実行し、操作ごとの結果を返します。
+// @Description  mode が atomic (デフォルト) の場合は 1 件でも失敗するとすべて取り消され、失敗した操作のエラーに応じたステータスを返します。
+// @Description  best_effort の場合は成功した操作だけを反映し、常に 200 を返します。
+// @Tags         ingredients
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        batch body usecase.BatchIngredientsRequest true "実行する操作の一覧 (最大 200 件)"
+// @Success      200 {object} usecase.BatchIngredientsResponse "操作ごとの結果"
+// @Failure      400 {object} usecase.BatchIngredientsResponse "リクエストが不正、または atomic モードで入力エラーの操作がありました"
+// @Failure      404 {object} usecase.BatchIngredientsResponse "atomic モードで対象の食材が見つからない操作がありました"
+// @Failure      412 {object} usecase.BatchIngredientsResponse "atomic モードでバージョンが一致しない操作がありました"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /ingredients/batch [post]
+// BatchIngredients handles POST /ingredients/batch
+func (h *IngredientHandler) BatchIngredients(c *gin.Context) {
+	var req usecase.BatchIngredientsRequest
+
+	// Bind and validate request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, err.Error())
+		return
+	}
+
+	// Call usecase
+	result, err := h.ingredientUsecase.BatchIngredients(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	// Render per-item errors; a rolled back batch answers with the status of the failure that aborted it
+	statusCode := http.StatusOK
+	for i := range result.Results {
+		item := &result.Results[i]
+		if item.Err == nil {
+			continue
+		}
+		itemStatus, response := errorResponse(item.Err)
+		item.Error = &response
+		if !result.Committed {
+			statusCode = itemStatus
+		}
+	}
+
+	c.JSON(statusCode, result)
+}
+
+// @Summary      食材をエクスポート
+// @Description  すべての食材を CSV または JSON でダウンロードします。出力したファイルはそのまま POST /ingredients/import で取り込めます。
+// @Tags         ingredients
+// @Produce      text/csv
+// @Produce      json
+// @Security     BearerAuth
+// @Param        format  query  string  false  "出力形式 (デフォルト: csv)"  Enums(csv, json)
+// @Success      200 {file} file "食材の一覧。列は name, category, location, quantity, barcode, price, store, purchase_date, expires_at"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /ingredients/export [get]
+// ExportIngredients handles GET /ingredients/export
+func (h *IngredientHandler) ExportIngredients(c *gin.Context) {
+	format := c.DefaultQuery("format", usecase.InventoryFormatCSV)
+
+	// Call usecase
+	data, err := h.ingredientUsecase.ExportIngredients(c.Request.Context(), format)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	contentType := "text/csv; charset=utf-8"
+	if format == usecase.InventoryFormatJSON {
+		contentType = "application/json; charset=utf-8"
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="ingredients-%s.%s"`, time.Now().Format("20060102"), format))
+	c.Data(http.StatusOK, contentType, data)
+}
+
+// @Summary      食材をインポート
+// @Description  CSV または JSON の食材一覧を取り込み、行ごとの結果を返します。列名は自動で判別され、mapping で明示することもできます。
+// @Description  日付の書式は自動で判別され、date_format で指定することもできます。dry_run を指定すると保存せずに結果だけを確認できます。
+// @Description  JSON のリクエストボディのほか、Content-Type が text/csv の生データも受け付けます。その場合のオプションはクエリパラメータで指定します。
+// @Tags         ingredients
+// @Accept       json
+// @Accept       text/csv
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request       body   usecase.ImportIngredientsRequest  true   "インポートする内容"
+// @Param        format        query  string  false  "text/csv 以外の生データの形式"  Enums(csv, json)
+// @Param        date_format   query  string  false  "日付の書式 (例: YYYY/MM/DD)"
+// @Param        on_duplicate  query  string  false  "同じ食材があった場合の扱い (デフォルト: skip)"  Enums(skip, merge, overwrite)
+// @Param        dry_run       query  bool    false  "保存せずに結果だけを返す"
+// @Param        mapping[name] query  string  false  "食材名の列名。ほかのフィールドも mapping[quantity] のように指定できます"
+// @Success      200 {object} usecase.ImportIngredientsResponse "行ごとの結果"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      412 {object} usecase.ErrorResponse "取り込み中に食材が他の更新により変更されました"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /ingredients/import [post]
+// ImportIngredients handles POST /ingredients/import
+func (h *IngredientHandler) ImportIngredients(c *gin.Context) {
+	var req usecase.ImportIngredientsRequest
+
+	switch c.ContentType() {
+	case "application/json", "":
+		// Bind and validate request body
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondBadRequest(c, err.Error())
+			return
+		}
+	default:
+		// Accept the raw file as the request body, with options in the query string
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			respondBadRequest(c, err.Error())
+			return
+		}
+		req.Content = string(body)
+		req.Format = c.Query("format")
+		if req.Format == "" && c.ContentType() == "text/csv" {
+			req.Format = usecase.InventoryFormatCSV
+		}
+		req.DateFormat = c.Query("date_format")
+		req.OnDuplicate = c.Query("on_duplicate")
+		req.Mapping = c.QueryMap("mapping")
+		if dryRun := c.Query("dry_run"); dryRun != "" {
+			if req.DryRun, err = strconv.ParseBool(dryRun); err != nil {
+				respondBadRequest(c, "Invalid dry_run")
+				return
+			}
+		}
+	}
+
+	// Call usecase
+	result, err := h.ingredientUsecase.ImportIngredients(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -6,8 +6,10 @@ import (
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -31,12 +33,12 @@ func (m *MockIngredientUsecase) CreateIngredient(ctx context.Context, req usecas
 	return args.Get(0).(*domain.Ingredient), args.Error(1)
 }
 
-func (m *MockIngredientUsecase) GetAllIngredients(ctx context.Context) ([]*domain.Ingredient, error) {
-	args := m.Called(ctx)
+func (m *MockIngredientUsecase) GetAllIngredients(ctx context.Context, req usecase.ListIngredientsRequest) (*usecase.IngredientListResponse, error) {
+	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*domain.Ingredient), args.Error(1)
+	return args.Get(0).(*usecase.IngredientListResponse), args.Error(1)
 }
 
 func (m *MockIngredientUsecase) GetIngredientByID(ctx context.Context, id int64) (*domain.Ingredient, error) {
@@ -55,11 +57,64 @@ func (m *MockIngredientUsecase) UpdateIngredient(ctx context.Context, id int64,
 	return args.Get(0).(*domain.Ingredient), args.Error(1)
 }
 
-func (m *MockIngredientUsecase) DeleteIngredient(ctx context.Context, id int64) error {
-	args := m.Called(ctx, id)
+func (m *MockIngredientUsecase) PatchIngredient(ctx context.Context, id int64, req usecase.PatchIngredientRequest) (*domain.Ingredient, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Ingredient), args.Error(1)
+}
+
+func (m *MockIngredientUsecase) DeleteIngredient(ctx context.Context, id int64, expectedVersions []int64) error {
+	args := m.Called(ctx, id, expectedVersions)
 	return args.Error(0)
 }
 
+func (m *MockIngredientUsecase) GetTrash(ctx context.Context) ([]*domain.Ingredient, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Ingredient), args.Error(1)
+}
+
+func (m *MockIngredientUsecase) RestoreIngredient(ctx context.Context, id int64) (*domain.Ingredient, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Ingredient), args.Error(1)
+}
+
+func (m *MockIngredientUsecase) PurgeTrash(ctx context.Context, retention time.Duration) (int64, error) {
+	args := m.Called(ctx, retention)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockIngredientUsecase) BatchIngredients(ctx context.Context, req usecase.BatchIngredientsRequest) (*usecase.BatchIngredientsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.BatchIngredientsResponse), args.Error(1)
+}
+
+func (m *MockIngredientUsecase) ExportIngredients(ctx context.Context, format string) ([]byte, error) {
+	args := m.Called(ctx, format)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockIngredientUsecase) ImportIngredients(ctx context.Context, req usecase.ImportIngredientsRequest) (*usecase.ImportIngredientsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.ImportIngredientsResponse), args.Error(1)
+}
+
 func setupTestRouter() *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	return gin.New()
@@ -293,7 +348,8 @@ func TestGetAllIngredients_Success(t *testing.T) {
 		},
 	}
 
-	mockUsecase.On("GetAllIngredients", mock.Anything).Return(mockIngredients, nil)
+	mockUsecase.On("GetAllIngredients", mock.Anything, usecase.ListIngredientsRequest{}).
+		Return(&usecase.IngredientListResponse{Items: mockIngredients}, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/ingredients", nil)
 	w := httptest.NewRecorder()
@@ -302,12 +358,13 @@ func TestGetAllIngredients_Success(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, w.Code)
 
-	var response []*domain.Ingredient
+	var response usecase.IngredientListResponse
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
-	assert.Len(t, response, 2)
-	assert.Equal(t, "にんじん", response[0].Name)
-	assert.Equal(t, "豚バラ肉", response[1].Name)
+	assert.Len(t, response.Items, 2)
+	assert.Equal(t, "にんじん", response.Items[0].Name)
+	assert.Equal(t, "豚バラ肉", response.Items[1].Name)
+	assert.NotContains(t, w.Body.String(), "next_cursor")
 	mockUsecase.AssertExpectations(t)
 }
 
@@ -318,22 +375,74 @@ func TestGetAllIngredients_EmptyResult(t *testing.T) {
 	router := setupTestRouter()
 	router.GET("/ingredients", handler.GetAllIngredients)
 
-	mockUsecase.On("GetAllIngredients", mock.Anything).Return([]*domain.Ingredient{}, nil)
+	mockUsecase.On("GetAllIngredients", mock.Anything, usecase.ListIngredientsRequest{}).
+		Return(&usecase.IngredientListResponse{Items: []*domain.Ingredient{}}, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/ingredients", nil)
 	w := httptest.NewRecorder()
 
 	router.ServeHTTP(w, req)
 
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"items":[]}`, w.Body.String())
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestGetAllIngredients_WithFilter tests that query parameters are passed to the usecase
+func TestGetAllIngredients_WithFilter(t *testing.T) {
+	mockUsecase := new(MockIngredientUsecase)
+	handler := NewIngredientHandler(mockUsecase)
+	router := setupTestRouter()
+	router.GET("/ingredients", handler.GetAllIngredients)
+
+	filter := usecase.ListIngredientsRequest{
+		Query:           "鶏",
+		Category:        domain.CategoryMeat,
+		Location:        domain.LocationFreezer,
+		PurchasedBefore: "2025-12-01",
+		Sort:            "expires_at",
+		Order:           "desc",
+		Cursor:          "abc",
+		Limit:           20,
+	}
+	mockUsecase.On("GetAllIngredients", mock.Anything, filter).
+		Return(&usecase.IngredientListResponse{
+			Items:      []*domain.Ingredient{{ID: 1, Name: "鶏もも肉", Category: domain.CategoryMeat, Location: domain.LocationFreezer}},
+			NextCursor: "def",
+		}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/ingredients?q=%E9%B6%8F&category=meat&location=freezer&purchased_before=2025-12-01&sort=expires_at&order=desc&cursor=abc&limit=20", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
 	assert.Equal(t, http.StatusOK, w.Code)
 
-	var response []*domain.Ingredient
+	var response usecase.IngredientListResponse
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
-	assert.Len(t, response, 0)
+	assert.Len(t, response.Items, 1)
+	assert.Equal(t, domain.LocationFreezer, response.Items[0].Location)
+	assert.Equal(t, "def", response.NextCursor)
 	mockUsecase.AssertExpectations(t)
 }
 
+// TestGetAllIngredients_InvalidLimit tests that a non-numeric limit is rejected before reaching the usecase
+func TestGetAllIngredients_InvalidLimit(t *testing.T) {
+	mockUsecase := new(MockIngredientUsecase)
+	handler := NewIngredientHandler(mockUsecase)
+	router := setupTestRouter()
+	router.GET("/ingredients", handler.GetAllIngredients)
+
+	req := httptest.NewRequest(http.MethodGet, "/ingredients?limit=many", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUsecase.AssertNotCalled(t, "GetAllIngredients", mock.Anything, mock.Anything)
+}
+
 // TestGetAllIngredients_UsecaseError tests error handling when usecase fails
 func TestGetAllIngredients_UsecaseError(t *testing.T) {
 	mockUsecase := new(MockIngredientUsecase)
@@ -341,7 +450,7 @@ func TestGetAllIngredients_UsecaseError(t *testing.T) {
 	router := setupTestRouter()
 	router.GET("/ingredients", handler.GetAllIngredients)
 
-	mockUsecase.On("GetAllIngredients", mock.Anything).Return(nil, errors.New("database error"))
+	mockUsecase.On("GetAllIngredients", mock.Anything, usecase.ListIngredientsRequest{}).Return(nil, errors.New("database error"))
 
 	req := httptest.NewRequest(http.MethodGet, "/ingredients", nil)
 	w := httptest.NewRecorder()
@@ -373,11 +482,9 @@ func TestUpdateIngredient_Success(t *testing.T) {
 		UpdatedAt: now,
 	}
 
-	newName := "大根"
-	newQuantity := "3本"
 	reqBody := usecase.UpdateIngredientRequest{
-		Name:     &newName,
-		Quantity: &newQuantity,
+		Name:     "大根",
+		Quantity: "3本",
 	}
 
 	mockUsecase.On("UpdateIngredient", mock.Anything, int64(1), reqBody).
@@ -408,9 +515,8 @@ func TestUpdateIngredient_InvalidID(t *testing.T) {
 	router := setupTestRouter()
 	router.PUT("/ingredients/:id", handler.UpdateIngredient)
 
-	newName := "大根"
 	reqBody := usecase.UpdateIngredientRequest{
-		Name: &newName,
+		Name: "大根",
 	}
 
 	body, _ := json.Marshal(reqBody)
@@ -437,9 +543,8 @@ func TestUpdateIngredient_NotFound(t *testing.T) {
 	router := setupTestRouter()
 	router.PUT("/ingredients/:id", handler.UpdateIngredient)
 
-	newName := "大根"
 	reqBody := usecase.UpdateIngredientRequest{
-		Name: &newName,
+		Name: "大根",
 	}
 
 	mockUsecase.On("UpdateIngredient", mock.Anything, int64(999), reqBody).
@@ -483,6 +588,105 @@ func TestUpdateIngredient_InvalidJSON(t *testing.T) {
 	mockUsecase.AssertNotCalled(t, "UpdateIngredient")
 }
 
+// TestUpdateIngredient_MissingName tests that PUT requires the full representation
+func TestUpdateIngredient_MissingName(t *testing.T) {
+	mockUsecase := new(MockIngredientUsecase)
+	handler := NewIngredientHandler(mockUsecase)
+	router := setupTestRouter()
+	router.PUT("/ingredients/:id", handler.UpdateIngredient)
+
+	req := httptest.NewRequest(http.MethodPut, "/ingredients/1", bytes.NewBufferString(`{"quantity": "3本"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUsecase.AssertNotCalled(t, "UpdateIngredient", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestPatchIngredient_ContentTypes tests that the patch format follows the request content type
+func TestPatchIngredient_ContentTypes(t *testing.T) {
+	tests := []struct {
+		contentType string
+		body        string
+		format      string
+	}{
+		{"application/merge-patch+json", `{"quantity": null}`, usecase.PatchFormatMerge},
+		{"application/json; charset=utf-8", `{"quantity": "1個"}`, usecase.PatchFormatMerge},
+		{"application/json-patch+json", `[{"op": "remove", "path": "/quantity"}]`, usecase.PatchFormatJSONPatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.contentType, func(t *testing.T) {
+			mockUsecase := new(MockIngredientUsecase)
+			handler := NewIngredientHandler(mockUsecase)
+			router := setupTestRouter()
+			router.PATCH("/ingredients/:id", handler.PatchIngredient)
+
+			expected := usecase.PatchIngredientRequest{Format: tt.format, Patch: []byte(tt.body)}
+			mockUsecase.On("PatchIngredient", mock.Anything, int64(1), expected).
+				Return(&domain.Ingredient{ID: 1, Name: "にんじん"}, nil)
+
+			req := httptest.NewRequest(http.MethodPatch, "/ingredients/1", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			var response domain.Ingredient
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, "にんじん", response.Name)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+// TestPatchIngredient_UnsupportedMediaType tests that other content types are refused
+func TestPatchIngredient_UnsupportedMediaType(t *testing.T) {
+	mockUsecase := new(MockIngredientUsecase)
+	handler := NewIngredientHandler(mockUsecase)
+	router := setupTestRouter()
+	router.PATCH("/ingredients/:id", handler.PatchIngredient)
+
+	req := httptest.NewRequest(http.MethodPatch, "/ingredients/1", bytes.NewBufferString("quantity=3"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+
+	var response usecase.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "unsupported_media_type", response.Error)
+	mockUsecase.AssertNotCalled(t, "PatchIngredient", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestPatchIngredient_InvalidPatch tests that patch validation errors become 400 responses
+func TestPatchIngredient_InvalidPatch(t *testing.T) {
+	mockUsecase := new(MockIngredientUsecase)
+	handler := NewIngredientHandler(mockUsecase)
+	router := setupTestRouter()
+	router.PATCH("/ingredients/:id", handler.PatchIngredient)
+
+	mockUsecase.On("PatchIngredient", mock.Anything, int64(1), mock.Anything).
+		Return(nil, fmt.Errorf("%w: patched ingredient is invalid", domain.ErrInvalidInput))
+
+	req := httptest.NewRequest(http.MethodPatch, "/ingredients/1", bytes.NewBufferString(`{"id": 5}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
 // TestDeleteIngredient_Success tests successful ingredient deletion
 func TestDeleteIngredient_Success(t *testing.T) {
 	mockUsecase := new(MockIngredientUsecase)
@@ -490,7 +694,7 @@ func TestDeleteIngredient_Success(t *testing.T) {
 	router := setupTestRouter()
 	router.DELETE("/ingredients/:id", handler.DeleteIngredient)
 
-	mockUsecase.On("DeleteIngredient", mock.Anything, int64(1)).Return(nil)
+	mockUsecase.On("DeleteIngredient", mock.Anything, int64(1), []int64(nil)).Return(nil)
 
 	req := httptest.NewRequest(http.MethodDelete, "/ingredients/1", nil)
 	w := httptest.NewRecorder()
@@ -531,7 +735,7 @@ func TestDeleteIngredient_NotFound(t *testing.T) {
 	router := setupTestRouter()
 	router.DELETE("/ingredients/:id", handler.DeleteIngredient)
 
-	mockUsecase.On("DeleteIngredient", mock.Anything, int64(999)).Return(sql.ErrNoRows)
+	mockUsecase.On("DeleteIngredient", mock.Anything, int64(999), []int64(nil)).Return(sql.ErrNoRows)
 
 	req := httptest.NewRequest(http.MethodDelete, "/ingredients/999", nil)
 	w := httptest.NewRecorder()
@@ -546,3 +750,423 @@ func TestDeleteIngredient_NotFound(t *testing.T) {
 	assert.Equal(t, "not_found", response.Error)
 	mockUsecase.AssertExpectations(t)
 }
+
+// TestGetIngredientByID_ETag tests that the ETag reflects the version and If-None-Match yields 304
+func TestGetIngredientByID_ETag(t *testing.T) {
+	mockUsecase := new(MockIngredientUsecase)
+	handler := NewIngredientHandler(mockUsecase)
+	router := setupTestRouter()
+	router.GET("/ingredients/:id", handler.GetIngredientByID)
+
+	mockUsecase.On("GetIngredientByID", mock.Anything, int64(1)).
+		Return(&domain.Ingredient{ID: 1, Name: "にんじん", Version: 3}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/ingredients/1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+
+	req = httptest.NewRequest(http.MethodGet, "/ingredients/1", nil)
+	req.Header.Set("If-None-Match", `"2", W/"3"`)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+	assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+}
+
+// TestGetAllIngredients_NotModified tests conditional requests against the list ETag
+func TestGetAllIngredients_NotModified(t *testing.T) {
+	mockUsecase := new(MockIngredientUsecase)
+	handler := NewIngredientHandler(mockUsecase)
+	router := setupTestRouter()
+	router.GET("/ingredients", handler.GetAllIngredients)
+
+	page := &usecase.IngredientListResponse{Items: []*domain.Ingredient{{ID: 1, Name: "にんじん", Version: 1}}}
+	mockUsecase.On("GetAllIngredients", mock.Anything, usecase.ListIngredientsRequest{}).Return(page, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/ingredients", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	etag := w.Header().Get("ETag")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, strings.HasPrefix(etag, `W/"`))
+
+	req = httptest.NewRequest(http.MethodGet, "/ingredients", nil)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotModified, w.Code)
+
+	// Any write to a listed ingredient changes the list ETag
+	page.Items[0].Version = 2
+	req = httptest.NewRequest(http.MethodGet, "/ingredients", nil)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEqual(t, etag, w.Header().Get("ETag"))
+}
+
+// TestUpdateIngredient_IfMatch tests that If-Match versions reach the usecase and the new ETag is returned
+func TestUpdateIngredient_IfMatch(t *testing.T) {
+	mockUsecase := new(MockIngredientUsecase)
+	handler := NewIngredientHandler(mockUsecase)
+	router := setupTestRouter()
+	router.PUT("/ingredients/:id", handler.UpdateIngredient)
+
+	expected := usecase.UpdateIngredientRequest{Name: "大根", ExpectedVersions: []int64{2}}
+	mockUsecase.On("UpdateIngredient", mock.Anything, int64(1), expected).
+		Return(&domain.Ingredient{ID: 1, Name: "大根", Version: 3}, nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/ingredients/1", bytes.NewBufferString(`{"name": "大根"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"2"`)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestUpdateIngredient_PreconditionFailed tests that a version conflict is reported as 412
+func TestUpdateIngredient_PreconditionFailed(t *testing.T) {
+	mockUsecase := new(MockIngredientUsecase)
+	handler := NewIngredientHandler(mockUsecase)
+	router := setupTestRouter()
+	router.PATCH("/ingredients/:id", handler.PatchIngredient)
+
+	mockUsecase.On("PatchIngredient", mock.Anything, int64(1), mock.Anything).
+		Return(nil, fmt.Errorf("ingredient 1 is at version 3: %w", domain.ErrVersionConflict))
+
+	req := httptest.NewRequest(http.MethodPatch, "/ingredients/1", bytes.NewBufferString(`{"quantity": "1本"}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	req.Header.Set("If-Match", `"2"`)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+
+	var response usecase.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "precondition_failed", response.Error)
+}
+
+// TestDeleteIngredient_WeakIfMatch tests that weak or malformed If-Match tags never match
+func TestDeleteIngredient_WeakIfMatch(t *testing.T) {
+	mockUsecase := new(MockIngredientUsecase)
+	handler := NewIngredientHandler(mockUsecase)
+	router := setupTestRouter()
+	router.DELETE("/ingredients/:id", handler.DeleteIngredient)
+
+	for _, ifMatch := range []string{`W/"2"`, `2`, `"two"`} {
+		req := httptest.NewRequest(http.MethodDelete, "/ingredients/1", nil)
+		req.Header.Set("If-Match", ifMatch)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code, ifMatch)
+	}
+
+	mockUsecase.AssertNotCalled(t, "DeleteIngredient", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestDeleteIngredient_IfMatchWildcard tests that If-Match: * deletes unconditionally
+func TestDeleteIngredient_IfMatchWildcard(t *testing.T) {
+	mockUsecase := new(MockIngredientUsecase)
+	handler := NewIngredientHandler(mockUsecase)
+	router := setupTestRouter()
+	router.DELETE("/ingredients/:id", handler.DeleteIngredient)
+
+	mockUsecase.On("DeleteIngredient", mock.Anything, int64(1), []int64(nil)).Return(nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/ingredients/1", nil)
+	req.Header.Set("If-Match", "*")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestBatchIngredients_Success tests a committed batch with per-item results
+func TestBatchIngredients_Success(t *testing.T) {
+	mockUsecase := new(MockIngredientUsecase)
+	handler := NewIngredientHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/ingredients/batch", handler.BatchIngredients)
+
+	mockUsecase.On("BatchIngredients", mock.Anything, mock.MatchedBy(func(req usecase.BatchIngredientsRequest) bool {
+		return req.Mode == usecase.BatchModeBestEffort && len(req.Operations) == 2 &&
+			req.Operations[0].Ingredient.Name == "にんじん" && req.Operations[1].ID == 3
+	})).Return(&usecase.BatchIngredientsResponse{
+		Mode:      usecase.BatchModeBestEffort,
+		Committed: true,
+		Succeeded: 1,
+		Failed:    1,
+		Results: []usecase.BatchIngredientResult{
+			{Index: 0, Op: usecase.BatchOpCreate, Status: usecase.BatchStatusSucceeded, ID: 10, Ingredient: &domain.Ingredient{ID: 10, Name: "にんじん"}},
+			{Index: 1, Op: usecase.BatchOpDelete, Status: usecase.BatchStatusFailed, ID: 3, Err: fmt.Errorf("ingredient not found: %w", sql.ErrNoRows)},
+		},
+	}, nil)
+
+	body := `{"mode": "best_effort", "operations": [{"op": "create", "ingredient": {"name": "にんじん"}}, {"op": "delete", "id": 3}]}`
+	req := httptest.NewRequest(http.MethodPost, "/ingredients/batch", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response usecase.BatchIngredientsResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.True(t, response.Committed)
+	assert.Equal(t, "にんじん", response.Results[0].Ingredient.Name)
+	assert.Nil(t, response.Results[0].Error)
+	assert.Equal(t, "not_found", response.Results[1].Error.Error)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestBatchIngredients_RolledBack tests that an aborted atomic batch answers with the failure's status
+func TestBatchIngredients_RolledBack(t *testing.T) {
+	mockUsecase := new(MockIngredientUsecase)
+	handler := NewIngredientHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/ingredients/batch", handler.BatchIngredients)
+
+	mockUsecase.On("BatchIngredients", mock.Anything, mock.Anything).Return(&usecase.BatchIngredientsResponse{
+		Mode:   usecase.BatchModeAtomic,
+		Failed: 1,
+		Results: []usecase.BatchIngredientResult{
+			{Index: 0, Op: usecase.BatchOpCreate, Status: usecase.BatchStatusRolledBack},
+			{Index: 1, Op: usecase.BatchOpUpdate, Status: usecase.BatchStatusFailed, ID: 1, Err: fmt.Errorf("ingredient 1 is at version 3: %w", domain.ErrVersionConflict)},
+		},
+	}, nil)
+
+	body := `{"operations": [{"op": "create", "ingredient": {"name": "にんじん"}}, {"op": "update", "id": 1, "version": 2, "ingredient": {"name": "大根"}}]}`
+	req := httptest.NewRequest(http.MethodPost, "/ingredients/batch", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+
+	var response usecase.BatchIngredientsResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.False(t, response.Committed)
+	assert.Equal(t, usecase.BatchStatusRolledBack, response.Results[0].Status)
+	assert.Equal(t, "precondition_failed", response.Results[1].Error.Error)
+}
+
+// TestBatchIngredients_InvalidRequest tests that a batch without operations is rejected
+func TestBatchIngredients_InvalidRequest(t *testing.T) {
+	mockUsecase := new(MockIngredientUsecase)
+	handler := NewIngredientHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/ingredients/batch", handler.BatchIngredients)
+
+	req := httptest.NewRequest(http.MethodPost, "/ingredients/batch", bytes.NewBufferString(`{"mode": "atomic"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUsecase.AssertNotCalled(t, "BatchIngredients", mock.Anything, mock.Anything)
+}
+
+// TestExportIngredients_CSV tests downloading the inventory as CSV
+func TestExportIngredients_CSV(t *testing.T) {
+	mockUsecase := new(MockIngredientUsecase)
+	handler := NewIngredientHandler(mockUsecase)
+	router := setupTestRouter()
+	router.GET("/ingredients/export", handler.ExportIngredients)
+
+	mockUsecase.On("ExportIngredients", mock.Anything, "csv").Return([]byte("name\nにんじん\n"), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/ingredients/export", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), `.csv"`)
+	assert.Equal(t, "name\nにんじん\n", w.Body.String())
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestExportIngredients_InvalidFormat tests that an unknown export format is rejected
+func TestExportIngredients_InvalidFormat(t *testing.T) {
+	mockUsecase := new(MockIngredientUsecase)
+	handler := NewIngredientHandler(mockUsecase)
+	router := setupTestRouter()
+	router.GET("/ingredients/export", handler.ExportIngredients)
+
+	mockUsecase.On("ExportIngredients", mock.Anything, "xlsx").
+		Return(nil, fmt.Errorf("%w: format must be \"csv\" or \"json\"", domain.ErrInvalidInput))
+
+	req := httptest.NewRequest(http.MethodGet, "/ingredients/export?format=xlsx", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// TestImportIngredients_JSONBody tests importing with options in a JSON request body
+func TestImportIngredients_JSONBody(t *testing.T) {
+	mockUsecase := new(MockIngredientUsecase)
+	handler := NewIngredientHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/ingredients/import", handler.ImportIngredients)
+
+	expected := usecase.ImportIngredientsRequest{
+		Content:     "品名\nにんじん",
+		Mapping:     map[string]string{"name": "品名"},
+		OnDuplicate: usecase.DuplicateMerge,
+		DryRun:      true,
+	}
+	mockUsecase.On("ImportIngredients", mock.Anything, expected).
+		Return(&usecase.ImportIngredientsResponse{DryRun: true, Created: 1}, nil)
+
+	body := `{"content": "品名\nにんじん", "mapping": {"name": "品名"}, "on_duplicate": "merge", "dry_run": true}`
+	req := httptest.NewRequest(http.MethodPost, "/ingredients/import", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response usecase.ImportIngredientsResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.True(t, response.DryRun)
+	assert.Equal(t, 1, response.Created)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestImportIngredients_RawCSV tests importing a raw CSV body with options in the query string
+func TestImportIngredients_RawCSV(t *testing.T) {
+	mockUsecase := new(MockIngredientUsecase)
+	handler := NewIngredientHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/ingredients/import", handler.ImportIngredients)
+
+	expected := usecase.ImportIngredientsRequest{
+		Format:      usecase.InventoryFormatCSV,
+		Content:     "item,amount\n卵,10個\n",
+		Mapping:     map[string]string{"name": "item", "quantity": "amount"},
+		DateFormat:  "DD/MM/YYYY",
+		OnDuplicate: usecase.DuplicateOverwrite,
+		DryRun:      true,
+	}
+	mockUsecase.On("ImportIngredients", mock.Anything, expected).
+		Return(&usecase.ImportIngredientsResponse{DryRun: true}, nil)
+
+	target := "/ingredients/import?dry_run=true&on_duplicate=overwrite&date_format=DD/MM/YYYY&mapping[name]=item&mapping[quantity]=amount"
+	req := httptest.NewRequest(http.MethodPost, target, bytes.NewBufferString("item,amount\n卵,10個\n"))
+	req.Header.Set("Content-Type", "text/csv")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestImportIngredients_InvalidDryRun tests that a malformed dry_run flag is rejected
+func TestImportIngredients_InvalidDryRun(t *testing.T) {
+	mockUsecase := new(MockIngredientUsecase)
+	handler := NewIngredientHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/ingredients/import", handler.ImportIngredients)
+
+	req := httptest.NewRequest(http.MethodPost, "/ingredients/import?dry_run=maybe", bytes.NewBufferString("name\n卵\n"))
+	req.Header.Set("Content-Type", "text/csv")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUsecase.AssertNotCalled(t, "ImportIngredients", mock.Anything, mock.Anything)
+}
+
+// TestGetTrash_Success tests listing the ingredients in the trash
+func TestGetTrash_Success(t *testing.T) {
+	mockUsecase := new(MockIngredientUsecase)
+	handler := NewIngredientHandler(mockUsecase)
+	router := setupTestRouter()
+	router.GET("/ingredients/trash", handler.GetTrash)
+	router.GET("/ingredients/:id", handler.GetIngredientByID)
+
+	deletedAt := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	mockUsecase.On("GetTrash", mock.Anything).Return([]*domain.Ingredient{{ID: 1, Name: "にんじん", DeletedAt: &deletedAt}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/ingredients/trash", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response []map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Len(t, response, 1)
+	assert.Equal(t, "2026-10-18T09:00:00Z", response[0]["deleted_at"])
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestRestoreIngredient_Success tests restoring an ingredient from the trash
+func TestRestoreIngredient_Success(t *testing.T) {
+	mockUsecase := new(MockIngredientUsecase)
+	handler := NewIngredientHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/ingredients/:id/restore", handler.RestoreIngredient)
+
+	mockUsecase.On("RestoreIngredient", mock.Anything, int64(1)).Return(&domain.Ingredient{ID: 1, Name: "にんじん", Version: 3}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/ingredients/1/restore", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestRestoreIngredient_NotInTrash tests that restoring an ingredient missing from the trash returns 404
+func TestRestoreIngredient_NotInTrash(t *testing.T) {
+	mockUsecase := new(MockIngredientUsecase)
+	handler := NewIngredientHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/ingredients/:id/restore", handler.RestoreIngredient)
+
+	mockUsecase.On("RestoreIngredient", mock.Anything, int64(999)).Return(nil, fmt.Errorf("ingredient not found in trash: %w", sql.ErrNoRows))
+
+	req := httptest.NewRequest(http.MethodPost, "/ingredients/999/restore", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// IntakeHandler handles HTTP requests that turn free text into draft ingredients
+type IntakeHandler struct {
+	intakeUsecase usecase.IntakeUsecase
+}
+
+// NewIntakeHandler creates a new IntakeHandler instance
+func NewIntakeHandler(intakeUsecase usecase.IntakeUsecase) *IntakeHandler {
+	return &IntakeHandler{
+		intakeUsecase: intakeUsecase,
+	}
+}
+
+// @Summary      レシートから食材の下書きを作成
+// @Description  OCRで読み取ったレシートのテキストから、食品の品名・数量・価格と購入日を読み取り、保存前の下書きとして返します。
+// @Description  小計・合計・税・支払いなどの行や日用品は除外されます。use_llm を指定すると設定済みのLLMでも読み取り、失敗した場合はルールによる結果と警告を返します。
+// @Description  下書きはそのまま POST /ingredients/batch の create 操作として送信できます。
+// @Tags         ingredients
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body  usecase.ParseReceiptRequest  true  "レシートのテキスト"
+// @Success      200 {object} usecase.IngredientDraftsResponse "食材の下書き"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /ingredients/parse-receipt [post]
+// ParseReceipt handles POST /ingredients/parse-receipt
+func (h *IntakeHandler) ParseReceipt(c *gin.Context) {
+	var req usecase.ParseReceiptRequest
+
+	// Bind and validate request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, err.Error())
+		return
+	}
+
+	// Call usecase
+	response, err := h.intakeUsecase.ParseReceipt(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary      短い文章から食材を追加
+// @Description  「卵10個と牛乳1本、昨日買った鶏もも300g」のような文章から、食材名・数量・購入日（「昨日」「3日前」などの相対的な日付も含む）を読み取ります。
+// @Description  まずルールで読み取り、読み取れない部分や辞書にない食材名がある場合は設定済みのLLMで読み取ります（llm で auto / always / never を指定できます）。
+// @Description  confirm を指定しない場合は下書きだけを返します。確認後は下書きを drafts に入れ、confirm を true にして送ると、すべての下書きが 1 つのトランザクションで保存されます。
+// @Tags         ingredients
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body  usecase.QuickAddRequest  true  "文章、または確認済みの下書き"
+// @Success      200 {object} usecase.QuickAddResponse "読み取った下書き"
+// @Success      201 {object} usecase.QuickAddResponse "保存された食材"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /ingredients/quick-add [post]
+// QuickAdd handles POST /ingredients/quick-add
+func (h *IntakeHandler) QuickAdd(c *gin.Context) {
+	var req usecase.QuickAddRequest
+
+	// Bind and validate request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, err.Error())
+		return
+	}
+
+	// Call usecase
+	response, err := h.intakeUsecase.QuickAdd(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	if response.Committed {
+		c.JSON(http.StatusCreated, response)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,193 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockIntakeUsecase is a mock implementation of IntakeUsecase
+type MockIntakeUsecase struct {
+	mock.Mock
+}
+
+func (m *MockIntakeUsecase) ParseReceipt(ctx context.Context, req usecase.ParseReceiptRequest) (*usecase.IngredientDraftsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.IngredientDraftsResponse), args.Error(1)
+}
+
+func (m *MockIntakeUsecase) QuickAdd(ctx context.Context, req usecase.QuickAddRequest) (*usecase.QuickAddResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.QuickAddResponse), args.Error(1)
+}
+
+// TestParseReceipt_Success tests reading draft ingredients from receipt text
+func TestParseReceipt_Success(t *testing.T) {
+	mockUsecase := new(MockIntakeUsecase)
+	handler := NewIntakeHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/ingredients/parse-receipt", handler.ParseReceipt)
+
+	purchaseDate := "2026-10-17"
+	price := 238
+	expected := &usecase.IngredientDraftsResponse{
+		PurchaseDate: &purchaseDate,
+		Drafts: []usecase.IngredientDraft{
+			{
+				CreateIngredientRequest: usecase.CreateIngredientRequest{Name: "牛乳", Category: "dairy", Location: "fridge", Price: &price, PurchaseDate: &purchaseDate},
+				Line:                    "牛乳 ¥238",
+			},
+		},
+		Ignored: []string{"合計 ¥238"},
+	}
+	reqBody := usecase.ParseReceiptRequest{Text: "2026/10/17\n牛乳 ¥238\n合計 ¥238"}
+	mockUsecase.On("ParseReceipt", mock.Anything, reqBody).Return(expected, nil)
+
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPost, "/ingredients/parse-receipt", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-10-17", response["purchase_date"])
+
+	drafts := response["drafts"].([]interface{})
+	assert.Len(t, drafts, 1)
+	draft := drafts[0].(map[string]interface{})
+	assert.Equal(t, "牛乳", draft["name"])
+	assert.Equal(t, float64(238), draft["price"])
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestParseReceipt_MissingText tests that a request without text is rejected
+func TestParseReceipt_MissingText(t *testing.T) {
+	mockUsecase := new(MockIntakeUsecase)
+	handler := NewIntakeHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/ingredients/parse-receipt", handler.ParseReceipt)
+
+	req := httptest.NewRequest(http.MethodPost, "/ingredients/parse-receipt", bytes.NewBufferString(`{"use_llm": true}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUsecase.AssertNotCalled(t, "ParseReceipt", mock.Anything, mock.Anything)
+}
+
+// TestParseReceipt_ValidationError tests that usecase validation errors become 400 responses
+func TestParseReceipt_ValidationError(t *testing.T) {
+	mockUsecase := new(MockIntakeUsecase)
+	handler := NewIntakeHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/ingredients/parse-receipt", handler.ParseReceipt)
+
+	mockUsecase.On("ParseReceipt", mock.Anything, mock.Anything).
+		Return(nil, fmt.Errorf("%w: text is required", domain.ErrInvalidInput))
+
+	req := httptest.NewRequest(http.MethodPost, "/ingredients/parse-receipt", bytes.NewBufferString(`{"text": " "}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response usecase.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "validation_error", response.Error)
+}
+
+// TestQuickAdd_Preview tests that drafts read from a shopping note are returned with 200
+func TestQuickAdd_Preview(t *testing.T) {
+	mockUsecase := new(MockIntakeUsecase)
+	handler := NewIntakeHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/ingredients/quick-add", handler.QuickAdd)
+
+	purchaseDate := "2026-10-17"
+	expected := &usecase.QuickAddResponse{
+		IngredientDraftsResponse: usecase.IngredientDraftsResponse{
+			PurchaseDate: &purchaseDate,
+			Drafts: []usecase.IngredientDraft{
+				{CreateIngredientRequest: usecase.CreateIngredientRequest{Name: "鶏もも", Quantity: "300g", PurchaseDate: &purchaseDate}},
+			},
+			Ignored: []string{},
+		},
+	}
+	mockUsecase.On("QuickAdd", mock.Anything, usecase.QuickAddRequest{Text: "昨日買った鶏もも300g"}).Return(expected, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/ingredients/quick-add", bytes.NewBufferString(`{"text": "昨日買った鶏もも300g"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, false, response["committed"])
+	assert.Len(t, response["drafts"], 1)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestQuickAdd_Confirm tests that saved drafts are returned with 201
+func TestQuickAdd_Confirm(t *testing.T) {
+	mockUsecase := new(MockIntakeUsecase)
+	handler := NewIntakeHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/ingredients/quick-add", handler.QuickAdd)
+
+	expected := &usecase.QuickAddResponse{
+		IngredientDraftsResponse: usecase.IngredientDraftsResponse{
+			Drafts:  []usecase.IngredientDraft{{CreateIngredientRequest: usecase.CreateIngredientRequest{Name: "卵", Quantity: "10個"}}},
+			Ignored: []string{},
+		},
+		Committed:   true,
+		Ingredients: []*domain.Ingredient{{ID: 1, Name: "卵", Quantity: "10個"}},
+	}
+	mockUsecase.On("QuickAdd", mock.Anything, mock.MatchedBy(func(req usecase.QuickAddRequest) bool {
+		return req.Confirm && len(req.Drafts) == 1
+	})).Return(expected, nil)
+
+	body := `{"confirm": true, "drafts": [{"name": "卵", "quantity": "10個"}]}`
+	req := httptest.NewRequest(http.MethodPost, "/ingredients/quick-add", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response usecase.QuickAddResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.True(t, response.Committed)
+	assert.Equal(t, int64(1), response.Ingredients[0].ID)
+	mockUsecase.AssertExpectations(t)
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// ProductHandler handles HTTP requests for the local barcode product database
+type ProductHandler struct {
+	productUsecase usecase.ProductUsecase
+}
+
+// NewProductHandler creates a new ProductHandler instance
+func NewProductHandler(productUsecase usecase.ProductUsecase) *ProductHandler {
+	return &ProductHandler{
+		productUsecase: productUsecase,
+	}
+}
+
+// @Summary      バーコードから商品を取得
+// @Description  JAN/EAN バーコードに対応する商品をローカルの商品データベースから取得します。ハイフンや空白、全角数字を含むバーコードも受け付けます。
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        barcode  path  string  true  "JAN/EAN バーコード (8桁または13桁)"
+// @Success      200 {object} domain.Product "商品"
+// @Failure      400 {object} usecase.ErrorResponse "バーコードが不正です"
+// @Failure      404 {object} usecase.ErrorResponse "商品が見つかりません"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /products/{barcode} [get]
+// GetProduct handles GET /products/:barcode
+func (h *ProductHandler) GetProduct(c *gin.Context) {
+	// Call usecase
+	product, err := h.productUsecase.GetProduct(c.Request.Context(), c.Param("barcode"))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, product)
+}
+
+// @Summary      商品データベースをインポート
+// @Description  CSV 形式の商品データベースを取り込みます。列名は自動で判別され、mapping で明示することもできます。
+// @Description  利用者の登録から学習した商品は、overwrite_learned を指定しない限り上書きされません。
+// @Description  JSON のリクエストボディのほか、Content-Type が text/csv の生データも受け付けます。その場合のオプションはクエリパラメータで指定します。
+// @Description  商品データは全世帯で共有されるため、サーバー管理者だけが取り込めます。閲覧者として世帯を選んでいる場合も拒否されます。
+// @Tags         products
+// @Accept       json
+// @Accept       text/csv
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request             body   usecase.ImportProductsRequest  true   "インポートする内容"
+// @Param        overwrite_learned   query  bool    false  "学習した商品も上書きする"
+// @Param        mapping[barcode]    query  string  false  "バーコードの列名。ほかのフィールドも mapping[name] のように指定できます"
+// @Success      200 {object} usecase.ImportProductsResponse "インポート結果"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      403 {object} usecase.ErrorResponse "サーバー管理者ではないか、世帯の閲覧者です"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /products/import [post]
+// ImportProducts handles POST /products/import
+func (h *ProductHandler) ImportProducts(c *gin.Context) {
+	var req usecase.ImportProductsRequest
+
+	switch c.ContentType() {
+	case "application/json", "":
+		// Bind and validate request body
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondBadRequest(c, err.Error())
+			return
+		}
+	default:
+		// Accept the raw file as the request body, with options in the query string
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			respondBadRequest(c, err.Error())
+			return
+		}
+		req.Content = string(body)
+		req.Mapping = c.QueryMap("mapping")
+		if overwrite := c.Query("overwrite_learned"); overwrite != "" {
+			if req.OverwriteLearned, err = strconv.ParseBool(overwrite); err != nil {
+				respondBadRequest(c, "Invalid overwrite_learned")
+				return
+			}
+		}
+	}
+
+	// Call usecase
+	result, err := h.productUsecase.ImportProducts(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
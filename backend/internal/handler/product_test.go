@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockProductUsecase is a mock implementation of ProductUsecase
+type MockProductUsecase struct {
+	mock.Mock
+}
+
+func (m *MockProductUsecase) GetProduct(ctx context.Context, barcode string) (*domain.Product, error) {
+	args := m.Called(ctx, barcode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Product), args.Error(1)
+}
+
+func (m *MockProductUsecase) ImportProducts(ctx context.Context, req usecase.ImportProductsRequest) (*usecase.ImportProductsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.ImportProductsResponse), args.Error(1)
+}
+
+// TestGetProduct_Success tests retrieving a product by its barcode
+func TestGetProduct_Success(t *testing.T) {
+	mockUsecase := new(MockProductUsecase)
+	handler := NewProductHandler(mockUsecase)
+	router := setupTestRouter()
+	router.GET("/products/:barcode", handler.GetProduct)
+
+	expected := &domain.Product{Barcode: "49021028", Name: "牛乳", Category: "dairy", Unit: "1000ml", Source: domain.ProductSourceImport}
+	mockUsecase.On("GetProduct", mock.Anything, "49021028").Return(expected, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/products/49021028", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response domain.Product
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "牛乳", response.Name)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestGetProduct_Errors tests the status codes of invalid and unknown barcodes
+func TestGetProduct_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"invalid barcode", fmt.Errorf("%w: invalid barcode", domain.ErrInvalidInput), http.StatusBadRequest},
+		{"unknown barcode", fmt.Errorf("product not found: %w", sql.ErrNoRows), http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockProductUsecase)
+			handler := NewProductHandler(mockUsecase)
+			router := setupTestRouter()
+			router.GET("/products/:barcode", handler.GetProduct)
+
+			mockUsecase.On("GetProduct", mock.Anything, "12345").Return(nil, tt.err)
+
+			req := httptest.NewRequest(http.MethodGet, "/products/12345", nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expected, w.Code)
+		})
+	}
+}
+
+// TestImportProducts_JSON tests importing a product dump sent as a JSON body
+func TestImportProducts_JSON(t *testing.T) {
+	mockUsecase := new(MockProductUsecase)
+	handler := NewProductHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/products/import", handler.ImportProducts)
+
+	reqBody := usecase.ImportProductsRequest{Content: "jan,商品名\n49021028,牛乳\n"}
+	expected := &usecase.ImportProductsResponse{
+		Mapping:  map[string]string{"barcode": "jan", "name": "商品名"},
+		Imported: 1,
+		Errors:   []usecase.ImportProductError{},
+	}
+	mockUsecase.On("ImportProducts", mock.Anything, reqBody).Return(expected, nil)
+
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPost, "/products/import", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestImportProducts_RawCSV tests importing a raw CSV body with options in the query string
+func TestImportProducts_RawCSV(t *testing.T) {
+	mockUsecase := new(MockProductUsecase)
+	handler := NewProductHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/products/import", handler.ImportProducts)
+
+	expectedReq := usecase.ImportProductsRequest{
+		Content:          "code,title\n49021028,牛乳\n",
+		Mapping:          map[string]string{"name": "title"},
+		OverwriteLearned: true,
+	}
+	mockUsecase.On("ImportProducts", mock.Anything, expectedReq).Return(&usecase.ImportProductsResponse{Imported: 1}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/products/import?overwrite_learned=true&mapping[name]=title", bytes.NewBufferString(expectedReq.Content))
+	req.Header.Set("Content-Type", "text/csv")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestImportProducts_InvalidOverwriteLearned tests that a malformed overwrite_learned is rejected
+func TestImportProducts_InvalidOverwriteLearned(t *testing.T) {
+	mockUsecase := new(MockProductUsecase)
+	handler := NewProductHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/products/import", handler.ImportProducts)
+
+	req := httptest.NewRequest(http.MethodPost, "/products/import?overwrite_learned=maybe", bytes.NewBufferString("jan,name\n"))
+	req.Header.Set("Content-Type", "text/csv")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUsecase.AssertNotCalled(t, "ImportProducts", mock.Anything, mock.Anything)
+}
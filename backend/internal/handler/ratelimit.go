@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/internal/usecase"
+	"github.com/Rin0530/DinnerDecider/backend/pkg/ratelimit"
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimit returns a middleware that limits each client with the read limiter for GET and HEAD requests and
+// the write limiter for every other method, answering 429 Too Many Requests once its bucket is empty.
+// Clients are told apart by API key, signed-in user or IP address, so it should run after RequireUser where
+// there is one. A nil limiter leaves its requests unlimited.
+// Every limited response carries the RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset headers.
+func RateLimit(read *ratelimit.Limiter, write *ratelimit.Limiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limiter := write
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			limiter = read
+		}
+		if limiter == nil {
+			c.Next()
+			return
+		}
+
+		result := limiter.Allow(rateLimitKey(c))
+		c.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("RateLimit-Reset", strconv.Itoa(seconds(result.Reset)))
+
+		if !result.Allowed {
+			retryAfter := seconds(result.RetryAfter)
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, usecase.ErrorResponse{
+				Error:   "too_many_requests",
+				Message: fmt.Sprintf("Rate limit exceeded; retry in %d seconds", retryAfter),
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// rateLimitKey identifies the client of a request: its API key, its signed-in user or its IP address.
+// The IP address comes from X-Forwarded-For only when the router trusts the proxy that sent the request.
+func rateLimitKey(c *gin.Context) string {
+	if key := domain.CurrentAPIKey(c.Request.Context()); key != nil {
+		return "key:" + strconv.FormatInt(key.ID, 10)
+	}
+	if user := domain.CurrentUser(c.Request.Context()); user != nil {
+		return "user:" + strconv.FormatInt(user.ID, 10)
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// seconds rounds d up to whole seconds for the rate limit headers
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
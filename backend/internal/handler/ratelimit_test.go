@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/internal/usecase"
+	"github.com/Rin0530/DinnerDecider/backend/pkg/ratelimit"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// withUser returns a middleware that signs the request in as user, as RequireUser does
+func withUser(user *domain.User) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(domain.WithUser(c.Request.Context(), user))
+		c.Next()
+	}
+}
+
+// TestRateLimit_TooManyRequests tests that clients over their limit get 429 with the rate limit headers
+func TestRateLimit_TooManyRequests(t *testing.T) {
+	router := setupTestRouter()
+	limiter := ratelimit.New(1, time.Minute, 2)
+	router.POST("/recipes/suggestion", withUser(&domain.User{ID: 1}), RateLimit(limiter, limiter), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	codes := make([]int, 0, 3)
+	var w *httptest.ResponseRecorder
+	for i := 0; i < 3; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/recipes/suggestion", nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		codes = append(codes, w.Code)
+	}
+
+	assert.Equal(t, []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests}, codes)
+	assert.Equal(t, "2", w.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "60", w.Header().Get("Retry-After"))
+	assert.Equal(t, "120", w.Header().Get("RateLimit-Reset"))
+
+	var response usecase.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "too_many_requests", response.Error)
+}
+
+// TestRateLimit_PerClient tests that users, API keys and anonymous clients each have their own bucket
+func TestRateLimit_PerClient(t *testing.T) {
+	router := setupTestRouter()
+	limiter := ratelimit.New(1, time.Minute, 1)
+	limit := RateLimit(limiter, limiter)
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.GET("/user/1", withUser(&domain.User{ID: 1}), limit, ok)
+	router.GET("/user/2", withUser(&domain.User{ID: 2}), limit, ok)
+	router.GET("/key", withUser(&domain.User{ID: 1}), withAPIKey(&domain.APIKey{ID: 3}), limit, ok)
+	router.GET("/anonymous", limit, ok)
+
+	for _, path := range []string{"/user/1", "/user/2", "/key", "/anonymous"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code, path)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/user/1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+}
+
+// TestRateLimit_ForwardedFor tests that X-Forwarded-For only tells clients apart behind a trusted proxy
+func TestRateLimit_ForwardedFor(t *testing.T) {
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		expected       []int
+	}{
+		{"untrusted", nil, []int{http.StatusOK, http.StatusTooManyRequests, http.StatusTooManyRequests}},
+		{"trusted proxy", []string{"192.0.2.0/24"}, []int{http.StatusOK, http.StatusOK, http.StatusOK}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupTestRouter()
+			err := router.SetTrustedProxies(tt.trustedProxies)
+			assert.NoError(t, err)
+			limiter := ratelimit.New(1, time.Minute, 1)
+			router.POST("/auth/login", RateLimit(limiter, limiter), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			codes := make([]int, 0, 3)
+			for _, forwardedFor := range []string{"198.51.100.1", "198.51.100.2", "198.51.100.3"} {
+				req := httptest.NewRequest(http.MethodPost, "/auth/login", nil)
+				req.RemoteAddr = "192.0.2.1:1234"
+				req.Header.Set("X-Forwarded-For", forwardedFor)
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				codes = append(codes, w.Code)
+			}
+
+			assert.Equal(t, tt.expected, codes)
+		})
+	}
+}
+
+// TestRateLimit_ReadsAndWrites tests that reads and writes use their own limiters and nil limiters do not limit
+func TestRateLimit_ReadsAndWrites(t *testing.T) {
+	router := setupTestRouter()
+	limit := RateLimit(nil, ratelimit.New(1, time.Minute, 1))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.GET("/ingredients", limit, ok)
+	router.POST("/ingredients", limit, ok)
+
+	for i := 0; i < 3; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/ingredients", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+	}
+
+	codes := make([]int, 0, 2)
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/ingredients", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		codes = append(codes, w.Code)
+	}
+	assert.Equal(t, []int{http.StatusOK, http.StatusTooManyRequests}, codes)
+}
@@ -22,26 +22,27 @@ func NewRecipeHandler(recipeUsecase usecase.RecipeUsecase) *RecipeHandler {
 
 // GetRecipeSuggestion handles POST /recipes/suggestion
 // @Summary 献立提案を取得
-// @Description 登録されている食材を基にAIが献立を提案します
+// @Description 登録されている食材を基にAIが献立を提案します。AIが利用できない場合は同梱のレシピカタログから提案します（source: "catalog"）
 // @Tags recipes
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Success 200 {object} domain.RecipeResponse "献立提案のリスト"
 // @Failure 500 {object} usecase.ErrorResponse "内部サーバーエラー"
-// @Failure 503 {object} usecase.ErrorResponse "サービス利用不可（AI APIが利用できない場合）"
+// @Failure 503 {object} usecase.ErrorResponse "サービス利用不可（AI APIが利用できず、フォールバックも無効な場合）"
 // @Router /recipes/suggestion [post]
 func (h *RecipeHandler) GetRecipeSuggestion(c *gin.Context) {
 	// Call usecase to get recipe suggestions
 	recipeResponse, err := h.recipeUsecase.GetRecipeSuggestion(c.Request.Context())
 	if err != nil {
 		// Check if it's a service unavailability error (Ollama API)
-		if strings.Contains(err.Error(), "ollama") || 
-		   strings.Contains(err.Error(), "timeout") ||
-		   strings.Contains(err.Error(), "connection") {
+		if strings.Contains(err.Error(), "ollama") ||
+			strings.Contains(err.Error(), "timeout") ||
+			strings.Contains(err.Error(), "connection") {
 			respondServiceUnavailable(c, "Recipe suggestion service is currently unavailable")
 			return
 		}
-		
+
 		// Handle other errors
 		handleError(c, err)
 		return
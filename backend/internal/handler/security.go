@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/usecase"
+	"github.com/Rin0530/DinnerDecider/backend/pkg/config"
+	"github.com/gin-gonic/gin"
+)
+
+// corsAllowedHeaders lists the request headers browsers may send cross-origin
+var corsAllowedHeaders = []string{"Authorization", "Content-Type", "If-Match", "If-None-Match", actorHeader, HouseholdHeader}
+
+// corsExposedHeaders lists the response headers browsers let cross-origin scripts read
+var corsExposedHeaders = []string{
+	"Content-Disposition", "ETag", "WWW-Authenticate", HouseholdHeader,
+	"RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "Retry-After",
+}
+
+// CORS returns a middleware that lets browsers on the configured origins call the API.
+// It answers preflight requests itself, so it must run before the routes that require a user.
+// Requests from other origins are served without CORS headers, which makes browsers block them.
+func CORS(cfg *config.CORSConfig) gin.HandlerFunc {
+	anyOrigin := slices.Contains(cfg.AllowedOrigins, "*")
+	methods := strings.Join(cfg.AllowedMethods, ", ")
+	maxAge := strconv.Itoa(int(cfg.MaxAge.Seconds()))
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" || len(cfg.AllowedOrigins) == 0 {
+			c.Next()
+			return
+		}
+
+		// The answer depends on the origin unless every origin gets the same one
+		if !anyOrigin || cfg.AllowCredentials {
+			c.Writer.Header().Add("Vary", "Origin")
+		}
+
+		preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+		allowed := anyOrigin || slices.Contains(cfg.AllowedOrigins, strings.TrimSuffix(origin, "/"))
+		if preflight {
+			allowed = allowed && slices.Contains(cfg.AllowedMethods, c.GetHeader("Access-Control-Request-Method"))
+		}
+
+		if allowed {
+			// Browsers refuse credentials with a wildcard origin, so name the origin instead
+			if anyOrigin && !cfg.AllowCredentials {
+				c.Header("Access-Control-Allow-Origin", "*")
+			} else {
+				c.Header("Access-Control-Allow-Origin", origin)
+			}
+			if cfg.AllowCredentials {
+				c.Header("Access-Control-Allow-Credentials", "true")
+			}
+		}
+
+		if preflight {
+			if allowed {
+				c.Header("Access-Control-Allow-Methods", methods)
+				c.Header("Access-Control-Allow-Headers", strings.Join(corsAllowedHeaders, ", "))
+				c.Header("Access-Control-Max-Age", maxAge)
+			}
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		if allowed {
+			c.Header("Access-Control-Expose-Headers", strings.Join(corsExposedHeaders, ", "))
+		}
+		c.Next()
+	}
+}
+
+// SecurityHeaders returns a middleware that adds the configured Content-Security-Policy and Referrer-Policy
+// headers to every response and stops browsers from sniffing content types
+func SecurityHeaders(cfg *config.SecurityConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("X-Content-Type-Options", "nosniff")
+		if cfg.ContentSecurityPolicy != "" {
+			c.Header("Content-Security-Policy", cfg.ContentSecurityPolicy)
+		}
+		if cfg.ReferrerPolicy != "" {
+			c.Header("Referrer-Policy", cfg.ReferrerPolicy)
+		}
+		c.Next()
+	}
+}
+
+// ContentSecurityPolicy returns a middleware that replaces the Content-Security-Policy set by SecurityHeaders,
+// for pages such as Swagger UI that load scripts and styles. An empty policy removes the header.
+func ContentSecurityPolicy(policy string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Security-Policy", policy)
+		c.Next()
+	}
+}
+
+// LimitBodySize returns a middleware that rejects request bodies larger than limit bytes with
+// 413 Request Entity Too Large. Bodies without a Content-Length are cut off at the limit instead,
+// which fails the request when it is read. A limit of zero or less leaves bodies unlimited.
+func LimitBodySize(limit int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if limit <= 0 || c.Request.Body == nil {
+			c.Next()
+			return
+		}
+
+		if c.Request.ContentLength > limit {
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, usecase.ErrorResponse{
+				Error:   "request_too_large",
+				Message: fmt.Sprintf("Request body must not exceed %d bytes", limit),
+			})
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		c.Next()
+	}
+}
@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Rin0530/DinnerDecider/backend/pkg/config"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// testCORSConfig allows the frontend's development server
+var testCORSConfig = config.CORSConfig{
+	AllowedOrigins: []string{"http://localhost:3000"},
+	AllowedMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE"},
+	MaxAge:         12 * time.Hour,
+}
+
+// TestCORS_Preflight tests that preflight requests from allowed origins are answered before authentication
+func TestCORS_Preflight(t *testing.T) {
+	router := setupTestRouter()
+	router.Use(CORS(&testCORSConfig))
+	router.PUT("/api/ingredients/:id", func(c *gin.Context) {
+		c.AbortWithStatus(http.StatusUnauthorized)
+	})
+
+	req := httptest.NewRequest(http.MethodOptions, "/api/ingredients/1", nil)
+	req.Header.Set("Origin", "http://localhost:3000")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPut)
+	req.Header.Set("Access-Control-Request-Headers", "authorization, if-match")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "http://localhost:3000", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "GET, POST, PUT, PATCH, DELETE", w.Header().Get("Access-Control-Allow-Methods"))
+	assert.Contains(t, w.Header().Get("Access-Control-Allow-Headers"), "If-Match")
+	assert.Contains(t, w.Header().Get("Access-Control-Allow-Headers"), HouseholdHeader)
+	assert.Equal(t, "43200", w.Header().Get("Access-Control-Max-Age"))
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Credentials"))
+	assert.Equal(t, "Origin", w.Header().Get("Vary"))
+}
+
+// TestCORS_Origins tests that only allowed origins get CORS headers on actual requests
+func TestCORS_Origins(t *testing.T) {
+	router := setupTestRouter()
+	router.Use(CORS(&testCORSConfig))
+	router.GET("/api/ingredients", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	tests := []struct {
+		origin string
+		want   string
+	}{
+		{origin: "http://localhost:3000", want: "http://localhost:3000"},
+		{origin: "http://evil.example.com", want: ""},
+		{origin: "", want: ""},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/api/ingredients", nil)
+		if tt.origin != "" {
+			req.Header.Set("Origin", tt.origin)
+		}
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code, tt.origin)
+		assert.Equal(t, tt.want, w.Header().Get("Access-Control-Allow-Origin"), tt.origin)
+		if tt.want != "" {
+			assert.Contains(t, w.Header().Get("Access-Control-Expose-Headers"), "ETag")
+		}
+	}
+}
+
+// TestCORS_AnyOriginWithCredentials tests that the wildcard origin is replaced by the caller's origin with credentials
+func TestCORS_AnyOriginWithCredentials(t *testing.T) {
+	router := setupTestRouter()
+	router.Use(CORS(&config.CORSConfig{AllowedOrigins: []string{"*"}, AllowedMethods: []string{"GET"}, AllowCredentials: true}))
+	router.GET("/api/ingredients", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/ingredients", nil)
+	req.Header.Set("Origin", "http://192.168.1.20:3000")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, "http://192.168.1.20:3000", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"))
+}
+
+// TestSecurityHeaders tests that responses carry the security headers and that Swagger UI gets its own policy
+func TestSecurityHeaders(t *testing.T) {
+	router := setupTestRouter()
+	router.Use(SecurityHeaders(&config.SecurityConfig{
+		ContentSecurityPolicy: "default-src 'none'",
+		ReferrerPolicy:        "no-referrer",
+	}))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.GET("/api/ingredients", ok)
+	router.GET("/swagger/*any", ContentSecurityPolicy("default-src 'self'"), ok)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/ingredients", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+	assert.Equal(t, "no-referrer", w.Header().Get("Referrer-Policy"))
+	assert.Equal(t, "default-src 'none'", w.Header().Get("Content-Security-Policy"))
+
+	req = httptest.NewRequest(http.MethodGet, "/swagger/index.html", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, "default-src 'self'", w.Header().Get("Content-Security-Policy"))
+}
+
+// TestLimitBodySize tests that bodies over the limit are rejected, whether or not they declare their length
+func TestLimitBodySize(t *testing.T) {
+	router := setupTestRouter()
+	router.Use(LimitBodySize(16))
+	router.POST("/api/ingredients", func(c *gin.Context) {
+		if _, err := io.ReadAll(c.Request.Body); err != nil {
+			respondBadRequest(c, err.Error())
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/ingredients", bytes.NewBufferString(`{"name":"卵"}`))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/ingredients", bytes.NewBufferString(`{"name":"たまねぎ"}`))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.Contains(t, w.Body.String(), `"error":"request_too_large"`)
+
+	// Without a Content-Length the body is cut off while it is read
+	req = httptest.NewRequest(http.MethodPost, "/api/ingredients", io.NopCloser(strings.NewReader(`{"name":"たまねぎ"}`)))
+	req.ContentLength = -1
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
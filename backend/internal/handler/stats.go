@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// StatsHandler handles HTTP requests for food waste and cooking statistics
+type StatsHandler struct {
+	statsUsecase usecase.StatsUsecase
+}
+
+// NewStatsHandler creates a new StatsHandler instance
+func NewStatsHandler(statsUsecase usecase.StatsUsecase) *StatsHandler {
+	return &StatsHandler{
+		statsUsecase: statsUsecase,
+	}
+}
+
+// @Summary      食材の消費と廃棄を集計
+// @Description  食材を使った記録を月・カテゴリごとに集計し、食べた件数（cooked、eaten）と捨てた件数（discarded、expired）、期間全体の廃棄率を返します。
+// @Tags         stats
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        since  query  string  false  "この日時以降の記録 (YYYY-MM-DD または RFC3339)"
+// @Param        until  query  string  false  "この日時より前の記録。日付のみの場合はその日を含みます (YYYY-MM-DD または RFC3339)"
+// @Success      200 {object} usecase.UsageStatsResponse "月・カテゴリごとの消費と廃棄"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /stats/usage [get]
+// GetUsage handles GET /stats/usage
+func (h *StatsHandler) GetUsage(c *gin.Context) {
+	var req usecase.StatsRequest
+
+	// Bind query parameters
+	if err := c.ShouldBindQuery(&req); err != nil {
+		respondBadRequest(c, err.Error())
+		return
+	}
+
+	// Call usecase
+	usage, err := h.statsUsecase.GetUsage(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, usage)
+}
+
+// @Summary      購入から消費までの日数を集計
+// @Description  購入日が分かる食材について、購入してから食べる（cooked、eaten）までの平均日数を全体とカテゴリごとに返します。
+// @Tags         stats
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        since  query  string  false  "この日時以降の記録 (YYYY-MM-DD または RFC3339)"
+// @Param        until  query  string  false  "この日時より前の記録。日付のみの場合はその日を含みます (YYYY-MM-DD または RFC3339)"
+// @Success      200 {object} usecase.PurchaseToUseResponse "購入から消費までの平均日数"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /stats/purchase-to-use [get]
+// GetPurchaseToUse handles GET /stats/purchase-to-use
+func (h *StatsHandler) GetPurchaseToUse(c *gin.Context) {
+	var req usecase.StatsRequest
+
+	// Bind query parameters
+	if err := c.ShouldBindQuery(&req); err != nil {
+		respondBadRequest(c, err.Error())
+		return
+	}
+
+	// Call usecase
+	durations, err := h.statsUsecase.GetPurchaseToUse(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, durations)
+}
+
+// @Summary      よく捨てる食材を取得
+// @Description  捨てた（discarded、expired）回数の多い食材を正規化した名前ごとに多い順で返します。
+// @Tags         stats
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        since  query  string  false  "この日時以降の記録 (YYYY-MM-DD または RFC3339)"
+// @Param        until  query  string  false  "この日時より前の記録。日付のみの場合はその日を含みます (YYYY-MM-DD または RFC3339)"
+// @Param        limit  query  int     false  "件数 (デフォルト: 10、最大: 100)"
+// @Success      200 {array} domain.WastedIngredient "よく捨てる食材"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /stats/most-wasted [get]
+// GetMostWasted handles GET /stats/most-wasted
+func (h *StatsHandler) GetMostWasted(c *gin.Context) {
+	var req usecase.StatsRequest
+
+	// Bind query parameters
+	if err := c.ShouldBindQuery(&req); err != nil {
+		respondBadRequest(c, err.Error())
+		return
+	}
+
+	// Call usecase
+	wasted, err := h.statsUsecase.GetMostWasted(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, wasted)
+}
+
+// @Summary      レシピ提案の実施率を取得
+// @Description  期間中のレシピ提案の数と、そのうち実際に作られた（suggestion_id 付きで cooked として食材を使った）提案の数と割合を返します。
+// @Tags         stats
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        since  query  string  false  "この日時以降の提案 (YYYY-MM-DD または RFC3339)"
+// @Param        until  query  string  false  "この日時より前の提案。日付のみの場合はその日を含みます (YYYY-MM-DD または RFC3339)"
+// @Success      200 {object} domain.SuggestionStats "レシピ提案の実施率"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /stats/suggestions [get]
+// GetSuggestionStats handles GET /stats/suggestions
+func (h *StatsHandler) GetSuggestionStats(c *gin.Context) {
+	var req usecase.StatsRequest
+
+	// Bind query parameters
+	if err := c.ShouldBindQuery(&req); err != nil {
+		respondBadRequest(c, err.Error())
+		return
+	}
+
+	// Call usecase
+	stats, err := h.statsUsecase.GetSuggestionStats(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// @Summary      食費を集計
+// @Description  価格を登録した食材の購入金額を、週（月曜始まり）・カテゴリ・購入先のいずれかごとに集計します。期間は購入日で絞り込みます。
+// @Tags         stats
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        since     query  string  false  "この日以降の購入 (YYYY-MM-DD または RFC3339)"
+// @Param        until     query  string  false  "この日より前の購入。日付のみの場合はその日を含みます (YYYY-MM-DD または RFC3339)"
+// @Param        group_by  query  string  false  "集計単位 (デフォルト: week)"  Enums(week, category, store)
+// @Success      200 {object} usecase.SpendReportResponse "集計単位ごとの購入金額"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /stats/spend [get]
+// GetSpend handles GET /stats/spend
+func (h *StatsHandler) GetSpend(c *gin.Context) {
+	var req usecase.StatsRequest
+
+	// Bind query parameters
+	if err := c.ShouldBindQuery(&req); err != nil {
+		respondBadRequest(c, err.Error())
+		return
+	}
+
+	// Call usecase
+	spend, err := h.statsUsecase.GetSpend(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, spend)
+}
+
+// @Summary      料理ごとの費用を推定
+// @Description  cooked として使った食材の推定費用（使った分の価格）を料理名ごとに合計し、作った日数と 1 回あたりの平均費用を返します。価格の分からない食材は費用に含めず unpriced_items に数えます。
+// @Tags         stats
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        since  query  string  false  "この日時以降に作った料理 (YYYY-MM-DD または RFC3339)"
+// @Param        until  query  string  false  "この日時より前に作った料理。日付のみの場合はその日を含みます (YYYY-MM-DD または RFC3339)"
+// @Success      200 {array} domain.RecipeCost "料理ごとの推定費用"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /stats/recipe-costs [get]
+// GetRecipeCosts handles GET /stats/recipe-costs
+func (h *StatsHandler) GetRecipeCosts(c *gin.Context) {
+	var req usecase.StatsRequest
+
+	// Bind query parameters
+	if err := c.ShouldBindQuery(&req); err != nil {
+		respondBadRequest(c, err.Error())
+		return
+	}
+
+	// Call usecase
+	costs, err := h.statsUsecase.GetRecipeCosts(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, costs)
+}
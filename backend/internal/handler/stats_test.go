@@ -0,0 +1,231 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockStatsUsecase is a mock implementation of StatsUsecase
+type MockStatsUsecase struct {
+	mock.Mock
+}
+
+func (m *MockStatsUsecase) GetUsage(ctx context.Context, req usecase.StatsRequest) (*usecase.UsageStatsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.UsageStatsResponse), args.Error(1)
+}
+
+func (m *MockStatsUsecase) GetPurchaseToUse(ctx context.Context, req usecase.StatsRequest) (*usecase.PurchaseToUseResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.PurchaseToUseResponse), args.Error(1)
+}
+
+func (m *MockStatsUsecase) GetMostWasted(ctx context.Context, req usecase.StatsRequest) ([]*domain.WastedIngredient, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.WastedIngredient), args.Error(1)
+}
+
+func (m *MockStatsUsecase) GetSuggestionStats(ctx context.Context, req usecase.StatsRequest) (*domain.SuggestionStats, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SuggestionStats), args.Error(1)
+}
+
+func (m *MockStatsUsecase) GetSpend(ctx context.Context, req usecase.StatsRequest) (*usecase.SpendReportResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.SpendReportResponse), args.Error(1)
+}
+
+func (m *MockStatsUsecase) GetRecipeCosts(ctx context.Context, req usecase.StatsRequest) ([]*domain.RecipeCost, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.RecipeCost), args.Error(1)
+}
+
+// TestGetUsage_Success tests the monthly usage statistics for a period
+func TestGetUsage_Success(t *testing.T) {
+	mockUsecase := new(MockStatsUsecase)
+	handler := NewStatsHandler(mockUsecase)
+	router := setupTestRouter()
+	router.GET("/stats/usage", handler.GetUsage)
+
+	expected := &usecase.UsageStatsResponse{
+		Items:     []*domain.CategoryUsage{{Month: "2026-10", Category: domain.CategoryVegetable, Consumed: 3, Wasted: 1}},
+		Consumed:  3,
+		Wasted:    1,
+		WasteRate: 0.25,
+	}
+	mockUsecase.On("GetUsage", mock.Anything, usecase.StatsRequest{Since: "2026-10-01", Until: "2026-10-31"}).Return(expected, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/stats/usage?since=2026-10-01&until=2026-10-31", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response usecase.UsageStatsResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.25, response.WasteRate)
+	assert.Len(t, response.Items, 1)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestGetUsage_InvalidInput tests that an invalid period returns 400
+func TestGetUsage_InvalidInput(t *testing.T) {
+	mockUsecase := new(MockStatsUsecase)
+	handler := NewStatsHandler(mockUsecase)
+	router := setupTestRouter()
+	router.GET("/stats/usage", handler.GetUsage)
+
+	mockUsecase.On("GetUsage", mock.Anything, usecase.StatsRequest{Since: "yesterday"}).
+		Return(nil, fmt.Errorf("%w: invalid since format", domain.ErrInvalidInput))
+
+	req := httptest.NewRequest(http.MethodGet, "/stats/usage?since=yesterday", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestGetMostWasted_Success tests the most wasted ranking with a limit
+func TestGetMostWasted_Success(t *testing.T) {
+	mockUsecase := new(MockStatsUsecase)
+	handler := NewStatsHandler(mockUsecase)
+	router := setupTestRouter()
+	router.GET("/stats/most-wasted", handler.GetMostWasted)
+
+	expected := []*domain.WastedIngredient{{CanonicalName: "もやし", Name: "もやし", Count: 3}}
+	mockUsecase.On("GetMostWasted", mock.Anything, usecase.StatsRequest{Limit: 5}).Return(expected, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/stats/most-wasted?limit=5", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response []*domain.WastedIngredient
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Len(t, response, 1)
+	assert.Equal(t, 3, response[0].Count)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestGetMostWasted_InvalidLimit tests that a non-numeric limit returns 400
+func TestGetMostWasted_InvalidLimit(t *testing.T) {
+	mockUsecase := new(MockStatsUsecase)
+	handler := NewStatsHandler(mockUsecase)
+	router := setupTestRouter()
+	router.GET("/stats/most-wasted", handler.GetMostWasted)
+
+	req := httptest.NewRequest(http.MethodGet, "/stats/most-wasted?limit=many", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUsecase.AssertNotCalled(t, "GetMostWasted", mock.Anything, mock.Anything)
+}
+
+// TestGetSuggestionStats_Success tests the fraction of suggestions that were cooked
+func TestGetSuggestionStats_Success(t *testing.T) {
+	mockUsecase := new(MockStatsUsecase)
+	handler := NewStatsHandler(mockUsecase)
+	router := setupTestRouter()
+	router.GET("/stats/suggestions", handler.GetSuggestionStats)
+
+	mockUsecase.On("GetSuggestionStats", mock.Anything, usecase.StatsRequest{}).
+		Return(&domain.SuggestionStats{Suggested: 4, Cooked: 1, CookedRate: 0.25}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/stats/suggestions", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response domain.SuggestionStats
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.25, response.CookedRate)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestGetSpend_Success tests the spend report grouped by store
+func TestGetSpend_Success(t *testing.T) {
+	mockUsecase := new(MockStatsUsecase)
+	handler := NewStatsHandler(mockUsecase)
+	router := setupTestRouter()
+	router.GET("/stats/spend", handler.GetSpend)
+
+	expected := &usecase.SpendReportResponse{
+		GroupBy: "store",
+		Total:   1500,
+		Count:   3,
+		Items:   []*domain.SpendTotal{{Key: "スーパーA", Total: 1500, Count: 3}},
+	}
+	mockUsecase.On("GetSpend", mock.Anything, usecase.StatsRequest{GroupBy: "store"}).Return(expected, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/stats/spend?group_by=store", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response usecase.SpendReportResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, 1500, response.Total)
+	assert.Equal(t, "スーパーA", response.Items[0].Key)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestGetRecipeCosts_InvalidInput tests that an invalid period returns 400
+func TestGetRecipeCosts_InvalidInput(t *testing.T) {
+	mockUsecase := new(MockStatsUsecase)
+	handler := NewStatsHandler(mockUsecase)
+	router := setupTestRouter()
+	router.GET("/stats/recipe-costs", handler.GetRecipeCosts)
+
+	mockUsecase.On("GetRecipeCosts", mock.Anything, usecase.StatsRequest{Until: "someday"}).
+		Return(nil, fmt.Errorf("%w: invalid until format", domain.ErrInvalidInput))
+
+	req := httptest.NewRequest(http.MethodGet, "/stats/recipe-costs?until=someday", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// SynonymHandler handles HTTP requests for the ingredient synonym dictionary
+type SynonymHandler struct {
+	synonymUsecase usecase.SynonymUsecase
+}
+
+// NewSynonymHandler creates a new SynonymHandler instance
+func NewSynonymHandler(synonymUsecase usecase.SynonymUsecase) *SynonymHandler {
+	return &SynonymHandler{
+		synonymUsecase: synonymUsecase,
+	}
+}
+
+// @Summary      同義語辞書を取得
+// @Description  食材名の正規化に使用する同義語辞書の全エントリを取得します。
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {array} domain.DictionaryEntry "辞書エントリのリスト"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /admin/synonyms [get]
+// ListSynonyms handles GET /admin/synonyms
+func (h *SynonymHandler) ListSynonyms(c *gin.Context) {
+	// Call usecase
+	entries, err := h.synonymUsecase.ListSynonyms(c.Request.Context())
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+// @Summary      同義語辞書のエントリを取得
+// @Description  指定された正規名の辞書エントリを取得します。
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        canonical  path  string  true  "正規名"
+// @Success      200 {object} domain.DictionaryEntry "辞書エントリ"
+// @Failure      404 {object} usecase.ErrorResponse "エントリが見つかりません"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /admin/synonyms/{canonical} [get]
+// GetSynonym handles GET /admin/synonyms/:canonical
+func (h *SynonymHandler) GetSynonym(c *gin.Context) {
+	// Call usecase
+	entry, err := h.synonymUsecase.GetSynonym(c.Request.Context(), c.Param("canonical"))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// @Summary      同義語辞書のエントリを登録・更新
+// @Description  指定された正規名の辞書エントリを登録、または置き換えます。現在の世帯の登録済みの食材の正規名も再計算されます。サーバー管理者のみ利用できます。
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        canonical  path  string                      true  "正規名"
+// @Param        request    body  usecase.SaveSynonymRequest  true  "別名と上位の食材"
+// @Success      200 {object} domain.DictionaryEntry "保存された辞書エントリ"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      403 {object} usecase.ErrorResponse "サーバー管理者ではありません"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /admin/synonyms/{canonical} [put]
+// SaveSynonym handles PUT /admin/synonyms/:canonical
+func (h *SynonymHandler) SaveSynonym(c *gin.Context) {
+	var req usecase.SaveSynonymRequest
+
+	// Bind and validate request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, err.Error())
+		return
+	}
+
+	// Call usecase
+	entry, err := h.synonymUsecase.SaveSynonym(c.Request.Context(), c.Param("canonical"), req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// @Summary      同義語辞書のエントリを削除
+// @Description  指定された正規名の辞書エントリを削除します。現在の世帯の登録済みの食材の正規名も再計算されます。サーバー管理者のみ利用できます。
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        canonical  path  string  true  "正規名"
+// @Success      204 "削除成功"
+// @Failure      403 {object} usecase.ErrorResponse "サーバー管理者ではありません"
+// @Failure      404 {object} usecase.ErrorResponse "エントリが見つかりません"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /admin/synonyms/{canonical} [delete]
+// DeleteSynonym handles DELETE /admin/synonyms/:canonical
+func (h *SynonymHandler) DeleteSynonym(c *gin.Context) {
+	// Call usecase
+	if err := h.synonymUsecase.DeleteSynonym(c.Request.Context(), c.Param("canonical")); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary      食材の正規名を再計算
+// @Description  現在の世帯の登録済みの食材について、現在の同義語辞書で正規名を再計算します。サーバー管理者のみ利用できます。
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} usecase.ReindexResponse "更新された食材の件数"
+// @Failure      403 {object} usecase.ErrorResponse "サーバー管理者ではありません"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /admin/synonyms/reindex [post]
+// ReindexIngredients handles POST /admin/synonyms/reindex
+func (h *SynonymHandler) ReindexIngredients(c *gin.Context) {
+	// Call usecase
+	result, err := h.synonymUsecase.ReindexIngredients(c.Request.Context())
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,235 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/internal/service"
+	"github.com/Rin0530/DinnerDecider/backend/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSynonymUsecase is a mock implementation of SynonymUsecase
+type MockSynonymUsecase struct {
+	mock.Mock
+}
+
+func (m *MockSynonymUsecase) ListSynonyms(ctx context.Context) ([]domain.DictionaryEntry, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.DictionaryEntry), args.Error(1)
+}
+
+func (m *MockSynonymUsecase) GetSynonym(ctx context.Context, canonical string) (*domain.DictionaryEntry, error) {
+	args := m.Called(ctx, canonical)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DictionaryEntry), args.Error(1)
+}
+
+func (m *MockSynonymUsecase) SaveSynonym(ctx context.Context, canonical string, req usecase.SaveSynonymRequest) (*domain.DictionaryEntry, error) {
+	args := m.Called(ctx, canonical, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DictionaryEntry), args.Error(1)
+}
+
+func (m *MockSynonymUsecase) DeleteSynonym(ctx context.Context, canonical string) error {
+	args := m.Called(ctx, canonical)
+	return args.Error(0)
+}
+
+func (m *MockSynonymUsecase) ReindexIngredients(ctx context.Context) (*usecase.ReindexResponse, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.ReindexResponse), args.Error(1)
+}
+
+// TestListSynonyms_Success tests listing the synonym dictionary
+func TestListSynonyms_Success(t *testing.T) {
+	mockUsecase := new(MockSynonymUsecase)
+	handler := NewSynonymHandler(mockUsecase)
+	router := setupTestRouter()
+	router.GET("/admin/synonyms", handler.ListSynonyms)
+
+	mockUsecase.On("ListSynonyms", mock.Anything).
+		Return([]domain.DictionaryEntry{{Canonical: "玉ねぎ", Aliases: []string{"たまねぎ"}}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/synonyms", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response []domain.DictionaryEntry
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Len(t, response, 1)
+	assert.Equal(t, "玉ねぎ", response[0].Canonical)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestGetSynonym_NotFound tests fetching a missing dictionary entry
+func TestGetSynonym_NotFound(t *testing.T) {
+	mockUsecase := new(MockSynonymUsecase)
+	handler := NewSynonymHandler(mockUsecase)
+	router := setupTestRouter()
+	router.GET("/admin/synonyms/:canonical", handler.GetSynonym)
+
+	mockUsecase.On("GetSynonym", mock.Anything, "豚肉").
+		Return(nil, fmt.Errorf("synonym not found: %w", sql.ErrNoRows))
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/synonyms/"+url.PathEscape("豚肉"), nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestSaveSynonym_Success tests creating a dictionary entry
+func TestSaveSynonym_Success(t *testing.T) {
+	mockUsecase := new(MockSynonymUsecase)
+	handler := NewSynonymHandler(mockUsecase)
+	router := setupTestRouter()
+	router.PUT("/admin/synonyms/:canonical", handler.SaveSynonym)
+
+	reqBody := usecase.SaveSynonymRequest{Aliases: []string{"豚バラ"}, Broader: "豚肉"}
+	mockUsecase.On("SaveSynonym", mock.Anything, "豚バラ肉", reqBody).
+		Return(&domain.DictionaryEntry{Canonical: "豚バラ肉", Aliases: []string{"豚バラ"}, Broader: "豚肉"}, nil)
+
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPut, "/admin/synonyms/"+url.PathEscape("豚バラ肉"), bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response domain.DictionaryEntry
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "豚肉", response.Broader)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestSaveSynonym_ValidationError tests that usecase validation errors map to 400
+func TestSaveSynonym_ValidationError(t *testing.T) {
+	mockUsecase := new(MockSynonymUsecase)
+	handler := NewSynonymHandler(mockUsecase)
+	router := setupTestRouter()
+	router.PUT("/admin/synonyms/:canonical", handler.SaveSynonym)
+
+	mockUsecase.On("SaveSynonym", mock.Anything, "青ねぎ", usecase.SaveSynonymRequest{Aliases: []string{"ねぎ"}}).
+		Return(nil, fmt.Errorf("%w: \"ねぎ\" already refers to \"長ねぎ\"", domain.ErrInvalidInput))
+
+	req := httptest.NewRequest(http.MethodPut, "/admin/synonyms/"+url.PathEscape("青ねぎ"), bytes.NewBufferString(`{"aliases":["ねぎ"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestSaveSynonym_InvalidJSON tests that malformed request bodies are rejected
+func TestSaveSynonym_InvalidJSON(t *testing.T) {
+	mockUsecase := new(MockSynonymUsecase)
+	handler := NewSynonymHandler(mockUsecase)
+	router := setupTestRouter()
+	router.PUT("/admin/synonyms/:canonical", handler.SaveSynonym)
+
+	req := httptest.NewRequest(http.MethodPut, "/admin/synonyms/x", bytes.NewBufferString("{invalid"))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUsecase.AssertNotCalled(t, "SaveSynonym")
+}
+
+// TestSaveSynonym_Viewer tests that viewers of a household cannot change the shared dictionary
+func TestSaveSynonym_Viewer(t *testing.T) {
+	handler := NewSynonymHandler(usecase.NewSynonymUsecase(nil, nil, service.NewIngredientNormalizer(nil)))
+	router := setupTestRouter()
+	router.Use(func(c *gin.Context) {
+		ctx := domain.WithHousehold(c.Request.Context(), &domain.Household{ID: 1, Role: domain.HouseholdRoleViewer})
+		c.Request = c.Request.WithContext(ctx)
+	})
+	router.PUT("/admin/synonyms/:canonical", handler.SaveSynonym)
+	router.DELETE("/admin/synonyms/:canonical", handler.DeleteSynonym)
+	router.POST("/admin/synonyms/reindex", handler.ReindexIngredients)
+
+	requests := []*http.Request{
+		httptest.NewRequest(http.MethodPut, "/admin/synonyms/"+url.PathEscape("豚肉"), bytes.NewBufferString(`{"aliases":["ぶたにく"]}`)),
+		httptest.NewRequest(http.MethodDelete, "/admin/synonyms/"+url.PathEscape("豚肉"), nil),
+		httptest.NewRequest(http.MethodPost, "/admin/synonyms/reindex", nil),
+	}
+	for _, req := range requests {
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code, req.Method)
+		assert.Contains(t, w.Body.String(), `"error":"forbidden"`, req.Method)
+	}
+}
+
+// TestDeleteSynonym_Success tests deleting a dictionary entry
+func TestDeleteSynonym_Success(t *testing.T) {
+	mockUsecase := new(MockSynonymUsecase)
+	handler := NewSynonymHandler(mockUsecase)
+	router := setupTestRouter()
+	router.DELETE("/admin/synonyms/:canonical", handler.DeleteSynonym)
+
+	mockUsecase.On("DeleteSynonym", mock.Anything, "豚肉").Return(nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/admin/synonyms/"+url.PathEscape("豚肉"), nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestReindexIngredients_Success tests re-normalizing stored ingredients
+func TestReindexIngredients_Success(t *testing.T) {
+	mockUsecase := new(MockSynonymUsecase)
+	handler := NewSynonymHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/admin/synonyms/reindex", handler.ReindexIngredients)
+
+	mockUsecase.On("ReindexIngredients", mock.Anything).Return(&usecase.ReindexResponse{Updated: 3}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/synonyms/reindex", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"updated":3}`, w.Body.String())
+	mockUsecase.AssertExpectations(t)
+}
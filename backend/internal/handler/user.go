@@ -0,0 +1,240 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// bearerPrefix starts the Authorization header value carrying a session token
+const bearerPrefix = "Bearer "
+
+// RequireUser returns a middleware that rejects requests without a valid session token or API key in the
+// Authorization header. Changes made by the request are recorded as made by the signed-in user, or for API keys
+// by the user who created the key followed by the key name.
+func RequireUser(userUsecase usecase.UserUsecase, apiKeyUsecase usecase.APIKeyUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		if !strings.HasPrefix(header, bearerPrefix) {
+			c.Header("WWW-Authenticate", "Bearer")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, usecase.ErrorResponse{
+				Error:   "unauthorized",
+				Message: "Missing bearer token",
+			})
+			return
+		}
+
+		token := strings.TrimSpace(strings.TrimPrefix(header, bearerPrefix))
+
+		var user *domain.User
+		var apiKey *domain.APIKey
+		var err error
+		if strings.HasPrefix(token, usecase.APIKeyPrefix) {
+			user, apiKey, err = apiKeyUsecase.Authenticate(c.Request.Context(), token)
+		} else {
+			user, err = userUsecase.Authenticate(c.Request.Context(), token)
+		}
+		if err != nil {
+			c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+			statusCode, response := errorResponse(err)
+			c.AbortWithStatusJSON(statusCode, response)
+			return
+		}
+
+		ctx := domain.WithUser(c.Request.Context(), user)
+		if apiKey != nil {
+			ctx = domain.WithAPIKey(ctx, apiKey)
+			ctx = domain.WithEventActor(ctx, user.Username+" ("+apiKey.Name+")")
+		} else {
+			ctx = domain.WithEventActor(ctx, user.Username)
+		}
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// UserHandler handles HTTP requests for user accounts and sign-in
+type UserHandler struct {
+	userUsecase usecase.UserUsecase
+}
+
+// NewUserHandler creates a new UserHandler instance
+func NewUserHandler(userUsecase usecase.UserUsecase) *UserHandler {
+	return &UserHandler{
+		userUsecase: userUsecase,
+	}
+}
+
+// @Summary      ユーザー登録
+// @Description  サインインせずにアカウントを作成し、セッショントークンを発行します。
+// @Description  最初のユーザー、または auth.open_registration が有効な場合のみ利用できます。それ以外は POST /users で作成してください。
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body usecase.RegisterRequest true "ユーザー名とパスワード"
+// @Success      201 {object} usecase.LoginResponse "発行されたトークン"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      403 {object} usecase.ErrorResponse "登録は締め切られています"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /auth/register [post]
+// Register handles POST /auth/register
+func (h *UserHandler) Register(c *gin.Context) {
+	var req usecase.RegisterRequest
+
+	// Bind and validate request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, err.Error())
+		return
+	}
+
+	// Call usecase
+	result, err := h.userUsecase.Register(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// @Summary      ログイン
+// @Description  ユーザー名とパスワードを確認し、セッショントークンを発行します。以降のリクエストでは Authorization: Bearer <token> を指定してください。
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body usecase.LoginRequest true "ユーザー名とパスワード"
+// @Success      200 {object} usecase.LoginResponse "発行されたトークン"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      401 {object} usecase.ErrorResponse "ユーザー名またはパスワードが違います"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /auth/login [post]
+// Login handles POST /auth/login
+func (h *UserHandler) Login(c *gin.Context) {
+	var req usecase.LoginRequest
+
+	// Bind and validate request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, err.Error())
+		return
+	}
+
+	// Call usecase
+	result, err := h.userUsecase.Login(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// @Summary      ログイン中のユーザーを取得
+// @Description  セッショントークンのユーザーを返します。
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} domain.User "ログイン中のユーザー"
+// @Failure      401 {object} usecase.ErrorResponse "認証が必要です"
+// @Router       /auth/me [get]
+// Me handles GET /auth/me
+func (h *UserHandler) Me(c *gin.Context) {
+	user := domain.CurrentUser(c.Request.Context())
+	if user == nil {
+		respondWithError(c, http.StatusUnauthorized, "unauthorized", "Not signed in")
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// @Summary      パスワードを変更
+// @Description  ログイン中のユーザーのパスワードを変更します。それまでに発行したトークンはすべて無効になり、新しいトークンを返します。
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body usecase.ChangePasswordRequest true "現在のパスワードと新しいパスワード"
+// @Success      200 {object} usecase.LoginResponse "新しいトークン"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      401 {object} usecase.ErrorResponse "認証が必要です"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /auth/password [put]
+// ChangePassword handles PUT /auth/password
+func (h *UserHandler) ChangePassword(c *gin.Context) {
+	var req usecase.ChangePasswordRequest
+
+	// Bind and validate request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, err.Error())
+		return
+	}
+
+	// Call usecase
+	result, err := h.userUsecase.ChangePassword(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// @Summary      ユーザー一覧を取得
+// @Description  現在の世帯のメンバーであるユーザーを取得します。
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {array} domain.User "ユーザーのリスト"
+// @Failure      401 {object} usecase.ErrorResponse "認証が必要です"
+// @Failure      403 {object} usecase.ErrorResponse "世帯に所属していません"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /users [get]
+// ListUsers handles GET /users
+func (h *UserHandler) ListUsers(c *gin.Context) {
+	// Call usecase
+	users, err := h.userUsecase.ListUsers(c.Request.Context())
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, users)
+}
+
+// @Summary      ユーザーを作成
+// @Description  家族など他の人のアカウントを作成します。登録が開放されていない場合はサーバー管理者のみ利用できます。作成したユーザーは招待コードで世帯に参加します。
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body usecase.RegisterRequest true "ユーザー名とパスワード"
+// @Success      201 {object} domain.User "作成されたユーザー"
+// @Failure      400 {object} usecase.ErrorResponse "リクエストが不正です"
+// @Failure      401 {object} usecase.ErrorResponse "認証が必要です"
+// @Failure      403 {object} usecase.ErrorResponse "サーバー管理者ではありません"
+// @Failure      500 {object} usecase.ErrorResponse "サーバー内部エラー"
+// @Router       /users [post]
+// CreateUser handles POST /users
+func (h *UserHandler) CreateUser(c *gin.Context) {
+	var req usecase.RegisterRequest
+
+	// Bind and validate request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, err.Error())
+		return
+	}
+
+	// Call usecase
+	user, err := h.userUsecase.CreateUser(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, user)
+}
@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockUserUsecase is a mock implementation of UserUsecase
+type MockUserUsecase struct {
+	mock.Mock
+}
+
+func (m *MockUserUsecase) Register(ctx context.Context, req usecase.RegisterRequest) (*usecase.LoginResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.LoginResponse), args.Error(1)
+}
+
+func (m *MockUserUsecase) Login(ctx context.Context, req usecase.LoginRequest) (*usecase.LoginResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.LoginResponse), args.Error(1)
+}
+
+func (m *MockUserUsecase) Authenticate(ctx context.Context, token string) (*domain.User, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserUsecase) ListUsers(ctx context.Context) ([]*domain.User, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.User), args.Error(1)
+}
+
+func (m *MockUserUsecase) CreateUser(ctx context.Context, req usecase.RegisterRequest) (*domain.User, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserUsecase) ChangePassword(ctx context.Context, req usecase.ChangePasswordRequest) (*usecase.LoginResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.LoginResponse), args.Error(1)
+}
+
+// TestRequireUser_MissingToken tests that requests without a bearer token are rejected
+func TestRequireUser_MissingToken(t *testing.T) {
+	mockUsecase := new(MockUserUsecase)
+	router := setupTestRouter()
+	router.GET("/protected", RequireUser(mockUsecase, new(MockAPIKeyUsecase)), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, "Bearer", w.Header().Get("WWW-Authenticate"))
+	mockUsecase.AssertNotCalled(t, "Authenticate", mock.Anything, mock.Anything)
+}
+
+// TestRequireUser_InvalidToken tests that tokens the usecase rejects are answered with 401
+func TestRequireUser_InvalidToken(t *testing.T) {
+	mockUsecase := new(MockUserUsecase)
+	router := setupTestRouter()
+	router.GET("/protected", RequireUser(mockUsecase, new(MockAPIKeyUsecase)), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	mockUsecase.On("Authenticate", mock.Anything, "expired").
+		Return(nil, fmt.Errorf("%w: token expired", domain.ErrUnauthorized))
+
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set("Authorization", "Bearer expired")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	var response usecase.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "unauthorized", response.Error)
+}
+
+// TestRequireUser_ValidToken tests that the signed-in user becomes the user and actor of the request
+func TestRequireUser_ValidToken(t *testing.T) {
+	mockUsecase := new(MockUserUsecase)
+	router := setupTestRouter()
+
+	var user *domain.User
+	var actor string
+	router.GET("/protected", RequireUser(mockUsecase, new(MockAPIKeyUsecase)), func(c *gin.Context) {
+		user = domain.CurrentUser(c.Request.Context())
+		actor = domain.EventActor(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+
+	mockUsecase.On("Authenticate", mock.Anything, "valid").Return(&domain.User{ID: 1, Username: "hanako"}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set("Authorization", "Bearer valid")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	if assert.NotNil(t, user) {
+		assert.Equal(t, int64(1), user.ID)
+	}
+	assert.Equal(t, "hanako", actor)
+}
+
+// TestLogin_Success tests signing in
+func TestLogin_Success(t *testing.T) {
+	mockUsecase := new(MockUserUsecase)
+	handler := NewUserHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/auth/login", handler.Login)
+
+	reqBody := usecase.LoginRequest{Username: "hanako", Password: "correct horse"}
+	mockUsecase.On("Login", mock.Anything, reqBody).
+		Return(&usecase.LoginResponse{Token: "token", User: &domain.User{ID: 1, Username: "hanako", PasswordHash: "secret"}}, nil)
+
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPost, "/auth/login", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"token":"token"`)
+	assert.NotContains(t, w.Body.String(), "secret")
+	mockUsecase.AssertExpectations(t)
+}
+
+// TestRegister_Closed tests that closed registration is answered with 403
+func TestRegister_Closed(t *testing.T) {
+	mockUsecase := new(MockUserUsecase)
+	handler := NewUserHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/auth/register", handler.Register)
+
+	reqBody := usecase.RegisterRequest{Username: "taro", Password: "correct horse"}
+	mockUsecase.On("Register", mock.Anything, reqBody).
+		Return(nil, fmt.Errorf("%w: registration is closed", domain.ErrForbidden))
+
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPost, "/auth/register", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
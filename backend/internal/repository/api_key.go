@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+)
+
+// APIKeyRepository defines the interface for API key data access
+type APIKeyRepository interface {
+	// GetByHousehold retrieves the API keys of a household, newest first
+	GetByHousehold(ctx context.Context, householdID int64) ([]*domain.APIKey, error)
+
+	// GetByHash retrieves the API key with the given SHA-256 hash
+	GetByHash(ctx context.Context, keyHash string) (*domain.APIKey, error)
+
+	// Create inserts a new API key and sets its ID and creation time
+	Create(ctx context.Context, key *domain.APIKey) error
+
+	// Delete revokes an API key of a household by its ID
+	Delete(ctx context.Context, householdID int64, id int64) error
+
+	// TouchLastUsed records when an API key was last used
+	TouchLastUsed(ctx context.Context, id int64, at time.Time) error
+}
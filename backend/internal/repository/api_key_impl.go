@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+// apiKeyRow is the database form of domain.APIKey, with the scopes stored as a JSON array
+type apiKeyRow struct {
+	ID          int64      `db:"id"`
+	Name        string     `db:"name"`
+	Prefix      string     `db:"prefix"`
+	KeyHash     string     `db:"key_hash"`
+	Scopes      string     `db:"scopes"`
+	UserID      int64      `db:"user_id"`
+	HouseholdID int64      `db:"household_id"`
+	LastUsedAt  *time.Time `db:"last_used_at"`
+	CreatedAt   time.Time  `db:"created_at"`
+}
+
+// apiKeyRepository is the MySQL implementation of APIKeyRepository
+type apiKeyRepository struct {
+	db *sqlx.DB
+}
+
+// NewAPIKeyRepository creates a new instance of APIKeyRepository
+func NewAPIKeyRepository(db *sqlx.DB) APIKeyRepository {
+	return &apiKeyRepository{
+		db: db,
+	}
+}
+
+// GetByHousehold retrieves the API keys of a household, newest first
+func (r *apiKeyRepository) GetByHousehold(ctx context.Context, householdID int64) ([]*domain.APIKey, error) {
+	query := `
+		SELECT id, name, prefix, key_hash, scopes, user_id, household_id, last_used_at, created_at
+		FROM api_keys
+		WHERE household_id = ?
+		ORDER BY id DESC
+	`
+
+	var rows []apiKeyRow
+	if err := r.db.SelectContext(ctx, &rows, query, householdID); err != nil {
+		return nil, fmt.Errorf("failed to get api keys: %w", err)
+	}
+
+	keys := make([]*domain.APIKey, 0, len(rows))
+	for _, row := range rows {
+		key, err := row.toDomain()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// GetByHash retrieves the API key with the given SHA-256 hash
+func (r *apiKeyRepository) GetByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	query := `
+		SELECT id, name, prefix, key_hash, scopes, user_id, household_id, last_used_at, created_at
+		FROM api_keys
+		WHERE key_hash = ?
+	`
+
+	var row apiKeyRow
+	if err := r.db.GetContext(ctx, &row, query, keyHash); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("api key not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	return row.toDomain()
+}
+
+// Create inserts a new API key and sets its ID and creation time
+func (r *apiKeyRepository) Create(ctx context.Context, key *domain.APIKey) error {
+	scopes := key.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+	encoded, err := json.Marshal(scopes)
+	if err != nil {
+		return fmt.Errorf("failed to encode api key scopes: %w", err)
+	}
+
+	key.CreatedAt = time.Now()
+
+	result, err := r.db.ExecContext(
+		ctx,
+		`INSERT INTO api_keys (name, prefix, key_hash, scopes, user_id, household_id, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		key.Name,
+		key.Prefix,
+		key.KeyHash,
+		string(encoded),
+		key.UserID,
+		key.HouseholdID,
+		key.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+	key.ID = id
+
+	return nil
+}
+
+// Delete revokes an API key of a household by its ID
+func (r *apiKeyRepository) Delete(ctx context.Context, householdID int64, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM api_keys WHERE id = ? AND household_id = ?`, id, householdID)
+	if err != nil {
+		return fmt.Errorf("failed to delete api key: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("api key not found: %w", sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+// TouchLastUsed records when an API key was last used
+func (r *apiKeyRepository) TouchLastUsed(ctx context.Context, id int64, at time.Time) error {
+	if _, err := r.db.ExecContext(ctx, `UPDATE api_keys SET last_used_at = ? WHERE id = ?`, at, id); err != nil {
+		return fmt.Errorf("failed to update api key: %w", err)
+	}
+
+	return nil
+}
+
+// toDomain decodes the stored scopes of an API key row
+func (row apiKeyRow) toDomain() (*domain.APIKey, error) {
+	key := &domain.APIKey{
+		ID:          row.ID,
+		Name:        row.Name,
+		Prefix:      row.Prefix,
+		KeyHash:     row.KeyHash,
+		UserID:      row.UserID,
+		HouseholdID: row.HouseholdID,
+		LastUsedAt:  row.LastUsedAt,
+		CreatedAt:   row.CreatedAt,
+	}
+
+	if err := json.Unmarshal([]byte(row.Scopes), &key.Scopes); err != nil {
+		return nil, fmt.Errorf("failed to decode api key scopes: %w", err)
+	}
+
+	return key, nil
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyGetByHash_Success(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewAPIKeyRepository(db)
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "name", "prefix", "key_hash", "scopes", "user_id", "household_id", "last_used_at", "created_at"}).
+		AddRow(3, "台所タブレット", "ddk_1a2b3c4d", "hash", `["inventory:read","recipes:read"]`, 1, 2, nil, now)
+	mock.ExpectQuery("SELECT (.+) FROM api_keys WHERE key_hash = \\?").
+		WithArgs("hash").
+		WillReturnRows(rows)
+
+	key, err := repo.GetByHash(context.Background(), "hash")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), key.ID)
+	assert.Equal(t, []string{domain.APIKeyScopeInventoryRead, domain.APIKeyScopeRecipesRead}, key.Scopes)
+	assert.Equal(t, int64(2), key.HouseholdID)
+	assert.Nil(t, key.LastUsedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPIKeyGetByHash_NotFound(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewAPIKeyRepository(db)
+
+	mock.ExpectQuery("SELECT (.+) FROM api_keys WHERE key_hash = \\?").
+		WithArgs("unknown").
+		WillReturnError(sql.ErrNoRows)
+
+	key, err := repo.GetByHash(context.Background(), "unknown")
+
+	assert.Nil(t, key)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPIKeyCreate_Success(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewAPIKeyRepository(db)
+
+	mock.ExpectExec("INSERT INTO api_keys").
+		WithArgs("ラズパイ", "ddk_1a2b3c4d", "hash", `["inventory:write"]`, int64(1), int64(2), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(4, 1))
+
+	key := &domain.APIKey{
+		Name:        "ラズパイ",
+		Prefix:      "ddk_1a2b3c4d",
+		KeyHash:     "hash",
+		Scopes:      []string{domain.APIKeyScopeInventoryWrite},
+		UserID:      1,
+		HouseholdID: 2,
+	}
+	err := repo.Create(context.Background(), key)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), key.ID)
+	assert.False(t, key.CreatedAt.IsZero())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPIKeyDelete_OtherHousehold(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewAPIKeyRepository(db)
+
+	mock.ExpectExec("DELETE FROM api_keys WHERE id = \\? AND household_id = \\?").
+		WithArgs(int64(4), int64(5)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := repo.Delete(context.Background(), 5, 4)
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
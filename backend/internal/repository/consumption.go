@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+)
+
+// ConsumptionRepository defines the interface for reading recorded ingredient consumptions.
+// Consumptions are written by IngredientRepository.Consume together with the change to the ingredient.
+type ConsumptionRepository interface {
+	// GetByIngredient retrieves the consumptions of an ingredient, newest first
+	GetByIngredient(ctx context.Context, ingredientID int64) ([]*domain.Consumption, error)
+}
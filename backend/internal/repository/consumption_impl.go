@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+// consumptionRepository is the MySQL implementation of ConsumptionRepository
+type consumptionRepository struct {
+	db *sqlx.DB
+}
+
+// NewConsumptionRepository creates a new instance of ConsumptionRepository
+func NewConsumptionRepository(db *sqlx.DB) ConsumptionRepository {
+	return &consumptionRepository{
+		db: db,
+	}
+}
+
+// GetByIngredient retrieves the consumptions of an ingredient, newest first
+func (r *consumptionRepository) GetByIngredient(ctx context.Context, ingredientID int64) ([]*domain.Consumption, error) {
+	query := `
+		SELECT id, ingredient_id, name, canonical_name, category, amount, remaining, used_up, reason, recipe, suggestion_id, cost, purchase_date, actor, created_at
+		FROM ingredient_consumptions
+		WHERE ingredient_id = ?`
+	scope, scopeArgs, err := householdCondition(ctx, "household_id")
+	if err != nil {
+		return nil, err
+	}
+	query += scope + ` ORDER BY id DESC`
+
+	var consumptions []*domain.Consumption
+	if err := r.db.SelectContext(ctx, &consumptions, query, append([]interface{}{ingredientID}, scopeArgs...)...); err != nil {
+		return nil, fmt.Errorf("failed to get consumptions: %w", err)
+	}
+
+	// Return empty slice instead of nil if the ingredient was never consumed
+	if consumptions == nil {
+		consumptions = []*domain.Consumption{}
+	}
+
+	return consumptions, nil
+}
+
+// recordConsumption inserts a consumption through exec, taking the actor and household from ctx
+func recordConsumption(ctx context.Context, exec sqlExecutor, consumption *domain.Consumption) error {
+	query := `
+		INSERT INTO ingredient_consumptions (ingredient_id, name, canonical_name, category, amount, remaining, used_up, reason, recipe, suggestion_id, cost, purchase_date, actor, created_at, household_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	consumption.Actor = domain.EventActor(ctx)
+	consumption.CreatedAt = time.Now()
+
+	result, err := exec.ExecContext(
+		ctx,
+		query,
+		consumption.IngredientID,
+		consumption.Name,
+		consumption.CanonicalName,
+		consumption.Category,
+		consumption.Amount,
+		consumption.Remaining,
+		consumption.UsedUp,
+		consumption.Reason,
+		consumption.Recipe,
+		consumption.SuggestionID,
+		consumption.Cost,
+		consumption.PurchaseDate,
+		consumption.Actor,
+		consumption.CreatedAt,
+		domain.HouseholdID(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record consumption: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	consumption.ID = id
+	return nil
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var consumptionColumns = []string{"id", "ingredient_id", "name", "canonical_name", "category", "amount", "remaining", "used_up", "reason", "recipe", "suggestion_id", "cost", "purchase_date", "actor", "created_at"}
+
+func TestConsumptionGetByIngredient_Success(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewConsumptionRepository(db)
+
+	now := time.Now()
+	rows := sqlmock.NewRows(consumptionColumns).
+		AddRow(2, 1, "牛乳", "牛乳", domain.CategoryDairy, "1本", "", true, domain.ConsumptionReasonEaten, "", nil, nil, nil, "bob", now).
+		AddRow(1, 1, "牛乳", "牛乳", domain.CategoryDairy, "1本", "1本", false, domain.ConsumptionReasonCooked, "グラタン", 3, 120, nil, "alice", now)
+	mock.ExpectQuery("SELECT (.+) FROM ingredient_consumptions WHERE ingredient_id = \\? ORDER BY id DESC").
+		WithArgs(int64(1)).
+		WillReturnRows(rows)
+
+	consumptions, err := repo.GetByIngredient(domain.WithAllHouseholds(context.Background()), 1)
+
+	assert.NoError(t, err)
+	assert.Len(t, consumptions, 2)
+	assert.True(t, consumptions[0].UsedUp)
+	assert.Equal(t, "グラタン", consumptions[1].Recipe)
+	assert.Equal(t, int64(3), *consumptions[1].SuggestionID)
+	assert.Equal(t, 120, *consumptions[1].Cost)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestConsumptionGetByIngredient_Empty(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewConsumptionRepository(db)
+
+	mock.ExpectQuery("SELECT (.+) FROM ingredient_consumptions").
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows(consumptionColumns))
+
+	consumptions, err := repo.GetByIngredient(domain.WithAllHouseholds(context.Background()), 1)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, consumptions)
+	assert.Empty(t, consumptions)
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+)
+
+// DietaryProfileRepository defines the interface for the dietary profiles of household members
+type DietaryProfileRepository interface {
+	// GetAll retrieves every dietary profile ordered by ID
+	GetAll(ctx context.Context) ([]*domain.DietaryProfile, error)
+
+	// GetByID retrieves a single dietary profile by its ID
+	GetByID(ctx context.Context, id int64) (*domain.DietaryProfile, error)
+
+	// Create inserts a new dietary profile and sets its ID and timestamps
+	Create(ctx context.Context, profile *domain.DietaryProfile) error
+
+	// Update replaces an existing dietary profile
+	Update(ctx context.Context, profile *domain.DietaryProfile) error
+
+	// Delete removes a dietary profile by its ID
+	Delete(ctx context.Context, id int64) error
+}
@@ -0,0 +1,237 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+// dietaryProfileRow is the database form of domain.DietaryProfile, with the lists stored as JSON arrays
+type dietaryProfileRow struct {
+	ID           int64     `db:"id"`
+	Name         string    `db:"name"`
+	Allergens    string    `db:"allergens"`
+	Restrictions string    `db:"restrictions"`
+	Dislikes     string    `db:"dislikes"`
+	CreatedAt    time.Time `db:"created_at"`
+	UpdatedAt    time.Time `db:"updated_at"`
+	OwnerID      *int64    `db:"owner_id"`
+	HouseholdID  *int64    `db:"household_id"`
+}
+
+// dietaryProfileRepository is the MySQL implementation of DietaryProfileRepository
+type dietaryProfileRepository struct {
+	db *sqlx.DB
+}
+
+// NewDietaryProfileRepository creates a new instance of DietaryProfileRepository
+func NewDietaryProfileRepository(db *sqlx.DB) DietaryProfileRepository {
+	return &dietaryProfileRepository{
+		db: db,
+	}
+}
+
+// GetAll retrieves every dietary profile ordered by ID
+func (r *dietaryProfileRepository) GetAll(ctx context.Context) ([]*domain.DietaryProfile, error) {
+	query := `
+		SELECT id, name, allergens, restrictions, dislikes, created_at, updated_at, owner_id, household_id
+		FROM dietary_profiles
+		WHERE 1 = 1`
+	scope, args, err := householdCondition(ctx, "household_id")
+	if err != nil {
+		return nil, err
+	}
+	query += scope + ` ORDER BY id`
+
+	var rows []dietaryProfileRow
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get dietary profiles: %w", err)
+	}
+
+	profiles := make([]*domain.DietaryProfile, 0, len(rows))
+	for _, row := range rows {
+		profile, err := row.toDomain()
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, profile)
+	}
+
+	return profiles, nil
+}
+
+// GetByID retrieves a single dietary profile by its ID
+func (r *dietaryProfileRepository) GetByID(ctx context.Context, id int64) (*domain.DietaryProfile, error) {
+	query := `
+		SELECT id, name, allergens, restrictions, dislikes, created_at, updated_at, owner_id, household_id
+		FROM dietary_profiles
+		WHERE id = ?`
+	scope, scopeArgs, err := householdCondition(ctx, "household_id")
+	if err != nil {
+		return nil, err
+	}
+	query += scope
+
+	var row dietaryProfileRow
+	if err := r.db.GetContext(ctx, &row, query, append([]interface{}{id}, scopeArgs...)...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("dietary profile not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get dietary profile by id: %w", err)
+	}
+
+	return row.toDomain()
+}
+
+// Create inserts a new dietary profile and sets its ID and timestamps
+func (r *dietaryProfileRepository) Create(ctx context.Context, profile *domain.DietaryProfile) error {
+	allergens, restrictions, dislikes, err := encodeDietaryLists(profile)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	profile.CreatedAt = now
+	profile.UpdatedAt = now
+	profile.OwnerID = domain.OwnerID(ctx)
+	profile.HouseholdID = domain.HouseholdID(ctx)
+
+	result, err := r.db.ExecContext(
+		ctx,
+		`INSERT INTO dietary_profiles (name, allergens, restrictions, dislikes, created_at, updated_at, owner_id, household_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		profile.Name,
+		allergens,
+		restrictions,
+		dislikes,
+		profile.CreatedAt,
+		profile.UpdatedAt,
+		profile.OwnerID,
+		profile.HouseholdID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create dietary profile: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+	profile.ID = id
+
+	return nil
+}
+
+// Update replaces an existing dietary profile
+func (r *dietaryProfileRepository) Update(ctx context.Context, profile *domain.DietaryProfile) error {
+	allergens, restrictions, dislikes, err := encodeDietaryLists(profile)
+	if err != nil {
+		return err
+	}
+
+	profile.UpdatedAt = time.Now()
+	scope, scopeArgs, err := householdCondition(ctx, "household_id")
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(
+		ctx,
+		`UPDATE dietary_profiles
+		SET name = ?, allergens = ?, restrictions = ?, dislikes = ?, updated_at = ?
+		WHERE id = ?`+scope,
+		append([]interface{}{
+			profile.Name,
+			allergens,
+			restrictions,
+			dislikes,
+			profile.UpdatedAt,
+			profile.ID,
+		}, scopeArgs...)...,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update dietary profile: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("dietary profile not found: %w", sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+// Delete removes a dietary profile by its ID
+func (r *dietaryProfileRepository) Delete(ctx context.Context, id int64) error {
+	scope, scopeArgs, err := householdCondition(ctx, "household_id")
+	if err != nil {
+		return err
+	}
+	result, err := r.db.ExecContext(ctx, `DELETE FROM dietary_profiles WHERE id = ?`+scope, append([]interface{}{id}, scopeArgs...)...)
+	if err != nil {
+		return fmt.Errorf("failed to delete dietary profile: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("dietary profile not found: %w", sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+// encodeDietaryLists encodes the lists of a profile for their JSON columns; nil lists are stored as []
+func encodeDietaryLists(profile *domain.DietaryProfile) (string, string, string, error) {
+	encoded := make([]string, 0, 3)
+	for _, list := range [][]string{profile.Allergens, profile.Restrictions, profile.Dislikes} {
+		if list == nil {
+			list = []string{}
+		}
+		data, err := json.Marshal(list)
+		if err != nil {
+			return "", "", "", fmt.Errorf("failed to encode dietary profile: %w", err)
+		}
+		encoded = append(encoded, string(data))
+	}
+	return encoded[0], encoded[1], encoded[2], nil
+}
+
+// toDomain decodes the stored lists of a profile row
+func (row dietaryProfileRow) toDomain() (*domain.DietaryProfile, error) {
+	profile := &domain.DietaryProfile{
+		ID:          row.ID,
+		Name:        row.Name,
+		CreatedAt:   row.CreatedAt,
+		UpdatedAt:   row.UpdatedAt,
+		OwnerID:     row.OwnerID,
+		HouseholdID: row.HouseholdID,
+	}
+
+	for _, list := range []struct {
+		value  string
+		target *[]string
+	}{
+		{row.Allergens, &profile.Allergens},
+		{row.Restrictions, &profile.Restrictions},
+		{row.Dislikes, &profile.Dislikes},
+	} {
+		if err := json.Unmarshal([]byte(list.value), list.target); err != nil {
+			return nil, fmt.Errorf("failed to decode dietary profile: %w", err)
+		}
+	}
+
+	return profile, nil
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var dietaryProfileColumns = []string{"id", "name", "allergens", "restrictions", "dislikes", "created_at", "updated_at"}
+
+func TestDietaryProfileGetAll_Success(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewDietaryProfileRepository(db)
+
+	now := time.Now()
+	rows := sqlmock.NewRows(dietaryProfileColumns).
+		AddRow(1, "太郎", `["egg","peanut"]`, `[]`, `["ピーマン"]`, now, now).
+		AddRow(2, "花子", `[]`, `["vegetarian"]`, `[]`, now, now)
+	mock.ExpectQuery("SELECT (.+) FROM dietary_profiles WHERE 1 = 1 ORDER BY id").WillReturnRows(rows)
+
+	profiles, err := repo.GetAll(domain.WithAllHouseholds(context.Background()))
+
+	assert.NoError(t, err)
+	assert.Len(t, profiles, 2)
+	assert.Equal(t, []string{domain.AllergenEgg, domain.AllergenPeanut}, profiles[0].Allergens)
+	assert.Equal(t, []string{"ピーマン"}, profiles[0].Dislikes)
+	assert.Equal(t, []string{domain.RestrictionVegetarian}, profiles[1].Restrictions)
+	assert.Empty(t, profiles[1].Allergens)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDietaryProfileGetAll_InvalidList(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewDietaryProfileRepository(db)
+
+	rows := sqlmock.NewRows(dietaryProfileColumns).
+		AddRow(1, "太郎", `{broken`, `[]`, `[]`, time.Now(), time.Now())
+	mock.ExpectQuery("SELECT (.+) FROM dietary_profiles WHERE 1 = 1 ORDER BY id").WillReturnRows(rows)
+
+	profiles, err := repo.GetAll(domain.WithAllHouseholds(context.Background()))
+
+	assert.Nil(t, profiles)
+	assert.Contains(t, err.Error(), "failed to decode dietary profile")
+}
+
+func TestDietaryProfileGetByID_NotFound(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewDietaryProfileRepository(db)
+
+	mock.ExpectQuery("SELECT (.+) FROM dietary_profiles WHERE id = \\?").
+		WithArgs(int64(9)).
+		WillReturnError(sql.ErrNoRows)
+
+	profile, err := repo.GetByID(domain.WithAllHouseholds(context.Background()), 9)
+
+	assert.Nil(t, profile)
+	assert.True(t, errors.Is(err, sql.ErrNoRows))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDietaryProfileCreate_Success(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewDietaryProfileRepository(db)
+
+	profile := &domain.DietaryProfile{Name: "太郎", Allergens: []string{domain.AllergenEgg}}
+	mock.ExpectExec("INSERT INTO dietary_profiles").
+		WithArgs("太郎", `["egg"]`, `[]`, `[]`, sqlmock.AnyArg(), sqlmock.AnyArg(), int64(7), int64(2)).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+
+	// The signed-in user owns the profile, which belongs to the current household
+	ctx := domain.WithUser(context.Background(), &domain.User{ID: 7, Username: "hanako"})
+	ctx = domain.WithHousehold(ctx, &domain.Household{ID: 2, Role: domain.HouseholdRoleMember})
+	err := repo.Create(ctx, profile)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), profile.ID)
+	assert.False(t, profile.CreatedAt.IsZero())
+	if assert.NotNil(t, profile.OwnerID) {
+		assert.Equal(t, int64(7), *profile.OwnerID)
+	}
+	if assert.NotNil(t, profile.HouseholdID) {
+		assert.Equal(t, int64(2), *profile.HouseholdID)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDietaryProfileUpdate_NotFound(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewDietaryProfileRepository(db)
+
+	profile := &domain.DietaryProfile{ID: 9, Name: "太郎", Dislikes: []string{"セロリ"}}
+	mock.ExpectExec("UPDATE dietary_profiles SET (.+) WHERE id = \\?").
+		WithArgs("太郎", `[]`, `[]`, `["セロリ"]`, sqlmock.AnyArg(), int64(9)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := repo.Update(domain.WithAllHouseholds(context.Background()), profile)
+
+	assert.True(t, errors.Is(err, sql.ErrNoRows))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDietaryProfileDelete_Success(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewDietaryProfileRepository(db)
+
+	mock.ExpectExec("DELETE FROM dietary_profiles WHERE id = \\?").
+		WithArgs(int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.Delete(domain.WithAllHouseholds(context.Background()), 1)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+)
+
+// HouseholdRepository defines the interface for households, their members and invitation codes
+type HouseholdRepository interface {
+	// GetByUser retrieves the households a user belongs to with the user's role in each, ordered by ID
+	GetByUser(ctx context.Context, userID int64) ([]*domain.Household, error)
+
+	// GetMembership retrieves a household with the role of a user in it.
+	// sql.ErrNoRows is returned when the household does not exist or the user is not a member.
+	GetMembership(ctx context.Context, householdID int64, userID int64) (*domain.Household, error)
+
+	// Create inserts a new household with ownerID as its owner and sets its ID and timestamps
+	Create(ctx context.Context, household *domain.Household, ownerID int64) error
+
+	// Update renames a household
+	Update(ctx context.Context, household *domain.Household) error
+
+	// GetMembers retrieves the members of a household in the order they joined
+	GetMembers(ctx context.Context, householdID int64) ([]*domain.HouseholdMember, error)
+
+	// UpdateMemberRole changes the role of a member of a household
+	UpdateMemberRole(ctx context.Context, householdID int64, userID int64, role string) error
+
+	// RemoveMember removes a user from a household
+	RemoveMember(ctx context.Context, householdID int64, userID int64) error
+
+	// CreateInvitation inserts a new invitation and sets its ID and creation time
+	CreateInvitation(ctx context.Context, invitation *domain.HouseholdInvitation) error
+
+	// GetInvitations retrieves the invitations of a household still usable at now, newest first
+	GetInvitations(ctx context.Context, householdID int64, now time.Time) ([]*domain.HouseholdInvitation, error)
+
+	// DeleteInvitation revokes an invitation of a household by its ID
+	DeleteInvitation(ctx context.Context, householdID int64, id int64) error
+
+	// RedeemInvitation uses up the invitation with the given code and adds the user to its household with the
+	// invitation's role in the same transaction, returning the household with that role.
+	// sql.ErrNoRows is returned when no invitation with the code is still usable at now.
+	RedeemInvitation(ctx context.Context, code string, userID int64, now time.Time) (*domain.Household, error)
+}
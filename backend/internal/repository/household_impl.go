@@ -0,0 +1,395 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+// householdRepository is the MySQL implementation of HouseholdRepository
+type householdRepository struct {
+	db *sqlx.DB
+}
+
+// NewHouseholdRepository creates a new instance of HouseholdRepository
+func NewHouseholdRepository(db *sqlx.DB) HouseholdRepository {
+	return &householdRepository{
+		db: db,
+	}
+}
+
+// GetByUser retrieves the households a user belongs to with the user's role in each, ordered by ID
+func (r *householdRepository) GetByUser(ctx context.Context, userID int64) ([]*domain.Household, error) {
+	query := `
+		SELECT h.id, h.name, m.role, h.created_at, h.updated_at
+		FROM households h
+		JOIN household_members m ON m.household_id = h.id
+		WHERE m.user_id = ?
+		ORDER BY h.id
+	`
+
+	var households []*domain.Household
+	if err := r.db.SelectContext(ctx, &households, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to get households: %w", err)
+	}
+
+	// Return empty slice instead of nil if the user belongs to no household
+	if households == nil {
+		households = []*domain.Household{}
+	}
+
+	return households, nil
+}
+
+// GetMembership retrieves a household with the role of a user in it
+func (r *householdRepository) GetMembership(ctx context.Context, householdID int64, userID int64) (*domain.Household, error) {
+	query := `
+		SELECT h.id, h.name, m.role, h.created_at, h.updated_at
+		FROM households h
+		JOIN household_members m ON m.household_id = h.id
+		WHERE h.id = ? AND m.user_id = ?
+	`
+
+	var household domain.Household
+	if err := r.db.GetContext(ctx, &household, query, householdID, userID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("household not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get household membership: %w", err)
+	}
+
+	return &household, nil
+}
+
+// Create inserts a new household together with the membership of its owner
+func (r *householdRepository) Create(ctx context.Context, household *domain.Household, ownerID int64) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	result, err := tx.ExecContext(
+		ctx,
+		`INSERT INTO households (name, created_at, updated_at) VALUES (?, ?, ?)`,
+		household.Name,
+		now,
+		now,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create household: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	if _, err := tx.ExecContext(
+		ctx,
+		`INSERT INTO household_members (household_id, user_id, role, created_at) VALUES (?, ?, ?, ?)`,
+		id,
+		ownerID,
+		domain.HouseholdRoleOwner,
+		now,
+	); err != nil {
+		return fmt.Errorf("failed to add household owner: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	household.ID = id
+	household.Role = domain.HouseholdRoleOwner
+	household.CreatedAt = now
+	household.UpdatedAt = now
+	return nil
+}
+
+// Update renames a household
+func (r *householdRepository) Update(ctx context.Context, household *domain.Household) error {
+	household.UpdatedAt = time.Now()
+
+	result, err := r.db.ExecContext(
+		ctx,
+		`UPDATE households SET name = ?, updated_at = ? WHERE id = ?`,
+		household.Name,
+		household.UpdatedAt,
+		household.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update household: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("household not found: %w", sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+// GetMembers retrieves the members of a household in the order they joined
+func (r *householdRepository) GetMembers(ctx context.Context, householdID int64) ([]*domain.HouseholdMember, error) {
+	query := `
+		SELECT m.household_id, m.user_id, u.username, m.role, m.created_at
+		FROM household_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.household_id = ?
+		ORDER BY m.created_at, m.user_id
+	`
+
+	var members []*domain.HouseholdMember
+	if err := r.db.SelectContext(ctx, &members, query, householdID); err != nil {
+		return nil, fmt.Errorf("failed to get household members: %w", err)
+	}
+
+	// Return empty slice instead of nil if the household has no members
+	if members == nil {
+		members = []*domain.HouseholdMember{}
+	}
+
+	return members, nil
+}
+
+// UpdateMemberRole changes the role of a member of a household
+func (r *householdRepository) UpdateMemberRole(ctx context.Context, householdID int64, userID int64, role string) error {
+	result, err := r.db.ExecContext(
+		ctx,
+		`UPDATE household_members SET role = ? WHERE household_id = ? AND user_id = ?`,
+		role,
+		householdID,
+		userID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update household member: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	// MySQL reports no affected rows when the role is unchanged, so only a missing member is an error
+	if rowsAffected == 0 {
+		if _, err := r.GetMembership(ctx, householdID, userID); err != nil {
+			return fmt.Errorf("household member not found: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// RemoveMember removes a user from a household
+func (r *householdRepository) RemoveMember(ctx context.Context, householdID int64, userID int64) error {
+	result, err := r.db.ExecContext(
+		ctx,
+		`DELETE FROM household_members WHERE household_id = ? AND user_id = ?`,
+		householdID,
+		userID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to remove household member: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("household member not found: %w", sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+// claimUnownedHouseholds makes a user the owner of every household left without members
+func claimUnownedHouseholds(ctx context.Context, exec sqlExecutor, userID int64) error {
+	query := `
+		INSERT INTO household_members (household_id, user_id, role, created_at)
+		SELECT h.id, ?, ?, ?
+		FROM households h
+		WHERE NOT EXISTS (SELECT 1 FROM household_members m WHERE m.household_id = h.id)
+	`
+
+	if _, err := exec.ExecContext(ctx, query, userID, domain.HouseholdRoleOwner, time.Now()); err != nil {
+		return fmt.Errorf("failed to claim households: %w", err)
+	}
+
+	return nil
+}
+
+// CreateInvitation inserts a new invitation and sets its ID and creation time
+func (r *householdRepository) CreateInvitation(ctx context.Context, invitation *domain.HouseholdInvitation) error {
+	invitation.CreatedAt = time.Now()
+
+	result, err := r.db.ExecContext(
+		ctx,
+		`INSERT INTO household_invitations (household_id, code, role, created_by, expires_at, created_at) VALUES (?, ?, ?, ?, ?, ?)`,
+		invitation.HouseholdID,
+		invitation.Code,
+		invitation.Role,
+		invitation.CreatedBy,
+		invitation.ExpiresAt,
+		invitation.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create invitation: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+	invitation.ID = id
+
+	return nil
+}
+
+// GetInvitations retrieves the invitations of a household still usable at now, newest first
+func (r *householdRepository) GetInvitations(ctx context.Context, householdID int64, now time.Time) ([]*domain.HouseholdInvitation, error) {
+	query := `
+		SELECT id, household_id, code, role, created_by, expires_at, created_at
+		FROM household_invitations
+		WHERE household_id = ? AND used_at IS NULL AND expires_at > ?
+		ORDER BY id DESC
+	`
+
+	var invitations []*domain.HouseholdInvitation
+	if err := r.db.SelectContext(ctx, &invitations, query, householdID, now); err != nil {
+		return nil, fmt.Errorf("failed to get invitations: %w", err)
+	}
+
+	// Return empty slice instead of nil if there are no pending invitations
+	if invitations == nil {
+		invitations = []*domain.HouseholdInvitation{}
+	}
+
+	return invitations, nil
+}
+
+// DeleteInvitation revokes an invitation of a household by its ID
+func (r *householdRepository) DeleteInvitation(ctx context.Context, householdID int64, id int64) error {
+	result, err := r.db.ExecContext(
+		ctx,
+		`DELETE FROM household_invitations WHERE id = ? AND household_id = ?`,
+		id,
+		householdID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete invitation: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("invitation not found: %w", sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+// RedeemInvitation uses up an invitation and adds the user to its household in the same transaction
+func (r *householdRepository) RedeemInvitation(ctx context.Context, code string, userID int64, now time.Time) (*domain.Household, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var invitation domain.HouseholdInvitation
+	if err := tx.GetContext(
+		ctx,
+		&invitation,
+		`SELECT id, household_id, code, role, created_by, expires_at, created_at
+		FROM household_invitations
+		WHERE code = ? AND used_at IS NULL AND expires_at > ?
+		FOR UPDATE`,
+		code,
+		now,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("invitation not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get invitation: %w", err)
+	}
+
+	var members int
+	if err := tx.GetContext(
+		ctx,
+		&members,
+		`SELECT COUNT(*) FROM household_members WHERE household_id = ? AND user_id = ?`,
+		invitation.HouseholdID,
+		userID,
+	); err != nil {
+		return nil, fmt.Errorf("failed to check household membership: %w", err)
+	}
+	if members > 0 {
+		return nil, fmt.Errorf("%w: you already belong to this household", domain.ErrInvalidInput)
+	}
+
+	if _, err := tx.ExecContext(
+		ctx,
+		`INSERT INTO household_members (household_id, user_id, role, created_at) VALUES (?, ?, ?, ?)`,
+		invitation.HouseholdID,
+		userID,
+		invitation.Role,
+		now,
+	); err != nil {
+		return nil, fmt.Errorf("failed to add household member: %w", err)
+	}
+
+	if _, err := tx.ExecContext(
+		ctx,
+		`UPDATE household_invitations SET used_by = ?, used_at = ? WHERE id = ?`,
+		userID,
+		now,
+		invitation.ID,
+	); err != nil {
+		return nil, fmt.Errorf("failed to use invitation: %w", err)
+	}
+
+	var household domain.Household
+	if err := tx.GetContext(
+		ctx,
+		&household,
+		`SELECT id, name, created_at, updated_at FROM households WHERE id = ?`,
+		invitation.HouseholdID,
+	); err != nil {
+		return nil, fmt.Errorf("failed to get household: %w", err)
+	}
+	household.Role = invitation.Role
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &household, nil
+}
+
+// householdCondition returns the SQL condition and argument limiting column to the household in ctx.
+// Outside of a household it fails with domain.ErrNoHousehold, unless ctx comes from domain.WithAllHouseholds
+// for background jobs such as the trash purge, in which case the condition is empty.
+func householdCondition(ctx context.Context, column string) (string, []interface{}, error) {
+	if id := domain.HouseholdID(ctx); id != nil {
+		return ` AND ` + column + ` = ?`, []interface{}{*id}, nil
+	}
+	if domain.AllHouseholds(ctx) {
+		return "", nil, nil
+	}
+	return "", nil, domain.ErrNoHousehold
+}
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHouseholdGetByUser_Success(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewHouseholdRepository(db)
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "name", "role", "created_at", "updated_at"}).
+		AddRow(1, "山田家", domain.HouseholdRoleOwner, now, now).
+		AddRow(4, "実家", domain.HouseholdRoleViewer, now, now)
+	mock.ExpectQuery("SELECT (.+) FROM households h JOIN household_members m (.+) WHERE m.user_id = \\? ORDER BY h.id").
+		WithArgs(int64(7)).
+		WillReturnRows(rows)
+
+	households, err := repo.GetByUser(context.Background(), 7)
+
+	assert.NoError(t, err)
+	assert.Len(t, households, 2)
+	assert.Equal(t, domain.HouseholdRoleViewer, households[1].Role)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHouseholdGetMembership_NotMember(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewHouseholdRepository(db)
+
+	mock.ExpectQuery("SELECT (.+) FROM households h JOIN household_members m (.+) WHERE h.id = \\? AND m.user_id = \\?").
+		WithArgs(int64(4), int64(7)).
+		WillReturnError(sql.ErrNoRows)
+
+	household, err := repo.GetMembership(context.Background(), 4, 7)
+
+	assert.Nil(t, household)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHouseholdCreate_Success(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewHouseholdRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO households").
+		WithArgs("山田家", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+	mock.ExpectExec("INSERT INTO household_members").
+		WithArgs(int64(3), int64(7), domain.HouseholdRoleOwner, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	household := &domain.Household{Name: "山田家"}
+	err := repo.Create(context.Background(), household, 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), household.ID)
+	assert.Equal(t, domain.HouseholdRoleOwner, household.Role)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHouseholdRedeemInvitation_Success(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewHouseholdRepository(db)
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM household_invitations WHERE code = \\? AND used_at IS NULL AND expires_at > \\? FOR UPDATE").
+		WithArgs("ABCD2345EFGH", now).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "household_id", "code", "role", "created_by", "expires_at", "created_at"}).
+			AddRow(5, 3, "ABCD2345EFGH", domain.HouseholdRoleViewer, 1, now.Add(time.Hour), now))
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM household_members").
+		WithArgs(int64(3), int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec("INSERT INTO household_members").
+		WithArgs(int64(3), int64(7), domain.HouseholdRoleViewer, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE household_invitations SET used_by = \\?, used_at = \\? WHERE id = \\?").
+		WithArgs(int64(7), now, int64(5)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT (.+) FROM households WHERE id = \\?").
+		WithArgs(int64(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).AddRow(3, "山田家", now, now))
+	mock.ExpectCommit()
+
+	household, err := repo.RedeemInvitation(context.Background(), "ABCD2345EFGH", 7, now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "山田家", household.Name)
+	assert.Equal(t, domain.HouseholdRoleViewer, household.Role)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHouseholdRedeemInvitation_AlreadyMember(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewHouseholdRepository(db)
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM household_invitations").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "household_id", "code", "role", "created_by", "expires_at", "created_at"}).
+			AddRow(5, 3, "ABCD2345EFGH", domain.HouseholdRoleMember, 1, now.Add(time.Hour), now))
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM household_members").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectRollback()
+
+	// The invitation stays usable for someone else
+	household, err := repo.RedeemInvitation(context.Background(), "ABCD2345EFGH", 7, now)
+
+	assert.Nil(t, household)
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHouseholdRedeemInvitation_Expired(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewHouseholdRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM household_invitations").
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	household, err := repo.RedeemInvitation(context.Background(), "ABCD2345EFGH", 7, time.Now())
+
+	assert.Nil(t, household)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -2,24 +2,171 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
 )
 
+// Ingredient sort keys
+const (
+	// IngredientSortCreatedAt orders ingredients by registration time, newest first by default
+	IngredientSortCreatedAt = "created_at"
+
+	// IngredientSortName orders ingredients by name
+	IngredientSortName = "name"
+
+	// IngredientSortPurchaseDate orders ingredients by purchase date; undated ingredients sort as the latest
+	IngredientSortPurchaseDate = "purchase_date"
+
+	// IngredientSortExpiresAt orders ingredients by expiry date; undated ingredients sort as the latest
+	IngredientSortExpiresAt = "expires_at"
+)
+
+// Sort orders
+const (
+	// OrderAsc sorts in ascending order
+	OrderAsc = "asc"
+
+	// OrderDesc sorts in descending order
+	OrderDesc = "desc"
+)
+
+// IngredientFilter narrows down ingredient listings.
+// Empty fields are ignored, so a zero filter matches every ingredient, newest first.
+type IngredientFilter struct {
+	// Category matches the ingredient category exactly
+	Category string
+
+	// Location matches the storage location exactly
+	Location string
+
+	// Query matches part of the ingredient name
+	Query string
+
+	// CanonicalQuery matches part of the canonical name; typically the normalized form of Query
+	CanonicalQuery string
+
+	// PurchasedBefore matches ingredients purchased strictly before the given date
+	PurchasedBefore *time.Time
+
+	// Sort is one of the IngredientSort* keys; defaults to IngredientSortCreatedAt
+	Sort string
+
+	// Order is OrderAsc or OrderDesc; defaults to descending for created_at and ascending otherwise
+	Order string
+
+	// After resumes the listing right after the ingredient the cursor was taken from
+	After *IngredientCursor
+
+	// Limit caps the number of returned ingredients; zero means no limit
+	Limit int
+}
+
+// IngredientCursor marks a position in a sorted ingredient listing
+type IngredientCursor struct {
+	// Value is the sort key of the last returned ingredient, as produced by NewIngredientCursor
+	Value string `json:"v"`
+
+	// ID breaks ties between ingredients sharing the same sort key
+	ID int64 `json:"id"`
+}
+
+// IsValidIngredientSort reports whether s is a known ingredient sort key
+func IsValidIngredientSort(s string) bool {
+	_, ok := ingredientSortColumns[s]
+	return ok
+}
+
+// NewIngredientCursor returns the cursor pointing right after the ingredient for the given sort key
+func NewIngredientCursor(ingredient *domain.Ingredient, sort string) IngredientCursor {
+	cursor := IngredientCursor{ID: ingredient.ID}
+
+	switch sort {
+	case IngredientSortName:
+		cursor.Value = ingredient.Name
+	case IngredientSortPurchaseDate:
+		cursor.Value = formatCursorDate(ingredient.PurchaseDate)
+	case IngredientSortExpiresAt:
+		cursor.Value = formatCursorDate(ingredient.ExpiresAt)
+	default:
+		cursor.Value = ingredient.CreatedAt.Format(time.RFC3339Nano)
+	}
+
+	return cursor
+}
+
+// formatCursorDate formats a nullable date the same way the listing query sorts it
+func formatCursorDate(date *time.Time) string {
+	if date == nil {
+		return undatedSortValue
+	}
+	return date.Format("2006-01-02")
+}
+
 // IngredientRepository defines the interface for ingredient data access
 type IngredientRepository interface {
 	// Create inserts a new ingredient into the database
 	Create(ctx context.Context, ingredient *domain.Ingredient) error
 
-	// GetAll retrieves all ingredients from the database
+	// GetAll retrieves all ingredients from the database, except those in the trash
 	GetAll(ctx context.Context) ([]*domain.Ingredient, error)
 
+	// Search retrieves ingredients matching the filter in the requested order
+	Search(ctx context.Context, filter IngredientFilter) ([]*domain.Ingredient, error)
+
+	// GetByID retrieves a single ingredient by its ID
+	GetByID(ctx context.Context, id int64) (*domain.Ingredient, error)
+
+	// Update modifies an existing ingredient in the database.
+	// The write only applies if the stored version still equals ingredient.Version, which is then incremented;
+	// otherwise domain.ErrVersionConflict is returned.
+	Update(ctx context.Context, ingredient *domain.Ingredient) error
+
+	// UpdateClassification sets the canonical name and category of an ingredient without touching updated_at
+	UpdateClassification(ctx context.Context, id int64, canonicalName string, category string) error
+
+	// Delete moves an ingredient to the trash by its ID if its stored version equals version;
+	// otherwise domain.ErrVersionConflict is returned. Ingredients in the trash are hidden from every other query.
+	Delete(ctx context.Context, id int64, version int64) error
+
+	// Consume stores the quantity left in ingredient if it is still at ingredient.Version, or moves it to the trash
+	// when consumption.UsedUp, and records the consumption in the same transaction
+	Consume(ctx context.Context, ingredient *domain.Ingredient, consumption *domain.Consumption) error
+
+	// GetDeleted retrieves the ingredients in the trash, most recently deleted first
+	GetDeleted(ctx context.Context) ([]*domain.Ingredient, error)
+
+	// Restore takes an ingredient out of the trash by its ID
+	Restore(ctx context.Context, id int64) error
+
+	// Purge permanently removes the ingredients moved to the trash before deletedBefore and returns how many were removed
+	Purge(ctx context.Context, deletedBefore time.Time) (int64, error)
+
+	// WithTx runs fn against a repository bound to a single database transaction.
+	// The transaction commits when fn returns nil and rolls back otherwise.
+	WithTx(ctx context.Context, fn func(tx IngredientTx) error) error
+}
+
+// IngredientStore holds the single-ingredient operations available both on the repository and inside a transaction
+type IngredientStore interface {
+	// Create inserts a new ingredient into the database
+	Create(ctx context.Context, ingredient *domain.Ingredient) error
+
 	// GetByID retrieves a single ingredient by its ID
 	GetByID(ctx context.Context, id int64) (*domain.Ingredient, error)
 
-	// Update modifies an existing ingredient in the database
+	// Update modifies an existing ingredient if it is still at ingredient.Version
 	Update(ctx context.Context, ingredient *domain.Ingredient) error
 
-	// Delete removes an ingredient from the database by its ID
-	Delete(ctx context.Context, id int64) error
+	// Delete moves an ingredient to the trash by its ID if it is still at version
+	Delete(ctx context.Context, id int64, version int64) error
+}
+
+// IngredientTx is an IngredientStore bound to an open database transaction
+type IngredientTx interface {
+	IngredientStore
+
+	// Savepoint runs fn inside a savepoint. If fn fails, only the writes made by fn are undone
+	// and the transaction stays usable.
+	Savepoint(ctx context.Context, fn func() error) error
 }
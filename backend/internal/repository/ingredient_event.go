@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+)
+
+// IngredientEventFilter narrows down the ingredient activity feed.
+// Empty fields are ignored, so a zero filter matches every event, newest first.
+type IngredientEventFilter struct {
+	// IngredientID matches the events of a single ingredient
+	IngredientID int64
+
+	// Actor matches who made the change exactly
+	Actor string
+
+	// Source matches one of the domain.EventSource* values
+	Source string
+
+	// Action matches one of the domain.EventAction* values
+	Action string
+
+	// Since matches events recorded at or after the given time
+	Since *time.Time
+
+	// Until matches events recorded strictly before the given time
+	Until *time.Time
+
+	// BeforeID resumes the feed right after the event with this ID
+	BeforeID int64
+
+	// Limit caps the number of returned events; zero means no limit
+	Limit int
+}
+
+// IngredientEventRepository defines the interface for reading the ingredient history.
+// Events are written by IngredientRepository in the same transaction as the change they record.
+type IngredientEventRepository interface {
+	// Search retrieves the events matching the filter, newest first
+	Search(ctx context.Context, filter IngredientEventFilter) ([]*domain.IngredientEvent, error)
+
+	// InventoryAt reconstructs the ingredients that were in the inventory at the given time, oldest first.
+	// Only ingredients with recorded history can be reconstructed.
+	InventoryAt(ctx context.Context, at time.Time) ([]*domain.Ingredient, error)
+}
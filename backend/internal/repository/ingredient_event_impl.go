@@ -0,0 +1,229 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+// ingredientEventRow is the database form of domain.IngredientEvent, with the snapshots stored as JSON
+type ingredientEventRow struct {
+	ID           int64          `db:"id"`
+	IngredientID int64          `db:"ingredient_id"`
+	Action       string         `db:"action"`
+	Actor        string         `db:"actor"`
+	Source       string         `db:"source"`
+	BeforeValue  sql.NullString `db:"before_value"`
+	AfterValue   sql.NullString `db:"after_value"`
+	CreatedAt    time.Time      `db:"created_at"`
+}
+
+// ingredientEventRepository is the MySQL implementation of IngredientEventRepository
+type ingredientEventRepository struct {
+	db *sqlx.DB
+}
+
+// NewIngredientEventRepository creates a new instance of IngredientEventRepository
+func NewIngredientEventRepository(db *sqlx.DB) IngredientEventRepository {
+	return &ingredientEventRepository{
+		db: db,
+	}
+}
+
+// Search retrieves the events matching the filter, newest first
+func (r *ingredientEventRepository) Search(ctx context.Context, filter IngredientEventFilter) ([]*domain.IngredientEvent, error) {
+	query := `
+		SELECT id, ingredient_id, action, actor, source, before_value, after_value, created_at
+		FROM ingredient_events
+		WHERE 1 = 1`
+	scope, args, err := householdCondition(ctx, "household_id")
+	if err != nil {
+		return nil, err
+	}
+	query += scope
+
+	if filter.IngredientID != 0 {
+		query += ` AND ingredient_id = ?`
+		args = append(args, filter.IngredientID)
+	}
+
+	if filter.Actor != "" {
+		query += ` AND actor = ?`
+		args = append(args, filter.Actor)
+	}
+
+	if filter.Source != "" {
+		query += ` AND source = ?`
+		args = append(args, filter.Source)
+	}
+
+	if filter.Action != "" {
+		query += ` AND action = ?`
+		args = append(args, filter.Action)
+	}
+
+	if filter.Since != nil {
+		query += ` AND created_at >= ?`
+		args = append(args, *filter.Since)
+	}
+
+	if filter.Until != nil {
+		query += ` AND created_at < ?`
+		args = append(args, *filter.Until)
+	}
+
+	if filter.BeforeID != 0 {
+		query += ` AND id < ?`
+		args = append(args, filter.BeforeID)
+	}
+
+	query += ` ORDER BY id DESC`
+
+	if filter.Limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, filter.Limit)
+	}
+
+	var rows []ingredientEventRow
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to search ingredient events: %w", err)
+	}
+
+	events := make([]*domain.IngredientEvent, 0, len(rows))
+	for _, row := range rows {
+		event, err := row.toDomain()
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// InventoryAt reconstructs the ingredients that were in the inventory at the given time from the latest event of each
+func (r *ingredientEventRepository) InventoryAt(ctx context.Context, at time.Time) ([]*domain.Ingredient, error) {
+	scope, scopeArgs, err := householdCondition(ctx, "household_id")
+	if err != nil {
+		return nil, err
+	}
+	query := `
+		SELECT e.after_value
+		FROM ingredient_events e
+		JOIN (
+			SELECT ingredient_id, MAX(id) AS id
+			FROM ingredient_events
+			WHERE created_at <= ?` + scope + `
+			GROUP BY ingredient_id
+		) latest ON latest.id = e.id
+		WHERE e.after_value IS NOT NULL
+		ORDER BY e.ingredient_id
+	`
+
+	var values []string
+	if err := r.db.SelectContext(ctx, &values, query, append([]interface{}{at}, scopeArgs...)...); err != nil {
+		return nil, fmt.Errorf("failed to reconstruct inventory: %w", err)
+	}
+
+	ingredients := make([]*domain.Ingredient, 0, len(values))
+	for _, value := range values {
+		var ingredient domain.Ingredient
+		if err := json.Unmarshal([]byte(value), &ingredient); err != nil {
+			return nil, fmt.Errorf("failed to decode ingredient snapshot: %w", err)
+		}
+		ingredients = append(ingredients, &ingredient)
+	}
+
+	return ingredients, nil
+}
+
+// recordEvent appends an entry to the ingredient history through exec,
+// taking the actor and source from ctx and the household from the ingredient
+func recordEvent(ctx context.Context, exec sqlExecutor, action string, ingredientID int64, before *domain.Ingredient, after *domain.Ingredient) error {
+	query := `
+		INSERT INTO ingredient_events (ingredient_id, action, actor, source, before_value, after_value, created_at, household_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	// The trash purge records events outside of any household, so the ingredient tells which one it belongs to
+	var householdID *int64
+	if after != nil {
+		householdID = after.HouseholdID
+	} else if before != nil {
+		householdID = before.HouseholdID
+	}
+
+	beforeValue, err := snapshotValue(before)
+	if err != nil {
+		return err
+	}
+	afterValue, err := snapshotValue(after)
+	if err != nil {
+		return err
+	}
+
+	_, err = exec.ExecContext(ctx, query,
+		ingredientID,
+		action,
+		domain.EventActor(ctx),
+		domain.EventSource(ctx),
+		beforeValue,
+		afterValue,
+		time.Now(),
+		householdID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record ingredient event: %w", err)
+	}
+
+	return nil
+}
+
+// snapshotValue encodes an ingredient snapshot for a JSON column; nil stays NULL
+func snapshotValue(ingredient *domain.Ingredient) (interface{}, error) {
+	if ingredient == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(ingredient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode ingredient snapshot: %w", err)
+	}
+	return string(data), nil
+}
+
+// toDomain decodes the stored snapshots of an event row
+func (row ingredientEventRow) toDomain() (*domain.IngredientEvent, error) {
+	event := &domain.IngredientEvent{
+		ID:           row.ID,
+		IngredientID: row.IngredientID,
+		Action:       row.Action,
+		Actor:        row.Actor,
+		Source:       row.Source,
+		CreatedAt:    row.CreatedAt,
+	}
+
+	for _, snapshot := range []struct {
+		value  sql.NullString
+		target **domain.Ingredient
+	}{
+		{row.BeforeValue, &event.Before},
+		{row.AfterValue, &event.After},
+	} {
+		if !snapshot.value.Valid {
+			continue
+		}
+		var ingredient domain.Ingredient
+		if err := json.Unmarshal([]byte(snapshot.value.String), &ingredient); err != nil {
+			return nil, fmt.Errorf("failed to decode ingredient snapshot: %w", err)
+		}
+		*snapshot.target = &ingredient
+	}
+
+	return event, nil
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var ingredientEventColumns = []string{"id", "ingredient_id", "action", "actor", "source", "before_value", "after_value", "created_at"}
+
+func TestIngredientEventSearch_Filters(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewIngredientEventRepository(db)
+
+	since := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Now()
+	rows := sqlmock.NewRows(ingredientEventColumns).
+		AddRow(12, 1, domain.EventActionUpdate, "alice", domain.EventSourceAPI, `{"id":1,"name":"にんじん","quantity":"2本"}`, `{"id":1,"name":"にんじん","quantity":"1本"}`, now).
+		AddRow(10, 1, domain.EventActionCreate, "alice", domain.EventSourceAPI, nil, `{"id":1,"name":"にんじん","quantity":"2本"}`, now)
+	mock.ExpectQuery("SELECT (.+) FROM ingredient_events WHERE 1 = 1 AND ingredient_id = \\? AND actor = \\? AND created_at >= \\? AND id < \\? ORDER BY id DESC LIMIT \\?").
+		WithArgs(int64(1), "alice", since, int64(20), 50).
+		WillReturnRows(rows)
+
+	events, err := repo.Search(domain.WithAllHouseholds(context.Background()), IngredientEventFilter{
+		IngredientID: 1,
+		Actor:        "alice",
+		Since:        &since,
+		BeforeID:     20,
+		Limit:        50,
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, "2本", events[0].Before.Quantity)
+	assert.Equal(t, "1本", events[0].After.Quantity)
+	assert.Nil(t, events[1].Before)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIngredientEventSearch_InvalidSnapshot(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewIngredientEventRepository(db)
+
+	rows := sqlmock.NewRows(ingredientEventColumns).
+		AddRow(1, 1, domain.EventActionCreate, "", domain.EventSourceSystem, nil, `{broken`, time.Now())
+	mock.ExpectQuery("SELECT (.+) FROM ingredient_events WHERE 1 = 1 ORDER BY id DESC").
+		WillReturnRows(rows)
+
+	events, err := repo.Search(domain.WithAllHouseholds(context.Background()), IngredientEventFilter{})
+
+	assert.Nil(t, events)
+	assert.Contains(t, err.Error(), "failed to decode ingredient snapshot")
+}
+
+func TestIngredientEventInventoryAt_Success(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewIngredientEventRepository(db)
+
+	at := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"after_value"}).
+		AddRow(`{"id":1,"name":"にんじん","quantity":"2本","version":2}`).
+		AddRow(`{"id":3,"name":"牛乳","quantity":"1本","version":1}`)
+	mock.ExpectQuery("SELECT e.after_value FROM ingredient_events e JOIN (.+) WHERE e.after_value IS NOT NULL ORDER BY e.ingredient_id").
+		WithArgs(at).
+		WillReturnRows(rows)
+
+	ingredients, err := repo.InventoryAt(domain.WithAllHouseholds(context.Background()), at)
+
+	assert.NoError(t, err)
+	assert.Len(t, ingredients, 2)
+	assert.Equal(t, "にんじん", ingredients[0].Name)
+	assert.Equal(t, int64(2), ingredients[0].Version)
+	assert.Equal(t, "牛乳", ingredients[1].Name)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -10,61 +10,155 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
+// undatedSortValue stands in for a missing date so undated ingredients sort after every real date
+const undatedSortValue = "9999-12-31"
+
+// ingredientSortColumns maps sort keys to the SQL expressions they order by
+var ingredientSortColumns = map[string]string{
+	IngredientSortCreatedAt:    "created_at",
+	IngredientSortName:         "name",
+	IngredientSortPurchaseDate: "COALESCE(purchase_date, '" + undatedSortValue + "')",
+	IngredientSortExpiresAt:    "COALESCE(expires_at, '" + undatedSortValue + "')",
+}
+
+// sqlExecutor is the part of *sqlx.DB and *sqlx.Tx the repository queries through
+type sqlExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+}
+
 // ingredientRepository is the MySQL implementation of IngredientRepository
 type ingredientRepository struct {
-	db *sqlx.DB
+	db   sqlExecutor
+	conn *sqlx.DB
+}
+
+// ingredientTx is the MySQL implementation of IngredientTx
+type ingredientTx struct {
+	*ingredientRepository
+	tx         *sqlx.Tx
+	savepoints int
 }
 
 // NewIngredientRepository creates a new instance of IngredientRepository
 func NewIngredientRepository(db *sqlx.DB) IngredientRepository {
 	return &ingredientRepository{
-		db: db,
+		db:   db,
+		conn: db,
 	}
 }
 
+// WithTx runs fn against a repository bound to a single database transaction
+func (r *ingredientRepository) WithTx(ctx context.Context, fn func(tx IngredientTx) error) error {
+	tx, err := r.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(&ingredientTx{ingredientRepository: &ingredientRepository{db: tx}, tx: tx}); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Savepoint runs fn inside a savepoint, rolling back to it if fn fails
+func (t *ingredientTx) Savepoint(ctx context.Context, fn func() error) error {
+	t.savepoints++
+	name := fmt.Sprintf("sp_%d", t.savepoints)
+
+	if _, err := t.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return fmt.Errorf("failed to create savepoint: %w", err)
+	}
+
+	if err := fn(); err != nil {
+		if _, rollbackErr := t.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); rollbackErr != nil {
+			return fmt.Errorf("failed to roll back to savepoint: %w", rollbackErr)
+		}
+		return err
+	}
+
+	if _, err := t.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name); err != nil {
+		return fmt.Errorf("failed to release savepoint: %w", err)
+	}
+
+	return nil
+}
+
 // Create inserts a new ingredient into the database
 func (r *ingredientRepository) Create(ctx context.Context, ingredient *domain.Ingredient) error {
 	query := `
-		INSERT INTO ingredients (name, quantity, purchase_date, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO ingredients (name, canonical_name, category, location, quantity, barcode, price, store, purchase_date, expires_at, version, created_at, updated_at, owner_id, household_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	now := time.Now()
 	ingredient.CreatedAt = now
 	ingredient.UpdatedAt = now
+	ingredient.Version = 1
+	ingredient.OwnerID = domain.OwnerID(ctx)
+	ingredient.HouseholdID = domain.HouseholdID(ctx)
 
-	result, err := r.db.ExecContext(
-		ctx,
-		query,
-		ingredient.Name,
-		ingredient.Quantity,
-		ingredient.PurchaseDate,
-		ingredient.CreatedAt,
-		ingredient.UpdatedAt,
-	)
-	if err != nil {
-		return fmt.Errorf("failed to create ingredient: %w", err)
-	}
+	return r.write(ctx, func(exec sqlExecutor) error {
+		result, err := exec.ExecContext(
+			ctx,
+			query,
+			ingredient.Name,
+			ingredient.CanonicalName,
+			ingredient.Category,
+			ingredient.Location,
+			ingredient.Quantity,
+			ingredient.Barcode,
+			ingredient.Price,
+			ingredient.Store,
+			ingredient.PurchaseDate,
+			ingredient.ExpiresAt,
+			ingredient.Version,
+			ingredient.CreatedAt,
+			ingredient.UpdatedAt,
+			ingredient.OwnerID,
+			ingredient.HouseholdID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create ingredient: %w", err)
+		}
 
-	id, err := result.LastInsertId()
-	if err != nil {
-		return fmt.Errorf("failed to get last insert id: %w", err)
-	}
+		id, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("failed to get last insert id: %w", err)
+		}
 
-	ingredient.ID = id
-	return nil
+		ingredient.ID = id
+		if ingredient.Price != nil {
+			if err := savePurchase(ctx, exec, ingredient); err != nil {
+				return err
+			}
+		}
+
+		return recordEvent(ctx, exec, domain.EventActionCreate, id, nil, ingredient)
+	})
 }
 
 // GetAll retrieves all ingredients from the database
 func (r *ingredientRepository) GetAll(ctx context.Context) ([]*domain.Ingredient, error) {
 	query := `
-		SELECT id, name, quantity, purchase_date, created_at, updated_at
+		SELECT id, name, canonical_name, category, location, quantity, barcode, price, store, purchase_date, expires_at, version, created_at, updated_at, deleted_at, owner_id, household_id
 		FROM ingredients
-		ORDER BY created_at DESC
-	`
+		WHERE deleted_at IS NULL`
+	scope, args, err := householdCondition(ctx, "household_id")
+	if err != nil {
+		return nil, err
+	}
+	query += scope + ` ORDER BY created_at DESC`
 
 	var ingredients []*domain.Ingredient
-	err := r.db.SelectContext(ctx, &ingredients, query)
+	err = r.db.SelectContext(ctx, &ingredients, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all ingredients: %w", err)
 	}
@@ -77,16 +171,110 @@ func (r *ingredientRepository) GetAll(ctx context.Context) ([]*domain.Ingredient
 	return ingredients, nil
 }
 
+// Search retrieves ingredients matching the filter in the requested order
+func (r *ingredientRepository) Search(ctx context.Context, filter IngredientFilter) ([]*domain.Ingredient, error) {
+	sort := filter.Sort
+	if sort == "" {
+		sort = IngredientSortCreatedAt
+	}
+	column, ok := ingredientSortColumns[sort]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown sort key %q", domain.ErrInvalidInput, filter.Sort)
+	}
+
+	descending := sort == IngredientSortCreatedAt
+	switch filter.Order {
+	case "":
+	case OrderAsc:
+		descending = false
+	case OrderDesc:
+		descending = true
+	default:
+		return nil, fmt.Errorf("%w: unknown sort order %q", domain.ErrInvalidInput, filter.Order)
+	}
+
+	query := `
+		SELECT id, name, canonical_name, category, location, quantity, barcode, price, store, purchase_date, expires_at, version, created_at, updated_at, deleted_at, owner_id, household_id
+		FROM ingredients
+		WHERE deleted_at IS NULL`
+	scope, args, err := householdCondition(ctx, "household_id")
+	if err != nil {
+		return nil, err
+	}
+	query += scope
+
+	if filter.Category != "" {
+		query += ` AND category = ?`
+		args = append(args, filter.Category)
+	}
+
+	if filter.Location != "" {
+		query += ` AND location = ?`
+		args = append(args, filter.Location)
+	}
+
+	if filter.Query != "" {
+		canonicalQuery := filter.CanonicalQuery
+		if canonicalQuery == "" {
+			canonicalQuery = filter.Query
+		}
+		query += ` AND (name LIKE ? OR canonical_name LIKE ?)`
+		args = append(args, "%"+escapeLike(filter.Query)+"%", "%"+escapeLike(canonicalQuery)+"%")
+	}
+
+	if filter.PurchasedBefore != nil {
+		query += ` AND purchase_date < ?`
+		args = append(args, filter.PurchasedBefore.Format("2006-01-02"))
+	}
+
+	comparison, direction := ">", "ASC"
+	if descending {
+		comparison, direction = "<", "DESC"
+	}
+
+	if filter.After != nil {
+		value, err := cursorArg(sort, filter.After.Value)
+		if err != nil {
+			return nil, err
+		}
+		query += fmt.Sprintf(` AND (%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?))`, column, comparison)
+		args = append(args, value, value, filter.After.ID)
+	}
+
+	query += fmt.Sprintf(` ORDER BY %[1]s %[2]s, id %[2]s`, column, direction)
+
+	if filter.Limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, filter.Limit)
+	}
+
+	var ingredients []*domain.Ingredient
+	if err := r.db.SelectContext(ctx, &ingredients, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to search ingredients: %w", err)
+	}
+
+	// Return empty slice instead of nil if no ingredients found
+	if ingredients == nil {
+		ingredients = []*domain.Ingredient{}
+	}
+
+	return ingredients, nil
+}
+
 // GetByID retrieves a single ingredient by its ID
 func (r *ingredientRepository) GetByID(ctx context.Context, id int64) (*domain.Ingredient, error) {
 	query := `
-		SELECT id, name, quantity, purchase_date, created_at, updated_at
+		SELECT id, name, canonical_name, category, location, quantity, barcode, price, store, purchase_date, expires_at, version, created_at, updated_at, deleted_at, owner_id, household_id
 		FROM ingredients
-		WHERE id = ?
-	`
+		WHERE id = ? AND deleted_at IS NULL`
+	scope, scopeArgs, err := householdCondition(ctx, "household_id")
+	if err != nil {
+		return nil, err
+	}
+	query += scope
 
 	var ingredient domain.Ingredient
-	err := r.db.GetContext(ctx, &ingredient, query, id)
+	err = r.db.GetContext(ctx, &ingredient, query, append([]interface{}{id}, scopeArgs...)...)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("ingredient not found: %w", err)
@@ -97,24 +285,44 @@ func (r *ingredientRepository) GetByID(ctx context.Context, id int64) (*domain.I
 	return &ingredient, nil
 }
 
-// Update modifies an existing ingredient in the database
+// Update modifies an existing ingredient in the database if it is still at the expected version
 func (r *ingredientRepository) Update(ctx context.Context, ingredient *domain.Ingredient) error {
+	return r.write(ctx, func(exec sqlExecutor) error {
+		return updateIngredient(ctx, exec, ingredient)
+	})
+}
+
+// updateIngredient modifies an ingredient through exec and records the change
+func updateIngredient(ctx context.Context, exec sqlExecutor, ingredient *domain.Ingredient) error {
 	query := `
 		UPDATE ingredients
-		SET name = ?, quantity = ?, purchase_date = ?, updated_at = ?
-		WHERE id = ?
+		SET name = ?, canonical_name = ?, category = ?, location = ?, quantity = ?, barcode = ?, price = ?, store = ?, purchase_date = ?, expires_at = ?,
+			version = version + 1, updated_at = ?
+		WHERE id = ? AND version = ? AND deleted_at IS NULL
 	`
 
-	ingredient.UpdatedAt = time.Now()
+	before, err := lockIngredient(ctx, exec, ingredient.ID)
+	if err != nil {
+		return err
+	}
 
-	result, err := r.db.ExecContext(
+	updatedAt := time.Now()
+	result, err := exec.ExecContext(
 		ctx,
 		query,
 		ingredient.Name,
+		ingredient.CanonicalName,
+		ingredient.Category,
+		ingredient.Location,
 		ingredient.Quantity,
+		ingredient.Barcode,
+		ingredient.Price,
+		ingredient.Store,
 		ingredient.PurchaseDate,
-		ingredient.UpdatedAt,
+		ingredient.ExpiresAt,
+		updatedAt,
 		ingredient.ID,
+		ingredient.Version,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to update ingredient: %w", err)
@@ -126,20 +334,75 @@ func (r *ingredientRepository) Update(ctx context.Context, ingredient *domain.In
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("ingredient not found")
+		return versionConflictError(before)
 	}
 
-	return nil
+	ingredient.UpdatedAt = updatedAt
+	ingredient.Version++
+	ingredient.CreatedAt = before.CreatedAt
+	ingredient.HouseholdID = before.HouseholdID
+	if purchaseChanged(before, ingredient) {
+		if err := savePurchase(ctx, exec, ingredient); err != nil {
+			return err
+		}
+	}
+
+	return recordEvent(ctx, exec, domain.EventActionUpdate, ingredient.ID, before, ingredient)
 }
 
-// Delete removes an ingredient from the database by its ID
-func (r *ingredientRepository) Delete(ctx context.Context, id int64) error {
+// UpdateClassification sets the canonical name and category of an ingredient without touching updated_at
+func (r *ingredientRepository) UpdateClassification(ctx context.Context, id int64, canonicalName string, category string) error {
 	query := `
-		DELETE FROM ingredients
+		UPDATE ingredients
+		SET canonical_name = ?, category = ?, version = version + 1, updated_at = updated_at
 		WHERE id = ?
 	`
 
-	result, err := r.db.ExecContext(ctx, query, id)
+	return r.write(ctx, func(exec sqlExecutor) error {
+		before, err := lockIngredient(ctx, exec, id)
+		if err != nil {
+			return err
+		}
+
+		if _, err := exec.ExecContext(ctx, query, canonicalName, category, id); err != nil {
+			return fmt.Errorf("failed to update ingredient classification: %w", err)
+		}
+
+		after := *before
+		after.CanonicalName = canonicalName
+		after.Category = category
+		after.Version++
+		if purchaseChanged(before, &after) {
+			if err := savePurchase(ctx, exec, &after); err != nil {
+				return err
+			}
+		}
+
+		return recordEvent(ctx, exec, domain.EventActionUpdate, id, before, &after)
+	})
+}
+
+// Delete moves an ingredient to the trash by its ID if it is still at the expected version
+func (r *ingredientRepository) Delete(ctx context.Context, id int64, version int64) error {
+	return r.write(ctx, func(exec sqlExecutor) error {
+		return deleteIngredient(ctx, exec, id, version)
+	})
+}
+
+// deleteIngredient moves an ingredient to the trash through exec and records the change
+func deleteIngredient(ctx context.Context, exec sqlExecutor, id int64, version int64) error {
+	query := `
+		UPDATE ingredients
+		SET deleted_at = ?, version = version + 1, updated_at = updated_at
+		WHERE id = ? AND version = ? AND deleted_at IS NULL
+	`
+
+	before, err := lockIngredient(ctx, exec, id)
+	if err != nil {
+		return err
+	}
+
+	result, err := exec.ExecContext(ctx, query, time.Now(), id, version)
 	if err != nil {
 		return fmt.Errorf("failed to delete ingredient: %w", err)
 	}
@@ -150,8 +413,268 @@ func (r *ingredientRepository) Delete(ctx context.Context, id int64) error {
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("ingredient not found")
+		return versionConflictError(before)
+	}
+
+	return recordEvent(ctx, exec, domain.EventActionDelete, id, before, nil)
+}
+
+// Consume stores the quantity left in the ingredient, or moves it to the trash when the consumption used it up,
+// and records the consumption in the same transaction
+func (r *ingredientRepository) Consume(ctx context.Context, ingredient *domain.Ingredient, consumption *domain.Consumption) error {
+	return r.write(ctx, func(exec sqlExecutor) error {
+		if consumption.UsedUp {
+			if err := deleteIngredient(ctx, exec, ingredient.ID, ingredient.Version); err != nil {
+				return err
+			}
+		} else if err := updateIngredient(ctx, exec, ingredient); err != nil {
+			return err
+		}
+
+		return recordConsumption(ctx, exec, consumption)
+	})
+}
+
+// GetDeleted retrieves the ingredients in the trash, most recently deleted first
+func (r *ingredientRepository) GetDeleted(ctx context.Context) ([]*domain.Ingredient, error) {
+	query := `
+		SELECT id, name, canonical_name, category, location, quantity, barcode, price, store, purchase_date, expires_at, version, created_at, updated_at, deleted_at, owner_id, household_id
+		FROM ingredients
+		WHERE deleted_at IS NOT NULL`
+	scope, args, err := householdCondition(ctx, "household_id")
+	if err != nil {
+		return nil, err
+	}
+	query += scope + ` ORDER BY deleted_at DESC, id DESC`
+
+	var ingredients []*domain.Ingredient
+	err = r.db.SelectContext(ctx, &ingredients, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted ingredients: %w", err)
+	}
+
+	// Return empty slice instead of nil if the trash is empty
+	if ingredients == nil {
+		ingredients = []*domain.Ingredient{}
+	}
+
+	return ingredients, nil
+}
+
+// Restore takes an ingredient out of the trash by its ID
+func (r *ingredientRepository) Restore(ctx context.Context, id int64) error {
+	query := `
+		UPDATE ingredients
+		SET deleted_at = NULL, version = version + 1, updated_at = updated_at
+		WHERE id = ? AND deleted_at IS NOT NULL`
+	scope, scopeArgs, err := householdCondition(ctx, "household_id")
+	if err != nil {
+		return err
+	}
+	query += scope
+
+	return r.write(ctx, func(exec sqlExecutor) error {
+		result, err := exec.ExecContext(ctx, query, append([]interface{}{id}, scopeArgs...)...)
+		if err != nil {
+			return fmt.Errorf("failed to restore ingredient: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			return fmt.Errorf("ingredient not found in trash: %w", sql.ErrNoRows)
+		}
+
+		after, err := lockIngredient(ctx, exec, id)
+		if err != nil {
+			return err
+		}
+
+		return recordEvent(ctx, exec, domain.EventActionRestore, id, nil, after)
+	})
+}
+
+// Purge permanently removes the ingredients moved to the trash before deletedBefore and returns how many were removed
+func (r *ingredientRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	scope, scopeArgs, err := householdCondition(ctx, "household_id")
+	if err != nil {
+		return 0, err
+	}
+	args := append([]interface{}{deletedBefore}, scopeArgs...)
+	selectQuery := `
+		SELECT id, name, canonical_name, category, location, quantity, barcode, price, store, purchase_date, expires_at, version, created_at, updated_at, deleted_at, owner_id, household_id
+		FROM ingredients
+		WHERE deleted_at IS NOT NULL AND deleted_at < ?` + scope + `
+		FOR UPDATE
+	`
+	deleteQuery := `
+		DELETE FROM ingredients
+		WHERE deleted_at IS NOT NULL AND deleted_at < ?` + scope
+
+	var purged int64
+	err = r.write(ctx, func(exec sqlExecutor) error {
+		var ingredients []*domain.Ingredient
+		if err := exec.SelectContext(ctx, &ingredients, selectQuery, args...); err != nil {
+			return fmt.Errorf("failed to get purged ingredients: %w", err)
+		}
+		if len(ingredients) == 0 {
+			return nil
+		}
+
+		result, err := exec.ExecContext(ctx, deleteQuery, args...)
+		if err != nil {
+			return fmt.Errorf("failed to purge ingredients: %w", err)
+		}
+
+		if purged, err = result.RowsAffected(); err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		for _, ingredient := range ingredients {
+			if err := recordEvent(ctx, exec, domain.EventActionPurge, ingredient.ID, ingredient, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return purged, nil
+}
+
+// write runs fn in the transaction the repository is bound to, or in a new one,
+// so a change and its history entry are committed together
+func (r *ingredientRepository) write(ctx context.Context, fn func(exec sqlExecutor) error) error {
+	if r.conn == nil {
+		return fn(r.db)
+	}
+
+	tx, err := r.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
 	return nil
 }
+
+// lockIngredient reads an ingredient of the household in ctx outside the trash and locks its row until the transaction ends
+func lockIngredient(ctx context.Context, exec sqlExecutor, id int64) (*domain.Ingredient, error) {
+	scope, scopeArgs, err := householdCondition(ctx, "household_id")
+	if err != nil {
+		return nil, err
+	}
+	query := `
+		SELECT id, name, canonical_name, category, location, quantity, barcode, price, store, purchase_date, expires_at, version, created_at, updated_at, deleted_at, owner_id, household_id
+		FROM ingredients
+		WHERE id = ? AND deleted_at IS NULL` + scope + `
+		FOR UPDATE
+	`
+
+	var ingredient domain.Ingredient
+	if err := exec.GetContext(ctx, &ingredient, query, append([]interface{}{id}, scopeArgs...)...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("ingredient not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get ingredient by id: %w", err)
+	}
+
+	return &ingredient, nil
+}
+
+// savePurchase keeps the purchase ledger entry of an ingredient in line with its price.
+// The ledger outlives the ingredient so spending can still be reported after it is used up and purged.
+func savePurchase(ctx context.Context, exec sqlExecutor, ingredient *domain.Ingredient) error {
+	if ingredient.Price == nil {
+		if _, err := exec.ExecContext(ctx, `DELETE FROM ingredient_purchases WHERE ingredient_id = ?`, ingredient.ID); err != nil {
+			return fmt.Errorf("failed to delete purchase: %w", err)
+		}
+		return nil
+	}
+
+	query := `
+		INSERT INTO ingredient_purchases (ingredient_id, name, category, store, price, purchased_on, household_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE name = VALUES(name), category = VALUES(category), store = VALUES(store),
+			price = VALUES(price), purchased_on = VALUES(purchased_on)
+	`
+
+	// Ingredients without a purchase date count as bought the day they were registered
+	purchasedOn := ingredient.CreatedAt
+	if ingredient.PurchaseDate != nil {
+		purchasedOn = *ingredient.PurchaseDate
+	}
+
+	if _, err := exec.ExecContext(
+		ctx,
+		query,
+		ingredient.ID,
+		ingredient.Name,
+		ingredient.Category,
+		ingredient.Store,
+		*ingredient.Price,
+		purchasedOn.Format("2006-01-02"),
+		ingredient.HouseholdID,
+	); err != nil {
+		return fmt.Errorf("failed to save purchase: %w", err)
+	}
+
+	return nil
+}
+
+// purchaseChanged reports whether an update touches what the purchase ledger records about an ingredient
+func purchaseChanged(before *domain.Ingredient, after *domain.Ingredient) bool {
+	if before.Price == nil && after.Price == nil {
+		return false
+	}
+	if before.Price == nil || after.Price == nil || *before.Price != *after.Price {
+		return true
+	}
+	return before.Name != after.Name || before.Category != after.Category || before.Store != after.Store ||
+		formatLedgerDate(before.PurchaseDate) != formatLedgerDate(after.PurchaseDate)
+}
+
+// formatLedgerDate formats an optional date for comparison, returning an empty string for nil
+func formatLedgerDate(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+	return date.Format("2006-01-02")
+}
+
+// versionConflictError reports that a versioned write was based on an older version than the stored one
+func versionConflictError(stored *domain.Ingredient) error {
+	return fmt.Errorf("ingredient %d is at version %d: %w", stored.ID, stored.Version, domain.ErrVersionConflict)
+}
+
+// cursorArg converts a cursor value back into the query argument compared against the sort column
+func cursorArg(sort string, value string) (interface{}, error) {
+	switch sort {
+	case IngredientSortCreatedAt:
+		createdAt, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid cursor", domain.ErrInvalidInput)
+		}
+		return createdAt, nil
+	case IngredientSortPurchaseDate, IngredientSortExpiresAt:
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			return nil, fmt.Errorf("%w: invalid cursor", domain.ErrInvalidInput)
+		}
+		return value, nil
+	default:
+		return value, nil
+	}
+}
@@ -22,6 +22,21 @@ func setupMockDB(t *testing.T) (*sqlx.DB, sqlmock.Sqlmock) {
 	return sqlxDB, mock
 }
 
+// expectLock expects the locking read of an ingredient outside the trash at the given version
+func expectLock(mock sqlmock.Sqlmock, id int64, version int64) {
+	mock.ExpectQuery("SELECT (.+) FROM ingredients WHERE id = \\? AND deleted_at IS NULL FOR UPDATE").
+		WithArgs(id).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "version", "created_at", "updated_at"}).
+			AddRow(id, "にんじん", version, time.Now(), time.Now()))
+}
+
+// expectEvent expects a history entry for the ingredient to be recorded
+func expectEvent(mock sqlmock.Sqlmock, ingredientID int64, action string) {
+	mock.ExpectExec("INSERT INTO ingredient_events").
+		WithArgs(ingredientID, action, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
 func TestCreate_Success(t *testing.T) {
 	db, mock := setupMockDB(t)
 	defer db.Close()
@@ -35,19 +50,58 @@ func TestCreate_Success(t *testing.T) {
 		PurchaseDate: &purchaseDate,
 	}
 
+	mock.ExpectBegin()
 	mock.ExpectExec("INSERT INTO ingredients").
-		WithArgs(ingredient.Name, ingredient.Quantity, ingredient.PurchaseDate, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WithArgs(ingredient.Name, ingredient.CanonicalName, ingredient.Category, ingredient.Location, ingredient.Quantity, ingredient.Barcode, ingredient.Price, ingredient.Store, ingredient.PurchaseDate, ingredient.ExpiresAt, int64(1), sqlmock.AnyArg(), sqlmock.AnyArg(), nil, nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO ingredient_events").
+		WithArgs(int64(1), domain.EventActionCreate, "alice", domain.EventSourceImport, nil, sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
 		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
 
-	err := repo.Create(context.Background(), ingredient)
+	ctx := domain.WithEventSource(domain.WithEventActor(context.Background(), "alice"), domain.EventSourceImport)
+	err := repo.Create(ctx, ingredient)
 
 	assert.NoError(t, err)
 	assert.Equal(t, int64(1), ingredient.ID)
+	assert.Equal(t, int64(1), ingredient.Version)
 	assert.NotZero(t, ingredient.CreatedAt)
 	assert.NotZero(t, ingredient.UpdatedAt)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestCreate_WithPrice(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewIngredientRepository(db)
+
+	price := 298
+	ingredient := &domain.Ingredient{
+		Name:     "豚バラ肉",
+		Category: domain.CategoryMeat,
+		Quantity: "200g",
+		Price:    &price,
+		Store:    "スーパーA",
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO ingredients").
+		WithArgs(ingredient.Name, ingredient.CanonicalName, ingredient.Category, ingredient.Location, ingredient.Quantity, ingredient.Barcode, &price, "スーパーA", ingredient.PurchaseDate, ingredient.ExpiresAt, int64(1), sqlmock.AnyArg(), sqlmock.AnyArg(), nil, nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	// Without a purchase date the ingredient counts as bought today
+	mock.ExpectExec("INSERT INTO ingredient_purchases (.+) ON DUPLICATE KEY UPDATE").
+		WithArgs(int64(1), "豚バラ肉", domain.CategoryMeat, "スーパーA", 298, time.Now().Format("2006-01-02"), nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectEvent(mock, 1, domain.EventActionCreate)
+	mock.ExpectCommit()
+
+	err := repo.Create(context.Background(), ingredient)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestCreate_DatabaseError(t *testing.T) {
 	db, mock := setupMockDB(t)
 	defer db.Close()
@@ -59,9 +113,11 @@ func TestCreate_DatabaseError(t *testing.T) {
 		Quantity: "200g",
 	}
 
+	mock.ExpectBegin()
 	mock.ExpectExec("INSERT INTO ingredients").
-		WithArgs(ingredient.Name, ingredient.Quantity, ingredient.PurchaseDate, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WithArgs(ingredient.Name, ingredient.CanonicalName, ingredient.Category, ingredient.Location, ingredient.Quantity, ingredient.Barcode, ingredient.Price, ingredient.Store, ingredient.PurchaseDate, ingredient.ExpiresAt, int64(1), sqlmock.AnyArg(), sqlmock.AnyArg(), nil, nil).
 		WillReturnError(sql.ErrConnDone)
+	mock.ExpectRollback()
 	err := repo.Create(context.Background(), ingredient)
 
 	assert.Error(t, err)
@@ -69,6 +125,26 @@ func TestCreate_DatabaseError(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestCreate_EventError(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewIngredientRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO ingredients").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO ingredient_events").
+		WillReturnError(sql.ErrConnDone)
+	mock.ExpectRollback()
+
+	err := repo.Create(context.Background(), &domain.Ingredient{Name: "にんじん"})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to record ingredient event")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestGetAll_Success(t *testing.T) {
 	db, mock := setupMockDB(t)
 	defer db.Close()
@@ -85,7 +161,7 @@ func TestGetAll_Success(t *testing.T) {
 	mock.ExpectQuery("SELECT (.+) FROM ingredients").
 		WillReturnRows(rows)
 
-	ingredients, err := repo.GetAll(context.Background())
+	ingredients, err := repo.GetAll(domain.WithAllHouseholds(context.Background()))
 
 	assert.NoError(t, err)
 	assert.Len(t, ingredients, 2)
@@ -105,7 +181,7 @@ func TestGetAll_EmptyResult(t *testing.T) {
 	mock.ExpectQuery("SELECT (.+) FROM ingredients").
 		WillReturnRows(rows)
 
-	ingredients, err := repo.GetAll(context.Background())
+	ingredients, err := repo.GetAll(domain.WithAllHouseholds(context.Background()))
 
 	assert.NoError(t, err)
 	assert.NotNil(t, ingredients)
@@ -122,7 +198,7 @@ func TestGetAll_DatabaseError(t *testing.T) {
 	mock.ExpectQuery("SELECT (.+) FROM ingredients").
 		WillReturnError(sql.ErrConnDone)
 
-	ingredients, err := repo.GetAll(context.Background())
+	ingredients, err := repo.GetAll(domain.WithAllHouseholds(context.Background()))
 
 	assert.Error(t, err)
 	assert.Nil(t, ingredients)
@@ -130,6 +206,135 @@ func TestGetAll_DatabaseError(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestSearch_WithFilter(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewIngredientRepository(db)
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "name", "canonical_name", "category", "location", "quantity", "purchase_date", "created_at", "updated_at"}).
+		AddRow(1, "鶏もも肉", "鶏もも肉", domain.CategoryMeat, domain.LocationFreezer, "300g", nil, now, now)
+
+	mock.ExpectQuery("SELECT (.+) FROM ingredients WHERE deleted_at IS NULL AND category = \\? AND location = \\? ORDER BY created_at DESC, id DESC").
+		WithArgs(domain.CategoryMeat, domain.LocationFreezer).
+		WillReturnRows(rows)
+
+	ingredients, err := repo.Search(domain.WithAllHouseholds(context.Background()), IngredientFilter{
+		Category: domain.CategoryMeat,
+		Location: domain.LocationFreezer,
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, ingredients, 1)
+	assert.Equal(t, domain.LocationFreezer, ingredients[0].Location)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearch_EmptyFilter(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewIngredientRepository(db)
+
+	mock.ExpectQuery("SELECT (.+) FROM ingredients WHERE deleted_at IS NULL ORDER BY created_at DESC, id DESC").
+		WithArgs().
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+
+	ingredients, err := repo.Search(domain.WithAllHouseholds(context.Background()), IngredientFilter{})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, ingredients)
+	assert.Len(t, ingredients, 0)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearch_QueryAndPurchasedBefore(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewIngredientRepository(db)
+
+	purchasedBefore := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery("SELECT (.+) FROM ingredients WHERE deleted_at IS NULL AND \\(name LIKE \\? OR canonical_name LIKE \\?\\) AND purchase_date < \\? ORDER BY name ASC, id ASC LIMIT \\?").
+		WithArgs("%たま\\_%", "%玉ねぎ%", "2025-12-01", 11).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+
+	_, err := repo.Search(domain.WithAllHouseholds(context.Background()), IngredientFilter{
+		Query:           "たま_",
+		CanonicalQuery:  "玉ねぎ",
+		PurchasedBefore: &purchasedBefore,
+		Sort:            IngredientSortName,
+		Limit:           11,
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearch_CursorByExpiresAt(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewIngredientRepository(db)
+
+	expiresAt := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	cursor := NewIngredientCursor(&domain.Ingredient{ID: 7, ExpiresAt: &expiresAt}, IngredientSortExpiresAt)
+	assert.Equal(t, IngredientCursor{Value: "2026-01-05", ID: 7}, cursor)
+
+	mock.ExpectQuery("SELECT (.+) FROM ingredients WHERE deleted_at IS NULL "+
+		"AND \\(COALESCE\\(expires_at, '9999-12-31'\\) < \\? OR \\(COALESCE\\(expires_at, '9999-12-31'\\) = \\? AND id < \\?\\)\\) "+
+		"ORDER BY COALESCE\\(expires_at, '9999-12-31'\\) DESC, id DESC").
+		WithArgs("2026-01-05", "2026-01-05", int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+
+	_, err := repo.Search(domain.WithAllHouseholds(context.Background()), IngredientFilter{
+		Sort:  IngredientSortExpiresAt,
+		Order: OrderDesc,
+		After: &cursor,
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearch_CursorByCreatedAt(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewIngredientRepository(db)
+
+	createdAt := time.Date(2025, 11, 30, 12, 34, 56, 0, time.UTC)
+	cursor := NewIngredientCursor(&domain.Ingredient{ID: 3, CreatedAt: createdAt}, "")
+
+	mock.ExpectQuery("SELECT (.+) FROM ingredients WHERE deleted_at IS NULL AND \\(created_at < \\? OR \\(created_at = \\? AND id < \\?\\)\\) ORDER BY created_at DESC, id DESC").
+		WithArgs(createdAt, createdAt, int64(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+
+	_, err := repo.Search(domain.WithAllHouseholds(context.Background()), IngredientFilter{After: &cursor})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearch_InvalidCursor(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewIngredientRepository(db)
+
+	_, err := repo.Search(domain.WithAllHouseholds(context.Background()), IngredientFilter{
+		Sort:  IngredientSortPurchaseDate,
+		After: &IngredientCursor{Value: "yesterday", ID: 1},
+	})
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+	_, err = repo.Search(domain.WithAllHouseholds(context.Background()), IngredientFilter{Sort: "price"})
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestGetByID_Success(t *testing.T) {
 	db, mock := setupMockDB(t)
 	defer db.Close()
@@ -146,7 +351,7 @@ func TestGetByID_Success(t *testing.T) {
 		WithArgs(1).
 		WillReturnRows(rows)
 
-	ingredient, err := repo.GetByID(context.Background(), 1)
+	ingredient, err := repo.GetByID(domain.WithAllHouseholds(context.Background()), 1)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, ingredient)
@@ -165,7 +370,7 @@ func TestGetByID_NotFound(t *testing.T) {
 		WithArgs(999).
 		WillReturnError(sql.ErrNoRows)
 
-	ingredient, err := repo.GetByID(context.Background(), 999)
+	ingredient, err := repo.GetByID(domain.WithAllHouseholds(context.Background()), 999)
 
 	assert.Error(t, err)
 	assert.Nil(t, ingredient)
@@ -173,6 +378,40 @@ func TestGetByID_NotFound(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestGetByID_OtherHousehold(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewIngredientRepository(db)
+
+	// Ingredients of other households are looked up as if they did not exist
+	mock.ExpectQuery("SELECT (.+) FROM ingredients WHERE id = \\? AND deleted_at IS NULL AND household_id = \\?").
+		WithArgs(1, int64(2)).
+		WillReturnError(sql.ErrNoRows)
+
+	ctx := domain.WithHousehold(context.Background(), &domain.Household{ID: 2, Role: domain.HouseholdRoleMember})
+	ingredient, err := repo.GetByID(ctx, 1)
+
+	assert.Nil(t, ingredient)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetByID_NoHousehold(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewIngredientRepository(db)
+
+	// Without a household nothing is read, instead of every household's ingredients
+	ingredient, err := repo.GetByID(context.Background(), 1)
+
+	assert.Nil(t, ingredient)
+	assert.ErrorIs(t, err, domain.ErrNoHousehold)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestGetByID_DatabaseError(t *testing.T) {
 	db, mock := setupMockDB(t)
 	defer db.Close()
@@ -183,7 +422,7 @@ func TestGetByID_DatabaseError(t *testing.T) {
 		WithArgs(1).
 		WillReturnError(sql.ErrConnDone)
 
-	ingredient, err := repo.GetByID(context.Background(), 1)
+	ingredient, err := repo.GetByID(domain.WithAllHouseholds(context.Background()), 1)
 
 	assert.Error(t, err)
 	assert.Nil(t, ingredient)
@@ -203,16 +442,55 @@ func TestUpdate_Success(t *testing.T) {
 		Name:         "にんじん",
 		Quantity:     "3本",
 		PurchaseDate: &purchaseDate,
+		Version:      3,
 	}
 
-	mock.ExpectExec("UPDATE ingredients").
-		WithArgs(ingredient.Name, ingredient.Quantity, ingredient.PurchaseDate, sqlmock.AnyArg(), ingredient.ID).
+	mock.ExpectBegin()
+	expectLock(mock, 1, 3)
+	mock.ExpectExec("UPDATE ingredients (.+) version = version \\+ 1, updated_at = \\? WHERE id = \\? AND version = \\? AND deleted_at IS NULL").
+		WithArgs(ingredient.Name, ingredient.CanonicalName, ingredient.Category, ingredient.Location, ingredient.Quantity, ingredient.Barcode, ingredient.Price, ingredient.Store, ingredient.PurchaseDate, ingredient.ExpiresAt, sqlmock.AnyArg(), ingredient.ID, ingredient.Version).
 		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectEvent(mock, 1, domain.EventActionUpdate)
+	mock.ExpectCommit()
 
-	err := repo.Update(context.Background(), ingredient)
+	err := repo.Update(domain.WithAllHouseholds(context.Background()), ingredient)
 
 	assert.NoError(t, err)
 	assert.NotZero(t, ingredient.UpdatedAt)
+	assert.Equal(t, int64(4), ingredient.Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdate_PriceChanged(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewIngredientRepository(db)
+
+	purchaseDate := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+	price := 150
+	ingredient := &domain.Ingredient{
+		ID:           1,
+		Name:         "にんじん",
+		Quantity:     "3本",
+		Price:        &price,
+		PurchaseDate: &purchaseDate,
+		Version:      3,
+	}
+
+	mock.ExpectBegin()
+	expectLock(mock, 1, 3)
+	mock.ExpectExec("UPDATE ingredients").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO ingredient_purchases").
+		WithArgs(int64(1), "にんじん", "", "", 150, "2025-12-31", nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectEvent(mock, 1, domain.EventActionUpdate)
+	mock.ExpectCommit()
+
+	err := repo.Update(domain.WithAllHouseholds(context.Background()), ingredient)
+
+	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -228,17 +506,47 @@ func TestUpdate_NotFound(t *testing.T) {
 		Quantity: "1個",
 	}
 
-	mock.ExpectExec("UPDATE ingredients").
-		WithArgs(ingredient.Name, ingredient.Quantity, ingredient.PurchaseDate, sqlmock.AnyArg(), ingredient.ID).
-		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM ingredients WHERE id = \\? AND deleted_at IS NULL FOR UPDATE").
+		WithArgs(ingredient.ID).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
 
-	err := repo.Update(context.Background(), ingredient)
+	err := repo.Update(domain.WithAllHouseholds(context.Background()), ingredient)
 
 	assert.Error(t, err)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
 	assert.Contains(t, err.Error(), "ingredient not found")
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestUpdate_VersionConflict(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewIngredientRepository(db)
+
+	ingredient := &domain.Ingredient{
+		ID:      1,
+		Name:    "にんじん",
+		Version: 2,
+	}
+
+	mock.ExpectBegin()
+	expectLock(mock, 1, 3)
+	mock.ExpectExec("UPDATE ingredients").
+		WithArgs(ingredient.Name, ingredient.CanonicalName, ingredient.Category, ingredient.Location, ingredient.Quantity, ingredient.Barcode, ingredient.Price, ingredient.Store, ingredient.PurchaseDate, ingredient.ExpiresAt, sqlmock.AnyArg(), ingredient.ID, ingredient.Version).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err := repo.Update(domain.WithAllHouseholds(context.Background()), ingredient)
+
+	assert.ErrorIs(t, err, domain.ErrVersionConflict)
+	assert.Contains(t, err.Error(), "version 3")
+	assert.Equal(t, int64(2), ingredient.Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestUpdate_DatabaseError(t *testing.T) {
 	db, mock := setupMockDB(t)
 	defer db.Close()
@@ -251,28 +559,59 @@ func TestUpdate_DatabaseError(t *testing.T) {
 		Quantity: "3本",
 	}
 
+	mock.ExpectBegin()
+	expectLock(mock, 1, 0)
 	mock.ExpectExec("UPDATE ingredients").
-		WithArgs(ingredient.Name, ingredient.Quantity, ingredient.PurchaseDate, sqlmock.AnyArg(), ingredient.ID).
+		WithArgs(ingredient.Name, ingredient.CanonicalName, ingredient.Category, ingredient.Location, ingredient.Quantity, ingredient.Barcode, ingredient.Price, ingredient.Store, ingredient.PurchaseDate, ingredient.ExpiresAt, sqlmock.AnyArg(), ingredient.ID, ingredient.Version).
 		WillReturnError(sql.ErrConnDone)
+	mock.ExpectRollback()
 
-	err := repo.Update(context.Background(), ingredient)
+	err := repo.Update(domain.WithAllHouseholds(context.Background()), ingredient)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to update ingredient")
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestUpdateClassification_Success(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewIngredientRepository(db)
+
+	mock.ExpectBegin()
+	expectLock(mock, 1, 1)
+	mock.ExpectExec("UPDATE ingredients SET canonical_name = \\?, category = \\?, version = version \\+ 1").
+		WithArgs("豚バラ肉", domain.CategoryMeat, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO ingredient_events").
+		WithArgs(int64(1), domain.EventActionUpdate, "", domain.EventSourceSystem, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err := repo.UpdateClassification(domain.WithAllHouseholds(context.Background()), 1, "豚バラ肉", domain.CategoryMeat)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestDelete_Success(t *testing.T) {
 	db, mock := setupMockDB(t)
 	defer db.Close()
 
 	repo := NewIngredientRepository(db)
 
-	mock.ExpectExec("DELETE FROM ingredients WHERE id = ?").
-		WithArgs(1).
+	mock.ExpectBegin()
+	expectLock(mock, 1, 2)
+	mock.ExpectExec("UPDATE ingredients SET deleted_at = \\?, version = version \\+ 1, updated_at = updated_at WHERE id = \\? AND version = \\? AND deleted_at IS NULL").
+		WithArgs(sqlmock.AnyArg(), 1, 2).
 		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO ingredient_events").
+		WithArgs(int64(1), domain.EventActionDelete, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), nil, sqlmock.AnyArg(), nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
 
-	err := repo.Delete(context.Background(), 1)
+	err := repo.Delete(domain.WithAllHouseholds(context.Background()), 1, 2)
 
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -284,30 +623,331 @@ func TestDelete_NotFound(t *testing.T) {
 
 	repo := NewIngredientRepository(db)
 
-	mock.ExpectExec("DELETE FROM ingredients WHERE id = ?").
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM ingredients WHERE id = \\? AND deleted_at IS NULL FOR UPDATE").
 		WithArgs(999).
-		WillReturnResult(sqlmock.NewResult(0, 0))
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
 
-	err := repo.Delete(context.Background(), 999)
+	err := repo.Delete(domain.WithAllHouseholds(context.Background()), 999, 1)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "ingredient not found")
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestDelete_VersionConflict(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewIngredientRepository(db)
+
+	mock.ExpectBegin()
+	expectLock(mock, 1, 2)
+	mock.ExpectExec("UPDATE ingredients SET deleted_at = \\?, version = version \\+ 1, updated_at = updated_at WHERE id = \\? AND version = \\? AND deleted_at IS NULL").
+		WithArgs(sqlmock.AnyArg(), 1, 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err := repo.Delete(domain.WithAllHouseholds(context.Background()), 1, 1)
+
+	assert.ErrorIs(t, err, domain.ErrVersionConflict)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestDelete_DatabaseError(t *testing.T) {
 	db, mock := setupMockDB(t)
 	defer db.Close()
 
 	repo := NewIngredientRepository(db)
 
-	mock.ExpectExec("DELETE FROM ingredients WHERE id = ?").
-		WithArgs(1).
+	mock.ExpectBegin()
+	expectLock(mock, 1, 1)
+	mock.ExpectExec("UPDATE ingredients SET deleted_at = \\?, version = version \\+ 1, updated_at = updated_at WHERE id = \\? AND version = \\? AND deleted_at IS NULL").
+		WithArgs(sqlmock.AnyArg(), 1, 1).
 		WillReturnError(sql.ErrConnDone)
+	mock.ExpectRollback()
 
-	err := repo.Delete(context.Background(), 1)
+	err := repo.Delete(domain.WithAllHouseholds(context.Background()), 1, 1)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to delete ingredient")
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestGetDeleted_Success(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewIngredientRepository(db)
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at", "deleted_at"}).
+		AddRow(2, "牛乳", now, now, now).
+		AddRow(1, "にんじん", now, now, now.Add(-time.Hour))
+
+	mock.ExpectQuery("SELECT (.+) FROM ingredients WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC, id DESC").
+		WillReturnRows(rows)
+
+	ingredients, err := repo.GetDeleted(domain.WithAllHouseholds(context.Background()))
+
+	assert.NoError(t, err)
+	assert.Len(t, ingredients, 2)
+	assert.NotNil(t, ingredients[0].DeletedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestConsume_Partial(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewIngredientRepository(db)
+
+	ingredient := &domain.Ingredient{ID: 1, Name: "にんじん", Quantity: "1本", Version: 2}
+	consumption := &domain.Consumption{IngredientID: 1, Name: "にんじん", Amount: "1本", Remaining: "1本", Reason: domain.ConsumptionReasonCooked}
+
+	mock.ExpectBegin()
+	expectLock(mock, 1, 2)
+	mock.ExpectExec("UPDATE ingredients").
+		WithArgs(ingredient.Name, ingredient.CanonicalName, ingredient.Category, ingredient.Location, "1本", ingredient.Barcode, ingredient.Price, ingredient.Store, ingredient.PurchaseDate, ingredient.ExpiresAt, sqlmock.AnyArg(), int64(1), int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectEvent(mock, 1, domain.EventActionUpdate)
+	mock.ExpectExec("INSERT INTO ingredient_consumptions").
+		WithArgs(int64(1), "にんじん", "", "", "1本", "1本", false, domain.ConsumptionReasonCooked, "", nil, nil, nil, "alice", sqlmock.AnyArg(), nil).
+		WillReturnResult(sqlmock.NewResult(7, 1))
+	mock.ExpectCommit()
+
+	err := repo.Consume(domain.WithEventActor(domain.WithAllHouseholds(context.Background()), "alice"), ingredient, consumption)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), ingredient.Version)
+	assert.Equal(t, int64(7), consumption.ID)
+	assert.Equal(t, "alice", consumption.Actor)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestConsume_UsedUp(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewIngredientRepository(db)
+
+	ingredient := &domain.Ingredient{ID: 1, Name: "牛乳", Quantity: "1本", Version: 2}
+	consumption := &domain.Consumption{IngredientID: 1, Name: "牛乳", Amount: "1本", UsedUp: true, Reason: domain.ConsumptionReasonEaten}
+
+	mock.ExpectBegin()
+	expectLock(mock, 1, 2)
+	mock.ExpectExec("UPDATE ingredients SET deleted_at = \\?").
+		WithArgs(sqlmock.AnyArg(), int64(1), int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectEvent(mock, 1, domain.EventActionDelete)
+	mock.ExpectExec("INSERT INTO ingredient_consumptions").
+		WillReturnResult(sqlmock.NewResult(8, 1))
+	mock.ExpectCommit()
+
+	err := repo.Consume(domain.WithAllHouseholds(context.Background()), ingredient, consumption)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestConsume_VersionConflict(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewIngredientRepository(db)
+
+	ingredient := &domain.Ingredient{ID: 1, Name: "牛乳", Quantity: "1本", Version: 1}
+	consumption := &domain.Consumption{IngredientID: 1, UsedUp: true, Reason: domain.ConsumptionReasonEaten}
+
+	mock.ExpectBegin()
+	expectLock(mock, 1, 2)
+	mock.ExpectExec("UPDATE ingredients SET deleted_at = \\?").
+		WithArgs(sqlmock.AnyArg(), int64(1), int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err := repo.Consume(domain.WithAllHouseholds(context.Background()), ingredient, consumption)
+
+	assert.ErrorIs(t, err, domain.ErrVersionConflict)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRestore_Success(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewIngredientRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE ingredients SET deleted_at = NULL, version = version \\+ 1, updated_at = updated_at WHERE id = \\? AND deleted_at IS NOT NULL").
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectLock(mock, 1, 3)
+	mock.ExpectExec("INSERT INTO ingredient_events").
+		WithArgs(int64(1), domain.EventActionRestore, sqlmock.AnyArg(), sqlmock.AnyArg(), nil, sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err := repo.Restore(domain.WithAllHouseholds(context.Background()), 1)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRestore_NotInTrash(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewIngredientRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE ingredients SET deleted_at = NULL").
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err := repo.Restore(domain.WithAllHouseholds(context.Background()), 1)
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPurge_Success(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewIngredientRepository(db)
+
+	deletedBefore := time.Date(2026, 9, 18, 0, 0, 0, 0, time.UTC)
+	deletedAt := deletedBefore.AddDate(0, 0, -1)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM ingredients WHERE deleted_at IS NOT NULL AND deleted_at < \\? FOR UPDATE").
+		WithArgs(deletedBefore).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "deleted_at"}).
+			AddRow(1, "にんじん", deletedAt).
+			AddRow(2, "牛乳", deletedAt))
+	mock.ExpectExec("DELETE FROM ingredients WHERE deleted_at IS NOT NULL AND deleted_at < \\?").
+		WithArgs(deletedBefore).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	expectEvent(mock, 1, domain.EventActionPurge)
+	expectEvent(mock, 2, domain.EventActionPurge)
+	mock.ExpectCommit()
+
+	purged, err := repo.Purge(domain.WithAllHouseholds(context.Background()), deletedBefore)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), purged)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWithTx_Commit(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewIngredientRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO ingredients").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectEvent(mock, 1, domain.EventActionCreate)
+	expectLock(mock, 2, 1)
+	mock.ExpectExec("UPDATE ingredients SET deleted_at = \\?, version = version \\+ 1, updated_at = updated_at WHERE id = \\? AND version = \\? AND deleted_at IS NULL").
+		WithArgs(sqlmock.AnyArg(), 2, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectEvent(mock, 2, domain.EventActionDelete)
+	mock.ExpectCommit()
+
+	err := repo.WithTx(domain.WithAllHouseholds(context.Background()), func(tx IngredientTx) error {
+		if err := tx.Create(domain.WithAllHouseholds(context.Background()), &domain.Ingredient{Name: "にんじん"}); err != nil {
+			return err
+		}
+		return tx.Delete(domain.WithAllHouseholds(context.Background()), 2, 1)
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWithTx_RollbackOnError(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewIngredientRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO ingredients").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectEvent(mock, 1, domain.EventActionCreate)
+	expectLock(mock, 2, 3)
+	mock.ExpectExec("UPDATE ingredients SET deleted_at = \\?, version = version \\+ 1, updated_at = updated_at WHERE id = \\? AND version = \\? AND deleted_at IS NULL").
+		WithArgs(sqlmock.AnyArg(), 2, 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err := repo.WithTx(domain.WithAllHouseholds(context.Background()), func(tx IngredientTx) error {
+		if err := tx.Create(domain.WithAllHouseholds(context.Background()), &domain.Ingredient{Name: "にんじん"}); err != nil {
+			return err
+		}
+		return tx.Delete(domain.WithAllHouseholds(context.Background()), 2, 1)
+	})
+
+	assert.ErrorIs(t, err, domain.ErrVersionConflict)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWithTx_Savepoint(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewIngredientRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("SAVEPOINT sp_1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO ingredients").
+		WillReturnError(sql.ErrConnDone)
+	mock.ExpectExec("ROLLBACK TO SAVEPOINT sp_1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("SAVEPOINT sp_2").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO ingredients").
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	expectEvent(mock, 2, domain.EventActionCreate)
+	mock.ExpectExec("RELEASE SAVEPOINT sp_2").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	var errs []error
+	err := repo.WithTx(context.Background(), func(tx IngredientTx) error {
+		for _, name := range []string{"にんじん", "玉ねぎ"} {
+			errs = append(errs, tx.Savepoint(context.Background(), func() error {
+				return tx.Create(context.Background(), &domain.Ingredient{Name: name})
+			}))
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Error(t, errs[0])
+	assert.NoError(t, errs[1])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWithTx_BeginError(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewIngredientRepository(db)
+
+	mock.ExpectBegin().WillReturnError(sql.ErrConnDone)
+
+	err := repo.WithTx(context.Background(), func(tx IngredientTx) error {
+		t.Fatal("fn must not run without a transaction")
+		return nil
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to begin transaction")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
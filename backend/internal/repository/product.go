@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+)
+
+// ProductRepository defines the interface for the local barcode product table
+type ProductRepository interface {
+	// GetByBarcode retrieves a single product by its normalized barcode
+	GetByBarcode(ctx context.Context, barcode string) (*domain.Product, error)
+
+	// Save inserts a product or replaces the product with the same barcode
+	Save(ctx context.Context, product *domain.Product) error
+
+	// Import inserts or replaces products in one transaction.
+	// When keepLearned is true, products learned from user entries are left unchanged.
+	Import(ctx context.Context, products []*domain.Product, keepLearned bool) error
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+// productImportChunkSize is the number of products written by one INSERT statement during an import
+const productImportChunkSize = 500
+
+// productUpdateColumns are the columns replaced when a product with the same barcode already exists.
+// source comes last because MySQL evaluates the assignments in order and the others check it.
+var productUpdateColumns = []string{"name", "category", "unit", "shelf_life_days", "updated_at", "source"}
+
+// productRepository is the MySQL implementation of ProductRepository
+type productRepository struct {
+	db *sqlx.DB
+}
+
+// NewProductRepository creates a new instance of ProductRepository
+func NewProductRepository(db *sqlx.DB) ProductRepository {
+	return &productRepository{
+		db: db,
+	}
+}
+
+// GetByBarcode retrieves a single product by its normalized barcode
+func (r *productRepository) GetByBarcode(ctx context.Context, barcode string) (*domain.Product, error) {
+	query := `
+		SELECT barcode, name, category, unit, shelf_life_days, source, created_at, updated_at
+		FROM products
+		WHERE barcode = ?
+	`
+
+	var product domain.Product
+	err := r.db.GetContext(ctx, &product, query, barcode)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("product not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get product by barcode: %w", err)
+	}
+
+	return &product, nil
+}
+
+// Save inserts a product or replaces the product with the same barcode
+func (r *productRepository) Save(ctx context.Context, product *domain.Product) error {
+	now := time.Now()
+	if product.CreatedAt.IsZero() {
+		product.CreatedAt = now
+	}
+	product.UpdatedAt = now
+
+	query, args := buildProductUpsert([]*domain.Product{product}, false)
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to save product: %w", err)
+	}
+
+	return nil
+}
+
+// Import inserts or replaces products in one transaction
+func (r *productRepository) Import(ctx context.Context, products []*domain.Product, keepLearned bool) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	for _, product := range products {
+		product.CreatedAt = now
+		product.UpdatedAt = now
+	}
+
+	for start := 0; start < len(products); start += productImportChunkSize {
+		end := min(start+productImportChunkSize, len(products))
+		query, args := buildProductUpsert(products[start:end], keepLearned)
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to import products: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// buildProductUpsert builds one INSERT statement for the products that replaces existing rows with the same barcode.
+// With keepLearned, rows learned from user entries keep their values.
+func buildProductUpsert(products []*domain.Product, keepLearned bool) (string, []interface{}) {
+	placeholders := make([]string, 0, len(products))
+	args := make([]interface{}, 0, len(products)*8)
+	for _, product := range products {
+		placeholders = append(placeholders, "(?, ?, ?, ?, ?, ?, ?, ?)")
+		args = append(args,
+			product.Barcode,
+			product.Name,
+			product.Category,
+			product.Unit,
+			product.ShelfLifeDays,
+			product.Source,
+			product.CreatedAt,
+			product.UpdatedAt,
+		)
+	}
+
+	assignments := make([]string, 0, len(productUpdateColumns))
+	for _, column := range productUpdateColumns {
+		if keepLearned {
+			assignments = append(assignments, fmt.Sprintf("%s = IF(source = '%s', %s, VALUES(%s))", column, domain.ProductSourceLearned, column, column))
+		} else {
+			assignments = append(assignments, fmt.Sprintf("%s = VALUES(%s)", column, column))
+		}
+	}
+
+	query := `INSERT INTO products (barcode, name, category, unit, shelf_life_days, source, created_at, updated_at)
+		VALUES ` + strings.Join(placeholders, ", ") + `
+		ON DUPLICATE KEY UPDATE ` + strings.Join(assignments, ", ")
+
+	return query, args
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var productColumns = []string{"barcode", "name", "category", "unit", "shelf_life_days", "source", "created_at", "updated_at"}
+
+func TestProductGetByBarcode_Success(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewProductRepository(db)
+
+	now := time.Now()
+	rows := sqlmock.NewRows(productColumns).
+		AddRow("4901234567894", "牛乳", domain.CategoryDairy, "1本", 10, domain.ProductSourceImport, now, now)
+	mock.ExpectQuery("SELECT (.+) FROM products WHERE barcode = \\?").
+		WithArgs("4901234567894").
+		WillReturnRows(rows)
+
+	product, err := repo.GetByBarcode(context.Background(), "4901234567894")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "牛乳", product.Name)
+	assert.Equal(t, 10, *product.ShelfLifeDays)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductGetByBarcode_NotFound(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewProductRepository(db)
+
+	mock.ExpectQuery("SELECT (.+) FROM products WHERE barcode = \\?").
+		WithArgs("4901234567894").
+		WillReturnError(sql.ErrNoRows)
+
+	product, err := repo.GetByBarcode(context.Background(), "4901234567894")
+
+	assert.Nil(t, product)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestProductSave_Success(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewProductRepository(db)
+
+	product := &domain.Product{Barcode: "4901234567894", Name: "牛乳", Category: domain.CategoryDairy, Source: domain.ProductSourceLearned}
+	mock.ExpectExec("INSERT INTO products (.+) ON DUPLICATE KEY UPDATE name = VALUES\\(name\\)").
+		WithArgs("4901234567894", "牛乳", domain.CategoryDairy, "", nil, domain.ProductSourceLearned, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.Save(context.Background(), product)
+
+	assert.NoError(t, err)
+	assert.NotZero(t, product.UpdatedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductImport_KeepLearned(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewProductRepository(db)
+
+	products := []*domain.Product{
+		{Barcode: "4901234567894", Name: "牛乳", Category: domain.CategoryDairy, Source: domain.ProductSourceImport},
+		{Barcode: "49021028", Name: "豆腐", Category: domain.CategoryOther, Source: domain.ProductSourceImport},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO products (.+) VALUES \\(.+\\), \\(.+\\) ON DUPLICATE KEY UPDATE name = IF\\(source = 'learned', name, VALUES\\(name\\)\\)").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	err := repo.Import(context.Background(), products, true)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductImport_RollsBackOnError(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewProductRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO products").WillReturnError(sql.ErrConnDone)
+	mock.ExpectRollback()
+
+	err := repo.Import(context.Background(), []*domain.Product{{Barcode: "49021028", Name: "豆腐"}}, false)
+
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
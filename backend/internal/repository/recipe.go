@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+)
+
+// RecipeFilter narrows down catalog recipe searches.
+// Empty fields are ignored, so a zero filter matches every recipe.
+type RecipeFilter struct {
+	// Query matches part of the recipe name
+	Query string
+
+	// Ingredient matches part of any ingredient name of the recipe
+	Ingredient string
+}
+
+// RecipeRepository defines the interface for recipe catalog data access
+type RecipeRepository interface {
+	// Create inserts a new recipe together with its ingredients and steps
+	Create(ctx context.Context, recipe *domain.Recipe) error
+
+	// GetByID retrieves a single recipe by its ID
+	GetByID(ctx context.Context, id int64) (*domain.Recipe, error)
+
+	// Search retrieves recipes matching the filter, ordered by name
+	Search(ctx context.Context, filter RecipeFilter) ([]*domain.Recipe, error)
+
+	// Delete removes a recipe and its ingredients and steps by its ID
+	Delete(ctx context.Context, id int64) error
+}
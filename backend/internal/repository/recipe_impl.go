@@ -0,0 +1,272 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+// recipeRepository is the MySQL implementation of RecipeRepository
+type recipeRepository struct {
+	db *sqlx.DB
+}
+
+// NewRecipeRepository creates a new instance of RecipeRepository
+func NewRecipeRepository(db *sqlx.DB) RecipeRepository {
+	return &recipeRepository{
+		db: db,
+	}
+}
+
+// recipeIngredientRow represents a row of the recipe_ingredients table
+type recipeIngredientRow struct {
+	RecipeID int64  `db:"recipe_id"`
+	Name     string `db:"name"`
+	Quantity string `db:"quantity"`
+	RawText  string `db:"raw_text"`
+}
+
+// recipeStepRow represents a row of the recipe_steps table
+type recipeStepRow struct {
+	RecipeID int64  `db:"recipe_id"`
+	Text     string `db:"text"`
+}
+
+// Create inserts a new recipe together with its ingredients and steps
+func (r *recipeRepository) Create(ctx context.Context, recipe *domain.Recipe) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	recipe.CreatedAt = now
+	recipe.UpdatedAt = now
+	recipe.OwnerID = domain.OwnerID(ctx)
+	recipe.HouseholdID = domain.HouseholdID(ctx)
+
+	result, err := tx.ExecContext(
+		ctx,
+		`INSERT INTO recipes (name, description, yield, prep_time_minutes, cook_time_minutes, total_time_minutes, source_url, created_at, updated_at, owner_id, household_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		recipe.Name,
+		recipe.Description,
+		recipe.Yield,
+		recipe.PrepTimeMinutes,
+		recipe.CookTimeMinutes,
+		recipe.TotalTimeMinutes,
+		recipe.SourceURL,
+		recipe.CreatedAt,
+		recipe.UpdatedAt,
+		recipe.OwnerID,
+		recipe.HouseholdID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create recipe: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	for i, ing := range recipe.Ingredients {
+		_, err := tx.ExecContext(
+			ctx,
+			`INSERT INTO recipe_ingredients (recipe_id, position, name, quantity, raw_text) VALUES (?, ?, ?, ?, ?)`,
+			id, i, ing.Name, ing.Quantity, ing.RawText,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create recipe ingredient: %w", err)
+		}
+	}
+
+	for i, step := range recipe.Steps {
+		_, err := tx.ExecContext(
+			ctx,
+			`INSERT INTO recipe_steps (recipe_id, position, text) VALUES (?, ?, ?)`,
+			id, i, step,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create recipe step: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit recipe: %w", err)
+	}
+
+	recipe.ID = id
+	return nil
+}
+
+// GetByID retrieves a single recipe by its ID
+func (r *recipeRepository) GetByID(ctx context.Context, id int64) (*domain.Recipe, error) {
+	query := `
+		SELECT id, name, description, yield, prep_time_minutes, cook_time_minutes, total_time_minutes, source_url, created_at, updated_at, owner_id, household_id
+		FROM recipes
+		WHERE id = ?`
+	scope, scopeArgs, err := householdCondition(ctx, "household_id")
+	if err != nil {
+		return nil, err
+	}
+	query += scope
+
+	var recipe domain.Recipe
+	err = r.db.GetContext(ctx, &recipe, query, append([]interface{}{id}, scopeArgs...)...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("recipe not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get recipe by id: %w", err)
+	}
+
+	if err := r.loadDetails(ctx, []*domain.Recipe{&recipe}); err != nil {
+		return nil, err
+	}
+
+	return &recipe, nil
+}
+
+// Search retrieves recipes matching the filter, ordered by name
+func (r *recipeRepository) Search(ctx context.Context, filter RecipeFilter) ([]*domain.Recipe, error) {
+	query := `
+		SELECT id, name, description, yield, prep_time_minutes, cook_time_minutes, total_time_minutes, source_url, created_at, updated_at, owner_id, household_id
+		FROM recipes r
+		WHERE 1 = 1`
+	scope, args, err := householdCondition(ctx, "r.household_id")
+	if err != nil {
+		return nil, err
+	}
+	query += scope
+
+	if filter.Query != "" {
+		query += ` AND r.name LIKE ?`
+		args = append(args, "%"+escapeLike(filter.Query)+"%")
+	}
+
+	if filter.Ingredient != "" {
+		query += ` AND EXISTS (
+			SELECT 1 FROM recipe_ingredients ri
+			WHERE ri.recipe_id = r.id AND ri.name LIKE ?
+		)`
+		args = append(args, "%"+escapeLike(filter.Ingredient)+"%")
+	}
+
+	query += ` ORDER BY r.name, r.id`
+
+	var recipes []*domain.Recipe
+	if err := r.db.SelectContext(ctx, &recipes, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to search recipes: %w", err)
+	}
+
+	// Return empty slice instead of nil if no recipes found
+	if recipes == nil {
+		return []*domain.Recipe{}, nil
+	}
+
+	if err := r.loadDetails(ctx, recipes); err != nil {
+		return nil, err
+	}
+
+	return recipes, nil
+}
+
+// Delete removes a recipe and its ingredients and steps by its ID
+func (r *recipeRepository) Delete(ctx context.Context, id int64) error {
+	scope, scopeArgs, err := householdCondition(ctx, "household_id")
+	if err != nil {
+		return err
+	}
+	result, err := r.db.ExecContext(ctx, `DELETE FROM recipes WHERE id = ?`+scope, append([]interface{}{id}, scopeArgs...)...)
+	if err != nil {
+		return fmt.Errorf("failed to delete recipe: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("recipe not found: %w", sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+// loadDetails fills in the ingredients and steps of the given recipes
+func (r *recipeRepository) loadDetails(ctx context.Context, recipes []*domain.Recipe) error {
+	if len(recipes) == 0 {
+		return nil
+	}
+
+	byID := make(map[int64]*domain.Recipe, len(recipes))
+	ids := make([]int64, 0, len(recipes))
+	for _, recipe := range recipes {
+		recipe.Ingredients = []domain.RecipeIngredient{}
+		recipe.Steps = []string{}
+		byID[recipe.ID] = recipe
+		ids = append(ids, recipe.ID)
+	}
+
+	query, args, err := sqlx.In(`
+		SELECT recipe_id, name, quantity, raw_text
+		FROM recipe_ingredients
+		WHERE recipe_id IN (?)
+		ORDER BY recipe_id, position
+	`, ids)
+	if err != nil {
+		return fmt.Errorf("failed to build recipe ingredients query: %w", err)
+	}
+
+	var ingredientRows []recipeIngredientRow
+	if err := r.db.SelectContext(ctx, &ingredientRows, r.db.Rebind(query), args...); err != nil {
+		return fmt.Errorf("failed to get recipe ingredients: %w", err)
+	}
+
+	for _, row := range ingredientRows {
+		if recipe, ok := byID[row.RecipeID]; ok {
+			recipe.Ingredients = append(recipe.Ingredients, domain.RecipeIngredient{
+				Name:     row.Name,
+				Quantity: row.Quantity,
+				RawText:  row.RawText,
+			})
+		}
+	}
+
+	query, args, err = sqlx.In(`
+		SELECT recipe_id, text
+		FROM recipe_steps
+		WHERE recipe_id IN (?)
+		ORDER BY recipe_id, position
+	`, ids)
+	if err != nil {
+		return fmt.Errorf("failed to build recipe steps query: %w", err)
+	}
+
+	var stepRows []recipeStepRow
+	if err := r.db.SelectContext(ctx, &stepRows, r.db.Rebind(query), args...); err != nil {
+		return fmt.Errorf("failed to get recipe steps: %w", err)
+	}
+
+	for _, row := range stepRows {
+		if recipe, ok := byID[row.RecipeID]; ok {
+			recipe.Steps = append(recipe.Steps, row.Text)
+		}
+	}
+
+	return nil
+}
+
+// escapeLike escapes the wildcard characters of a LIKE pattern
+func escapeLike(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return replacer.Replace(s)
+}
@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var recipeColumns = []string{"id", "name", "description", "yield", "prep_time_minutes", "cook_time_minutes", "total_time_minutes", "source_url", "created_at", "updated_at"}
+
+func TestRecipeCreate_Success(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewRecipeRepository(db)
+
+	cookTime := 30
+	recipe := &domain.Recipe{
+		Name:            "肉じゃが",
+		Yield:           "4人分",
+		CookTimeMinutes: &cookTime,
+		Ingredients: []domain.RecipeIngredient{
+			{Name: "じゃがいも", Quantity: "3個", RawText: "じゃがいも 3個"},
+		},
+		Steps: []string{"切る", "煮る"},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO recipes").
+		WithArgs("肉じゃが", "", "4人分", nil, &cookTime, nil, "", sqlmock.AnyArg(), sqlmock.AnyArg(), nil, nil).
+		WillReturnResult(sqlmock.NewResult(5, 1))
+	mock.ExpectExec("INSERT INTO recipe_ingredients").
+		WithArgs(int64(5), 0, "じゃがいも", "3個", "じゃがいも 3個").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO recipe_steps").
+		WithArgs(int64(5), 0, "切る").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO recipe_steps").
+		WithArgs(int64(5), 1, "煮る").
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectCommit()
+
+	err := repo.Create(context.Background(), recipe)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), recipe.ID)
+	assert.NotZero(t, recipe.CreatedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRecipeCreate_RollsBackOnError(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewRecipeRepository(db)
+
+	recipe := &domain.Recipe{
+		Name:        "肉じゃが",
+		Ingredients: []domain.RecipeIngredient{{Name: "じゃがいも"}},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO recipes").WillReturnResult(sqlmock.NewResult(5, 1))
+	mock.ExpectExec("INSERT INTO recipe_ingredients").WillReturnError(sql.ErrConnDone)
+	mock.ExpectRollback()
+
+	err := repo.Create(context.Background(), recipe)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to create recipe ingredient")
+	assert.Zero(t, recipe.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRecipeGetByID_Success(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewRecipeRepository(db)
+
+	now := time.Now()
+	mock.ExpectQuery("SELECT (.+) FROM recipes WHERE id = ?").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(recipeColumns).
+			AddRow(1, "肉じゃが", "", "4人分", nil, 30, nil, "", now, now))
+	mock.ExpectQuery("SELECT (.+) FROM recipe_ingredients WHERE recipe_id IN").
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"recipe_id", "name", "quantity", "raw_text"}).
+			AddRow(1, "じゃがいも", "3個", "じゃがいも 3個").
+			AddRow(1, "豚肉", "200g", "豚肉 200g"))
+	mock.ExpectQuery("SELECT (.+) FROM recipe_steps WHERE recipe_id IN").
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"recipe_id", "text"}).
+			AddRow(1, "切る"))
+
+	recipe, err := repo.GetByID(domain.WithAllHouseholds(context.Background()), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "肉じゃが", recipe.Name)
+	assert.Len(t, recipe.Ingredients, 2)
+	assert.Equal(t, []string{"切る"}, recipe.Steps)
+	assert.Equal(t, 30, *recipe.CookTimeMinutes)
+	assert.Nil(t, recipe.PrepTimeMinutes)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRecipeGetByID_NotFound(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewRecipeRepository(db)
+
+	mock.ExpectQuery("SELECT (.+) FROM recipes WHERE id = ?").
+		WithArgs(999).
+		WillReturnError(sql.ErrNoRows)
+
+	recipe, err := repo.GetByID(domain.WithAllHouseholds(context.Background()), 999)
+
+	assert.Nil(t, recipe)
+	assert.True(t, errors.Is(err, sql.ErrNoRows))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRecipeSearch_WithFilters(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewRecipeRepository(db)
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT (.+) FROM recipes r WHERE 1 = 1 AND r.name LIKE \? AND EXISTS (.+) ORDER BY r.name`).
+		WithArgs("%丼%", `%100\%卵%`).
+		WillReturnRows(sqlmock.NewRows(recipeColumns).
+			AddRow(2, "親子丼", "", "", nil, nil, nil, "", now, now))
+	mock.ExpectQuery("SELECT (.+) FROM recipe_ingredients").
+		WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"recipe_id", "name", "quantity", "raw_text"}))
+	mock.ExpectQuery("SELECT (.+) FROM recipe_steps").
+		WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"recipe_id", "text"}))
+
+	recipes, err := repo.Search(domain.WithAllHouseholds(context.Background()), RecipeFilter{Query: "丼", Ingredient: "100%卵"})
+
+	assert.NoError(t, err)
+	assert.Len(t, recipes, 1)
+	assert.NotNil(t, recipes[0].Ingredients)
+	assert.NotNil(t, recipes[0].Steps)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRecipeSearch_EmptyResult(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewRecipeRepository(db)
+
+	mock.ExpectQuery("SELECT (.+) FROM recipes r").
+		WillReturnRows(sqlmock.NewRows(recipeColumns))
+
+	recipes, err := repo.Search(domain.WithAllHouseholds(context.Background()), RecipeFilter{})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, recipes)
+	assert.Len(t, recipes, 0)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRecipeDelete_NotFound(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewRecipeRepository(db)
+
+	mock.ExpectExec("DELETE FROM recipes WHERE id = ?").
+		WithArgs(999).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := repo.Delete(domain.WithAllHouseholds(context.Background()), 999)
+
+	assert.True(t, errors.Is(err, sql.ErrNoRows))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
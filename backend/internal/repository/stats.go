@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+)
+
+// Spend report groupings
+const (
+	// SpendGroupWeek totals the purchases of each week, starting on Monday
+	SpendGroupWeek = "week"
+
+	// SpendGroupCategory totals the purchases of each ingredient category
+	SpendGroupCategory = "category"
+
+	// SpendGroupStore totals the purchases made at each store
+	SpendGroupStore = "store"
+)
+
+// IsValidSpendGroup reports whether g is a known spend report grouping
+func IsValidSpendGroup(g string) bool {
+	return g == SpendGroupWeek || g == SpendGroupCategory || g == SpendGroupStore
+}
+
+// StatsFilter limits the statistics to records made within a period. Nil bounds are open.
+type StatsFilter struct {
+	// Since matches records made at or after the given time
+	Since *time.Time
+
+	// Until matches records made strictly before the given time
+	Until *time.Time
+}
+
+// StatsRepository defines the interface for aggregating the recorded consumptions and recipe suggestions
+type StatsRepository interface {
+	// UsageByCategoryMonth counts the consumed and wasted uses per month and category, oldest month first
+	UsageByCategoryMonth(ctx context.Context, filter StatsFilter) ([]*domain.CategoryUsage, error)
+
+	// PurchaseToUse averages the days from purchase to being cooked or eaten per category
+	PurchaseToUse(ctx context.Context, filter StatsFilter) ([]*domain.CategoryPurchaseToUse, error)
+
+	// MostWasted ranks ingredients by how often they were thrown away, returning at most limit entries
+	MostWasted(ctx context.Context, filter StatsFilter, limit int) ([]*domain.WastedIngredient, error)
+
+	// SuggestionCooking counts the recipe suggestions made in the period and how many of them were cooked
+	SuggestionCooking(ctx context.Context, filter StatsFilter) (*domain.SuggestionStats, error)
+
+	// Spend totals the prices of the purchases made in the period per SpendGroup* key, largest total first
+	// except for weeks, which are listed oldest first
+	Spend(ctx context.Context, filter StatsFilter, groupBy string) ([]*domain.SpendTotal, error)
+
+	// RecipeCosts totals the estimated cost of the ingredients cooked for each recipe, most recently cooked first
+	RecipeCosts(ctx context.Context, filter StatsFilter) ([]*domain.RecipeCost, error)
+}
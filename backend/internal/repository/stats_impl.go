@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+// statsRepository is the MySQL implementation of StatsRepository
+type statsRepository struct {
+	db *sqlx.DB
+}
+
+// NewStatsRepository creates a new instance of StatsRepository
+func NewStatsRepository(db *sqlx.DB) StatsRepository {
+	return &statsRepository{
+		db: db,
+	}
+}
+
+// UsageByCategoryMonth counts the consumed and wasted uses per month and category, oldest month first
+func (r *statsRepository) UsageByCategoryMonth(ctx context.Context, filter StatsFilter) ([]*domain.CategoryUsage, error) {
+	period, periodArgs, err := statsConditions(ctx, filter, "household_id", "created_at")
+	if err != nil {
+		return nil, err
+	}
+	args := append([]interface{}{domain.UsedReasons, domain.WasteReasons}, periodArgs...)
+
+	query, args, err := sqlx.In(`
+		SELECT DATE_FORMAT(created_at, '%Y-%m') AS month, category,
+			SUM(CASE WHEN reason IN (?) THEN 1 ELSE 0 END) AS consumed,
+			SUM(CASE WHEN reason IN (?) THEN 1 ELSE 0 END) AS wasted
+		FROM ingredient_consumptions
+		WHERE 1 = 1`+period+`
+		GROUP BY month, category
+		ORDER BY month, category
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build usage query: %w", err)
+	}
+
+	var usage []*domain.CategoryUsage
+	if err := r.db.SelectContext(ctx, &usage, r.db.Rebind(query), args...); err != nil {
+		return nil, fmt.Errorf("failed to aggregate usage: %w", err)
+	}
+
+	return usage, nil
+}
+
+// PurchaseToUse averages the days from purchase to being cooked or eaten per category
+func (r *statsRepository) PurchaseToUse(ctx context.Context, filter StatsFilter) ([]*domain.CategoryPurchaseToUse, error) {
+	period, periodArgs, err := statsConditions(ctx, filter, "household_id", "created_at")
+	if err != nil {
+		return nil, err
+	}
+	args := append([]interface{}{domain.UsedReasons}, periodArgs...)
+
+	query, args, err := sqlx.In(`
+		SELECT category, AVG(DATEDIFF(created_at, purchase_date)) AS average_days, COUNT(*) AS count
+		FROM ingredient_consumptions
+		WHERE purchase_date IS NOT NULL AND reason IN (?)`+period+`
+		GROUP BY category
+		ORDER BY category
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build purchase to use query: %w", err)
+	}
+
+	var durations []*domain.CategoryPurchaseToUse
+	if err := r.db.SelectContext(ctx, &durations, r.db.Rebind(query), args...); err != nil {
+		return nil, fmt.Errorf("failed to aggregate purchase to use: %w", err)
+	}
+
+	return durations, nil
+}
+
+// MostWasted ranks ingredients by how often they were thrown away, returning at most limit entries
+func (r *statsRepository) MostWasted(ctx context.Context, filter StatsFilter, limit int) ([]*domain.WastedIngredient, error) {
+	period, periodArgs, err := statsConditions(ctx, filter, "household_id", "created_at")
+	if err != nil {
+		return nil, err
+	}
+	args := append([]interface{}{domain.WasteReasons}, periodArgs...)
+	args = append(args, limit)
+
+	query, args, err := sqlx.In(`
+		SELECT canonical_name, MAX(name) AS name, COUNT(*) AS count, MAX(created_at) AS last_wasted_at
+		FROM ingredient_consumptions
+		WHERE reason IN (?)`+period+`
+		GROUP BY canonical_name
+		ORDER BY count DESC, last_wasted_at DESC
+		LIMIT ?
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build most wasted query: %w", err)
+	}
+
+	var wasted []*domain.WastedIngredient
+	if err := r.db.SelectContext(ctx, &wasted, r.db.Rebind(query), args...); err != nil {
+		return nil, fmt.Errorf("failed to aggregate wasted ingredients: %w", err)
+	}
+
+	return wasted, nil
+}
+
+// SuggestionCooking counts the recipe suggestions made in the period and how many of them were cooked
+func (r *statsRepository) SuggestionCooking(ctx context.Context, filter StatsFilter) (*domain.SuggestionStats, error) {
+	period, periodArgs, err := statsConditions(ctx, filter, "s.household_id", "s.created_at")
+	if err != nil {
+		return nil, err
+	}
+	args := append([]interface{}{domain.ConsumptionReasonCooked}, periodArgs...)
+
+	// Only cooking in the household the suggestion was made for counts
+	query := `
+		SELECT COUNT(DISTINCT s.id) AS suggested, COUNT(DISTINCT c.suggestion_id) AS cooked
+		FROM recipe_suggestions s
+		LEFT JOIN ingredient_consumptions c ON c.suggestion_id = s.id AND c.household_id = s.household_id AND c.reason = ?
+		WHERE 1 = 1` + period
+
+	var stats domain.SuggestionStats
+	if err := r.db.GetContext(ctx, &stats, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to aggregate recipe suggestions: %w", err)
+	}
+
+	return &stats, nil
+}
+
+// Spend totals the prices of the purchases made in the period per SpendGroup* key
+func (r *statsRepository) Spend(ctx context.Context, filter StatsFilter, groupBy string) ([]*domain.SpendTotal, error) {
+	key, order := "", "total DESC, spend_key"
+	switch groupBy {
+	case SpendGroupWeek:
+		key, order = "DATE_FORMAT(DATE_SUB(purchased_on, INTERVAL WEEKDAY(purchased_on) DAY), '%Y-%m-%d')", "spend_key"
+	case SpendGroupCategory:
+		key = "category"
+	case SpendGroupStore:
+		key = "store"
+	default:
+		return nil, fmt.Errorf("%w: unknown spend grouping %q", domain.ErrInvalidInput, groupBy)
+	}
+
+	period, args, err := statsConditions(ctx, filter, "household_id", "purchased_on")
+	if err != nil {
+		return nil, err
+	}
+	query := `
+		SELECT ` + key + ` AS spend_key, SUM(price) AS total, COUNT(*) AS count
+		FROM ingredient_purchases
+		WHERE 1 = 1` + period + `
+		GROUP BY spend_key
+		ORDER BY ` + order
+
+	var totals []*domain.SpendTotal
+	if err := r.db.SelectContext(ctx, &totals, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to aggregate spend: %w", err)
+	}
+
+	return totals, nil
+}
+
+// RecipeCosts totals the estimated cost of the ingredients cooked for each recipe, most recently cooked first
+func (r *statsRepository) RecipeCosts(ctx context.Context, filter StatsFilter) ([]*domain.RecipeCost, error) {
+	period, periodArgs, err := statsConditions(ctx, filter, "household_id", "created_at")
+	if err != nil {
+		return nil, err
+	}
+	args := append([]interface{}{domain.ConsumptionReasonCooked}, periodArgs...)
+
+	query := `
+		SELECT recipe, COUNT(DISTINCT DATE(created_at)) AS times_cooked, COALESCE(SUM(cost), 0) AS total_cost,
+			SUM(CASE WHEN cost IS NULL THEN 1 ELSE 0 END) AS unpriced_items, MAX(created_at) AS last_cooked_at
+		FROM ingredient_consumptions
+		WHERE reason = ? AND recipe <> ''` + period + `
+		GROUP BY recipe
+		ORDER BY last_cooked_at DESC, recipe`
+
+	var costs []*domain.RecipeCost
+	if err := r.db.SelectContext(ctx, &costs, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to aggregate recipe costs: %w", err)
+	}
+
+	return costs, nil
+}
+
+// statsConditions returns the SQL conditions and arguments limiting the records to the household in ctx
+// through householdColumn, and column to the filter period
+func statsConditions(ctx context.Context, filter StatsFilter, householdColumn string, column string) (string, []interface{}, error) {
+	conditions, args, err := householdCondition(ctx, householdColumn)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if filter.Since != nil {
+		conditions += ` AND ` + column + ` >= ?`
+		args = append(args, *filter.Since)
+	}
+
+	if filter.Until != nil {
+		conditions += ` AND ` + column + ` < ?`
+		args = append(args, *filter.Until)
+	}
+
+	return conditions, args, nil
+}
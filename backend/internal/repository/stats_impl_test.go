@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatsUsageByCategoryMonth_Success(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewStatsRepository(db)
+
+	since := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"month", "category", "consumed", "wasted"}).
+		AddRow("2026-09", domain.CategoryVegetable, 4, 1).
+		AddRow("2026-10", domain.CategoryMeat, 2, 0)
+	mock.ExpectQuery("SELECT DATE_FORMAT\\(created_at, '%Y-%m'\\) AS month, category, (.+) FROM ingredient_consumptions WHERE 1 = 1 AND created_at >= \\? GROUP BY month, category").
+		WithArgs(domain.ConsumptionReasonCooked, domain.ConsumptionReasonEaten, domain.ConsumptionReasonDiscarded, domain.ConsumptionReasonExpired, since).
+		WillReturnRows(rows)
+
+	usage, err := repo.UsageByCategoryMonth(domain.WithAllHouseholds(context.Background()), StatsFilter{Since: &since})
+
+	assert.NoError(t, err)
+	assert.Len(t, usage, 2)
+	assert.Equal(t, "2026-09", usage[0].Month)
+	assert.Equal(t, 1, usage[0].Wasted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStatsPurchaseToUse_Success(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewStatsRepository(db)
+
+	rows := sqlmock.NewRows([]string{"category", "average_days", "count"}).
+		AddRow(domain.CategoryMeat, 2.5, 4)
+	mock.ExpectQuery("SELECT category, AVG\\(DATEDIFF\\(created_at, purchase_date\\)\\) AS average_days, (.+) WHERE purchase_date IS NOT NULL AND reason IN \\(\\?, \\?\\)").
+		WithArgs(domain.ConsumptionReasonCooked, domain.ConsumptionReasonEaten).
+		WillReturnRows(rows)
+
+	durations, err := repo.PurchaseToUse(domain.WithAllHouseholds(context.Background()), StatsFilter{})
+
+	assert.NoError(t, err)
+	assert.Len(t, durations, 1)
+	assert.Equal(t, 2.5, durations[0].AverageDays)
+	assert.Equal(t, 4, durations[0].Count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStatsMostWasted_Success(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewStatsRepository(db)
+
+	until := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"canonical_name", "name", "count", "last_wasted_at"}).
+		AddRow("もやし", "もやし", 3, now)
+	mock.ExpectQuery("SELECT canonical_name, (.+) WHERE reason IN \\(\\?, \\?\\) AND created_at < \\? GROUP BY canonical_name (.+) LIMIT \\?").
+		WithArgs(domain.ConsumptionReasonDiscarded, domain.ConsumptionReasonExpired, until, 5).
+		WillReturnRows(rows)
+
+	wasted, err := repo.MostWasted(domain.WithAllHouseholds(context.Background()), StatsFilter{Until: &until}, 5)
+
+	assert.NoError(t, err)
+	assert.Len(t, wasted, 1)
+	assert.Equal(t, 3, wasted[0].Count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStatsSuggestionCooking_Success(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewStatsRepository(db)
+
+	rows := sqlmock.NewRows([]string{"suggested", "cooked"}).AddRow(10, 3)
+	mock.ExpectQuery("SELECT COUNT\\(DISTINCT s.id\\) AS suggested, COUNT\\(DISTINCT c.suggestion_id\\) AS cooked FROM recipe_suggestions s LEFT JOIN ingredient_consumptions c ON c.suggestion_id = s.id AND c.household_id = s.household_id").
+		WithArgs(domain.ConsumptionReasonCooked).
+		WillReturnRows(rows)
+
+	stats, err := repo.SuggestionCooking(domain.WithAllHouseholds(context.Background()), StatsFilter{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 10, stats.Suggested)
+	assert.Equal(t, 3, stats.Cooked)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStatsSuggestionCooking_Error(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewStatsRepository(db)
+
+	mock.ExpectQuery("SELECT COUNT").
+		WillReturnError(errors.New("database error"))
+
+	stats, err := repo.SuggestionCooking(domain.WithAllHouseholds(context.Background()), StatsFilter{})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to aggregate recipe suggestions")
+	assert.Nil(t, stats)
+}
+
+func TestStatsSpend_ByWeek(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewStatsRepository(db)
+
+	since := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"spend_key", "total", "count"}).
+		AddRow("2026-09-28", 1200, 4).
+		AddRow("2026-10-05", 800, 2)
+	mock.ExpectQuery("SELECT DATE_FORMAT\\(DATE_SUB\\(purchased_on, INTERVAL WEEKDAY\\(purchased_on\\) DAY\\), '%Y-%m-%d'\\) AS spend_key, (.+) FROM ingredient_purchases WHERE 1 = 1 AND purchased_on >= \\? GROUP BY spend_key ORDER BY spend_key").
+		WithArgs(since).
+		WillReturnRows(rows)
+
+	totals, err := repo.Spend(domain.WithAllHouseholds(context.Background()), StatsFilter{Since: &since}, SpendGroupWeek)
+
+	assert.NoError(t, err)
+	assert.Len(t, totals, 2)
+	assert.Equal(t, "2026-09-28", totals[0].Key)
+	assert.Equal(t, 1200, totals[0].Total)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStatsSpend_ByStore(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewStatsRepository(db)
+
+	rows := sqlmock.NewRows([]string{"spend_key", "total", "count"}).AddRow("スーパーA", 1500, 3)
+	mock.ExpectQuery("SELECT store AS spend_key, (.+) GROUP BY spend_key ORDER BY total DESC, spend_key").
+		WillReturnRows(rows)
+
+	totals, err := repo.Spend(domain.WithAllHouseholds(context.Background()), StatsFilter{}, SpendGroupStore)
+
+	assert.NoError(t, err)
+	assert.Len(t, totals, 1)
+	assert.Equal(t, "スーパーA", totals[0].Key)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStatsSpend_UnknownGroup(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewStatsRepository(db)
+
+	totals, err := repo.Spend(context.Background(), StatsFilter{}, "month")
+
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	assert.Nil(t, totals)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStatsRecipeCosts_Success(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewStatsRepository(db)
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"recipe", "times_cooked", "total_cost", "unpriced_items", "last_cooked_at"}).
+		AddRow("豚汁", 2, 900, 1, now)
+	mock.ExpectQuery("SELECT recipe, COUNT\\(DISTINCT DATE\\(created_at\\)\\) AS times_cooked, (.+) FROM ingredient_consumptions WHERE reason = \\? AND recipe <> '' GROUP BY recipe").
+		WithArgs(domain.ConsumptionReasonCooked).
+		WillReturnRows(rows)
+
+	costs, err := repo.RecipeCosts(domain.WithAllHouseholds(context.Background()), StatsFilter{})
+
+	assert.NoError(t, err)
+	assert.Len(t, costs, 1)
+	assert.Equal(t, 900, costs[0].TotalCost)
+	assert.Equal(t, 1, costs[0].UnpricedItems)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
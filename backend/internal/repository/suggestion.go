@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+)
+
+// SuggestionRepository defines the interface for the log of recipe suggestions shown to the household
+type SuggestionRepository interface {
+	// Record logs every suggestion of the response and sets their IDs
+	Record(ctx context.Context, response *domain.RecipeResponse) error
+	// Exists reports whether the suggestion was shown to the household in ctx
+	Exists(ctx context.Context, id int64) (bool, error)
+}
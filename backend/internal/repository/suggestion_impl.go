@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+// suggestionRepository is the MySQL implementation of SuggestionRepository
+type suggestionRepository struct {
+	db *sqlx.DB
+}
+
+// NewSuggestionRepository creates a new instance of SuggestionRepository
+func NewSuggestionRepository(db *sqlx.DB) SuggestionRepository {
+	return &suggestionRepository{
+		db: db,
+	}
+}
+
+// Record logs every suggestion of the response in one transaction and sets their IDs
+func (r *suggestionRepository) Record(ctx context.Context, response *domain.RecipeResponse) error {
+	query := `
+		INSERT INTO recipe_suggestions (name, source, created_at, household_id)
+		VALUES (?, ?, ?, ?)
+	`
+
+	if len(response.Suggestions) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	householdID := domain.HouseholdID(ctx)
+	for i := range response.Suggestions {
+		result, err := tx.ExecContext(ctx, query, response.Suggestions[i].Name, response.Source, now, householdID)
+		if err != nil {
+			return fmt.Errorf("failed to record recipe suggestion: %w", err)
+		}
+
+		id, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("failed to get last insert id: %w", err)
+		}
+		response.Suggestions[i].ID = id
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Exists reports whether the suggestion was shown to the household in ctx
+func (r *suggestionRepository) Exists(ctx context.Context, id int64) (bool, error) {
+	scope, scopeArgs, err := householdCondition(ctx, "household_id")
+	if err != nil {
+		return false, err
+	}
+	query := `SELECT EXISTS(SELECT 1 FROM recipe_suggestions WHERE id = ?` + scope + `)`
+
+	var exists bool
+	if err := r.db.GetContext(ctx, &exists, query, append([]interface{}{id}, scopeArgs...)...); err != nil {
+		return false, fmt.Errorf("failed to check recipe suggestion: %w", err)
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuggestionRecord_Success(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewSuggestionRepository(db)
+
+	response := &domain.RecipeResponse{
+		Suggestions: []domain.RecipeSuggestion{{Name: "豚汁"}, {Name: "肉じゃが"}},
+		Source:      domain.RecipeSourceLLM,
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO recipe_suggestions").
+		WithArgs("豚汁", domain.RecipeSourceLLM, sqlmock.AnyArg(), nil).
+		WillReturnResult(sqlmock.NewResult(5, 1))
+	mock.ExpectExec("INSERT INTO recipe_suggestions").
+		WithArgs("肉じゃが", domain.RecipeSourceLLM, sqlmock.AnyArg(), nil).
+		WillReturnResult(sqlmock.NewResult(6, 1))
+	mock.ExpectCommit()
+
+	err := repo.Record(context.Background(), response)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), response.Suggestions[0].ID)
+	assert.Equal(t, int64(6), response.Suggestions[1].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSuggestionRecord_Empty(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewSuggestionRepository(db)
+
+	err := repo.Record(context.Background(), &domain.RecipeResponse{})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSuggestionRecord_Error(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewSuggestionRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO recipe_suggestions").
+		WillReturnError(errors.New("database error"))
+	mock.ExpectRollback()
+
+	err := repo.Record(context.Background(), &domain.RecipeResponse{
+		Suggestions: []domain.RecipeSuggestion{{Name: "豚汁"}},
+		Source:      domain.RecipeSourceCatalog,
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to record recipe suggestion")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSuggestionExists_OtherHousehold(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewSuggestionRepository(db)
+
+	ctx := domain.WithHousehold(context.Background(), &domain.Household{ID: 2, Role: domain.HouseholdRoleMember})
+	mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM recipe_suggestions WHERE id = \\? AND household_id = \\?\\)").
+		WithArgs(int64(5), int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+	exists, err := repo.Exists(ctx, 5)
+
+	assert.NoError(t, err)
+	assert.False(t, exists)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSuggestionExists_NoHousehold(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewSuggestionRepository(db)
+
+	_, err := repo.Exists(context.Background(), 5)
+
+	assert.ErrorIs(t, err, domain.ErrNoHousehold)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+)
+
+// SynonymRepository defines the interface for ingredient synonym dictionary access
+type SynonymRepository interface {
+	// GetAll retrieves all dictionary entries
+	GetAll(ctx context.Context) ([]domain.DictionaryEntry, error)
+
+	// GetByCanonical retrieves a single dictionary entry by its canonical name
+	GetByCanonical(ctx context.Context, canonical string) (*domain.DictionaryEntry, error)
+
+	// Save inserts a dictionary entry or replaces the entry with the same canonical name
+	Save(ctx context.Context, entry *domain.DictionaryEntry) error
+
+	// Delete removes a dictionary entry by its canonical name
+	Delete(ctx context.Context, canonical string) error
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+)
+
+// fileSynonymRepository is the JSON file implementation of SynonymRepository
+type fileSynonymRepository struct {
+	mu      sync.RWMutex
+	path    string
+	entries []domain.DictionaryEntry
+}
+
+// NewFileSynonymRepository creates a new instance of SynonymRepository backed by a JSON file.
+// When the file does not exist it is created from seed. An empty path keeps the
+// dictionary in memory only, so changes are lost on restart.
+func NewFileSynonymRepository(path string, seed []domain.DictionaryEntry) (SynonymRepository, error) {
+	r := &fileSynonymRepository{
+		path:    path,
+		entries: append([]domain.DictionaryEntry(nil), seed...),
+	}
+
+	if path == "" {
+		return r, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		if err := r.persist(); err != nil {
+			return nil, err
+		}
+		return r, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read synonym dictionary: %w", err)
+	}
+
+	var dict domain.Dictionary
+	if err := json.Unmarshal(data, &dict); err != nil {
+		return nil, fmt.Errorf("failed to parse synonym dictionary: %w", err)
+	}
+	r.entries = dict.Entries
+
+	return r, nil
+}
+
+// GetAll retrieves all dictionary entries
+func (r *fileSynonymRepository) GetAll(ctx context.Context) ([]domain.DictionaryEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := make([]domain.DictionaryEntry, len(r.entries))
+	copy(entries, r.entries)
+	return entries, nil
+}
+
+// GetByCanonical retrieves a single dictionary entry by its canonical name
+func (r *fileSynonymRepository) GetByCanonical(ctx context.Context, canonical string) (*domain.DictionaryEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	i := r.indexOf(canonical)
+	if i < 0 {
+		return nil, fmt.Errorf("synonym not found: %w", sql.ErrNoRows)
+	}
+
+	entry := r.entries[i]
+	return &entry, nil
+}
+
+// Save inserts a dictionary entry or replaces the entry with the same canonical name
+func (r *fileSynonymRepository) Save(ctx context.Context, entry *domain.DictionaryEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous := r.entries
+	r.entries = append([]domain.DictionaryEntry(nil), r.entries...)
+	if i := r.indexOf(entry.Canonical); i >= 0 {
+		r.entries[i] = *entry
+	} else {
+		r.entries = append(r.entries, *entry)
+	}
+
+	if err := r.persist(); err != nil {
+		r.entries = previous
+		return err
+	}
+	return nil
+}
+
+// Delete removes a dictionary entry by its canonical name
+func (r *fileSynonymRepository) Delete(ctx context.Context, canonical string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.indexOf(canonical)
+	if i < 0 {
+		return fmt.Errorf("synonym not found: %w", sql.ErrNoRows)
+	}
+
+	previous := r.entries
+	r.entries = append(append([]domain.DictionaryEntry(nil), r.entries[:i]...), r.entries[i+1:]...)
+
+	if err := r.persist(); err != nil {
+		r.entries = previous
+		return err
+	}
+	return nil
+}
+
+// indexOf returns the position of the entry with the given canonical name, or -1
+func (r *fileSynonymRepository) indexOf(canonical string) int {
+	for i, entry := range r.entries {
+		if entry.Canonical == canonical {
+			return i
+		}
+	}
+	return -1
+}
+
+// persist writes the entries to the dictionary file, replacing it atomically
+func (r *fileSynonymRepository) persist() error {
+	if r.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(domain.Dictionary{Entries: r.entries}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal synonym dictionary: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(r.path), filepath.Base(r.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write synonym dictionary: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write synonym dictionary: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write synonym dictionary: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), r.path); err != nil {
+		return fmt.Errorf("failed to write synonym dictionary: %w", err)
+	}
+
+	return nil
+}
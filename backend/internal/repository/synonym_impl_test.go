@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSynonym_NewSeedsMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dictionary.json")
+	seed := []domain.DictionaryEntry{{Canonical: "玉ねぎ", Aliases: []string{"たまねぎ"}}}
+
+	repo, err := NewFileSynonymRepository(path, seed)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	entries, err := repo.GetAll(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, seed, entries)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var dict domain.Dictionary
+	if err := json.Unmarshal(data, &dict); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert.Equal(t, seed, dict.Entries)
+}
+
+func TestSynonym_NewLoadsExistingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dictionary.json")
+	content := `{"entries":[{"canonical":"豚バラ肉","aliases":["豚バラ"],"broader":"豚肉"}]}`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	repo, err := NewFileSynonymRepository(path, []domain.DictionaryEntry{{Canonical: "ignored"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	entry, err := repo.GetByCanonical(context.Background(), "豚バラ肉")
+	assert.NoError(t, err)
+	assert.Equal(t, "豚肉", entry.Broader)
+
+	_, err = repo.GetByCanonical(context.Background(), "ignored")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestSynonym_NewInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dictionary.json")
+	if err := os.WriteFile(path, []byte("not json"), 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err := NewFileSynonymRepository(path, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse synonym dictionary")
+}
+
+func TestSynonym_SaveAndDeletePersist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dictionary.json")
+	repo, err := NewFileSynonymRepository(path, []domain.DictionaryEntry{{Canonical: "卵", Aliases: []string{"たまご"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := repo.Save(ctx, &domain.DictionaryEntry{Canonical: "卵", Aliases: []string{"たまご", "玉子"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := repo.Save(ctx, &domain.DictionaryEntry{Canonical: "牛乳", Aliases: []string{"ミルク"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := repo.Delete(ctx, "牛乳"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Reopen the file to verify the changes were written
+	reopened, err := NewFileSynonymRepository(path, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	entries, err := reopened.GetAll(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.DictionaryEntry{{Canonical: "卵", Aliases: []string{"たまご", "玉子"}}}, entries)
+}
+
+func TestSynonym_DeleteNotFound(t *testing.T) {
+	repo, err := NewFileSynonymRepository("", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = repo.Delete(context.Background(), "存在しない")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
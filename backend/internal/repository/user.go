@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+)
+
+// UserRepository defines the interface for user account data access
+type UserRepository interface {
+	// GetByHousehold retrieves the members of a household ordered by ID
+	GetByHousehold(ctx context.Context, householdID int64) ([]*domain.User, error)
+
+	// GetByID retrieves a single user by ID
+	GetByID(ctx context.Context, id int64) (*domain.User, error)
+
+	// GetByUsername retrieves a single user by username
+	GetByUsername(ctx context.Context, username string) (*domain.User, error)
+
+	// Count returns the number of users
+	Count(ctx context.Context) (int, error)
+
+	// Create inserts a new user and sets its ID and timestamps.
+	// A username that is already taken is reported as domain.ErrInvalidInput.
+	Create(ctx context.Context, user *domain.User) error
+
+	// Register inserts a new user like Create unless allow rejects the number of users that already exist.
+	// Registrations run one at a time, and the first user becomes the server administrator and the owner of every
+	// household without members in the same transaction, so concurrent registrations cannot both count as the first.
+	Register(ctx context.Context, user *domain.User, allow func(count int) error) error
+
+	// UpdatePassword stores user.PasswordHash and raises user.SessionVersion, revoking the tokens issued before
+	UpdatePassword(ctx context.Context, user *domain.User) error
+}
@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+)
+
+// registrationLock names the MySQL lock that makes registrations run one at a time
+const registrationLock = "dinnerdecider_registration"
+
+// registrationLockTimeout is how many seconds a registration waits for the ones before it
+const registrationLockTimeout = 10
+
+// mysqlDuplicateEntry is the MySQL error number of a unique key violation
+const mysqlDuplicateEntry = 1062
+
+// userRepository is the MySQL implementation of UserRepository
+type userRepository struct {
+	db *sqlx.DB
+}
+
+// NewUserRepository creates a new instance of UserRepository
+func NewUserRepository(db *sqlx.DB) UserRepository {
+	return &userRepository{
+		db: db,
+	}
+}
+
+// GetByHousehold retrieves the members of a household ordered by ID
+func (r *userRepository) GetByHousehold(ctx context.Context, householdID int64) ([]*domain.User, error) {
+	query := `
+		SELECT u.id, u.username, u.password_hash, u.is_admin, u.session_version, u.created_at, u.updated_at
+		FROM users u
+		JOIN household_members m ON m.user_id = u.id
+		WHERE m.household_id = ?
+		ORDER BY u.id
+	`
+
+	var users []*domain.User
+	if err := r.db.SelectContext(ctx, &users, query, householdID); err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	// Return empty slice instead of nil if no users found
+	if users == nil {
+		users = []*domain.User{}
+	}
+
+	return users, nil
+}
+
+// GetByID retrieves a single user by ID
+func (r *userRepository) GetByID(ctx context.Context, id int64) (*domain.User, error) {
+	query := `
+		SELECT id, username, password_hash, is_admin, session_version, created_at, updated_at
+		FROM users
+		WHERE id = ?
+	`
+
+	var user domain.User
+	if err := r.db.GetContext(ctx, &user, query, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("user not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get user by id: %w", err)
+	}
+
+	return &user, nil
+}
+
+// GetByUsername retrieves a single user by username
+func (r *userRepository) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	query := `
+		SELECT id, username, password_hash, is_admin, session_version, created_at, updated_at
+		FROM users
+		WHERE username = ?
+	`
+
+	var user domain.User
+	if err := r.db.GetContext(ctx, &user, query, username); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("user not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get user by username: %w", err)
+	}
+
+	return &user, nil
+}
+
+// Count returns the number of users
+func (r *userRepository) Count(ctx context.Context) (int, error) {
+	var count int
+	if err := r.db.GetContext(ctx, &count, `SELECT COUNT(*) FROM users`); err != nil {
+		return 0, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	return count, nil
+}
+
+// Create inserts a new user and sets its ID and timestamps
+func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
+	return insertUser(ctx, r.db, user)
+}
+
+// Register inserts a new user unless allow rejects the number of users that already exist.
+// The first user becomes the server administrator and claims the households without members in the same transaction.
+func (r *userRepository) Register(ctx context.Context, user *domain.User, allow func(count int) error) error {
+	// Named locks belong to a connection, so hold one for the lock and the transaction
+	conn, err := r.db.Connx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer conn.Close()
+
+	var locked sql.NullInt64
+	if err := conn.GetContext(ctx, &locked, `SELECT GET_LOCK(?, ?)`, registrationLock, registrationLockTimeout); err != nil {
+		return fmt.Errorf("failed to lock registrations: %w", err)
+	}
+	if locked.Int64 != 1 {
+		return fmt.Errorf("timed out waiting for another registration")
+	}
+	defer conn.ExecContext(context.Background(), `SELECT RELEASE_LOCK(?)`, registrationLock)
+
+	tx, err := conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var count int
+	if err := tx.GetContext(ctx, &count, `SELECT COUNT(*) FROM users`); err != nil {
+		return fmt.Errorf("failed to count users: %w", err)
+	}
+	if err := allow(count); err != nil {
+		return err
+	}
+
+	user.IsAdmin = count == 0
+	if err := insertUser(ctx, tx, user); err != nil {
+		return err
+	}
+
+	// The first user takes over the households nobody belongs to yet,
+	// such as the one the data stored before households existed was moved to
+	if count == 0 {
+		if err := claimUnownedHouseholds(ctx, tx, user.ID); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// insertUser inserts a user through exec and sets its ID and timestamps
+func insertUser(ctx context.Context, exec sqlExecutor, user *domain.User) error {
+	now := time.Now()
+	user.CreatedAt = now
+	user.UpdatedAt = now
+
+	result, err := exec.ExecContext(
+		ctx,
+		`INSERT INTO users (username, password_hash, is_admin, created_at, updated_at) VALUES (?, ?, ?, ?, ?)`,
+		user.Username,
+		user.PasswordHash,
+		user.IsAdmin,
+		user.CreatedAt,
+		user.UpdatedAt,
+	)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry {
+			return fmt.Errorf("%w: username %q is already taken", domain.ErrInvalidInput, user.Username)
+		}
+		return fmt.Errorf("failed to create user: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+	user.ID = id
+
+	return nil
+}
+
+// UpdatePassword stores user.PasswordHash and raises user.SessionVersion, revoking the tokens issued before
+func (r *userRepository) UpdatePassword(ctx context.Context, user *domain.User) error {
+	result, err := r.db.ExecContext(
+		ctx,
+		`UPDATE users SET password_hash = ?, session_version = session_version + 1, updated_at = ? WHERE id = ?`,
+		user.PasswordHash,
+		time.Now(),
+		user.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("user not found: %w", sql.ErrNoRows)
+	}
+
+	user.SessionVersion++
+	return nil
+}
@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserGetByHousehold_Success(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewUserRepository(db)
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "username", "password_hash", "created_at", "updated_at"}).
+		AddRow(1, "hanako", "$2a$10$hash", now, now)
+	mock.ExpectQuery("SELECT (.+) FROM users u JOIN household_members m ON m.user_id = u.id WHERE m.household_id = \\? ORDER BY u.id").
+		WithArgs(int64(2)).
+		WillReturnRows(rows)
+
+	users, err := repo.GetByHousehold(context.Background(), 2)
+
+	assert.NoError(t, err)
+	assert.Len(t, users, 1)
+	assert.Equal(t, "hanako", users[0].Username)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserGetByUsername_Success(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewUserRepository(db)
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "username", "password_hash", "created_at", "updated_at"}).
+		AddRow(1, "hanako", "$2a$10$hash", now, now)
+	mock.ExpectQuery("SELECT (.+) FROM users WHERE username = \\?").
+		WithArgs("hanako").
+		WillReturnRows(rows)
+
+	user, err := repo.GetByUsername(context.Background(), "hanako")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), user.ID)
+	assert.Equal(t, "$2a$10$hash", user.PasswordHash)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserGetByUsername_NotFound(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewUserRepository(db)
+
+	mock.ExpectQuery("SELECT (.+) FROM users WHERE username = \\?").
+		WithArgs("nobody").
+		WillReturnError(sql.ErrNoRows)
+
+	user, err := repo.GetByUsername(context.Background(), "nobody")
+
+	assert.Nil(t, user)
+	assert.True(t, errors.Is(err, sql.ErrNoRows))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserCreate_Success(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewUserRepository(db)
+
+	mock.ExpectExec("INSERT INTO users").
+		WithArgs("hanako", "$2a$10$hash", false, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(4, 1))
+
+	user := &domain.User{Username: "hanako", PasswordHash: "$2a$10$hash"}
+	err := repo.Create(context.Background(), user)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), user.ID)
+	assert.False(t, user.CreatedAt.IsZero())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserCreate_DuplicateUsername(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewUserRepository(db)
+
+	mock.ExpectExec("INSERT INTO users").
+		WithArgs("hanako", "$2a$10$hash", false, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'hanako' for key 'users.username'"})
+
+	err := repo.Create(context.Background(), &domain.User{Username: "hanako", PasswordHash: "$2a$10$hash"})
+
+	assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRegister_FirstUserClaimsHouseholds(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewUserRepository(db)
+
+	mock.ExpectQuery("SELECT GET_LOCK\\(\\?, \\?\\)").
+		WithArgs(registrationLock, registrationLockTimeout).
+		WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(1))
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM users").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec("INSERT INTO users").
+		WithArgs("hanako", "$2a$10$hash", true, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO household_members (.+) FROM households h WHERE NOT EXISTS").
+		WithArgs(int64(1), domain.HouseholdRoleOwner, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectExec("SELECT RELEASE_LOCK\\(\\?\\)").
+		WithArgs(registrationLock).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	user := &domain.User{Username: "hanako", PasswordHash: "$2a$10$hash"}
+	err := repo.Register(context.Background(), user, func(count int) error { return nil })
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), user.ID)
+	assert.True(t, user.IsAdmin)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRegister_Rejected(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewUserRepository(db)
+
+	mock.ExpectQuery("SELECT GET_LOCK\\(\\?, \\?\\)").
+		WithArgs(registrationLock, registrationLockTimeout).
+		WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(1))
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM users").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectRollback()
+	mock.ExpectExec("SELECT RELEASE_LOCK\\(\\?\\)").
+		WithArgs(registrationLock).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	closed := errors.New("registration is closed")
+	err := repo.Register(context.Background(), &domain.User{Username: "taro"}, func(count int) error {
+		if count > 0 {
+			return closed
+		}
+		return nil
+	})
+
+	assert.ErrorIs(t, err, closed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserUpdatePassword_Success(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewUserRepository(db)
+
+	mock.ExpectExec("UPDATE users SET password_hash = \\?, session_version = session_version \\+ 1, updated_at = \\? WHERE id = \\?").
+		WithArgs("$2a$10$new", sqlmock.AnyArg(), int64(3)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	user := &domain.User{ID: 3, PasswordHash: "$2a$10$new", SessionVersion: 2}
+	err := repo.UpdatePassword(context.Background(), user)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, user.SessionVersion)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserUpdatePassword_NotFound(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewUserRepository(db)
+
+	mock.ExpectExec("UPDATE users SET password_hash = \\?, session_version = session_version \\+ 1, updated_at = \\? WHERE id = \\?").
+		WithArgs("$2a$10$new", sqlmock.AnyArg(), int64(9)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	user := &domain.User{ID: 9, PasswordHash: "$2a$10$new"}
+	err := repo.UpdatePassword(context.Background(), user)
+
+	assert.True(t, errors.Is(err, sql.ErrNoRows))
+	assert.Equal(t, 0, user.SessionVersion)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,12 @@
+package service
+
+import (
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+)
+
+// DietaryChecker defines the interface for validating recipe suggestions against dietary profiles
+type DietaryChecker interface {
+	// Check returns the rules of the profiles that a suggestion breaks through its name, ingredients,
+	// available items or missing items
+	Check(suggestion *domain.RecipeSuggestion, profiles []*domain.DietaryProfile) []domain.DietaryViolation
+}
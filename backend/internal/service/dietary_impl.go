@@ -0,0 +1,217 @@
+package service
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/pkg/config"
+	"github.com/Rin0530/DinnerDecider/backend/pkg/textnorm"
+)
+
+//go:embed data/dietary_dictionary.json
+var defaultDietaryDictionary []byte
+
+// dietaryDictionary represents the structure of an allergen dictionary file
+type dietaryDictionary struct {
+	Groups []dietaryGroup `json:"groups"`
+
+	// Restrictions maps dietary restriction codes to the groups they rule out
+	Restrictions map[string][]string `json:"restrictions"`
+}
+
+// dietaryGroup lists the names that reveal an allergen or a food group such as meat
+type dietaryGroup struct {
+	Code       string   `json:"code"`
+	Keywords   []string `json:"keywords"`             // matched anywhere in a folded name
+	Exclude    []string `json:"exclude,omitempty"`    // names that contain a keyword without containing the food, such as 焼きそば for そば
+	Categories []string `json:"categories,omitempty"` // ingredient categories that belong to the group as a whole
+}
+
+// dietaryCheckerImpl implements DietaryChecker interface
+type dietaryCheckerImpl struct {
+	// groups maps group codes to groups with folded keywords
+	groups map[string]dietaryGroup
+
+	restrictions map[string][]string
+	normalizer   IngredientNormalizer
+}
+
+// NewDietaryChecker creates a new instance of DietaryChecker.
+// The bundled allergen dictionary is used unless cfg.DictionaryPath points to a JSON file.
+// Every allergen and restriction must be covered, so a dictionary cannot silently stop enforcing one.
+func NewDietaryChecker(cfg *config.DietaryConfig, normalizer IngredientNormalizer) (DietaryChecker, error) {
+	data := defaultDietaryDictionary
+	if cfg.DictionaryPath != "" {
+		fileData, err := os.ReadFile(cfg.DictionaryPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read allergen dictionary: %w", err)
+		}
+		data = fileData
+	}
+
+	var dict dietaryDictionary
+	if err := json.Unmarshal(data, &dict); err != nil {
+		return nil, fmt.Errorf("failed to parse allergen dictionary: %w", err)
+	}
+
+	groups := make(map[string]dietaryGroup, len(dict.Groups))
+	for _, group := range dict.Groups {
+		groups[group.Code] = dietaryGroup{
+			Code:       group.Code,
+			Keywords:   foldAll(group.Keywords),
+			Exclude:    foldAll(group.Exclude),
+			Categories: group.Categories,
+		}
+	}
+
+	for _, allergen := range domain.Allergens {
+		if _, ok := groups[allergen.Code]; !ok {
+			return nil, fmt.Errorf("allergen dictionary has no entry for allergen %s", allergen.Code)
+		}
+	}
+	for _, restriction := range domain.DietaryRestrictions {
+		codes, ok := dict.Restrictions[restriction.Code]
+		if !ok || len(codes) == 0 {
+			return nil, fmt.Errorf("allergen dictionary has no entry for restriction %s", restriction.Code)
+		}
+		for _, code := range codes {
+			if _, ok := groups[code]; !ok {
+				return nil, fmt.Errorf("restriction %s refers to unknown group %s", restriction.Code, code)
+			}
+		}
+	}
+
+	return &dietaryCheckerImpl{
+		groups:       groups,
+		restrictions: dict.Restrictions,
+		normalizer:   normalizer,
+	}, nil
+}
+
+// foldAll folds every non-empty name
+func foldAll(names []string) []string {
+	folded := make([]string, 0, len(names))
+	for _, name := range names {
+		if f := textnorm.Fold(name); f != "" {
+			folded = append(folded, f)
+		}
+	}
+	return folded
+}
+
+// Check returns the rules of the profiles that a suggestion breaks
+func (c *dietaryCheckerImpl) Check(suggestion *domain.RecipeSuggestion, profiles []*domain.DietaryProfile) []domain.DietaryViolation {
+	items := suggestionItems(suggestion)
+
+	var violations []domain.DietaryViolation
+	seen := make(map[domain.DietaryViolation]bool)
+	add := func(v domain.DietaryViolation) {
+		if !seen[v] {
+			seen[v] = true
+			violations = append(violations, v)
+		}
+	}
+
+	for _, profile := range profiles {
+		for _, item := range items {
+			for _, code := range profile.Allergens {
+				allergen, ok := domain.LookupAllergen(code)
+				if ok && c.inGroup(item, allergen.Code) {
+					add(domain.DietaryViolation{Profile: profile.Name, Item: item, Kind: domain.ViolationAllergen, Code: allergen.Code, Label: allergen.Label})
+				}
+			}
+
+			for _, code := range profile.Restrictions {
+				restriction, ok := domain.LookupDietaryRestriction(code)
+				if !ok {
+					continue
+				}
+				if slices.ContainsFunc(c.restrictions[restriction.Code], func(group string) bool { return c.inGroup(item, group) }) {
+					add(domain.DietaryViolation{Profile: profile.Name, Item: item, Kind: domain.ViolationRestriction, Code: restriction.Code, Label: restriction.Label})
+				}
+			}
+
+			for _, dislike := range profile.Dislikes {
+				if c.isDisliked(item, dislike) {
+					add(domain.DietaryViolation{Profile: profile.Name, Item: item, Kind: domain.ViolationDislike, Code: dislike, Label: dislike})
+				}
+			}
+		}
+	}
+
+	return violations
+}
+
+// suggestionItems lists the names in a suggestion that can reveal its ingredients.
+// Steps are left out because they mention tools such as フライパン and negations such as 卵を使わない.
+func suggestionItems(suggestion *domain.RecipeSuggestion) []string {
+	items := []string{suggestion.Name}
+	for _, ri := range suggestion.Ingredients {
+		items = append(items, ri.Name)
+	}
+	items = append(items, suggestion.AvailableItems...)
+	items = append(items, suggestion.MissingItems...)
+
+	var unique []string
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		if item != "" && !slices.Contains(unique, item) {
+			unique = append(unique, item)
+		}
+	}
+	return unique
+}
+
+// inGroup reports whether a name, its canonical name or a more general ingredient belongs to the group
+func (c *dietaryCheckerImpl) inGroup(name string, code string) bool {
+	group, ok := c.groups[code]
+	if !ok {
+		return false
+	}
+
+	canonical, _ := c.normalizer.Lookup(name)
+	for _, candidate := range append([]string{name, canonical}, c.normalizer.Broader(canonical)...) {
+		text := textnorm.Fold(candidate)
+		excluded := false
+		for _, exclude := range group.Exclude {
+			if strings.Contains(text, exclude) {
+				// Blank out the excluded name so the rest of the text is still checked
+				text = strings.ReplaceAll(text, exclude, " ")
+				excluded = true
+			}
+		}
+
+		for _, keyword := range group.Keywords {
+			if strings.Contains(text, keyword) {
+				return true
+			}
+		}
+
+		if !excluded && slices.Contains(group.Categories, c.normalizer.Category(candidate)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isDisliked reports whether a name is or contains a disliked ingredient, including more specific
+// ingredients such as 豚バラ肉 for 豚肉
+func (c *dietaryCheckerImpl) isDisliked(name string, dislike string) bool {
+	folded := textnorm.Fold(dislike)
+	if folded == "" {
+		return false
+	}
+	if strings.Contains(textnorm.Fold(name), folded) {
+		return true
+	}
+
+	disliked := c.normalizer.Canonical(dislike)
+	canonical, _ := c.normalizer.Lookup(name)
+	return canonical == disliked || slices.Contains(c.normalizer.Broader(canonical), disliked)
+}
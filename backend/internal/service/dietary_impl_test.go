@@ -0,0 +1,172 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/pkg/config"
+)
+
+func newDefaultDietaryChecker(t *testing.T) DietaryChecker {
+	t.Helper()
+	entries, err := DefaultDictionary()
+	if err != nil {
+		t.Fatalf("Failed to load dictionary: %v", err)
+	}
+	checker, err := NewDietaryChecker(&config.DietaryConfig{}, NewIngredientNormalizer(entries))
+	if err != nil {
+		t.Fatalf("Failed to load allergen dictionary: %v", err)
+	}
+	return checker
+}
+
+func TestNewDietaryChecker_IncompleteDictionary(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"invalid json", "not json"},
+		{"missing allergen", `{"groups": [{"code": "egg", "keywords": ["卵"]}], "restrictions": {}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "dietary.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatalf("Failed to write dictionary: %v", err)
+			}
+
+			_, err := NewDietaryChecker(&config.DietaryConfig{DictionaryPath: path}, NewIngredientNormalizer(nil))
+			if err == nil {
+				t.Fatal("Expected error for incomplete dictionary, got nil")
+			}
+		})
+	}
+}
+
+func TestDietaryChecker_Allergens(t *testing.T) {
+	checker := newDefaultDietaryChecker(t)
+
+	tests := []struct {
+		name     string
+		allergen string
+		item     string
+		expected bool
+	}{
+		{"peanut in ingredient", domain.AllergenPeanut, "ピーナッツ", true},
+		{"egg in mayonnaise", domain.AllergenEgg, "マヨネーズ", true},
+		{"egg in dish name", domain.AllergenEgg, "親子丼", true},
+		{"milk in butter", domain.AllergenMilk, "バター", true},
+		{"soy milk is not milk", domain.AllergenMilk, "豆乳", false},
+		{"wheat in soy sauce", domain.AllergenWheat, "醤油", true},
+		{"yakisoba is not buckwheat", domain.AllergenBuckwheat, "焼きそば", false},
+		{"buckwheat noodles", domain.AllergenBuckwheat, "ざるそば", true},
+		{"watermelon is not squid", domain.AllergenSquid, "すいか", false},
+		{"chicken thigh is not peach", domain.AllergenPeach, "鶏もも肉", false},
+		{"pork belly", domain.AllergenPork, "豚バラ肉", true},
+		{"bacon is pork", domain.AllergenPork, "ベーコン", true},
+		{"milk is not beef", domain.AllergenBeef, "牛乳", false},
+		{"unrelated", domain.AllergenShrimp, "キャベツ", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profiles := []*domain.DietaryProfile{{Name: "太郎", Allergens: []string{tt.allergen}}}
+			suggestion := &domain.RecipeSuggestion{
+				Name:        "テスト料理",
+				Ingredients: []domain.RecipeIngredient{{Name: tt.item, Quantity: "1個"}},
+			}
+
+			violations := checker.Check(suggestion, profiles)
+			if (len(violations) > 0) != tt.expected {
+				t.Fatalf("Expected violation=%v, got %+v", tt.expected, violations)
+			}
+			if tt.expected {
+				v := violations[0]
+				if v.Profile != "太郎" || v.Kind != domain.ViolationAllergen || v.Code != tt.allergen || v.Item != tt.item || !v.IsHard() {
+					t.Errorf("Unexpected violation %+v", v)
+				}
+			}
+		})
+	}
+}
+
+func TestDietaryChecker_Restrictions(t *testing.T) {
+	checker := newDefaultDietaryChecker(t)
+
+	tests := []struct {
+		name        string
+		restriction string
+		item        string
+		expected    bool
+	}{
+		{"vegetarian and chicken", domain.RestrictionVegetarian, "鶏むね肉", true},
+		{"vegetarian and tuna", domain.RestrictionVegetarian, "ツナ缶", true},
+		{"vegetarian and soy meat", domain.RestrictionVegetarian, "大豆ミート", false},
+		{"vegetarian and egg", domain.RestrictionVegetarian, "卵", false},
+		{"vegan and egg", domain.RestrictionVegan, "卵", true},
+		{"pescatarian and salmon", domain.RestrictionPescatarian, "鮭", false},
+		{"halal and mirin", domain.RestrictionHalal, "みりん", true},
+		{"halal and ham", domain.RestrictionHalal, "ハム", true},
+		{"no beef and ground beef mix", domain.RestrictionNoBeef, "合いびき肉", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profiles := []*domain.DietaryProfile{{Name: "花子", Restrictions: []string{tt.restriction}}}
+			suggestion := &domain.RecipeSuggestion{Name: "テスト料理", MissingItems: []string{tt.item}}
+
+			violations := checker.Check(suggestion, profiles)
+			if (len(violations) > 0) != tt.expected {
+				t.Fatalf("Expected violation=%v, got %+v", tt.expected, violations)
+			}
+			if tt.expected && (violations[0].Kind != domain.ViolationRestriction || violations[0].Code != tt.restriction) {
+				t.Errorf("Unexpected violation %+v", violations[0])
+			}
+		})
+	}
+}
+
+func TestDietaryChecker_Dislikes(t *testing.T) {
+	checker := newDefaultDietaryChecker(t)
+
+	profiles := []*domain.DietaryProfile{{Name: "太郎", Dislikes: []string{"ピーマン", "豚肉"}}}
+	suggestion := &domain.RecipeSuggestion{
+		Name: "青椒肉絲",
+		Ingredients: []domain.RecipeIngredient{
+			{Name: "ぴーまん", Quantity: "3個"},
+			{Name: "豚バラ肉", Quantity: "200g"},
+			{Name: "醤油", Quantity: "大さじ1"},
+		},
+		Steps: []string{"ピーマンを細切りにする"},
+	}
+
+	violations := checker.Check(suggestion, profiles)
+
+	if len(violations) != 2 {
+		t.Fatalf("Expected 2 violations, got %+v", violations)
+	}
+	if violations[0].Item != "ぴーまん" || violations[0].Code != "ピーマン" || violations[0].IsHard() {
+		t.Errorf("Unexpected violation %+v", violations[0])
+	}
+	if violations[1].Item != "豚バラ肉" || violations[1].Code != "豚肉" {
+		t.Errorf("Unexpected violation %+v", violations[1])
+	}
+}
+
+func TestDietaryChecker_IgnoresSteps(t *testing.T) {
+	checker := newDefaultDietaryChecker(t)
+
+	profiles := []*domain.DietaryProfile{{Name: "太郎", Allergens: []string{domain.AllergenWheat, domain.AllergenEgg}}}
+	suggestion := &domain.RecipeSuggestion{
+		Name:        "野菜炒め",
+		Ingredients: []domain.RecipeIngredient{{Name: "キャベツ", Quantity: "1/4個"}},
+		Steps:       []string{"フライパンで炒める", "卵は使わない"},
+	}
+
+	if violations := checker.Check(suggestion, profiles); len(violations) != 0 {
+		t.Errorf("Expected no violations, got %+v", violations)
+	}
+}
@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+)
+
+// FallbackRecommender defines the interface for generating recipe suggestions
+// without the LLM, from a local recipe catalog
+type FallbackRecommender interface {
+	// Recommend scores catalog recipes against the available ingredients
+	Recommend(ctx context.Context, ingredients []*domain.Ingredient) (*domain.RecipeResponse, error)
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/pkg/config"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed data/fallback_recipes.json
+var defaultFallbackCatalog []byte
+
+// Scoring weights for catalog recipes
+const (
+	coverageWeight = 0.7
+	urgencyWeight  = 0.3
+
+	// urgencyHorizon is the age at which an ingredient without an expiry date is considered fully urgent
+	urgencyHorizon = 14 * 24 * time.Hour
+
+	// expiryHorizon is how long before its expiry date an ingredient starts to become urgent
+	expiryHorizon = 7 * 24 * time.Hour
+)
+
+// fallbackCatalog represents the structure of a recipe catalog file
+type fallbackCatalog struct {
+	Recipes []fallbackRecipe `json:"recipes" yaml:"recipes"`
+}
+
+// fallbackRecipe represents a single recipe in the catalog
+type fallbackRecipe struct {
+	Name        string   `json:"name" yaml:"name"`
+	Ingredients []string `json:"ingredients" yaml:"ingredients"`
+	Steps       []string `json:"steps" yaml:"steps"`
+}
+
+// scoredRecipe holds a recipe together with its computed score
+type scoredRecipe struct {
+	recipe    fallbackRecipe
+	score     float64
+	available []string
+	missing   []string
+}
+
+// fallbackRecommenderImpl implements FallbackRecommender interface
+type fallbackRecommenderImpl struct {
+	catalog        fallbackCatalog
+	matcher        IngredientMatcher
+	maxSuggestions int
+	now            func() time.Time
+}
+
+// NewFallbackRecommender creates a new instance of FallbackRecommender.
+// The bundled catalog is used unless cfg.CatalogPath points to a JSON or YAML file.
+func NewFallbackRecommender(cfg *config.FallbackConfig, matcher IngredientMatcher) (FallbackRecommender, error) {
+	data, unmarshal := defaultFallbackCatalog, json.Unmarshal
+	if cfg.CatalogPath != "" {
+		fileData, err := os.ReadFile(cfg.CatalogPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read recipe catalog: %w", err)
+		}
+		data = fileData
+
+		// The extension decides the format; anything but YAML is read as JSON like before
+		switch strings.ToLower(filepath.Ext(cfg.CatalogPath)) {
+		case ".yaml", ".yml":
+			unmarshal = yaml.Unmarshal
+		}
+	}
+
+	var catalog fallbackCatalog
+	if err := unmarshal(data, &catalog); err != nil {
+		return nil, fmt.Errorf("failed to parse recipe catalog: %w", err)
+	}
+
+	maxSuggestions := cfg.MaxSuggestions
+	if maxSuggestions <= 0 {
+		maxSuggestions = 3
+	}
+
+	return &fallbackRecommenderImpl{
+		catalog:        catalog,
+		matcher:        matcher,
+		maxSuggestions: maxSuggestions,
+		now:            time.Now,
+	}, nil
+}
+
+// Recommend scores catalog recipes by ingredient coverage and expiry urgency
+func (r *fallbackRecommenderImpl) Recommend(ctx context.Context, ingredients []*domain.Ingredient) (*domain.RecipeResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var scored []scoredRecipe
+	for _, recipe := range r.catalog.Recipes {
+		if len(recipe.Ingredients) == 0 {
+			continue
+		}
+
+		matched := 0
+		urgency := 0.0
+		var available, missing []string
+		for _, required := range recipe.Ingredients {
+			item := r.matcher.Find(required, ingredients)
+			if item == nil {
+				missing = append(missing, required)
+				continue
+			}
+			matched++
+			available = append(available, required)
+			if u := r.urgency(item); u > urgency {
+				urgency = u
+			}
+		}
+
+		// Skip recipes that use nothing from the refrigerator
+		if matched == 0 {
+			continue
+		}
+
+		coverage := float64(matched) / float64(len(recipe.Ingredients))
+		scored = append(scored, scoredRecipe{
+			recipe:    recipe,
+			score:     coverageWeight*coverage + urgencyWeight*urgency,
+			available: available,
+			missing:   missing,
+		})
+	}
+
+	// Highest score first; break ties by name for deterministic output
+	sort.SliceStable(scored, func(i, j int) bool {
+		if scored[i].score != scored[j].score {
+			return scored[i].score > scored[j].score
+		}
+		return scored[i].recipe.Name < scored[j].recipe.Name
+	})
+
+	if len(scored) > r.maxSuggestions {
+		scored = scored[:r.maxSuggestions]
+	}
+
+	suggestions := make([]domain.RecipeSuggestion, 0, len(scored))
+	for _, s := range scored {
+		missing := s.missing
+		if missing == nil {
+			missing = []string{}
+		}
+		recipeIngredients := make([]domain.RecipeIngredient, 0, len(s.recipe.Ingredients))
+		for _, name := range s.recipe.Ingredients {
+			recipeIngredients = append(recipeIngredients, domain.RecipeIngredient{Name: name})
+		}
+		suggestions = append(suggestions, domain.RecipeSuggestion{
+			Name:           s.recipe.Name,
+			Ingredients:    recipeIngredients,
+			Steps:          s.recipe.Steps,
+			AvailableItems: s.available,
+			MissingItems:   missing,
+		})
+	}
+
+	return &domain.RecipeResponse{
+		Suggestions: suggestions,
+		Source:      domain.RecipeSourceCatalog,
+	}, nil
+}
+
+// urgency estimates how soon an ingredient should be used, from 0 (fresh) to 1 (use now).
+// It uses the time left until the expiry date, or the time since purchase when there is none.
+func (r *fallbackRecommenderImpl) urgency(ing *domain.Ingredient) float64 {
+	if ing.ExpiresAt != nil {
+		left := ing.ExpiresAt.Sub(r.now())
+		if left <= 0 {
+			return 1
+		}
+		if left >= expiryHorizon {
+			return 0
+		}
+		return 1 - float64(left)/float64(expiryHorizon)
+	}
+	if ing.PurchaseDate == nil {
+		return 0
+	}
+	age := r.now().Sub(*ing.PurchaseDate)
+	if age <= 0 {
+		return 0
+	}
+	if age >= urgencyHorizon {
+		return 1
+	}
+	return float64(age) / float64(urgencyHorizon)
+}
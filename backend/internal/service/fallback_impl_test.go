@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/pkg/config"
+)
+
+func TestNewFallbackRecommender_DefaultCatalog(t *testing.T) {
+	recommender, err := NewFallbackRecommender(&config.FallbackConfig{}, newDefaultMatcher(t))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	impl := recommender.(*fallbackRecommenderImpl)
+	if len(impl.catalog.Recipes) == 0 {
+		t.Error("Expected bundled catalog to contain recipes")
+	}
+	if impl.maxSuggestions != 3 {
+		t.Errorf("Expected default max suggestions 3, got %d", impl.maxSuggestions)
+	}
+}
+
+func TestNewFallbackRecommender_YAMLCatalog(t *testing.T) {
+	catalog := `recipes:
+  - name: 豚汁
+    ingredients: [豚肉, 大根, 味噌]
+    steps:
+      - 具材を切る
+      - 煮て味噌を溶く
+`
+	path := filepath.Join(t.TempDir(), "catalog.yml")
+	if err := os.WriteFile(path, []byte(catalog), 0o600); err != nil {
+		t.Fatalf("Failed to write catalog: %v", err)
+	}
+
+	recommender, err := NewFallbackRecommender(&config.FallbackConfig{CatalogPath: path}, newDefaultMatcher(t))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	recipes := recommender.(*fallbackRecommenderImpl).catalog.Recipes
+	if len(recipes) != 1 || recipes[0].Name != "豚汁" {
+		t.Fatalf("Expected the YAML recipe, got %+v", recipes)
+	}
+	if len(recipes[0].Ingredients) != 3 || len(recipes[0].Steps) != 2 {
+		t.Errorf("Expected 3 ingredients and 2 steps, got %+v", recipes[0])
+	}
+}
+
+func TestNewFallbackRecommender_InvalidCatalogFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catalog.json")
+	if err := os.WriteFile(path, []byte("not json"), 0o600); err != nil {
+		t.Fatalf("Failed to write catalog: %v", err)
+	}
+
+	_, err := NewFallbackRecommender(&config.FallbackConfig{CatalogPath: path}, newDefaultMatcher(t))
+	if err == nil {
+		t.Fatal("Expected error for invalid catalog, got nil")
+	}
+}
+
+func TestRecommend_ScoresByCoverageAndUrgency(t *testing.T) {
+	now := time.Date(2025, 11, 20, 0, 0, 0, 0, time.UTC)
+	recommender := &fallbackRecommenderImpl{
+		catalog: fallbackCatalog{
+			Recipes: []fallbackRecipe{
+				{Name: "親子丼", Ingredients: []string{"鶏肉", "卵", "玉ねぎ", "ご飯"}, Steps: []string{"煮る"}},
+				{Name: "卵焼き", Ingredients: []string{"卵"}, Steps: []string{"焼く"}},
+				{Name: "ほうれん草のおひたし", Ingredients: []string{"ほうれん草"}, Steps: []string{"茹でる"}},
+				{Name: "鮭のムニエル", Ingredients: []string{"鮭", "バター"}, Steps: []string{"焼く"}},
+			},
+		},
+		matcher:        newDefaultMatcher(t),
+		maxSuggestions: 3,
+		now:            func() time.Time { return now },
+	}
+
+	oldPurchase := now.AddDate(0, 0, -14)
+	ingredients := []*domain.Ingredient{
+		{ID: 1, Name: "鶏もも肉", PurchaseDate: &oldPurchase},
+		{ID: 2, Name: "卵"},
+		{ID: 3, Name: "玉ねぎ"},
+		{ID: 4, Name: "鮭"},
+	}
+
+	result, err := recommender.Recommend(context.Background(), ingredients)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if result.Source != domain.RecipeSourceCatalog {
+		t.Errorf("Expected source %q, got %q", domain.RecipeSourceCatalog, result.Source)
+	}
+
+	if len(result.Suggestions) != 3 {
+		t.Fatalf("Expected 3 suggestions, got %d", len(result.Suggestions))
+	}
+
+	// 親子丼: 0.7*0.75 + 0.3*1.0 = 0.825, 卵焼き: 0.7, 鮭のムニエル: 0.35
+	expectedOrder := []string{"親子丼", "卵焼き", "鮭のムニエル"}
+	for i, name := range expectedOrder {
+		if result.Suggestions[i].Name != name {
+			t.Errorf("Expected suggestion %d to be '%s', got '%s'", i, name, result.Suggestions[i].Name)
+		}
+	}
+
+	if len(result.Suggestions[0].MissingItems) != 1 || result.Suggestions[0].MissingItems[0] != "ご飯" {
+		t.Errorf("Expected missing items [ご飯], got %v", result.Suggestions[0].MissingItems)
+	}
+
+	if len(result.Suggestions[0].AvailableItems) != 3 {
+		t.Errorf("Expected 3 available items, got %v", result.Suggestions[0].AvailableItems)
+	}
+
+	if result.Suggestions[1].MissingItems == nil {
+		t.Error("Expected empty missing items slice, got nil")
+	}
+}
+
+func TestRecommend_UrgencyPrefersExpiryDate(t *testing.T) {
+	now := time.Date(2025, 11, 20, 0, 0, 0, 0, time.UTC)
+	recommender := &fallbackRecommenderImpl{
+		catalog: fallbackCatalog{
+			Recipes: []fallbackRecipe{
+				{Name: "鮭のムニエル", Ingredients: []string{"鮭"}, Steps: []string{"焼く"}},
+				{Name: "卵焼き", Ingredients: []string{"卵"}, Steps: []string{"焼く"}},
+				{Name: "ほうれん草のおひたし", Ingredients: []string{"ほうれん草"}, Steps: []string{"茹でる"}},
+			},
+		},
+		matcher:        newDefaultMatcher(t),
+		maxSuggestions: 3,
+		now:            func() time.Time { return now },
+	}
+
+	// The salmon was bought today but expires tomorrow; the eggs were bought long ago but keep for weeks
+	today := now
+	tomorrow := now.AddDate(0, 0, 1)
+	oldPurchase := now.AddDate(0, 0, -14)
+	nextMonth := now.AddDate(0, 1, 0)
+	ingredients := []*domain.Ingredient{
+		{ID: 1, Name: "鮭", PurchaseDate: &today, ExpiresAt: &tomorrow},
+		{ID: 2, Name: "卵", PurchaseDate: &oldPurchase, ExpiresAt: &nextMonth},
+		{ID: 3, Name: "ほうれん草", PurchaseDate: &oldPurchase},
+	}
+
+	if got := recommender.urgency(ingredients[0]); got < 0.8 {
+		t.Errorf("Expected an ingredient expiring tomorrow to be urgent, got %v", got)
+	}
+	if got := recommender.urgency(ingredients[1]); got != 0 {
+		t.Errorf("Expected an ingredient expiring next month to be fresh, got %v", got)
+	}
+	if got := recommender.urgency(ingredients[2]); got != 1 {
+		t.Errorf("Expected an old ingredient without an expiry date to be urgent, got %v", got)
+	}
+
+	result, err := recommender.Recommend(context.Background(), ingredients)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expectedOrder := []string{"ほうれん草のおひたし", "鮭のムニエル", "卵焼き"}
+	if len(result.Suggestions) != len(expectedOrder) {
+		t.Fatalf("Expected %d suggestions, got %d", len(expectedOrder), len(result.Suggestions))
+	}
+	for i, name := range expectedOrder {
+		if result.Suggestions[i].Name != name {
+			t.Errorf("Expected suggestion %d to be '%s', got '%s'", i, name, result.Suggestions[i].Name)
+		}
+	}
+}
+
+func TestRecommend_NoMatches(t *testing.T) {
+	recommender, err := NewFallbackRecommender(&config.FallbackConfig{}, newDefaultMatcher(t))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	result, err := recommender.Recommend(context.Background(), []*domain.Ingredient{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(result.Suggestions) != 0 {
+		t.Errorf("Expected no suggestions, got %d", len(result.Suggestions))
+	}
+}
+
+func TestRecommend_ContextCancelled(t *testing.T) {
+	recommender, err := NewFallbackRecommender(&config.FallbackConfig{}, newDefaultMatcher(t))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := recommender.Recommend(ctx, nil); err == nil {
+		t.Fatal("Expected context cancellation error, got nil")
+	}
+}
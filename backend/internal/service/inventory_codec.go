@@ -0,0 +1,23 @@
+package service
+
+import (
+	"io"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+)
+
+// InventoryCodec defines the interface for reading and writing the ingredient inventory as CSV or JSON.
+// Both formats use the columns in domain.InventoryColumns, so an export can be imported again as is.
+type InventoryCodec interface {
+	// EncodeCSV writes ingredients as a UTF-8 CSV table with a header row
+	EncodeCSV(w io.Writer, ingredients []*domain.Ingredient) error
+
+	// EncodeJSON writes ingredients as a JSON array of flat objects
+	EncodeJSON(w io.Writer, ingredients []*domain.Ingredient) error
+
+	// DecodeCSV reads a CSV table whose first row names the columns
+	DecodeCSV(data []byte) (*domain.InventoryTable, error)
+
+	// DecodeJSON reads a JSON array of flat objects, one per row
+	DecodeJSON(data []byte) (*domain.InventoryTable, error)
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+)
+
+// utf8BOM marks CSV exports as UTF-8 so spreadsheet applications do not mistake them for Shift_JIS
+const utf8BOM = "\uFEFF"
+
+// inventoryCodecImpl implements InventoryCodec interface
+type inventoryCodecImpl struct{}
+
+// NewInventoryCodec creates a new instance of InventoryCodec
+func NewInventoryCodec() InventoryCodec {
+	return &inventoryCodecImpl{}
+}
+
+// EncodeCSV writes ingredients as a UTF-8 CSV table with a header row
+func (c *inventoryCodecImpl) EncodeCSV(w io.Writer, ingredients []*domain.Ingredient) error {
+	if _, err := io.WriteString(w, utf8BOM); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(domain.InventoryColumns); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	for _, ingredient := range ingredients {
+		values := inventoryValues(ingredient)
+		record := make([]string, len(domain.InventoryColumns))
+		for i, column := range domain.InventoryColumns {
+			record[i] = values[column]
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
+
+// EncodeJSON writes ingredients as a JSON array of flat objects
+func (c *inventoryCodecImpl) EncodeJSON(w io.Writer, ingredients []*domain.Ingredient) error {
+	records := make([]map[string]string, 0, len(ingredients))
+	for _, ingredient := range ingredients {
+		records = append(records, inventoryValues(ingredient))
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(records); err != nil {
+		return fmt.Errorf("failed to write JSON: %w", err)
+	}
+	return nil
+}
+
+// DecodeCSV reads a CSV table whose first row names the columns
+func (c *inventoryCodecImpl) DecodeCSV(data []byte) (*domain.InventoryTable, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte(utf8BOM))))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("CSV has no header row")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV: %w", err)
+	}
+
+	table := &domain.InventoryTable{}
+	for _, column := range header {
+		table.Columns = append(table.Columns, strings.TrimSpace(column))
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse CSV: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := domain.InventoryRow{Line: line, Values: make(map[string]string, len(table.Columns))}
+		blank := true
+		for i, value := range record {
+			if i >= len(table.Columns) || table.Columns[i] == "" {
+				continue
+			}
+			value = strings.TrimSpace(value)
+			row.Values[table.Columns[i]] = value
+			blank = blank && value == ""
+		}
+
+		// Spreadsheets often leave empty rows at the end
+		if !blank {
+			table.Rows = append(table.Rows, row)
+		}
+	}
+
+	return table, nil
+}
+
+// DecodeJSON reads a JSON array of flat objects, one per row
+func (c *inventoryCodecImpl) DecodeJSON(data []byte) (*domain.InventoryTable, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var objects []map[string]interface{}
+	if err := decoder.Decode(&objects); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: expected an array of objects: %w", err)
+	}
+
+	table := &domain.InventoryTable{}
+	seen := map[string]bool{}
+	for i, object := range objects {
+		row := domain.InventoryRow{Line: i + 1, Values: make(map[string]string, len(object))}
+		for key, value := range object {
+			if !seen[key] {
+				seen[key] = true
+				table.Columns = append(table.Columns, key)
+			}
+			row.Values[key] = jsonCellText(value)
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	sort.Strings(table.Columns)
+
+	return table, nil
+}
+
+// inventoryValues returns the inventory columns of an ingredient as text
+func inventoryValues(ingredient *domain.Ingredient) map[string]string {
+	return map[string]string{
+		domain.InventoryColumnName:         ingredient.Name,
+		domain.InventoryColumnCategory:     ingredient.Category,
+		domain.InventoryColumnLocation:     ingredient.Location,
+		domain.InventoryColumnQuantity:     ingredient.Quantity,
+		domain.InventoryColumnBarcode:      ingredient.Barcode,
+		domain.InventoryColumnPrice:        formatInventoryPrice(ingredient.Price),
+		domain.InventoryColumnStore:        ingredient.Store,
+		domain.InventoryColumnPurchaseDate: formatInventoryDate(ingredient.PurchaseDate),
+		domain.InventoryColumnExpiresAt:    formatInventoryDate(ingredient.ExpiresAt),
+	}
+}
+
+// formatInventoryPrice formats an optional price in yen, or an empty string when missing
+func formatInventoryPrice(price *int) string {
+	if price == nil {
+		return ""
+	}
+	return strconv.Itoa(*price)
+}
+
+// formatInventoryDate formats an optional date as YYYY-MM-DD, or an empty string when missing
+func formatInventoryDate(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+	return date.Format("2006-01-02")
+}
+
+// jsonCellText converts a decoded JSON value into cell text; nested values keep their JSON form
+func jsonCellText(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(v)
+	case json.Number:
+		return v.String()
+	case bool:
+		return fmt.Sprint(v)
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+)
+
+func newTestInventory() []*domain.Ingredient {
+	purchaseDate := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	price := 158
+	return []*domain.Ingredient{
+		{ID: 1, Name: "にんじん", Category: "vegetable", Location: "fridge", Quantity: "3本", Barcode: "4901234567894", Price: &price, Store: "スーパーA", PurchaseDate: &purchaseDate},
+		{ID: 2, Name: "醤油, 濃口", Category: "seasoning", Location: "pantry", Quantity: "1本"},
+	}
+}
+
+func TestEncodeCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewInventoryCodec().EncodeCSV(&buf, newTestInventory()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := "\uFEFFname,category,location,quantity,barcode,price,store,purchase_date,expires_at\n" +
+		"にんじん,vegetable,fridge,3本,4901234567894,158,スーパーA,2025-12-01,\n" +
+		"\"醤油, 濃口\",seasoning,pantry,1本,,,,,\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+}
+
+func TestEncodeJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewInventoryCodec().EncodeJSON(&buf, nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if strings.TrimSpace(buf.String()) != "[]" {
+		t.Errorf("Expected an empty array, got %s", buf.String())
+	}
+}
+
+func TestInventoryCodec_RoundTrip(t *testing.T) {
+	codec := NewInventoryCodec()
+	inventory := newTestInventory()
+
+	var csvData, jsonData bytes.Buffer
+	if err := codec.EncodeCSV(&csvData, inventory); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := codec.EncodeJSON(&jsonData, inventory); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	fromCSV, err := codec.DecodeCSV(csvData.Bytes())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	fromJSON, err := codec.DecodeJSON(jsonData.Bytes())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !reflect.DeepEqual(fromCSV.Columns, domain.InventoryColumns) {
+		t.Errorf("Expected columns %v, got %v", domain.InventoryColumns, fromCSV.Columns)
+	}
+	if len(fromCSV.Rows) != 2 || len(fromJSON.Rows) != 2 {
+		t.Fatalf("Expected 2 rows from each format, got %d and %d", len(fromCSV.Rows), len(fromJSON.Rows))
+	}
+	for i := range fromCSV.Rows {
+		if !reflect.DeepEqual(fromCSV.Rows[i].Values, fromJSON.Rows[i].Values) {
+			t.Errorf("Row %d differs: CSV %v, JSON %v", i, fromCSV.Rows[i].Values, fromJSON.Rows[i].Values)
+		}
+	}
+	if fromCSV.Rows[1].Values["name"] != "醤油, 濃口" {
+		t.Errorf("Expected quoted name to survive, got %q", fromCSV.Rows[1].Values["name"])
+	}
+	for column, expected := range map[string]string{"barcode": "4901234567894", "price": "158", "store": "スーパーA"} {
+		if fromCSV.Rows[0].Values[column] != expected {
+			t.Errorf("Expected %s %q to survive, got %q", column, expected, fromCSV.Rows[0].Values[column])
+		}
+	}
+}
+
+func TestDecodeCSV_LinesAndBlankRows(t *testing.T) {
+	data := []byte("品名,数量,購入日\n" +
+		"にんじん,3本,2025/12/01\n" +
+		",,\n" +
+		"\"玉ねぎ\n(新)\",2個\n")
+
+	table, err := NewInventoryCodec().DecodeCSV(data)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(table.Rows) != 2 {
+		t.Fatalf("Expected 2 rows, got %d", len(table.Rows))
+	}
+	if table.Rows[0].Line != 2 || table.Rows[1].Line != 4 {
+		t.Errorf("Expected lines 2 and 4, got %d and %d", table.Rows[0].Line, table.Rows[1].Line)
+	}
+	if table.Rows[1].Values["数量"] != "2個" {
+		t.Errorf("Expected quantity '2個', got %q", table.Rows[1].Values["数量"])
+	}
+	if _, ok := table.Rows[1].Values["購入日"]; ok {
+		t.Error("Expected missing trailing cell to be absent")
+	}
+}
+
+func TestDecodeCSV_Empty(t *testing.T) {
+	if _, err := NewInventoryCodec().DecodeCSV(nil); err == nil {
+		t.Error("Expected error for empty CSV, got nil")
+	}
+}
+
+func TestDecodeJSON_ScalarValues(t *testing.T) {
+	table, err := NewInventoryCodec().DecodeJSON([]byte(`[{"name": "卵", "quantity": 10, "expires_at": null, "tags": ["朝食"]}]`))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := map[string]string{"name": "卵", "quantity": "10", "expires_at": "", "tags": `["朝食"]`}
+	if !reflect.DeepEqual(table.Rows[0].Values, expected) {
+		t.Errorf("Expected %v, got %v", expected, table.Rows[0].Values)
+	}
+	if !reflect.DeepEqual(table.Columns, []string{"expires_at", "name", "quantity", "tags"}) {
+		t.Errorf("Expected sorted columns, got %v", table.Columns)
+	}
+}
+
+func TestDecodeJSON_NotAnArray(t *testing.T) {
+	if _, err := NewInventoryCodec().DecodeJSON([]byte(`{"name": "卵"}`)); err == nil {
+		t.Error("Expected error for a JSON object, got nil")
+	}
+}
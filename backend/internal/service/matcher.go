@@ -0,0 +1,14 @@
+package service
+
+import (
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+)
+
+// IngredientMatcher defines the interface for matching recipe ingredients against the inventory
+type IngredientMatcher interface {
+	// Find returns the inventory item that satisfies a recipe ingredient, or nil
+	Find(name string, inventory []*domain.Ingredient) *domain.Ingredient
+
+	// Reconcile recomputes the available and missing items of each suggestion from the inventory
+	Reconcile(resp *domain.RecipeResponse, inventory []*domain.Ingredient)
+}
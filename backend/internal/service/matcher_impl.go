@@ -0,0 +1,118 @@
+package service
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/pkg/textnorm"
+)
+
+// minContainmentLength is the minimum length of a folded name used for partial matching
+const minContainmentLength = 2
+
+// ingredientMatcherImpl implements IngredientMatcher interface
+type ingredientMatcherImpl struct {
+	normalizer IngredientNormalizer
+}
+
+// NewIngredientMatcher creates a new instance of IngredientMatcher
+func NewIngredientMatcher(normalizer IngredientNormalizer) IngredientMatcher {
+	return &ingredientMatcherImpl{
+		normalizer: normalizer,
+	}
+}
+
+// Find returns the inventory item that satisfies a recipe ingredient, or nil.
+// Exact canonical matches win over broader matches (豚バラ肉 satisfies 豚肉),
+// which win over partial matches of the folded names. Partial matching is skipped
+// when both names are in the dictionary, so 玉ねぎ is never satisfied by ねぎ.
+func (m *ingredientMatcherImpl) Find(name string, inventory []*domain.Ingredient) *domain.Ingredient {
+	want, wantKnown := m.normalizer.Lookup(name)
+	if want == "" {
+		return nil
+	}
+
+	// Pass 1: same canonical ingredient
+	for _, ing := range inventory {
+		if ing != nil && m.normalizer.Canonical(ing.Name) == want {
+			return ing
+		}
+	}
+
+	// Pass 2: the inventory item is a more specific kind of the recipe ingredient
+	for _, ing := range inventory {
+		if ing == nil {
+			continue
+		}
+		for _, broader := range m.normalizer.Broader(m.normalizer.Canonical(ing.Name)) {
+			if broader == want {
+				return ing
+			}
+		}
+	}
+
+	// Pass 3: one folded name contains the other
+	foldedWant := textnorm.Fold(name)
+	if utf8.RuneCountInString(foldedWant) < minContainmentLength {
+		return nil
+	}
+	for _, ing := range inventory {
+		if ing == nil {
+			continue
+		}
+		if _, haveKnown := m.normalizer.Lookup(ing.Name); wantKnown && haveKnown {
+			continue
+		}
+		foldedHave := textnorm.Fold(ing.Name)
+		if utf8.RuneCountInString(foldedHave) < minContainmentLength {
+			continue
+		}
+		if strings.Contains(foldedHave, foldedWant) || strings.Contains(foldedWant, foldedHave) {
+			return ing
+		}
+	}
+
+	return nil
+}
+
+// Reconcile recomputes the available and missing items of each suggestion from the inventory.
+// Items the LLM reported as missing are dropped when they are actually in stock, and
+// recipe ingredients that are not in stock are added to the missing items.
+func (m *ingredientMatcherImpl) Reconcile(resp *domain.RecipeResponse, inventory []*domain.Ingredient) {
+	if resp == nil {
+		return
+	}
+
+	for i := range resp.Suggestions {
+		suggestion := &resp.Suggestions[i]
+
+		available := []string{}
+		missing := []string{}
+		seen := make(map[string]bool)
+
+		classify := func(name string) {
+			key := m.normalizer.Canonical(name)
+			if key == "" || seen[key] {
+				return
+			}
+			seen[key] = true
+
+			if m.Find(name, inventory) != nil {
+				available = append(available, name)
+			} else {
+				missing = append(missing, name)
+			}
+		}
+
+		for _, ing := range suggestion.Ingredients {
+			classify(ing.Name)
+		}
+		for _, name := range suggestion.MissingItems {
+			classify(name)
+		}
+
+		suggestion.AvailableItems = available
+		suggestion.MissingItems = missing
+	}
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+)
+
+// newDefaultMatcher creates a matcher backed by the bundled dictionary
+func newDefaultMatcher(t *testing.T) IngredientMatcher {
+	t.Helper()
+	entries, err := DefaultDictionary()
+	if err != nil {
+		t.Fatalf("Failed to load dictionary: %v", err)
+	}
+	return NewIngredientMatcher(NewIngredientNormalizer(entries))
+}
+
+func TestMatcher_Find(t *testing.T) {
+	matcher := newDefaultMatcher(t)
+	inventory := []*domain.Ingredient{
+		{ID: 1, Name: "タマネギ"},
+		{ID: 2, Name: "豚バラ"},
+		{ID: 3, Name: "Eggs"},
+		{ID: 4, Name: "ねぎ"},
+		{ID: 5, Name: "北海道産バターコーン"},
+	}
+
+	tests := []struct {
+		name       string
+		expectedID int64
+	}{
+		{"玉ねぎ", 1},
+		{"onion", 1},
+		{"豚肉", 2},
+		{"卵", 3},
+		{"長ねぎ", 4},
+		{"バターコーン", 5},
+		{"鶏肉", 0},
+		{"豚ひき肉", 0},
+		{"", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := matcher.Find(tt.name, inventory)
+			if tt.expectedID == 0 {
+				if got != nil {
+					t.Errorf("Expected no match, got %q", got.Name)
+				}
+				return
+			}
+			if got == nil || got.ID != tt.expectedID {
+				t.Errorf("Expected ingredient %d, got %v", tt.expectedID, got)
+			}
+		})
+	}
+}
+
+func TestMatcher_Find_NoPartialMatchBetweenKnownIngredients(t *testing.T) {
+	matcher := newDefaultMatcher(t)
+	inventory := []*domain.Ingredient{{ID: 1, Name: "ねぎ"}, {ID: 2, Name: "トマトケチャップ"}}
+
+	if got := matcher.Find("玉ねぎ", inventory); got != nil {
+		t.Errorf("Expected ねぎ not to satisfy 玉ねぎ, got %q", got.Name)
+	}
+	if got := matcher.Find("トマト", inventory); got != nil {
+		t.Errorf("Expected ケチャップ not to satisfy トマト, got %q", got.Name)
+	}
+}
+
+func TestMatcher_Reconcile(t *testing.T) {
+	matcher := newDefaultMatcher(t)
+	inventory := []*domain.Ingredient{
+		{ID: 1, Name: "たまねぎ"},
+		{ID: 2, Name: "鶏もも肉"},
+		{ID: 3, Name: "卵"},
+	}
+
+	resp := &domain.RecipeResponse{
+		Suggestions: []domain.RecipeSuggestion{
+			{
+				Name: "親子丼",
+				Ingredients: []domain.RecipeIngredient{
+					{Name: "鶏肉", Quantity: "200g"},
+					{Name: "玉ねぎ", Quantity: "1/2個"},
+					{Name: "卵", Quantity: "2個"},
+					{Name: "ご飯", Quantity: "2杯"},
+				},
+				// The LLM claims onions are missing even though they are in stock
+				MissingItems: []string{"タマネギ", "三つ葉"},
+			},
+			{
+				Name:         "卵かけご飯",
+				MissingItems: []string{"たまご"},
+			},
+		},
+	}
+
+	matcher.Reconcile(resp, inventory)
+
+	first := resp.Suggestions[0]
+	if !reflect.DeepEqual(first.AvailableItems, []string{"鶏肉", "玉ねぎ", "卵"}) {
+		t.Errorf("Unexpected available items: %v", first.AvailableItems)
+	}
+	if !reflect.DeepEqual(first.MissingItems, []string{"ご飯", "三つ葉"}) {
+		t.Errorf("Unexpected missing items: %v", first.MissingItems)
+	}
+
+	second := resp.Suggestions[1]
+	if len(second.MissingItems) != 0 || second.MissingItems == nil {
+		t.Errorf("Expected empty missing items, got %v", second.MissingItems)
+	}
+	if !reflect.DeepEqual(second.AvailableItems, []string{"たまご"}) {
+		t.Errorf("Unexpected available items: %v", second.AvailableItems)
+	}
+}
+
+func TestMatcher_Reconcile_NilResponse(t *testing.T) {
+	matcher := newDefaultMatcher(t)
+	matcher.Reconcile(nil, nil)
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+)
+
+// IngredientNormalizer defines the interface for mapping ingredient names to canonical names
+type IngredientNormalizer interface {
+	// Canonical returns the canonical name of an ingredient.
+	// Names missing from the dictionary are returned in folded form.
+	Canonical(name string) string
+
+	// Lookup returns the canonical name of an ingredient and whether it is in the dictionary
+	Lookup(name string) (string, bool)
+
+	// Broader returns the more general canonical names of a canonical name, nearest first
+	Broader(canonical string) []string
+
+	// Category returns the category of an ingredient, or an empty string when it cannot be determined
+	Category(name string) string
+
+	// Reload replaces the dictionary entries used for normalization
+	Reload(entries []domain.DictionaryEntry)
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/pkg/textnorm"
+)
+
+//go:embed data/ingredient_dictionary.json
+var defaultIngredientDictionary []byte
+
+// ingredientNormalizerImpl implements IngredientNormalizer interface
+type ingredientNormalizerImpl struct {
+	mu sync.RWMutex
+
+	// index maps folded names and aliases to canonical names
+	index map[string]string
+
+	// broader maps canonical names to more general canonical names
+	broader map[string]string
+
+	// categories maps canonical names to ingredient categories
+	categories map[string]string
+}
+
+// categoryKeywords guesses the category of names missing from the dictionary.
+// Keywords are matched against folded names in order.
+var categoryKeywords = []struct {
+	keyword  string
+	category string
+}{
+	{"肉", domain.CategoryMeat},
+	{"魚", domain.CategorySeafood},
+	{"豆腐", domain.CategorySoy},
+	{"麺", domain.CategoryGrain},
+	{"そーす", domain.CategorySeasoning},
+	{"たれ", domain.CategorySeasoning},
+	{"酢", domain.CategorySeasoning},
+	{"野菜", domain.CategoryVegetable},
+}
+
+// DefaultDictionary returns the bundled ingredient synonym dictionary
+func DefaultDictionary() ([]domain.DictionaryEntry, error) {
+	var dict domain.Dictionary
+	if err := json.Unmarshal(defaultIngredientDictionary, &dict); err != nil {
+		return nil, fmt.Errorf("failed to parse ingredient dictionary: %w", err)
+	}
+	return dict.Entries, nil
+}
+
+// NewIngredientNormalizer creates a new instance of IngredientNormalizer from dictionary entries.
+// Later entries take precedence over earlier ones for conflicting aliases.
+func NewIngredientNormalizer(entries []domain.DictionaryEntry) IngredientNormalizer {
+	n := &ingredientNormalizerImpl{}
+	n.Reload(entries)
+	return n
+}
+
+// Reload replaces the dictionary entries used for normalization
+func (n *ingredientNormalizerImpl) Reload(entries []domain.DictionaryEntry) {
+	index := make(map[string]string)
+	broader := make(map[string]string)
+	categories := make(map[string]string)
+
+	for _, entry := range entries {
+		canonical := strings.TrimSpace(entry.Canonical)
+		if canonical == "" {
+			continue
+		}
+
+		index[textnorm.Fold(canonical)] = canonical
+		for _, alias := range entry.Aliases {
+			if folded := textnorm.Fold(alias); folded != "" {
+				index[folded] = canonical
+			}
+		}
+
+		if name := strings.TrimSpace(entry.Broader); name != "" {
+			broader[canonical] = name
+		}
+
+		if domain.IsValidCategory(entry.Category) {
+			categories[canonical] = entry.Category
+		}
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.index = index
+	n.broader = broader
+	n.categories = categories
+}
+
+// Canonical returns the canonical name of an ingredient
+func (n *ingredientNormalizerImpl) Canonical(name string) string {
+	canonical, _ := n.Lookup(name)
+	return canonical
+}
+
+// Lookup returns the canonical name of an ingredient and whether it is in the dictionary
+func (n *ingredientNormalizerImpl) Lookup(name string) (string, bool) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	folded := textnorm.Fold(name)
+	if canonical, ok := n.index[folded]; ok {
+		return canonical, true
+	}
+	return folded, false
+}
+
+// Broader returns the more general canonical names of a canonical name, nearest first
+func (n *ingredientNormalizerImpl) Broader(canonical string) []string {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	return n.broaderLocked(canonical)
+}
+
+// Category returns the category of an ingredient, or an empty string when it cannot be determined
+func (n *ingredientNormalizerImpl) Category(name string) string {
+	canonical, _ := n.Lookup(name)
+
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	// Fall back to the categories of more general ingredients
+	for _, candidate := range append([]string{canonical}, n.broaderLocked(canonical)...) {
+		if category, ok := n.categories[candidate]; ok {
+			return category
+		}
+	}
+
+	folded := textnorm.Fold(name)
+	for _, k := range categoryKeywords {
+		if strings.Contains(folded, k.keyword) {
+			return k.category
+		}
+	}
+
+	return ""
+}
+
+// broaderLocked returns the broader chain of a canonical name; callers must hold n.mu
+func (n *ingredientNormalizerImpl) broaderLocked(canonical string) []string {
+	var chain []string
+	seen := map[string]bool{canonical: true}
+
+	for current := canonical; ; {
+		next, ok := n.broader[current]
+		if !ok {
+			break
+		}
+		// Resolve the broader name through the index in case it was written as an alias
+		if resolved, ok := n.index[textnorm.Fold(next)]; ok {
+			next = resolved
+		}
+		if seen[next] {
+			break
+		}
+		seen[next] = true
+		chain = append(chain, next)
+		current = next
+	}
+
+	return chain
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+)
+
+func TestDefaultDictionary(t *testing.T) {
+	entries, err := DefaultDictionary()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(entries) == 0 {
+		t.Fatal("Expected bundled dictionary to contain entries")
+	}
+}
+
+func TestNormalizer_Canonical(t *testing.T) {
+	entries, err := DefaultDictionary()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	normalizer := NewIngredientNormalizer(entries)
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"玉ねぎ", "玉ねぎ"},
+		{"たまねぎ", "玉ねぎ"},
+		{"タマネギ", "玉ねぎ"},
+		{"ﾀﾏﾈｷﾞ", "玉ねぎ"},
+		{" Onions ", "玉ねぎ"},
+		{"Potatoes", "じゃがいも"},
+		{"豚バラ", "豚バラ肉"},
+		{"未知の食材", "未知の食材"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := normalizer.Canonical(tt.input); got != tt.expected {
+				t.Errorf("Canonical(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNormalizer_Lookup(t *testing.T) {
+	normalizer := NewIngredientNormalizer([]domain.DictionaryEntry{
+		{Canonical: "卵", Aliases: []string{"たまご"}},
+	})
+
+	if canonical, ok := normalizer.Lookup("タマゴ"); !ok || canonical != "卵" {
+		t.Errorf("Expected (卵, true), got (%q, %v)", canonical, ok)
+	}
+	if canonical, ok := normalizer.Lookup("Bacon"); ok || canonical != "bacon" {
+		t.Errorf("Expected (bacon, false), got (%q, %v)", canonical, ok)
+	}
+}
+
+func TestNormalizer_Broader(t *testing.T) {
+	normalizer := NewIngredientNormalizer([]domain.DictionaryEntry{
+		{Canonical: "豚肉"},
+		{Canonical: "豚バラ肉", Broader: "豚肉"},
+		{Canonical: "豚バラブロック", Broader: "豚バラ"},
+		{Canonical: "豚バラ", Broader: "豚バラ肉"},
+		// Cycles must not loop forever
+		{Canonical: "A", Broader: "B"},
+		{Canonical: "B", Broader: "A"},
+	})
+
+	if got := normalizer.Broader("豚バラブロック"); !reflect.DeepEqual(got, []string{"豚バラ", "豚バラ肉", "豚肉"}) {
+		t.Errorf("Expected [豚バラ 豚バラ肉 豚肉], got %v", got)
+	}
+	if got := normalizer.Broader("豚肉"); len(got) != 0 {
+		t.Errorf("Expected no broader names, got %v", got)
+	}
+	if got := normalizer.Broader("A"); !reflect.DeepEqual(got, []string{"B"}) {
+		t.Errorf("Expected [B], got %v", got)
+	}
+}
+
+func TestNormalizer_Reload(t *testing.T) {
+	normalizer := NewIngredientNormalizer([]domain.DictionaryEntry{
+		{Canonical: "豚肉", Aliases: []string{"pork"}},
+	})
+
+	normalizer.Reload([]domain.DictionaryEntry{
+		{Canonical: "豚バラ肉", Aliases: []string{"pork belly"}, Broader: "豚肉"},
+	})
+
+	if got := normalizer.Canonical("Pork Belly"); got != "豚バラ肉" {
+		t.Errorf("Expected 豚バラ肉, got %q", got)
+	}
+	if _, ok := normalizer.Lookup("pork"); ok {
+		t.Error("Expected removed alias to be unknown after reload")
+	}
+}
+
+func TestNormalizer_Category(t *testing.T) {
+	normalizer := NewIngredientNormalizer([]domain.DictionaryEntry{
+		{Canonical: "豚肉", Category: domain.CategoryMeat},
+		{Canonical: "豚バラ肉", Aliases: []string{"pork belly"}, Broader: "豚肉"},
+		{Canonical: "にんじん", Aliases: []string{"carrot"}, Category: domain.CategoryVegetable},
+		{Canonical: "謎の食材", Category: "unknown"},
+	})
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Carrots", domain.CategoryVegetable},
+		{"pork belly", domain.CategoryMeat},
+		{"ラム肉", domain.CategoryMeat},
+		{"ウスターソース", domain.CategorySeasoning},
+		{"謎の食材", ""},
+		{"チョコレート", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := normalizer.Category(tt.input); got != tt.expected {
+				t.Errorf("Category(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+)
+
+// NutritionTable defines the interface for looking up nutrition by ingredient name
+type NutritionTable interface {
+	// Lookup returns the nutrition facts of an ingredient, trying its canonical name and
+	// then more general ingredients when the name itself is not in the table
+	Lookup(name string) (*domain.NutritionFacts, bool)
+
+	// ForIngredient returns the nutrition of an inventory item, or nil when it is not in the table
+	ForIngredient(ingredient *domain.Ingredient) *domain.IngredientNutrition
+
+	// EstimateRecipe estimates the nutrition per serving of a recipe from its ingredient lines.
+	// servings defaults to 2 when not positive. It returns nil when no ingredient could be counted.
+	EstimateRecipe(ingredients []domain.RecipeIngredient, servings int) *domain.RecipeNutrition
+}
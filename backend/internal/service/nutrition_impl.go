@@ -0,0 +1,287 @@
+package service
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/pkg/config"
+	"github.com/Rin0530/DinnerDecider/backend/pkg/quantity"
+	"github.com/Rin0530/DinnerDecider/backend/pkg/textnorm"
+	"golang.org/x/text/unicode/norm"
+)
+
+//go:embed data/nutrition.csv
+var defaultNutritionTable []byte
+
+// defaultServings is the number of servings assumed for recipes that do not state one
+const defaultServings = 2
+
+// nutritionColumns lists the columns a nutrition table must have
+var nutritionColumns = []string{"name", "energy_kcal", "protein_g", "fat_g", "carbohydrate_g", "salt_g", "piece_grams", "grams_per_ml"}
+
+// spoonMeasures maps Japanese measuring spoons and cups to milliliters
+var spoonMeasures = []struct {
+	prefix string
+	ml     float64
+}{
+	{"大さじ", 15},
+	{"小さじ", 5},
+	{"カップ", 200},
+}
+
+// unweighedQuantities lists quantities too vague to count, such as "to taste"
+var unweighedQuantities = []string{"適量", "適宜", "少々", "少量", "ひとつまみ", "お好みで"}
+
+// nutritionTableImpl implements NutritionTable interface
+type nutritionTableImpl struct {
+	// facts maps folded food names to their nutrition facts
+	facts map[string]*domain.NutritionFacts
+
+	normalizer IngredientNormalizer
+}
+
+// NewNutritionTable creates a new instance of NutritionTable.
+// The bundled table is used unless cfg.TablePath points to a CSV file with the same columns.
+func NewNutritionTable(cfg *config.NutritionConfig, normalizer IngredientNormalizer) (NutritionTable, error) {
+	data := defaultNutritionTable
+	if cfg.TablePath != "" {
+		fileData, err := os.ReadFile(cfg.TablePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read nutrition table: %w", err)
+		}
+		data = fileData
+	}
+
+	facts, err := parseNutritionTable(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse nutrition table: %w", err)
+	}
+
+	return &nutritionTableImpl{
+		facts:      facts,
+		normalizer: normalizer,
+	}, nil
+}
+
+// parseNutritionTable reads a nutrition CSV; lines starting with # are comments
+func parseNutritionTable(data []byte) (map[string]*domain.NutritionFacts, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte(utf8BOM))))
+	reader.Comment = '#'
+	reader.FieldsPerRecord = len(nutritionColumns)
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	for i, column := range nutritionColumns {
+		if strings.TrimSpace(header[i]) != column {
+			return nil, fmt.Errorf("column %d must be %s, got %q", i+1, column, header[i])
+		}
+	}
+
+	facts := make(map[string]*domain.NutritionFacts)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		name := strings.TrimSpace(record[0])
+		if name == "" {
+			continue
+		}
+
+		values := make([]float64, len(record)-1)
+		for i, field := range record[1:] {
+			field = strings.TrimSpace(field)
+			if field == "" {
+				continue
+			}
+			value, err := strconv.ParseFloat(field, 64)
+			if err != nil || value < 0 {
+				return nil, fmt.Errorf("invalid %s for %s: %q", nutritionColumns[i+1], name, field)
+			}
+			values[i] = value
+		}
+
+		facts[textnorm.Fold(name)] = &domain.NutritionFacts{
+			Name: name,
+			Per100g: domain.Nutrition{
+				EnergyKcal:    values[0],
+				ProteinG:      values[1],
+				FatG:          values[2],
+				CarbohydrateG: values[3],
+				SaltG:         values[4],
+			},
+			PieceGrams: values[5],
+			GramsPerML: values[6],
+		}
+	}
+
+	return facts, nil
+}
+
+// Lookup returns the nutrition facts of an ingredient
+func (t *nutritionTableImpl) Lookup(name string) (*domain.NutritionFacts, bool) {
+	if facts, ok := t.facts[textnorm.Fold(name)]; ok {
+		return facts, true
+	}
+
+	canonical, ok := t.normalizer.Lookup(name)
+	if !ok {
+		return nil, false
+	}
+
+	// Fall back to more general ingredients, so 豚ロース薄切り can use the values of 豚肉
+	for _, candidate := range append([]string{canonical}, t.normalizer.Broader(canonical)...) {
+		if facts, ok := t.facts[textnorm.Fold(candidate)]; ok {
+			return facts, true
+		}
+	}
+	return nil, false
+}
+
+// ForIngredient returns the nutrition of an inventory item
+func (t *nutritionTableImpl) ForIngredient(ingredient *domain.Ingredient) *domain.IngredientNutrition {
+	name := ingredient.CanonicalName
+	if name == "" {
+		name = ingredient.Name
+	}
+
+	facts, ok := t.Lookup(name)
+	if !ok {
+		return nil
+	}
+
+	nutrition := &domain.IngredientNutrition{Per100g: facts.Per100g}
+	if grams, ok := weigh(ingredient.Quantity, facts); ok {
+		total := roundNutrition(scaleNutrition(facts.Per100g, grams/100))
+		nutrition.Total = &total
+	}
+	return nutrition
+}
+
+// EstimateRecipe estimates the nutrition per serving of a recipe
+func (t *nutritionTableImpl) EstimateRecipe(ingredients []domain.RecipeIngredient, servings int) *domain.RecipeNutrition {
+	if servings <= 0 {
+		servings = defaultServings
+	}
+
+	var total domain.Nutrition
+	result := &domain.RecipeNutrition{Servings: servings, Counted: []string{}}
+	for _, ri := range ingredients {
+		facts, ok := t.Lookup(ri.Name)
+		if !ok {
+			result.Uncounted = append(result.Uncounted, ri.Name)
+			continue
+		}
+
+		grams, ok := weigh(ri.Quantity, facts)
+		if !ok {
+			result.Uncounted = append(result.Uncounted, ri.Name)
+			continue
+		}
+
+		total = addNutrition(total, scaleNutrition(facts.Per100g, grams/100))
+		result.Counted = append(result.Counted, ri.Name)
+	}
+
+	if len(result.Counted) == 0 {
+		return nil
+	}
+
+	result.PerServing = roundNutrition(scaleNutrition(total, 1/float64(servings)))
+	return result
+}
+
+// weigh converts a quantity such as "200g", "大さじ2" or "1/2個" to grams
+func weigh(text string, facts *domain.NutritionFacts) (float64, bool) {
+	text = strings.TrimSpace(norm.NFKC.String(text))
+	if text == "" {
+		return 0, false
+	}
+	for _, vague := range unweighedQuantities {
+		if strings.Contains(text, vague) {
+			return 0, false
+		}
+	}
+
+	density := facts.GramsPerML
+	if density <= 0 {
+		density = 1
+	}
+
+	for _, spoon := range spoonMeasures {
+		if rest, ok := strings.CutPrefix(text, spoon.prefix); ok {
+			q, ok := quantity.Parse(rest)
+			if !ok {
+				return 0, false
+			}
+			return q.Amount * spoon.ml * density, true
+		}
+	}
+
+	q, ok := quantity.Parse(text)
+	if !ok {
+		return 0, false
+	}
+
+	if base, ok := quantity.ToBase(q); ok {
+		if base.Unit == "ml" {
+			return base.Amount * density, true
+		}
+		return base.Amount, true
+	}
+
+	// Anything else counts pieces, such as 2個, 1本 or a bare 3
+	if facts.PieceGrams <= 0 {
+		return 0, false
+	}
+	return q.Amount * facts.PieceGrams, true
+}
+
+// scaleNutrition multiplies every nutrient by factor
+func scaleNutrition(n domain.Nutrition, factor float64) domain.Nutrition {
+	return domain.Nutrition{
+		EnergyKcal:    n.EnergyKcal * factor,
+		ProteinG:      n.ProteinG * factor,
+		FatG:          n.FatG * factor,
+		CarbohydrateG: n.CarbohydrateG * factor,
+		SaltG:         n.SaltG * factor,
+	}
+}
+
+// addNutrition sums two amounts of nutrients
+func addNutrition(a domain.Nutrition, b domain.Nutrition) domain.Nutrition {
+	return domain.Nutrition{
+		EnergyKcal:    a.EnergyKcal + b.EnergyKcal,
+		ProteinG:      a.ProteinG + b.ProteinG,
+		FatG:          a.FatG + b.FatG,
+		CarbohydrateG: a.CarbohydrateG + b.CarbohydrateG,
+		SaltG:         a.SaltG + b.SaltG,
+	}
+}
+
+// roundNutrition rounds every nutrient to one decimal place
+func roundNutrition(n domain.Nutrition) domain.Nutrition {
+	round := func(v float64) float64 { return math.Round(v*10) / 10 }
+	return domain.Nutrition{
+		EnergyKcal:    round(n.EnergyKcal),
+		ProteinG:      round(n.ProteinG),
+		FatG:          round(n.FatG),
+		CarbohydrateG: round(n.CarbohydrateG),
+		SaltG:         round(n.SaltG),
+	}
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/pkg/config"
+)
+
+func newDefaultNutritionTable(t *testing.T) NutritionTable {
+	t.Helper()
+	entries, err := DefaultDictionary()
+	if err != nil {
+		t.Fatalf("Failed to load dictionary: %v", err)
+	}
+	table, err := NewNutritionTable(&config.NutritionConfig{}, NewIngredientNormalizer(entries))
+	if err != nil {
+		t.Fatalf("Failed to load nutrition table: %v", err)
+	}
+	return table
+}
+
+func TestNewNutritionTable_CoversDictionary(t *testing.T) {
+	table := newDefaultNutritionTable(t)
+
+	entries, err := DefaultDictionary()
+	if err != nil {
+		t.Fatalf("Failed to load dictionary: %v", err)
+	}
+	for _, entry := range entries {
+		if _, ok := table.Lookup(entry.Canonical); !ok {
+			t.Errorf("Expected %s to be in the bundled nutrition table", entry.Canonical)
+		}
+	}
+}
+
+func TestNewNutritionTable_InvalidFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"wrong header", "name,kcal\n卵,142\n"},
+		{"invalid value", "name,energy_kcal,protein_g,fat_g,carbohydrate_g,salt_g,piece_grams,grams_per_ml\n卵,abc,12.2,10.2,0.4,0.4,50,\n"},
+		{"negative value", "name,energy_kcal,protein_g,fat_g,carbohydrate_g,salt_g,piece_grams,grams_per_ml\n卵,-1,12.2,10.2,0.4,0.4,50,\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "nutrition.csv")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatalf("Failed to write table: %v", err)
+			}
+
+			_, err := NewNutritionTable(&config.NutritionConfig{TablePath: path}, NewIngredientNormalizer(nil))
+			if err == nil {
+				t.Fatal("Expected error for invalid table, got nil")
+			}
+		})
+	}
+}
+
+func TestNutritionTable_Lookup(t *testing.T) {
+	table := newDefaultNutritionTable(t)
+
+	tests := []struct {
+		input    string
+		expected string
+		ok       bool
+	}{
+		{"卵", "卵", true},
+		{"たまねぎ", "玉ねぎ", true},
+		{"ｻﾗﾀﾞ油", "サラダ油", true},
+		{"鶏もも肉", "鶏もも肉", true},
+		{"ドラゴンフルーツ", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			facts, ok := table.Lookup(tt.input)
+			if ok != tt.ok {
+				t.Fatalf("Expected ok=%v, got %v", tt.ok, ok)
+			}
+			if ok && facts.Name != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, facts.Name)
+			}
+		})
+	}
+}
+
+func TestNutritionTable_LookupBroader(t *testing.T) {
+	entries := []domain.DictionaryEntry{
+		{Canonical: "豚肉"},
+		{Canonical: "豚肩ロース", Broader: "豚肉"},
+	}
+	table, err := NewNutritionTable(&config.NutritionConfig{}, NewIngredientNormalizer(entries))
+	if err != nil {
+		t.Fatalf("Failed to load nutrition table: %v", err)
+	}
+
+	facts, ok := table.Lookup("豚肩ロース")
+	if !ok {
+		t.Fatal("Expected 豚肩ロース to fall back to 豚肉")
+	}
+	if facts.Name != "豚肉" {
+		t.Errorf("Expected 豚肉, got %s", facts.Name)
+	}
+}
+
+func TestNutritionTable_ForIngredient(t *testing.T) {
+	table := newDefaultNutritionTable(t)
+
+	tests := []struct {
+		name           string
+		ingredient     *domain.Ingredient
+		expectedTotal  float64
+		expectTotal    bool
+		expectNotFound bool
+	}{
+		{"grams", &domain.Ingredient{Name: "豚バラ肉", CanonicalName: "豚バラ肉", Quantity: "200g"}, 732, true, false},
+		{"pieces", &domain.Ingredient{Name: "卵", CanonicalName: "卵", Quantity: "6個"}, 426, true, false},
+		{"liters", &domain.Ingredient{Name: "牛乳", CanonicalName: "牛乳", Quantity: "1L"}, 628.3, true, false},
+		{"package without weight", &domain.Ingredient{Name: "豚バラ肉", CanonicalName: "豚バラ肉", Quantity: "1パック"}, 0, false, false},
+		{"unknown", &domain.Ingredient{Name: "ドラゴンフルーツ", CanonicalName: "どらごんふるーつ", Quantity: "1個"}, 0, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nutrition := table.ForIngredient(tt.ingredient)
+			if tt.expectNotFound {
+				if nutrition != nil {
+					t.Errorf("Expected no nutrition, got %+v", nutrition)
+				}
+				return
+			}
+			if nutrition == nil {
+				t.Fatal("Expected nutrition, got nil")
+			}
+			if (nutrition.Total != nil) != tt.expectTotal {
+				t.Fatalf("Expected total=%v, got %+v", tt.expectTotal, nutrition.Total)
+			}
+			if tt.expectTotal && nutrition.Total.EnergyKcal != tt.expectedTotal {
+				t.Errorf("Expected %v kcal, got %v", tt.expectedTotal, nutrition.Total.EnergyKcal)
+			}
+		})
+	}
+}
+
+func TestNutritionTable_EstimateRecipe(t *testing.T) {
+	table := newDefaultNutritionTable(t)
+
+	ingredients := []domain.RecipeIngredient{
+		{Name: "鶏もも肉", Quantity: "200g"},
+		{Name: "卵", Quantity: "2個"},
+		{Name: "醤油", Quantity: "大さじ1"},
+		{Name: "塩", Quantity: "少々"},
+		{Name: "謎のスパイス", Quantity: "5g"},
+	}
+
+	result := table.EstimateRecipe(ingredients, 0)
+	if result == nil {
+		t.Fatal("Expected an estimate, got nil")
+	}
+
+	if result.Servings != 2 {
+		t.Errorf("Expected default 2 servings, got %d", result.Servings)
+	}
+	// 鶏もも肉 380 + 卵 142 + 醤油 18g 13.86 = 535.86 kcal over two servings
+	if result.PerServing.EnergyKcal != 267.9 {
+		t.Errorf("Expected 267.9 kcal per serving, got %v", result.PerServing.EnergyKcal)
+	}
+	// 醤油 18g 2.61 + 卵 0.4 + 鶏もも肉 0.4 = 3.41 g of salt over two servings
+	if result.PerServing.SaltG != 1.7 {
+		t.Errorf("Expected 1.7 g salt per serving, got %v", result.PerServing.SaltG)
+	}
+	if len(result.Counted) != 3 {
+		t.Errorf("Expected 3 counted ingredients, got %v", result.Counted)
+	}
+	if len(result.Uncounted) != 2 || result.Uncounted[0] != "塩" || result.Uncounted[1] != "謎のスパイス" {
+		t.Errorf("Expected 塩 and 謎のスパイス to be uncounted, got %v", result.Uncounted)
+	}
+}
+
+func TestNutritionTable_EstimateRecipeNothingCounted(t *testing.T) {
+	table := newDefaultNutritionTable(t)
+
+	result := table.EstimateRecipe([]domain.RecipeIngredient{{Name: "塩", Quantity: "適量"}}, 4)
+	if result != nil {
+		t.Errorf("Expected nil estimate, got %+v", result)
+	}
+}
@@ -2,12 +2,26 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
 )
 
+// SuggestionOptions carries optional context for recipe generation
+type SuggestionOptions struct {
+	// CatalogRecipes are household recipes the LLM should prefer when the ingredients allow
+	CatalogRecipes []*domain.Recipe
+
+	// DietaryProfiles are the household's allergies and restrictions, which the LLM must respect, and dislikes it should avoid
+	DietaryProfiles []*domain.DietaryProfile
+}
+
 // OllamaService defines the interface for interacting with Ollama API
 type OllamaService interface {
 	// GenerateRecipeSuggestion generates recipe suggestions based on available ingredients
-	GenerateRecipeSuggestion(ctx context.Context, ingredients []*domain.Ingredient) (*domain.RecipeResponse, error)
+	GenerateRecipeSuggestion(ctx context.Context, ingredients []*domain.Ingredient, opts SuggestionOptions) (*domain.RecipeResponse, error)
+
+	// ExtractIngredients lists the food items in free text such as a receipt or a shopping note.
+	// Relative dates in the text are resolved against today.
+	ExtractIngredients(ctx context.Context, text string, today time.Time) (*domain.ParsedIngredients, error)
 }
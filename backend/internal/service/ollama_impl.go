@@ -48,13 +48,16 @@ type ollamaResponse struct {
 
 // promptTemplate is the template for generating recipe suggestions
 const promptTemplate = `あなたはプロの料理人兼管理栄養士です。以下の食材を使って作れる、美味しくて簡単な夕食の献立を3つ提案してください。
-それぞれの献立には、料理名、簡単な作り方、そして不足している食材（もしあれば）を記載してください。
+それぞれの献立には、料理名、何人分か、使用する全ての食材と分量、簡単な作り方、そして不足している食材（もしあれば）を記載してください。
+分量は栄養価を計算できるよう、「200g」「2個」「大さじ1」のように数値と単位で書いてください。
 回答は必ずJSON形式で、以下のフォーマットに従ってください。
 
 {
   "suggestions": [
     {
       "name": "料理名",
+      "servings": 2,
+      "ingredients": [{"name": "食材名", "quantity": "分量"}],
       "steps": ["手順1", "手順2", "手順3"],
       "missing_items": ["不足している食材1"]
     }
@@ -62,16 +65,133 @@ const promptTemplate = `あなたはプロの料理人兼管理栄養士です
 }
 
 # 利用可能な食材
+%s%s`
+
+// catalogPromptTemplate is appended to the prompt when catalog recipes are available
+const catalogPromptTemplate = `
+
+# 家族のお気に入りレシピ
+以下は家族が登録しているレシピです。利用可能な食材で作れるものがあれば優先して提案し、料理名はそのまま使ってください。
+%s`
+
+// dietaryPromptTemplate is appended to the prompt when household members have allergies or dietary restrictions
+const dietaryPromptTemplate = `
+
+# 食事制限（厳守）
+以下は家族のアレルギーと食事制限です。健康と安全に関わるため、必ず守ってください。
+該当する食材や、それを含む調味料・加工品は、材料にも不足している食材にも含めないでください。
+%s`
+
+// dislikePromptTemplate is appended to the prompt when household members have disliked foods
+const dislikePromptTemplate = `
+
+# 苦手な食材
+以下の食材はできるだけ使わないでください。
 %s`
 
 // GenerateRecipeSuggestion generates recipe suggestions based on available ingredients
-func (s *ollamaServiceImpl) GenerateRecipeSuggestion(ctx context.Context, ingredients []*domain.Ingredient) (*domain.RecipeResponse, error) {
+func (s *ollamaServiceImpl) GenerateRecipeSuggestion(ctx context.Context, ingredients []*domain.Ingredient, opts SuggestionOptions) (*domain.RecipeResponse, error) {
 	// Format ingredients list
 	ingredientsList := s.formatIngredients(ingredients)
 
+	// Format catalog recipes used to ground the suggestions
+	catalogSection := ""
+	if len(opts.CatalogRecipes) > 0 {
+		catalogSection = fmt.Sprintf(catalogPromptTemplate, s.formatCatalogRecipes(opts.CatalogRecipes))
+	}
+
+	// State the dietary constraints last so the LLM weighs them above the catalog
+	constraints, dislikes := s.formatDietaryProfiles(opts.DietaryProfiles)
+	if constraints != "" {
+		catalogSection += fmt.Sprintf(dietaryPromptTemplate, constraints)
+	}
+	if dislikes != "" {
+		catalogSection += fmt.Sprintf(dislikePromptTemplate, dislikes)
+	}
+
 	// Build prompt
-	prompt := fmt.Sprintf(promptTemplate, ingredientsList)
+	prompt := fmt.Sprintf(promptTemplate, ingredientsList, catalogSection)
+
+	output, err := s.generate(ctx, prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the recipe response from the LLM output
+	var recipeResp domain.RecipeResponse
+	if err := json.Unmarshal([]byte(output), &recipeResp); err != nil {
+		return nil, fmt.Errorf("failed to parse recipe response: %w", err)
+	}
+
+	return &recipeResp, nil
+}
+
+// extractedIngredients is the JSON structure the LLM answers extraction prompts with
+type extractedIngredients struct {
+	PurchaseDate string `json:"purchase_date"`
+	Items        []struct {
+		Name         string `json:"name"`
+		Quantity     string `json:"quantity"`
+		PurchaseDate string `json:"purchase_date"`
+	} `json:"items"`
+}
+
+// extractionPromptTemplate is the template for extracting food items from free text
+const extractionPromptTemplate = `以下のテキストは、スーパーのレシート、または買った食材のメモです。食品だけを抽出してください。
+日用品、割引、小計、合計、税、支払いなどの行は除外してください。
+「昨日」「3日前」などの相対的な日付は、今日を %s として YYYY-MM-DD 形式に変換してください。
+回答は必ずJSON形式で、以下のフォーマットに従ってください。わからない値は空文字にしてください。
+
+{
+  "purchase_date": "テキスト全体の購入日 (YYYY-MM-DD)",
+  "items": [{"name": "食材名", "quantity": "数量と単位", "purchase_date": "その食材の購入日 (YYYY-MM-DD)"}]
+}
+
+# テキスト
+%s`
+
+// ExtractIngredients lists the food items in free text such as a receipt or a shopping note
+func (s *ollamaServiceImpl) ExtractIngredients(ctx context.Context, text string, today time.Time) (*domain.ParsedIngredients, error) {
+	prompt := fmt.Sprintf(extractionPromptTemplate, today.Format("2006-01-02"), text)
 
+	output, err := s.generate(ctx, prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	var extracted extractedIngredients
+	if err := json.Unmarshal([]byte(output), &extracted); err != nil {
+		return nil, fmt.Errorf("failed to parse extraction response: %w", err)
+	}
+
+	// Dates the LLM got wrong are dropped rather than failing the whole extraction
+	result := &domain.ParsedIngredients{PurchaseDate: parseExtractedDate(extracted.PurchaseDate)}
+	for _, item := range extracted.Items {
+		name := strings.TrimSpace(item.Name)
+		if name == "" {
+			continue
+		}
+		result.Items = append(result.Items, domain.ParsedIngredient{
+			Name:         name,
+			Quantity:     strings.TrimSpace(item.Quantity),
+			PurchaseDate: parseExtractedDate(item.PurchaseDate),
+		})
+	}
+
+	return result, nil
+}
+
+// parseExtractedDate parses a YYYY-MM-DD date from LLM output, returning nil when it is missing or malformed
+func parseExtractedDate(value string) *time.Time {
+	date, err := time.Parse("2006-01-02", strings.TrimSpace(value))
+	if err != nil {
+		return nil
+	}
+	return &date
+}
+
+// generate sends a prompt to the Ollama API in JSON mode and returns the raw model output
+func (s *ollamaServiceImpl) generate(ctx context.Context, prompt string) (string, error) {
 	// Create request payload
 	reqPayload := ollamaRequest{
 		Model:  s.config.Model,
@@ -82,14 +202,14 @@ func (s *ollamaServiceImpl) GenerateRecipeSuggestion(ctx context.Context, ingred
 
 	reqBody, err := json.Marshal(reqPayload)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
+		return "", fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	// Create HTTP request
 	endpoint := fmt.Sprintf("%s/api/generate", s.config.Endpoint)
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewBuffer(reqBody))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
@@ -97,43 +217,92 @@ func (s *ollamaServiceImpl) GenerateRecipeSuggestion(ctx context.Context, ingred
 	// Send request
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request to Ollama API: %w", err)
+		return "", fmt.Errorf("failed to send request to Ollama API: %w", err)
 	}
 	defer resp.Body.Close()
 
 	// Check status code
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("Ollama API returned status %d: %s", resp.StatusCode, string(body))
+		return "", fmt.Errorf("Ollama API returned status %d: %s", resp.StatusCode, string(body))
 	}
 
 	// Read response body
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return "", fmt.Errorf("failed to read response body: %w", err)
 	}
 
 	// Parse Ollama response
 	var ollamaResp ollamaResponse
 	if err := json.Unmarshal(body, &ollamaResp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal Ollama response: %w", err)
+		return "", fmt.Errorf("failed to unmarshal Ollama response: %w", err)
 	}
 
-	// Parse the recipe response from the LLM output
-	var recipeResp domain.RecipeResponse
-	if err := json.Unmarshal([]byte(ollamaResp.Response), &recipeResp); err != nil {
-		return nil, fmt.Errorf("failed to parse recipe response: %w", err)
-	}
-
-	return &recipeResp, nil
+	return ollamaResp.Response, nil
 }
 
-// formatIngredients formats the ingredients list into a human-readable string
+// frozenNote is appended to the ingredient list when frozen items are available
+const frozenNote = "※冷凍の食材は解凍が必要です。傷みやすい冷蔵の食材を優先して使ってください。"
+
+// formatIngredients formats the ingredients list into a human-readable string.
+// Ingredients with a storage location are grouped by location and category.
 func (s *ollamaServiceImpl) formatIngredients(ingredients []*domain.Ingredient) string {
 	if len(ingredients) == 0 {
 		return "食材がありません"
 	}
 
+	grouped := false
+	for _, ing := range ingredients {
+		if ing.Location != "" {
+			grouped = true
+			break
+		}
+	}
+	if !grouped {
+		return s.formatIngredientList(ingredients)
+	}
+
+	// Group by location, then category, keeping the display order of each
+	groups := make(map[string]map[string][]*domain.Ingredient)
+	for _, ing := range ingredients {
+		location := ing.Location
+		if !domain.IsValidLocation(location) {
+			location = domain.LocationFridge
+		}
+		category := ing.Category
+		if !domain.IsValidCategory(category) {
+			category = domain.CategoryOther
+		}
+		if groups[location] == nil {
+			groups[location] = make(map[string][]*domain.Ingredient)
+		}
+		groups[location][category] = append(groups[location][category], ing)
+	}
+
+	var lines []string
+	for _, location := range domain.Locations {
+		byCategory, ok := groups[location]
+		if !ok {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("【%s】", domain.LocationLabel(location)))
+		for _, category := range domain.Categories {
+			if items, ok := byCategory[category]; ok {
+				lines = append(lines, fmt.Sprintf("- %s: %s", domain.CategoryLabel(category), s.formatIngredientList(items)))
+			}
+		}
+	}
+
+	if _, ok := groups[domain.LocationFreezer]; ok {
+		lines = append(lines, frozenNote)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// formatIngredientList formats ingredients as a comma-separated list with quantities
+func (s *ollamaServiceImpl) formatIngredientList(ingredients []*domain.Ingredient) string {
 	var parts []string
 	for _, ing := range ingredients {
 		if ing.Quantity != "" {
@@ -145,3 +314,49 @@ func (s *ollamaServiceImpl) formatIngredients(ingredients []*domain.Ingredient)
 
 	return strings.Join(parts, ", ")
 }
+
+// formatCatalogRecipes formats catalog recipes as one line per recipe with its ingredients
+func (s *ollamaServiceImpl) formatCatalogRecipes(recipes []*domain.Recipe) string {
+	var lines []string
+	for _, recipe := range recipes {
+		var names []string
+		for _, ing := range recipe.Ingredients {
+			names = append(names, ing.Name)
+		}
+		lines = append(lines, fmt.Sprintf("- %s: %s", recipe.Name, strings.Join(names, ", ")))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// formatDietaryProfiles formats the allergies and restrictions of each profile, and separately its dislikes,
+// one line per profile
+func (s *ollamaServiceImpl) formatDietaryProfiles(profiles []*domain.DietaryProfile) (string, string) {
+	var constraintLines, dislikeLines []string
+	for _, profile := range profiles {
+		var rules []string
+		if len(profile.Allergens) > 0 {
+			labels := make([]string, 0, len(profile.Allergens))
+			for _, code := range profile.Allergens {
+				if allergen, ok := domain.LookupAllergen(code); ok {
+					labels = append(labels, allergen.Label)
+				}
+			}
+			rules = append(rules, fmt.Sprintf("アレルギー（%s）", strings.Join(labels, "、")))
+		}
+		for _, code := range profile.Restrictions {
+			if restriction, ok := domain.LookupDietaryRestriction(code); ok {
+				rules = append(rules, fmt.Sprintf("%s（%s）", restriction.Label, restriction.Description))
+			}
+		}
+
+		if len(rules) > 0 {
+			constraintLines = append(constraintLines, fmt.Sprintf("- %s: %s", profile.Name, strings.Join(rules, "、")))
+		}
+		if len(profile.Dislikes) > 0 {
+			dislikeLines = append(dislikeLines, fmt.Sprintf("- %s: %s", profile.Name, strings.Join(profile.Dislikes, "、")))
+		}
+	}
+
+	return strings.Join(constraintLines, "\n"), strings.Join(dislikeLines, "\n")
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -78,7 +79,7 @@ func TestGenerateRecipeSuggestion_Success(t *testing.T) {
 
 	// Execute
 	ctx := context.Background()
-	result, err := service.GenerateRecipeSuggestion(ctx, ingredients)
+	result, err := service.GenerateRecipeSuggestion(ctx, ingredients, SuggestionOptions{})
 
 	// Verify
 	if err != nil {
@@ -133,7 +134,7 @@ func TestGenerateRecipeSuggestion_EmptyIngredients(t *testing.T) {
 
 	// Execute with empty ingredients
 	ctx := context.Background()
-	result, err := service.GenerateRecipeSuggestion(ctx, []*domain.Ingredient{})
+	result, err := service.GenerateRecipeSuggestion(ctx, []*domain.Ingredient{}, SuggestionOptions{})
 
 	// Verify
 	if err != nil {
@@ -167,7 +168,7 @@ func TestGenerateRecipeSuggestion_Timeout(t *testing.T) {
 
 	// Execute
 	ctx := context.Background()
-	_, err := service.GenerateRecipeSuggestion(ctx, ingredients)
+	_, err := service.GenerateRecipeSuggestion(ctx, ingredients, SuggestionOptions{})
 
 	// Verify timeout error
 	if err == nil {
@@ -196,7 +197,7 @@ func TestGenerateRecipeSuggestion_HTTPError(t *testing.T) {
 
 	// Execute
 	ctx := context.Background()
-	_, err := service.GenerateRecipeSuggestion(ctx, ingredients)
+	_, err := service.GenerateRecipeSuggestion(ctx, ingredients, SuggestionOptions{})
 
 	// Verify error
 	if err == nil {
@@ -226,7 +227,7 @@ func TestGenerateRecipeSuggestion_InvalidOllamaResponse(t *testing.T) {
 
 	// Execute
 	ctx := context.Background()
-	_, err := service.GenerateRecipeSuggestion(ctx, ingredients)
+	_, err := service.GenerateRecipeSuggestion(ctx, ingredients, SuggestionOptions{})
 
 	// Verify error
 	if err == nil {
@@ -263,7 +264,7 @@ func TestGenerateRecipeSuggestion_InvalidRecipeResponse(t *testing.T) {
 
 	// Execute
 	ctx := context.Background()
-	_, err := service.GenerateRecipeSuggestion(ctx, ingredients)
+	_, err := service.GenerateRecipeSuggestion(ctx, ingredients, SuggestionOptions{})
 
 	// Verify error
 	if err == nil {
@@ -295,7 +296,7 @@ func TestGenerateRecipeSuggestion_ContextCancellation(t *testing.T) {
 	cancel() // Cancel immediately
 
 	// Execute
-	_, err := service.GenerateRecipeSuggestion(ctx, ingredients)
+	_, err := service.GenerateRecipeSuggestion(ctx, ingredients, SuggestionOptions{})
 
 	// Verify context cancellation error
 	if err == nil {
@@ -344,6 +345,24 @@ func TestFormatIngredients(t *testing.T) {
 			},
 			expected: "にんじん(2本), 豚バラ肉(200g), 玉ねぎ",
 		},
+		{
+			name: "Grouped by location and category",
+			ingredients: []*domain.Ingredient{
+				{Name: "鶏もも肉", Quantity: "300g", Category: domain.CategoryMeat, Location: domain.LocationFreezer},
+				{Name: "にんじん", Quantity: "2本", Category: domain.CategoryVegetable, Location: domain.LocationFridge},
+				{Name: "豚バラ肉", Quantity: "200g", Category: domain.CategoryMeat, Location: domain.LocationFridge},
+				{Name: "玉ねぎ", Category: domain.CategoryVegetable, Location: domain.LocationFridge},
+				{Name: "醤油", Category: domain.CategorySeasoning, Location: domain.LocationPantry},
+			},
+			expected: "【冷蔵】\n" +
+				"- 野菜: にんじん(2本), 玉ねぎ\n" +
+				"- 肉: 豚バラ肉(200g)\n" +
+				"【冷凍】\n" +
+				"- 肉: 鶏もも肉(300g)\n" +
+				"【常温】\n" +
+				"- 調味料: 醤油\n" +
+				frozenNote,
+		},
 	}
 
 	for _, tt := range tests {
@@ -364,3 +383,131 @@ func mustMarshalJSON(v interface{}) string {
 	}
 	return string(data)
 }
+
+func TestFormatCatalogRecipes(t *testing.T) {
+	cfg := &config.OllamaConfig{
+		Endpoint: "http://localhost:11434",
+		Model:    "llama2",
+		Timeout:  30 * time.Second,
+	}
+	service := NewOllamaService(cfg).(*ollamaServiceImpl)
+
+	recipes := []*domain.Recipe{
+		{Name: "うちの親子丼", Ingredients: []domain.RecipeIngredient{{Name: "鶏もも肉"}, {Name: "卵"}}},
+		{Name: "冷奴", Ingredients: []domain.RecipeIngredient{{Name: "豆腐"}}},
+	}
+
+	expected := "- うちの親子丼: 鶏もも肉, 卵\n- 冷奴: 豆腐"
+	if result := service.formatCatalogRecipes(recipes); result != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, result)
+	}
+}
+
+func TestFormatDietaryProfiles(t *testing.T) {
+	cfg := &config.OllamaConfig{
+		Endpoint: "http://localhost:11434",
+		Model:    "llama2",
+		Timeout:  30 * time.Second,
+	}
+	service := NewOllamaService(cfg).(*ollamaServiceImpl)
+
+	profiles := []*domain.DietaryProfile{
+		{Name: "太郎", Allergens: []string{domain.AllergenEgg, domain.AllergenPeanut}, Dislikes: []string{"ピーマン"}},
+		{Name: "花子", Restrictions: []string{domain.RestrictionNoPork}},
+		{Name: "次郎"},
+	}
+
+	constraints, dislikes := service.formatDietaryProfiles(profiles)
+
+	expectedConstraints := "- 太郎: アレルギー（卵、落花生）\n- 花子: 豚肉なし（豚肉とその加工品（ベーコン・ハム・ソーセージなど）を使わない）"
+	if constraints != expectedConstraints {
+		t.Errorf("Expected '%s', got '%s'", expectedConstraints, constraints)
+	}
+	if dislikes != "- 太郎: ピーマン" {
+		t.Errorf("Expected '- 太郎: ピーマン', got '%s'", dislikes)
+	}
+}
+
+func TestExtractIngredients_Success(t *testing.T) {
+	mockOllamaResponse := ollamaResponse{
+		Model:     "llama2",
+		CreatedAt: time.Now(),
+		Response: `{"purchase_date": "2026-10-17", "items": [
+			{"name": "卵", "quantity": "10個", "purchase_date": ""},
+			{"name": " 鶏もも肉 ", "quantity": "300g", "purchase_date": "2026-10-16"},
+			{"name": "", "quantity": "1本", "purchase_date": "昨日"}
+		]}`,
+		Done: true,
+	}
+
+	var prompt string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ollamaRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+		prompt = req.Prompt
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(mockOllamaResponse)
+	}))
+	defer server.Close()
+
+	cfg := &config.OllamaConfig{
+		Endpoint: server.URL,
+		Model:    "llama2",
+		Timeout:  30 * time.Second,
+	}
+	service := NewOllamaService(cfg)
+
+	today := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	result, err := service.ExtractIngredients(context.Background(), "卵10個、昨日買った鶏もも300g", today)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !strings.Contains(prompt, "2026-10-18") || !strings.Contains(prompt, "卵10個、昨日買った鶏もも300g") {
+		t.Errorf("Expected prompt to contain today's date and the text, got '%s'", prompt)
+	}
+	if result.PurchaseDate == nil || result.PurchaseDate.Format("2006-01-02") != "2026-10-17" {
+		t.Errorf("Expected purchase date 2026-10-17, got %v", result.PurchaseDate)
+	}
+	if len(result.Items) != 2 {
+		t.Fatalf("Expected 2 items, got %d", len(result.Items))
+	}
+	if result.Items[0].Name != "卵" || result.Items[0].Quantity != "10個" || result.Items[0].PurchaseDate != nil {
+		t.Errorf("Unexpected first item: %+v", result.Items[0])
+	}
+	if result.Items[1].Name != "鶏もも肉" || result.Items[1].PurchaseDate == nil {
+		t.Errorf("Unexpected second item: %+v", result.Items[1])
+	}
+}
+
+func TestExtractIngredients_InvalidResponse(t *testing.T) {
+	mockOllamaResponse := ollamaResponse{
+		Model:     "llama2",
+		CreatedAt: time.Now(),
+		Response:  "invalid json",
+		Done:      true,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(mockOllamaResponse)
+	}))
+	defer server.Close()
+
+	cfg := &config.OllamaConfig{
+		Endpoint: server.URL,
+		Model:    "llama2",
+		Timeout:  30 * time.Second,
+	}
+	service := NewOllamaService(cfg)
+
+	_, err := service.ExtractIngredients(context.Background(), "卵", time.Now())
+	if err == nil {
+		t.Fatal("Expected extraction parse error, got nil")
+	}
+}
@@ -0,0 +1,15 @@
+package service
+
+import (
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+)
+
+// RecipeImporter defines the interface for extracting recipes from schema.org Recipe markup.
+// Parsing happens offline; nothing is fetched from the network.
+type RecipeImporter interface {
+	// ParseJSONLD extracts recipes from a JSON-LD document
+	ParseJSONLD(data []byte) ([]*domain.Recipe, error)
+
+	// ParseHTML extracts recipes from the JSON-LD script blocks of an HTML page
+	ParseHTML(html []byte) ([]*domain.Recipe, error)
+}
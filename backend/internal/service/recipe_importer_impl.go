@@ -0,0 +1,282 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+)
+
+// ErrNoRecipeFound is returned when a document contains no schema.org Recipe
+var ErrNoRecipeFound = errors.New("no schema.org Recipe found")
+
+// jsonLDScriptPattern matches <script type="application/ld+json"> blocks
+var jsonLDScriptPattern = regexp.MustCompile(`(?is)<script[^>]*type\s*=\s*["']?application/ld\+json["']?[^>]*>(.*?)</script>`)
+
+// isoDurationPattern matches ISO 8601 durations such as PT1H30M or P0DT45M
+var isoDurationPattern = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// htmlTagPattern matches HTML tags embedded in text values
+var htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// quantityWords are Japanese quantity expressions that carry no digits
+var quantityWords = []string{"少々", "適量", "適宜", "お好みで", "ひとつまみ", "大さじ", "小さじ", "カップ", "少量"}
+
+// recipeImporterImpl implements RecipeImporter interface
+type recipeImporterImpl struct{}
+
+// NewRecipeImporter creates a new instance of RecipeImporter
+func NewRecipeImporter() RecipeImporter {
+	return &recipeImporterImpl{}
+}
+
+// ParseJSONLD extracts recipes from a JSON-LD document
+func (i *recipeImporterImpl) ParseJSONLD(data []byte) ([]*domain.Recipe, error) {
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON-LD: %w", err)
+	}
+
+	var recipes []*domain.Recipe
+	for _, node := range collectRecipeNodes(doc) {
+		recipe := parseRecipeNode(node)
+		if recipe.Name == "" {
+			continue
+		}
+		recipes = append(recipes, recipe)
+	}
+
+	if len(recipes) == 0 {
+		return nil, ErrNoRecipeFound
+	}
+
+	return recipes, nil
+}
+
+// ParseHTML extracts recipes from the JSON-LD script blocks of an HTML page
+func (i *recipeImporterImpl) ParseHTML(page []byte) ([]*domain.Recipe, error) {
+	var recipes []*domain.Recipe
+	for _, match := range jsonLDScriptPattern.FindAllSubmatch(page, -1) {
+		parsed, err := i.ParseJSONLD(match[1])
+		if err != nil {
+			// Pages often carry unrelated or broken JSON-LD blocks; skip them
+			continue
+		}
+		recipes = append(recipes, parsed...)
+	}
+
+	if len(recipes) == 0 {
+		return nil, ErrNoRecipeFound
+	}
+
+	return recipes, nil
+}
+
+// collectRecipeNodes walks a JSON-LD document and returns every node typed as Recipe
+func collectRecipeNodes(node interface{}) []map[string]interface{} {
+	var nodes []map[string]interface{}
+
+	switch v := node.(type) {
+	case []interface{}:
+		for _, item := range v {
+			nodes = append(nodes, collectRecipeNodes(item)...)
+		}
+	case map[string]interface{}:
+		if hasType(v, "Recipe") {
+			nodes = append(nodes, v)
+		}
+		if graph, ok := v["@graph"]; ok {
+			nodes = append(nodes, collectRecipeNodes(graph)...)
+		}
+		if entity, ok := v["mainEntity"]; ok {
+			nodes = append(nodes, collectRecipeNodes(entity)...)
+		}
+	}
+
+	return nodes
+}
+
+// hasType reports whether a JSON-LD node has the given @type
+func hasType(node map[string]interface{}, typeName string) bool {
+	for _, t := range toStrings(node["@type"]) {
+		if t == typeName || strings.HasSuffix(t, "/"+typeName) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRecipeNode converts a JSON-LD Recipe node into a domain recipe
+func parseRecipeNode(node map[string]interface{}) *domain.Recipe {
+	recipe := &domain.Recipe{
+		Name:        cleanText(firstString(node["name"])),
+		Description: cleanText(firstString(node["description"])),
+		Yield:       cleanText(firstString(node["recipeYield"])),
+		SourceURL:   firstString(node["url"]),
+		Ingredients: []domain.RecipeIngredient{},
+		Steps:       []string{},
+	}
+
+	recipe.PrepTimeMinutes = parseISODuration(firstString(node["prepTime"]))
+	recipe.CookTimeMinutes = parseISODuration(firstString(node["cookTime"]))
+	recipe.TotalTimeMinutes = parseISODuration(firstString(node["totalTime"]))
+
+	ingredientLines := toStrings(node["recipeIngredient"])
+	if len(ingredientLines) == 0 {
+		// "ingredients" is the deprecated name of recipeIngredient
+		ingredientLines = toStrings(node["ingredients"])
+	}
+	for _, line := range ingredientLines {
+		line = cleanText(line)
+		if line == "" {
+			continue
+		}
+		recipe.Ingredients = append(recipe.Ingredients, parseIngredientLine(line))
+	}
+
+	recipe.Steps = append(recipe.Steps, parseInstructions(node["recipeInstructions"])...)
+
+	return recipe
+}
+
+// parseInstructions flattens recipeInstructions, which may be text, HowToStep or HowToSection
+func parseInstructions(value interface{}) []string {
+	var steps []string
+
+	switch v := value.(type) {
+	case string:
+		for _, line := range strings.Split(v, "\n") {
+			if line = cleanText(line); line != "" {
+				steps = append(steps, line)
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			steps = append(steps, parseInstructions(item)...)
+		}
+	case map[string]interface{}:
+		if elements, ok := v["itemListElement"]; ok {
+			steps = append(steps, parseInstructions(elements)...)
+		} else if text := cleanText(firstString(v["text"])); text != "" {
+			steps = append(steps, text)
+		} else if name := cleanText(firstString(v["name"])); name != "" {
+			steps = append(steps, name)
+		}
+	}
+
+	return steps
+}
+
+// parseIngredientLine splits an ingredient line such as "玉ねぎ 1個" or "2 cups flour"
+// into a name and a quantity, keeping the original text
+func parseIngredientLine(line string) domain.RecipeIngredient {
+	ingredient := domain.RecipeIngredient{Name: line, RawText: line}
+
+	fields := strings.FieldsFunc(line, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '　'
+	})
+	if len(fields) < 2 {
+		return ingredient
+	}
+
+	// Japanese style: name first, quantity last ("玉ねぎ 1/2個")
+	if last := fields[len(fields)-1]; isQuantity(last) {
+		ingredient.Name = strings.Join(fields[:len(fields)-1], " ")
+		ingredient.Quantity = last
+		return ingredient
+	}
+
+	// English style: quantity first ("2 cups flour")
+	if isQuantity(fields[0]) {
+		n := 1
+		if len(fields) > 2 && !isQuantity(fields[1]) && len(fields[1]) <= 6 {
+			// Include a unit such as "cups" or "tbsp"
+			n = 2
+		}
+		ingredient.Quantity = strings.Join(fields[:n], " ")
+		ingredient.Name = strings.Join(fields[n:], " ")
+	}
+
+	return ingredient
+}
+
+// isQuantity reports whether a token looks like an amount
+func isQuantity(token string) bool {
+	for _, r := range token {
+		if unicode.IsDigit(r) || strings.ContainsRune("½¼¾⅓⅔", r) {
+			return true
+		}
+	}
+	for _, word := range quantityWords {
+		if strings.HasPrefix(token, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseISODuration converts an ISO 8601 duration to whole minutes
+func parseISODuration(value string) *int {
+	match := isoDurationPattern.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(value)))
+	if match == nil || value == "" {
+		return nil
+	}
+
+	days, _ := strconv.Atoi(match[1])
+	hours, _ := strconv.Atoi(match[2])
+	minutes, _ := strconv.Atoi(match[3])
+	seconds, _ := strconv.ParseFloat(match[4], 64)
+
+	total := days*24*60 + hours*60 + minutes + int(seconds/60)
+	return &total
+}
+
+// firstString returns the first string found in a JSON-LD value
+func firstString(value interface{}) string {
+	values := toStrings(value)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// toStrings converts a JSON-LD value (string, number, array or object) into strings
+func toStrings(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case float64:
+		return []string{strconv.FormatFloat(v, 'f', -1, 64)}
+	case []interface{}:
+		var result []string
+		for _, item := range v {
+			result = append(result, toStrings(item)...)
+		}
+		return result
+	case map[string]interface{}:
+		// Objects such as {"@value": "..."} or {"@id": "..."}
+		if val, ok := v["@value"]; ok {
+			return toStrings(val)
+		}
+		if val, ok := v["text"]; ok {
+			return toStrings(val)
+		}
+		if val, ok := v["@id"]; ok {
+			return toStrings(val)
+		}
+	}
+	return nil
+}
+
+// cleanText unescapes HTML entities, strips tags and collapses whitespace
+func cleanText(s string) string {
+	s = html.UnescapeString(s)
+	s = htmlTagPattern.ReplaceAllString(s, "")
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseJSONLD_SingleRecipe(t *testing.T) {
+	data := []byte(`{
+		"@context": "https://schema.org",
+		"@type": "Recipe",
+		"name": "肉じゃが",
+		"description": "家庭の味 &amp; 定番",
+		"recipeYield": ["4人分"],
+		"prepTime": "PT15M",
+		"cookTime": "PT1H5M",
+		"totalTime": "PT1H20M",
+		"url": "https://example.com/nikujaga",
+		"recipeIngredient": ["じゃがいも　3個", "豚こま肉 200g", "塩 少々", "だし"],
+		"recipeInstructions": [
+			{"@type": "HowToStep", "text": "野菜を切る"},
+			{"@type": "HowToSection", "name": "煮る", "itemListElement": [
+				{"@type": "HowToStep", "text": "肉を炒める"},
+				{"@type": "HowToStep", "text": "<b>20分</b>煮込む"}
+			]}
+		]
+	}`)
+
+	recipes, err := NewRecipeImporter().ParseJSONLD(data)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(recipes) != 1 {
+		t.Fatalf("Expected 1 recipe, got %d", len(recipes))
+	}
+
+	recipe := recipes[0]
+	if recipe.Name != "肉じゃが" {
+		t.Errorf("Expected name '肉じゃが', got '%s'", recipe.Name)
+	}
+	if recipe.Description != "家庭の味 & 定番" {
+		t.Errorf("Expected unescaped description, got '%s'", recipe.Description)
+	}
+	if recipe.Yield != "4人分" {
+		t.Errorf("Expected yield '4人分', got '%s'", recipe.Yield)
+	}
+	if recipe.PrepTimeMinutes == nil || *recipe.PrepTimeMinutes != 15 {
+		t.Errorf("Expected prep time 15, got %v", recipe.PrepTimeMinutes)
+	}
+	if recipe.CookTimeMinutes == nil || *recipe.CookTimeMinutes != 65 {
+		t.Errorf("Expected cook time 65, got %v", recipe.CookTimeMinutes)
+	}
+	if recipe.SourceURL != "https://example.com/nikujaga" {
+		t.Errorf("Expected source URL, got '%s'", recipe.SourceURL)
+	}
+
+	expectedIngredients := []struct{ name, quantity string }{
+		{"じゃがいも", "3個"},
+		{"豚こま肉", "200g"},
+		{"塩", "少々"},
+		{"だし", ""},
+	}
+	if len(recipe.Ingredients) != len(expectedIngredients) {
+		t.Fatalf("Expected %d ingredients, got %d", len(expectedIngredients), len(recipe.Ingredients))
+	}
+	for i, expected := range expectedIngredients {
+		if recipe.Ingredients[i].Name != expected.name || recipe.Ingredients[i].Quantity != expected.quantity {
+			t.Errorf("Expected ingredient %d to be %s/%s, got %s/%s", i,
+				expected.name, expected.quantity, recipe.Ingredients[i].Name, recipe.Ingredients[i].Quantity)
+		}
+	}
+
+	expectedSteps := []string{"野菜を切る", "肉を炒める", "20分煮込む"}
+	if len(recipe.Steps) != len(expectedSteps) {
+		t.Fatalf("Expected %d steps, got %d", len(expectedSteps), len(recipe.Steps))
+	}
+	for i, step := range expectedSteps {
+		if recipe.Steps[i] != step {
+			t.Errorf("Expected step %d to be '%s', got '%s'", i, step, recipe.Steps[i])
+		}
+	}
+}
+
+func TestParseJSONLD_GraphAndTypeArray(t *testing.T) {
+	data := []byte(`{
+		"@context": "https://schema.org",
+		"@graph": [
+			{"@type": "WebPage", "name": "ページ"},
+			{"@type": ["Recipe", "NewsArticle"], "name": "Pancakes",
+			 "recipeIngredient": ["2 cups flour", "1 egg"],
+			 "recipeInstructions": "Mix everything.\nFry."}
+		]
+	}`)
+
+	recipes, err := NewRecipeImporter().ParseJSONLD(data)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(recipes) != 1 || recipes[0].Name != "Pancakes" {
+		t.Fatalf("Expected the Pancakes recipe, got %v", recipes)
+	}
+
+	if recipes[0].Ingredients[0].Name != "flour" || recipes[0].Ingredients[0].Quantity != "2 cups" {
+		t.Errorf("Expected flour/2 cups, got %s/%s", recipes[0].Ingredients[0].Name, recipes[0].Ingredients[0].Quantity)
+	}
+	if recipes[0].Ingredients[1].Name != "egg" || recipes[0].Ingredients[1].Quantity != "1" {
+		t.Errorf("Expected egg/1, got %s/%s", recipes[0].Ingredients[1].Name, recipes[0].Ingredients[1].Quantity)
+	}
+	if len(recipes[0].Steps) != 2 {
+		t.Errorf("Expected 2 steps, got %d", len(recipes[0].Steps))
+	}
+}
+
+func TestParseJSONLD_NoRecipe(t *testing.T) {
+	_, err := NewRecipeImporter().ParseJSONLD([]byte(`{"@type": "Article", "name": "記事"}`))
+	if !errors.Is(err, ErrNoRecipeFound) {
+		t.Errorf("Expected ErrNoRecipeFound, got %v", err)
+	}
+}
+
+func TestParseJSONLD_InvalidJSON(t *testing.T) {
+	_, err := NewRecipeImporter().ParseJSONLD([]byte(`{not json`))
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+}
+
+func TestParseHTML_ExtractsScripts(t *testing.T) {
+	page := []byte(`<!DOCTYPE html>
+<html><head>
+<script type="application/ld+json">{ broken</script>
+<script type='application/ld+json'>
+{"@context": "https://schema.org", "@type": "Recipe", "name": "親子丼", "recipeIngredient": ["鶏もも肉 1枚", "卵 2個"]}
+</script>
+</head><body>親子丼の作り方</body></html>`)
+
+	recipes, err := NewRecipeImporter().ParseHTML(page)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(recipes) != 1 || recipes[0].Name != "親子丼" {
+		t.Fatalf("Expected the 親子丼 recipe, got %v", recipes)
+	}
+	if len(recipes[0].Ingredients) != 2 {
+		t.Errorf("Expected 2 ingredients, got %d", len(recipes[0].Ingredients))
+	}
+}
+
+func TestParseHTML_NoScripts(t *testing.T) {
+	_, err := NewRecipeImporter().ParseHTML([]byte(`<html><body>no data</body></html>`))
+	if !errors.Is(err, ErrNoRecipeFound) {
+		t.Errorf("Expected ErrNoRecipeFound, got %v", err)
+	}
+}
+
+func TestParseISODuration(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int
+		valid    bool
+	}{
+		{"PT30M", 30, true},
+		{"PT1H", 60, true},
+		{"P1DT2H", 1560, true},
+		{"pt45m", 45, true},
+		{"30 minutes", 0, false},
+		{"", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := parseISODuration(tt.input)
+			if !tt.valid {
+				if result != nil {
+					t.Errorf("Expected nil, got %d", *result)
+				}
+				return
+			}
+			if result == nil || *result != tt.expected {
+				t.Errorf("Expected %d, got %v", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"time"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+)
+
+// IngredientTextParser defines the interface for reading ingredients out of free text with deterministic rules
+type IngredientTextParser interface {
+	// ParseReceipt reads the food items, quantities, prices and purchase date from OCR'd receipt text
+	// in the usual Japanese supermarket layout. Lines that are not food are returned as ignored.
+	ParseReceipt(text string) *domain.ParsedIngredients
+
+	// ParseQuickAdd reads the items, quantities and purchase dates from a short shopping note such as
+	// "卵10個と牛乳1本、昨日買った鶏もも300g". Relative dates such as 昨日 are resolved against today.
+	ParseQuickAdd(text string, today time.Time) *domain.ParsedIngredients
+}
@@ -0,0 +1,448 @@
+package service
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"golang.org/x/text/unicode/norm"
+)
+
+// receiptDatePattern matches dates such as 2026年10月17日, 2026/10/17 or 2026-10-17
+var receiptDatePattern = regexp.MustCompile(`(\d{4})\s*[年/.\-]\s*(\d{1,2})\s*[月/.\-]\s*(\d{1,2})`)
+
+// reiwaDatePattern matches Japanese era dates such as 令和8年10月17日
+var reiwaDatePattern = regexp.MustCompile(`令和\s*(\d{1,2}|元)\s*年\s*(\d{1,2})\s*月\s*(\d{1,2})\s*日`)
+
+// decorationPattern matches separator lines made only of symbols
+var decorationPattern = regexp.MustCompile(`^[\s\-=*_~・.#+]+$`)
+
+// receiptPricePattern matches an item line ending in a price, with an optional sign and tax marker
+var receiptPricePattern = regexp.MustCompile(`^(.+?)(?:\s+|\s*[¥\\])\s*([-−△▲]?)\s*[¥\\]?(\d[\d,]*)\s*円?\s*[※*軽内外税非]*$`)
+
+// multiplyPatterns match lines such as "2コX単108 216" or "@108x2" that give the count of the item above
+var multiplyPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^(?P<count>\d+)\s*(?:コ|個|点)?\s*[xX×]\s*(?:@|単)?\s*[¥\\]?(?P<unit>\d[\d,]*)(?:\s+[¥\\]?(?P<total>\d[\d,]*))?\s*[※*軽内外税非]*$`),
+	regexp.MustCompile(`^(?:@|単)\s*[¥\\]?(?P<unit>\d[\d,]*)\s*[xX×]\s*(?P<count>\d+)\s*(?:コ|個|点)?(?:\s+[¥\\]?(?P<total>\d[\d,]*))?\s*[※*軽内外税非]*$`),
+}
+
+// itemPrefixPattern matches reduced-tax markers and product codes printed before item names
+var itemPrefixPattern = regexp.MustCompile(`^(?:[※*◎☆★#・]+\s*|\d{3,13}\s+)+`)
+
+// trailingQuantityPattern matches a quantity at the end of an item name, such as "300g" or "10個入"
+var trailingQuantityPattern = regexp.MustCompile(`(?i)^(.*?)\s*(\d+(?:\.\d+)?)\s*(kg|g|ml|l|個|本|枚|パック|p|袋|玉|束|切|尾|丁|缶|コ)入?り?$`)
+
+// quantityUnitAliases maps receipt abbreviations of units to their usual spelling
+var quantityUnitAliases = map[string]string{
+	"コ": "個",
+	"p": "パック",
+	"P": "パック",
+}
+
+// receiptSummaryKeywords mark lines that describe the purchase rather than an item
+var receiptSummaryKeywords = []string{
+	"計", "税", "預", "釣", "現金", "クレジット", "カード", "ポイント", "点数", "買上", "対象",
+	"電子マネー", "支払", "レジ", "領収", "TEL", "電話", "担当", "NO.", "営業時間", "ありがとう",
+}
+
+// receiptDiscountKeywords mark lines that lower the price of the item above
+var receiptDiscountKeywords = []string{"割引", "値引", "引き", "クーポン", "OFF", "半額"}
+
+// nonFoodKeywords mark household goods sold alongside food
+var nonFoodKeywords = []string{
+	"ティッシュ", "トイレットペーパー", "洗剤", "シャンプー", "リンス", "電池", "ラップ", "ゴミ袋", "ごみ袋",
+	"歯ブラシ", "歯磨", "キッチンペーパー", "ホイル", "柔軟剤", "漂白", "マスク", "綿棒", "石鹸", "ハンドソープ",
+}
+
+// ingredientTextParserImpl implements IngredientTextParser interface
+type ingredientTextParserImpl struct{}
+
+// NewIngredientTextParser creates a new instance of IngredientTextParser
+func NewIngredientTextParser() IngredientTextParser {
+	return &ingredientTextParserImpl{}
+}
+
+// ParseReceipt reads the food items, quantities, prices and purchase date from OCR'd receipt text
+func (p *ingredientTextParserImpl) ParseReceipt(text string) *domain.ParsedIngredients {
+	result := &domain.ParsedIngredients{}
+
+	// pending holds a line without a price that may be an item whose count and price follow on the next line
+	pending := ""
+	flush := func() {
+		if pending != "" {
+			result.Ignored = append(result.Ignored, pending)
+			pending = ""
+		}
+	}
+
+	// last is the item that multiply and discount lines apply to; it is cleared by lines that end an item
+	var last *domain.ParsedIngredient
+	for _, raw := range strings.Split(text, "\n") {
+		// NFKC turns half-width katakana and full-width digits into their usual forms;
+		// the column padding of the receipt is collapsed
+		line := strings.Join(strings.Fields(norm.NFKC.String(raw)), " ")
+		if line == "" || decorationPattern.MatchString(line) {
+			continue
+		}
+
+		if date := parseReceiptDate(line); date != nil {
+			flush()
+			if result.PurchaseDate == nil {
+				result.PurchaseDate = date
+			}
+			result.Ignored = append(result.Ignored, line)
+			last = nil
+			continue
+		}
+
+		if count, total, ok := parseMultiplyLine(line); ok {
+			if pending != "" {
+				if !p.appendItem(result, pending, total, pending) {
+					flush()
+					last = nil
+					continue
+				}
+				pending = ""
+				last = &result.Items[len(result.Items)-1]
+			} else if last == nil {
+				result.Ignored = append(result.Ignored, line)
+				continue
+			}
+			if last.Quantity == "" {
+				last.Quantity = strconv.Itoa(count) + "個"
+			}
+			last.Price = total
+			continue
+		}
+
+		match := receiptPricePattern.FindStringSubmatch(line)
+		if match == nil {
+			flush()
+			if containsAny(strings.ToUpper(line), receiptSummaryKeywords) {
+				result.Ignored = append(result.Ignored, line)
+				last = nil
+				continue
+			}
+			pending = line
+			continue
+		}
+		flush()
+
+		name := strings.TrimSpace(match[1])
+		price, _ := strconv.Atoi(strings.ReplaceAll(match[3], ",", ""))
+
+		if match[2] != "" || containsAny(strings.ToUpper(name), receiptDiscountKeywords) {
+			if last == nil {
+				result.Ignored = append(result.Ignored, line)
+				continue
+			}
+			last.Price -= price
+			continue
+		}
+
+		if containsAny(strings.ToUpper(name), receiptSummaryKeywords) {
+			result.Ignored = append(result.Ignored, line)
+			last = nil
+			continue
+		}
+
+		if !p.appendItem(result, name, price, line) {
+			result.Ignored = append(result.Ignored, line)
+			last = nil
+			continue
+		}
+		last = &result.Items[len(result.Items)-1]
+	}
+	flush()
+
+	return result
+}
+
+// appendItem adds the item named on a receipt line, reporting false when the name is not food
+func (p *ingredientTextParserImpl) appendItem(result *domain.ParsedIngredients, name string, price int, line string) bool {
+	name = strings.TrimSpace(itemPrefixPattern.ReplaceAllString(name, ""))
+	name, quantity := splitTrailingQuantity(name)
+	if name == "" || strings.Trim(name, "0123456789., ") == "" || containsAny(name, nonFoodKeywords) {
+		return false
+	}
+
+	result.Items = append(result.Items, domain.ParsedIngredient{
+		Name:     name,
+		Quantity: quantity,
+		Price:    price,
+		Line:     line,
+	})
+	return true
+}
+
+// splitTrailingQuantity separates a quantity written at the end of an item name
+func splitTrailingQuantity(name string) (string, string) {
+	match := trailingQuantityPattern.FindStringSubmatch(name)
+	if match == nil || strings.TrimSpace(match[1]) == "" {
+		return name, ""
+	}
+
+	unit := match[3]
+	if alias, ok := quantityUnitAliases[unit]; ok {
+		unit = alias
+	}
+	return strings.TrimSpace(match[1]), match[2] + strings.ToLower(unit)
+}
+
+// parseMultiplyLine reads the count and the total price from a multiply line
+func parseMultiplyLine(line string) (int, int, bool) {
+	for _, pattern := range multiplyPatterns {
+		match := pattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		count, _ := strconv.Atoi(match[pattern.SubexpIndex("count")])
+		unit, _ := strconv.Atoi(strings.ReplaceAll(match[pattern.SubexpIndex("unit")], ",", ""))
+		total := count * unit
+		if value := match[pattern.SubexpIndex("total")]; value != "" {
+			total, _ = strconv.Atoi(strings.ReplaceAll(value, ",", ""))
+		}
+		return count, total, true
+	}
+	return 0, 0, false
+}
+
+// parseReceiptDate reads a Gregorian or Reiwa date from a line, returning nil when there is none
+func parseReceiptDate(line string) *time.Time {
+	var year, month, day int
+	if match := receiptDatePattern.FindStringSubmatch(line); match != nil {
+		year, _ = strconv.Atoi(match[1])
+		month, _ = strconv.Atoi(match[2])
+		day, _ = strconv.Atoi(match[3])
+	} else if match := reiwaDatePattern.FindStringSubmatch(line); match != nil {
+		year = 1
+		if match[1] != "元" {
+			year, _ = strconv.Atoi(match[1])
+		}
+		// Reiwa 1 is 2019
+		year += 2018
+		month, _ = strconv.Atoi(match[2])
+		day, _ = strconv.Atoi(match[3])
+	} else {
+		return nil
+	}
+
+	date, ok := calendarDate(year, month, day)
+	if !ok {
+		return nil
+	}
+	return &date
+}
+
+// containsAny reports whether s contains any of the keywords
+func containsAny(s string, keywords []string) bool {
+	for _, keyword := range keywords {
+		if strings.Contains(s, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// quickAddUnits lists the units understood after a number in a shopping note, longest spellings first
+const quickAddUnits = `kg|mg|ml|cc|g|l|個|本|枚|パック|袋|玉|束|切れ|切|尾|丁|缶|株|房|合|杯|箱|瓶|匹|つ|コ|p`
+
+// kanjiQuantityPattern matches kanji numerals followed by a counter, such as 三本 or 十二個
+var kanjiQuantityPattern = regexp.MustCompile(`([一二三四五六七八九十]+)\s*(個|本|枚|パック|袋|玉|束|切れ|切|尾|丁|缶|株|房|合|杯|箱|瓶|匹)`)
+
+// quickAddConjunctionPattern matches the と, や or space that joins an item to the next one after a quantity
+var quickAddConjunctionPattern = regexp.MustCompile(`(?i)(\d\s*(?:` + quickAddUnits + `)?)(?:\s*(?:と|や|&|\+)\s*|\s+)`)
+
+// quickAddSeparatorPattern matches punctuation between the items of a shopping note
+var quickAddSeparatorPattern = regexp.MustCompile(`[、,;。・\n]+`)
+
+// quickAddQuantityPattern matches a quantity together with the particle joining it to the name
+var quickAddQuantityPattern = regexp.MustCompile(`(?i)(?:を|は|[xX×])?\s*(\d+(?:\.\d+|/\d+)?)\s*(` + quickAddUnits + `)?\s*の?`)
+
+// quickAddFillerPattern matches verbs of buying that carry no item information
+var quickAddFillerPattern = regexp.MustCompile(`(?:に|で)?(?:買ってきた|買いました|買った|購入した|購入|もらった|貰った)`)
+
+// relativeDatePatterns match date expressions in a shopping note, resolved against today.
+// Each resolver receives the submatches and returns the date, or false when the text is not a valid date.
+var relativeDatePatterns = []struct {
+	pattern *regexp.Regexp
+	resolve func(match []string, today time.Time) (time.Time, bool)
+}{
+	{regexp.MustCompile(`(\d{4})\s*[年/\-]\s*(\d{1,2})\s*[月/\-]\s*(\d{1,2})\s*日?`), func(match []string, today time.Time) (time.Time, bool) {
+		return calendarDate(atoi(match[1]), atoi(match[2]), atoi(match[3]))
+	}},
+	// M/D needs a particle or the end of the segment after it so that fractions such as 1/2個 are not read as dates
+	{regexp.MustCompile(`(\d{1,2})\s*(?:月\s*(\d{1,2})\s*日|/(\d{1,2})(?:に|の|\s|$))`), func(match []string, today time.Time) (time.Time, bool) {
+		// Dates without a year are in the past year when they would otherwise be in the future
+		day := atoi(match[2] + match[3])
+		date, ok := calendarDate(today.Year(), atoi(match[1]), day)
+		if ok && date.After(today) {
+			date, ok = calendarDate(today.Year()-1, atoi(match[1]), day)
+		}
+		return date, ok
+	}},
+	{regexp.MustCompile(`(\d+)\s*日前`), func(match []string, today time.Time) (time.Time, bool) {
+		return today.AddDate(0, 0, -atoi(match[1])), true
+	}},
+	{regexp.MustCompile(`(\d+)\s*週間前`), func(match []string, today time.Time) (time.Time, bool) {
+		return today.AddDate(0, 0, -7*atoi(match[1])), true
+	}},
+	{regexp.MustCompile(`一昨日|おととい`), func(match []string, today time.Time) (time.Time, bool) {
+		return today.AddDate(0, 0, -2), true
+	}},
+	{regexp.MustCompile(`昨日|きのう`), func(match []string, today time.Time) (time.Time, bool) {
+		return today.AddDate(0, 0, -1), true
+	}},
+	{regexp.MustCompile(`今日|本日|きょう|今朝|さっき`), func(match []string, today time.Time) (time.Time, bool) {
+		return today, true
+	}},
+}
+
+// ParseQuickAdd reads the items, quantities and purchase dates from a short shopping note
+func (p *ingredientTextParserImpl) ParseQuickAdd(text string, today time.Time) *domain.ParsedIngredients {
+	result := &domain.ParsedIngredients{}
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+
+	// current is the date set by a segment that holds only a date, such as the 昨日 in "昨日、卵10個"
+	var current *time.Time
+	// undated are the items read since the last date, which a trailing date applies to
+	var undated []int
+	for _, segment := range splitQuickAdd(text) {
+		date, rest := extractRelativeDate(segment, today)
+		rest = quickAddFillerPattern.ReplaceAllString(rest, "")
+		quantity, rest := extractQuickAddQuantity(rest)
+		name := strings.TrimRight(strings.TrimSpace(rest), "をはが ")
+
+		if name == "" {
+			if date == nil || quantity != "" {
+				result.Ignored = append(result.Ignored, segment)
+				continue
+			}
+			if result.PurchaseDate == nil {
+				result.PurchaseDate = date
+			}
+			for _, i := range undated {
+				result.Items[i].PurchaseDate = date
+			}
+			current, undated = date, nil
+			continue
+		}
+
+		if date == nil {
+			date = current
+			if current == nil {
+				undated = append(undated, len(result.Items))
+			}
+		} else if result.PurchaseDate == nil {
+			result.PurchaseDate = date
+		}
+
+		result.Items = append(result.Items, domain.ParsedIngredient{
+			Name:         name,
+			Quantity:     quantity,
+			PurchaseDate: date,
+			Line:         segment,
+		})
+	}
+
+	return result
+}
+
+// splitQuickAdd normalizes a shopping note and splits it into one segment per item
+func splitQuickAdd(text string) []string {
+	text = norm.NFKC.String(text)
+	text = kanjiQuantityPattern.ReplaceAllStringFunc(text, func(match string) string {
+		sub := kanjiQuantityPattern.FindStringSubmatch(match)
+		return strconv.Itoa(kanjiToNumber(sub[1])) + sub[2]
+	})
+	text = quickAddConjunctionPattern.ReplaceAllString(text, "$1\n")
+
+	var segments []string
+	for _, segment := range quickAddSeparatorPattern.Split(text, -1) {
+		if segment = strings.Join(strings.Fields(segment), " "); segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+// extractRelativeDate finds the first date expression in a segment and returns it with the expression removed
+func extractRelativeDate(segment string, today time.Time) (*time.Time, string) {
+	for _, candidate := range relativeDatePatterns {
+		loc := candidate.pattern.FindStringSubmatchIndex(segment)
+		if loc == nil {
+			continue
+		}
+
+		match := make([]string, len(loc)/2)
+		for i := range match {
+			if loc[2*i] >= 0 {
+				match[i] = segment[loc[2*i]:loc[2*i+1]]
+			}
+		}
+		date, ok := candidate.resolve(match, today)
+		if !ok {
+			continue
+		}
+
+		rest := segment[:loc[0]] + segment[loc[1]:]
+		// Drop the particle that tied the date to the item, as in 昨日の鶏もも or 昨日に買った
+		rest = strings.TrimLeft(strings.TrimSpace(rest), "のに ")
+		return &date, rest
+	}
+	return nil, segment
+}
+
+// extractQuickAddQuantity finds the first quantity in a segment and returns it with the quantity removed
+func extractQuickAddQuantity(segment string) (string, string) {
+	loc := quickAddQuantityPattern.FindStringSubmatchIndex(segment)
+	if loc == nil {
+		return "", segment
+	}
+
+	quantity := segment[loc[2]:loc[3]]
+	if loc[4] >= 0 {
+		unit := segment[loc[4]:loc[5]]
+		if alias, ok := quantityUnitAliases[unit]; ok {
+			unit = alias
+		} else if unit == "つ" {
+			unit = "個"
+		}
+		quantity += strings.ToLower(unit)
+	}
+	return quantity, segment[:loc[0]] + segment[loc[1]:]
+}
+
+// kanjiToNumber converts kanji numerals below one hundred, such as 三 or 二十五, into a number
+func kanjiToNumber(kanji string) int {
+	digits := map[rune]int{'一': 1, '二': 2, '三': 3, '四': 4, '五': 5, '六': 6, '七': 7, '八': 8, '九': 9}
+
+	total, digit := 0, 0
+	for _, r := range kanji {
+		if r == '十' {
+			if digit == 0 {
+				digit = 1
+			}
+			total += digit * 10
+			digit = 0
+			continue
+		}
+		digit = digits[r]
+	}
+	return total + digit
+}
+
+// calendarDate builds a date, reporting false when the day does not exist
+func calendarDate(year, month, day int) (time.Time, bool) {
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	return date, date.Month() == time.Month(month) && date.Day() == day
+}
+
+// atoi converts a string of digits matched by a pattern into a number
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseReceipt_SupermarketReceipt(t *testing.T) {
+	text := `ｽｰﾊﾟｰ ﾃﾞｨﾅｰ 新宿店
+TEL 03-1234-5678
+２０２６年１０月１７日(土) 18:32
+--------------------------------
+*ﾀﾏﾈｷﾞ 3ｺ入          ¥298
+*4901234567890 牛乳 1000ml   ¥238
+*ﾊﾞﾅﾅ
+  2ｺX単108            ¥216
+*鶏もも肉 300g       ¥598
+  値引                  -100
+*ﾆﾝｼﾞﾝ               ¥158
+  @79x2
+ｷｯﾁﾝﾍﾟｰﾊﾟｰ           ¥328
+--------------------------------
+小計               ¥1,836
+外税8%対象額        ¥1,508
+合計               ¥1,938
+お預り             ¥2,000
+お釣り                ¥62`
+
+	result := NewIngredientTextParser().ParseReceipt(text)
+
+	if result.PurchaseDate == nil || result.PurchaseDate.Format("2006-01-02") != "2026-10-17" {
+		t.Errorf("Expected purchase date 2026-10-17, got %v", result.PurchaseDate)
+	}
+
+	expected := []struct {
+		name, quantity string
+		price          int
+	}{
+		{"タマネギ", "3個", 298},
+		{"牛乳", "1000ml", 238},
+		{"バナナ", "2個", 216},
+		{"鶏もも肉", "300g", 498},
+		{"ニンジン", "2個", 158},
+	}
+	if len(result.Items) != len(expected) {
+		t.Fatalf("Expected %d items, got %d: %+v", len(expected), len(result.Items), result.Items)
+	}
+	for i, e := range expected {
+		item := result.Items[i]
+		if item.Name != e.name || item.Quantity != e.quantity || item.Price != e.price {
+			t.Errorf("Item %d: expected %s %s %d, got %s %s %d", i, e.name, e.quantity, e.price, item.Name, item.Quantity, item.Price)
+		}
+	}
+
+	ignored := map[string]bool{}
+	for _, line := range result.Ignored {
+		ignored[line] = true
+	}
+	for _, line := range []string{"キッチンペーパー ¥328", "合計 ¥1,938", "TEL 03-1234-5678", "スーパー ディナー 新宿店"} {
+		if !ignored[line] {
+			t.Errorf("Expected '%s' to be ignored, got %v", line, result.Ignored)
+		}
+	}
+}
+
+func TestParseReceipt_ReiwaDate(t *testing.T) {
+	result := NewIngredientTextParser().ParseReceipt("令和8年10月1日\n豆腐 ¥98")
+
+	if result.PurchaseDate == nil || result.PurchaseDate.Format("2006-01-02") != "2026-10-01" {
+		t.Errorf("Expected purchase date 2026-10-01, got %v", result.PurchaseDate)
+	}
+	if len(result.Items) != 1 || result.Items[0].Name != "豆腐" || result.Items[0].Price != 98 {
+		t.Errorf("Expected a single 豆腐 item, got %+v", result.Items)
+	}
+}
+
+func TestParseReceipt_NoItems(t *testing.T) {
+	result := NewIngredientTextParser().ParseReceipt("合計 ¥0\n値引 -50\n2026/13/40")
+
+	if len(result.Items) != 0 {
+		t.Errorf("Expected no items, got %+v", result.Items)
+	}
+	if result.PurchaseDate != nil {
+		t.Errorf("Expected no purchase date, got %v", result.PurchaseDate)
+	}
+	if len(result.Ignored) != 3 {
+		t.Errorf("Expected 3 ignored lines, got %v", result.Ignored)
+	}
+}
+
+func TestParseQuickAdd(t *testing.T) {
+	today := time.Date(2026, 10, 18, 21, 30, 0, 0, time.Local)
+
+	tests := []struct {
+		name         string
+		text         string
+		purchaseDate string
+		items        []struct{ name, quantity, purchaseDate string }
+	}{
+		{
+			name:         "conjunctions and relative date",
+			text:         "卵10個と牛乳1本、昨日買った鶏もも300g",
+			purchaseDate: "2026-10-17",
+			items: []struct{ name, quantity, purchaseDate string }{
+				{"卵", "10個", ""},
+				{"牛乳", "1本", ""},
+				{"鶏もも", "300g", "2026-10-17"},
+			},
+		},
+		{
+			name:         "leading date applies to the following items",
+			text:         "3日前、にんじん三本 10個の卵",
+			purchaseDate: "2026-10-15",
+			items: []struct{ name, quantity, purchaseDate string }{
+				{"にんじん", "3本", "2026-10-15"},
+				{"卵", "10個", "2026-10-15"},
+			},
+		},
+		{
+			name:         "trailing date applies to the items before it",
+			text:         "鶏もも 300g 昨日",
+			purchaseDate: "2026-10-17",
+			items: []struct{ name, quantity, purchaseDate string }{
+				{"鶏もも", "300g", "2026-10-17"},
+			},
+		},
+		{
+			name:         "month and day in the future are last year",
+			text:         "玉ねぎ1/2個・10/20に買った豆腐",
+			purchaseDate: "2025-10-20",
+			items: []struct{ name, quantity, purchaseDate string }{
+				{"玉ねぎ", "1/2個", ""},
+				{"豆腐", "", "2025-10-20"},
+			},
+		},
+	}
+
+	parser := NewIngredientTextParser()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := parser.ParseQuickAdd(tt.text, today)
+
+			if got := formatTestDate(result.PurchaseDate); got != tt.purchaseDate {
+				t.Errorf("Expected purchase date '%s', got '%s'", tt.purchaseDate, got)
+			}
+			if len(result.Items) != len(tt.items) {
+				t.Fatalf("Expected %d items, got %d: %+v", len(tt.items), len(result.Items), result.Items)
+			}
+			for i, e := range tt.items {
+				item := result.Items[i]
+				if item.Name != e.name || item.Quantity != e.quantity || formatTestDate(item.PurchaseDate) != e.purchaseDate {
+					t.Errorf("Item %d: expected %s %s %s, got %s %s %s",
+						i, e.name, e.quantity, e.purchaseDate, item.Name, item.Quantity, formatTestDate(item.PurchaseDate))
+				}
+			}
+		})
+	}
+}
+
+func TestParseQuickAdd_Ignored(t *testing.T) {
+	result := NewIngredientTextParser().ParseQuickAdd("買った、300g", time.Now())
+
+	if len(result.Items) != 0 {
+		t.Errorf("Expected no items, got %+v", result.Items)
+	}
+	if len(result.Ignored) != 2 {
+		t.Errorf("Expected 2 ignored segments, got %v", result.Ignored)
+	}
+}
+
+// formatTestDate formats an optional date as YYYY-MM-DD, or an empty string when it is nil
+func formatTestDate(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+	return date.Format("2006-01-02")
+}
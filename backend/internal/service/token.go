@@ -0,0 +1,17 @@
+package service
+
+import (
+	"time"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+)
+
+// TokenService defines the interface for the signed session tokens issued at login
+type TokenService interface {
+	// Issue signs a session token for user and returns it together with its expiry
+	Issue(user *domain.User) (string, time.Time, error)
+
+	// Verify checks the signature and expiry of a token and returns the ID of the user it was issued to
+	// together with the user's session version at the time. Invalid or expired tokens are reported as domain.ErrUnauthorized.
+	Verify(token string) (userID int64, sessionVersion int, err error)
+}
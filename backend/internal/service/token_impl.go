@@ -0,0 +1,127 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/pkg/config"
+)
+
+// minTokenSecretLength is the shortest configured secret accepted for signing tokens
+const minTokenSecretLength = 32
+
+// defaultTokenTTL is how long tokens stay valid when no lifetime is configured
+const defaultTokenTTL = 7 * 24 * time.Hour
+
+// tokenHeader is the JOSE header of every token; only HS256 is issued and accepted
+var tokenHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// tokenClaims is the payload of a session token
+type tokenClaims struct {
+	Subject   string `json:"sub"`  // user ID
+	Name      string `json:"name"` // username at the time of issue
+	Version   int    `json:"ver"`  // session version of the user at the time of issue
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// tokenService issues and verifies HS256 JSON Web Tokens
+type tokenService struct {
+	secret []byte
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// NewTokenService creates a new instance of TokenService.
+// Without a configured secret tokens are signed with a random key and stop working when the server restarts.
+func NewTokenService(cfg *config.AuthConfig) (TokenService, error) {
+	secret := []byte(cfg.TokenSecret)
+	if len(secret) == 0 {
+		secret = make([]byte, minTokenSecretLength)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, fmt.Errorf("failed to generate token secret: %w", err)
+		}
+	} else if len(secret) < minTokenSecretLength {
+		return nil, fmt.Errorf("token secret must be at least %d bytes long", minTokenSecretLength)
+	}
+
+	ttl := cfg.TokenTTL
+	if ttl <= 0 {
+		ttl = defaultTokenTTL
+	}
+
+	return &tokenService{
+		secret: secret,
+		ttl:    ttl,
+		now:    time.Now,
+	}, nil
+}
+
+// Issue signs a session token for user and returns it together with its expiry
+func (s *tokenService) Issue(user *domain.User) (string, time.Time, error) {
+	now := s.now()
+	expiresAt := now.Add(s.ttl)
+
+	payload, err := json.Marshal(tokenClaims{
+		Subject:   strconv.FormatInt(user.ID, 10),
+		Name:      user.Username,
+		Version:   user.SessionVersion,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+	})
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to encode token: %w", err)
+	}
+
+	signingInput := tokenHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return signingInput + "." + s.sign(signingInput), expiresAt, nil
+}
+
+// Verify checks the signature and expiry of a token and returns the user it was issued to and their session version
+func (s *tokenService) Verify(token string) (int64, int, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != tokenHeader {
+		return 0, 0, fmt.Errorf("%w: malformed token", domain.ErrUnauthorized)
+	}
+
+	signingInput := parts[0] + "." + parts[1]
+	if !hmac.Equal([]byte(parts[2]), []byte(s.sign(signingInput))) {
+		return 0, 0, fmt.Errorf("%w: invalid token signature", domain.ErrUnauthorized)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: malformed token", domain.ErrUnauthorized)
+	}
+
+	var claims tokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return 0, 0, fmt.Errorf("%w: malformed token", domain.ErrUnauthorized)
+	}
+
+	if s.now().Unix() >= claims.ExpiresAt {
+		return 0, 0, fmt.Errorf("%w: token expired", domain.ErrUnauthorized)
+	}
+
+	userID, err := strconv.ParseInt(claims.Subject, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: malformed token", domain.ErrUnauthorized)
+	}
+
+	return userID, claims.Version, nil
+}
+
+// sign returns the base64url encoded HMAC-SHA256 signature of the signing input
+func (s *tokenService) sign(signingInput string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(signingInput))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
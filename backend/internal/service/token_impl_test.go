@@ -0,0 +1,79 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/pkg/config"
+)
+
+const testTokenSecret = "0123456789abcdef0123456789abcdef"
+
+func TestTokenService_RoundTrip(t *testing.T) {
+	tokens, err := NewTokenService(&config.AuthConfig{TokenSecret: testTokenSecret, TokenTTL: time.Hour})
+	if err != nil {
+		t.Fatalf("Failed to create token service: %v", err)
+	}
+
+	token, expiresAt, err := tokens.Issue(&domain.User{ID: 42, Username: "hanako", SessionVersion: 3})
+	if err != nil {
+		t.Fatalf("Failed to issue token: %v", err)
+	}
+	if time.Until(expiresAt) <= 0 || time.Until(expiresAt) > time.Hour {
+		t.Errorf("Expected the token to expire within an hour, got %v", expiresAt)
+	}
+
+	userID, sessionVersion, err := tokens.Verify(token)
+	if err != nil {
+		t.Fatalf("Expected the token to verify, got %v", err)
+	}
+	if userID != 42 {
+		t.Errorf("Expected user 42, got %d", userID)
+	}
+	if sessionVersion != 3 {
+		t.Errorf("Expected session version 3, got %d", sessionVersion)
+	}
+}
+
+func TestTokenService_RejectsInvalidTokens(t *testing.T) {
+	issuer, _ := NewTokenService(&config.AuthConfig{TokenSecret: testTokenSecret})
+	token, _, _ := issuer.Issue(&domain.User{ID: 1, Username: "taro"})
+
+	other, _ := NewTokenService(&config.AuthConfig{TokenSecret: strings.Repeat("x", 32)})
+	expired := issuer.(*tokenService)
+	past := *expired
+	past.now = func() time.Time { return time.Now().Add(-30 * 24 * time.Hour) }
+	oldToken, _, _ := past.Issue(&domain.User{ID: 1, Username: "taro"})
+
+	parts := strings.Split(token, ".")
+	tampered := parts[0] + "." + strings.TrimRight(parts[1], "=") + "e30." + parts[2]
+
+	tests := []struct {
+		name    string
+		service TokenService
+		token   string
+	}{
+		{"other secret", other, token},
+		{"expired", issuer, oldToken},
+		{"tampered payload", issuer, tampered},
+		{"malformed", issuer, "not-a-token"},
+		{"unsigned", issuer, parts[0] + "." + parts[1] + "."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := tt.service.Verify(tt.token); !errors.Is(err, domain.ErrUnauthorized) {
+				t.Errorf("Expected ErrUnauthorized, got %v", err)
+			}
+		})
+	}
+}
+
+func TestNewTokenService_ShortSecret(t *testing.T) {
+	if _, err := NewTokenService(&config.AuthConfig{TokenSecret: "short"}); err == nil {
+		t.Error("Expected an error for a secret shorter than 32 bytes")
+	}
+}
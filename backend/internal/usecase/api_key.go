@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+)
+
+// APIKeyUsecase defines the business logic interface for API keys used by scripts and devices
+type APIKeyUsecase interface {
+	// Authenticate resolves an API key to the key and the user it acts on behalf of, and records its use
+	Authenticate(ctx context.Context, key string) (*domain.User, *domain.APIKey, error)
+
+	// ListKeys retrieves the API keys of the current household; only its owners may see them
+	ListKeys(ctx context.Context) ([]*domain.APIKey, error)
+
+	// CreateKey creates an API key for the current household; only its owners may do so
+	CreateKey(ctx context.Context, req CreateAPIKeyRequest) (*CreateAPIKeyResponse, error)
+
+	// RevokeKey deletes an API key of the current household; only its owners may do so
+	RevokeKey(ctx context.Context, id int64) error
+}
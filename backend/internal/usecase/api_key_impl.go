@@ -0,0 +1,209 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/internal/repository"
+	"github.com/Rin0530/DinnerDecider/backend/pkg/logger"
+)
+
+// APIKeyPrefix starts every API key, which tells them apart from session tokens
+const APIKeyPrefix = "ddk_"
+
+// API key limits
+const (
+	apiKeyRandomBytes   = 32
+	apiKeyPrefixLength  = len(APIKeyPrefix) + 8 // stored in plain text to tell keys apart
+	maxAPIKeyNameLength = 64
+
+	// apiKeyTouchInterval keeps busy devices from writing the last-used time on every request
+	apiKeyTouchInterval = time.Minute
+)
+
+// errInvalidAPIKey is returned for unknown and revoked API keys alike
+var errInvalidAPIKey = fmt.Errorf("%w: invalid or revoked api key", domain.ErrUnauthorized)
+
+// apiKeyUsecase implements the APIKeyUsecase interface
+type apiKeyUsecase struct {
+	repo  repository.APIKeyRepository
+	users repository.UserRepository
+	now   func() time.Time
+}
+
+// NewAPIKeyUsecase creates a new instance of APIKeyUsecase
+func NewAPIKeyUsecase(repo repository.APIKeyRepository, users repository.UserRepository) APIKeyUsecase {
+	return &apiKeyUsecase{
+		repo:  repo,
+		users: users,
+		now:   time.Now,
+	}
+}
+
+// Authenticate resolves an API key to the key and the user it acts on behalf of, and records its use
+func (u *apiKeyUsecase) Authenticate(ctx context.Context, key string) (*domain.User, *domain.APIKey, error) {
+	if !strings.HasPrefix(key, APIKeyPrefix) {
+		return nil, nil, errInvalidAPIKey
+	}
+
+	apiKey, err := u.repo.GetByHash(ctx, hashAPIKey(key))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, errInvalidAPIKey
+		}
+		return nil, nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	// Keys of deleted users stop working with their sessions
+	user, err := u.users.GetByID(ctx, apiKey.UserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, fmt.Errorf("%w: the user of this api key no longer exists", domain.ErrUnauthorized)
+		}
+		return nil, nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// The last-used time is informational, so failing to record it does not reject the request
+	now := u.now()
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= apiKeyTouchInterval {
+		if err := u.repo.TouchLastUsed(ctx, apiKey.ID, now); err != nil {
+			logger.WithError(err).Warn("Failed to record api key use")
+		} else {
+			apiKey.LastUsedAt = &now
+		}
+	}
+
+	return user, apiKey, nil
+}
+
+// ListKeys retrieves the API keys of the current household
+func (u *apiKeyUsecase) ListKeys(ctx context.Context) ([]*domain.APIKey, error) {
+	household, err := requireHouseholdOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := u.repo.GetByHousehold(ctx, household.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// CreateKey creates an API key for the current household
+func (u *apiKeyUsecase) CreateKey(ctx context.Context, req CreateAPIKeyRequest) (*CreateAPIKeyResponse, error) {
+	household, err := requireHouseholdOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: api key name cannot be empty", domain.ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(name) > maxAPIKeyNameLength {
+		return nil, fmt.Errorf("%w: api key name must be at most %d characters", domain.ErrInvalidInput, maxAPIKeyNameLength)
+	}
+
+	scopes, err := validateAPIKeyScopes(req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := newAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey := &domain.APIKey{
+		Name:        name,
+		Prefix:      key[:apiKeyPrefixLength],
+		KeyHash:     hashAPIKey(key),
+		Scopes:      scopes,
+		UserID:      domain.CurrentUser(ctx).ID,
+		HouseholdID: household.ID,
+	}
+	if err := u.repo.Create(ctx, apiKey); err != nil {
+		return nil, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	return &CreateAPIKeyResponse{Key: key, APIKey: apiKey}, nil
+}
+
+// RevokeKey deletes an API key of the current household
+func (u *apiKeyUsecase) RevokeKey(ctx context.Context, id int64) error {
+	household, err := requireHouseholdOwner(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := u.repo.Delete(ctx, household.ID, id); err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	return nil
+}
+
+// requireHouseholdOwner returns the current household when the signed-in user owns it.
+// Requests made with an API key are rejected so a leaked key cannot mint more keys.
+func requireHouseholdOwner(ctx context.Context) (*domain.Household, error) {
+	if _, err := signedInUser(ctx); err != nil {
+		return nil, err
+	}
+	if domain.CurrentAPIKey(ctx) != nil {
+		return nil, fmt.Errorf("%w: api keys cannot manage api keys; sign in instead", domain.ErrForbidden)
+	}
+
+	household := domain.CurrentHousehold(ctx)
+	if household == nil || household.Role != domain.HouseholdRoleOwner {
+		return nil, fmt.Errorf("%w: only owners of the household can manage its api keys", domain.ErrForbidden)
+	}
+
+	return household, nil
+}
+
+// validateAPIKeyScopes checks that scopes is a non-empty list of known scopes and removes duplicates
+func validateAPIKeyScopes(scopes []string) ([]string, error) {
+	valid := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if !domain.IsValidAPIKeyScope(scope) {
+			return nil, fmt.Errorf("%w: unknown scope %q; use one of %s", domain.ErrInvalidInput, scope, strings.Join(domain.APIKeyScopes, ", "))
+		}
+		if !slices.Contains(valid, scope) {
+			valid = append(valid, scope)
+		}
+	}
+
+	if len(valid) == 0 {
+		return nil, fmt.Errorf("%w: an api key needs at least one scope", domain.ErrInvalidInput)
+	}
+
+	return valid, nil
+}
+
+// newAPIKey generates a random API key
+func newAPIKey() (string, error) {
+	random := make([]byte, apiKeyRandomBytes)
+	if _, err := rand.Read(random); err != nil {
+		return "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	return APIKeyPrefix + hex.EncodeToString(random), nil
+}
+
+// hashAPIKey returns the hex-encoded SHA-256 hash under which a key is stored.
+// Keys are long random strings, so unlike passwords they need no slow, salted hash.
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,179 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAPIKeyRepository is a mock implementation of APIKeyRepository
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyRepository) GetByHousehold(ctx context.Context, householdID int64) ([]*domain.APIKey, error) {
+	args := m.Called(ctx, householdID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) GetByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	args := m.Called(ctx, keyHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) Create(ctx context.Context, key *domain.APIKey) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) Delete(ctx context.Context, householdID int64, id int64) error {
+	args := m.Called(ctx, householdID, id)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) TouchLastUsed(ctx context.Context, id int64, at time.Time) error {
+	args := m.Called(ctx, id, at)
+	return args.Error(0)
+}
+
+// ownerOf returns a context whose requests are made by the user with the given ID as the owner of household 2
+func ownerOf(id int64) context.Context {
+	return domain.WithHousehold(signedIn(id), &domain.Household{ID: 2, Role: domain.HouseholdRoleOwner})
+}
+
+// TestAuthenticateAPIKey_Success tests that keys are looked up by hash and their use is recorded
+func TestAuthenticateAPIKey_Success(t *testing.T) {
+	mockRepo := new(MockAPIKeyRepository)
+	mockUsers := new(MockUserRepository)
+	usecase := NewAPIKeyUsecase(mockRepo, mockUsers)
+
+	mockRepo.On("GetByHash", mock.Anything, hashAPIKey("ddk_secret")).
+		Return(&domain.APIKey{ID: 3, UserID: 1, HouseholdID: 2}, nil)
+	mockUsers.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1, Username: "hanako"}, nil)
+	mockRepo.On("TouchLastUsed", mock.Anything, int64(3), mock.Anything).Return(nil)
+
+	user, key, err := usecase.Authenticate(context.Background(), "ddk_secret")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "hanako", user.Username)
+	assert.NotNil(t, key.LastUsedAt)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestAuthenticateAPIKey_RecentlyUsed tests that keys used moments ago are not written again
+func TestAuthenticateAPIKey_RecentlyUsed(t *testing.T) {
+	mockRepo := new(MockAPIKeyRepository)
+	mockUsers := new(MockUserRepository)
+	usecase := NewAPIKeyUsecase(mockRepo, mockUsers)
+
+	lastUsed := time.Now().Add(-10 * time.Second)
+	mockRepo.On("GetByHash", mock.Anything, mock.Anything).
+		Return(&domain.APIKey{ID: 3, UserID: 1, HouseholdID: 2, LastUsedAt: &lastUsed}, nil)
+	mockUsers.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1, Username: "hanako"}, nil)
+
+	_, _, err := usecase.Authenticate(context.Background(), "ddk_secret")
+
+	assert.NoError(t, err)
+	mockRepo.AssertNotCalled(t, "TouchLastUsed", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestAuthenticateAPIKey_Revoked tests that unknown and revoked keys are rejected with 401
+func TestAuthenticateAPIKey_Revoked(t *testing.T) {
+	mockRepo := new(MockAPIKeyRepository)
+	mockUsers := new(MockUserRepository)
+	usecase := NewAPIKeyUsecase(mockRepo, mockUsers)
+
+	mockRepo.On("GetByHash", mock.Anything, mock.Anything).
+		Return(nil, fmt.Errorf("api key not found: %w", sql.ErrNoRows))
+
+	user, key, err := usecase.Authenticate(context.Background(), "ddk_revoked")
+
+	assert.Nil(t, user)
+	assert.Nil(t, key)
+	assert.ErrorIs(t, err, domain.ErrUnauthorized)
+}
+
+// TestCreateAPIKey_Success tests that only the hash and a short prefix of a new key are stored
+func TestCreateAPIKey_Success(t *testing.T) {
+	mockRepo := new(MockAPIKeyRepository)
+	usecase := NewAPIKeyUsecase(mockRepo, new(MockUserRepository))
+
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.APIKey")).Return(nil)
+
+	response, err := usecase.CreateKey(ownerOf(1), CreateAPIKeyRequest{
+		Name:   " 台所タブレット ",
+		Scopes: []string{domain.APIKeyScopeInventoryRead, domain.APIKeyScopeRecipesRead, domain.APIKeyScopeInventoryRead},
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(response.Key, APIKeyPrefix))
+	assert.Equal(t, "台所タブレット", response.APIKey.Name)
+	assert.Equal(t, hashAPIKey(response.Key), response.APIKey.KeyHash)
+	assert.True(t, strings.HasPrefix(response.Key, response.APIKey.Prefix))
+	assert.Less(t, len(response.APIKey.Prefix), len(response.Key))
+	assert.Equal(t, []string{domain.APIKeyScopeInventoryRead, domain.APIKeyScopeRecipesRead}, response.APIKey.Scopes)
+	assert.Equal(t, int64(1), response.APIKey.UserID)
+	assert.Equal(t, int64(2), response.APIKey.HouseholdID)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestCreateAPIKey_UnknownScope tests that scopes are validated
+func TestCreateAPIKey_UnknownScope(t *testing.T) {
+	mockRepo := new(MockAPIKeyRepository)
+	usecase := NewAPIKeyUsecase(mockRepo, new(MockUserRepository))
+
+	response, err := usecase.CreateKey(ownerOf(1), CreateAPIKeyRequest{Name: "ラズパイ", Scopes: []string{"admin"}})
+
+	assert.Nil(t, response)
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+// TestCreateAPIKey_NotAllowed tests that members and API keys themselves cannot create keys
+func TestCreateAPIKey_NotAllowed(t *testing.T) {
+	mockRepo := new(MockAPIKeyRepository)
+	usecase := NewAPIKeyUsecase(mockRepo, new(MockUserRepository))
+	req := CreateAPIKeyRequest{Name: "ラズパイ", Scopes: []string{domain.APIKeyScopeRecipesRead}}
+
+	member := domain.WithHousehold(signedIn(1), &domain.Household{ID: 2, Role: domain.HouseholdRoleMember})
+	_, err := usecase.CreateKey(member, req)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+
+	withKey := domain.WithAPIKey(ownerOf(1), &domain.APIKey{ID: 3, Scopes: domain.APIKeyScopes})
+	_, err = usecase.CreateKey(withKey, req)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+// TestResolveHousehold_APIKey tests that API keys stay in the household they were created in
+func TestResolveHousehold_APIKey(t *testing.T) {
+	mockRepo := new(MockHouseholdRepository)
+	usecase := NewHouseholdUsecase(mockRepo)
+	ctx := domain.WithAPIKey(signedIn(7), &domain.APIKey{ID: 3, UserID: 7, HouseholdID: 5})
+
+	mockRepo.On("GetMembership", mock.Anything, int64(5), int64(7)).
+		Return(&domain.Household{ID: 5, Role: domain.HouseholdRoleMember}, nil)
+
+	household, err := usecase.Resolve(ctx, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), household.ID)
+
+	household, err = usecase.Resolve(ctx, 2)
+	assert.Nil(t, household)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	mockRepo.AssertExpectations(t)
+}
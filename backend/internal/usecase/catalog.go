@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+)
+
+// CatalogUsecase defines the business logic interface for the local recipe catalog
+type CatalogUsecase interface {
+	// ImportRecipes parses schema.org Recipe markup and stores the recipes found
+	ImportRecipes(ctx context.Context, req ImportRecipesRequest) ([]*domain.Recipe, error)
+
+	// SearchRecipes retrieves catalog recipes by name or ingredient
+	SearchRecipes(ctx context.Context, req SearchRecipesRequest) ([]*domain.Recipe, error)
+
+	// GetRecipe retrieves a catalog recipe by ID
+	GetRecipe(ctx context.Context, id int64) (*domain.Recipe, error)
+
+	// DeleteRecipe deletes a catalog recipe by ID
+	DeleteRecipe(ctx context.Context, id int64) error
+}
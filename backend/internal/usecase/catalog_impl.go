@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/internal/repository"
+	"github.com/Rin0530/DinnerDecider/backend/internal/service"
+)
+
+// catalogUsecase implements the CatalogUsecase interface
+type catalogUsecase struct {
+	repo     repository.RecipeRepository
+	importer service.RecipeImporter
+}
+
+// NewCatalogUsecase creates a new instance of CatalogUsecase
+func NewCatalogUsecase(repo repository.RecipeRepository, importer service.RecipeImporter) CatalogUsecase {
+	return &catalogUsecase{
+		repo:     repo,
+		importer: importer,
+	}
+}
+
+// ImportRecipes parses schema.org Recipe markup and stores the recipes found
+func (u *catalogUsecase) ImportRecipes(ctx context.Context, req ImportRecipesRequest) ([]*domain.Recipe, error) {
+	if err := checkWritable(ctx); err != nil {
+		return nil, err
+	}
+
+	content := strings.TrimSpace(req.Content)
+	if content == "" {
+		return nil, fmt.Errorf("%w: content is required", domain.ErrInvalidInput)
+	}
+
+	// Detect the format from the content when not specified
+	format := req.Format
+	if format == "" {
+		format = ImportFormatHTML
+		if strings.HasPrefix(content, "{") || strings.HasPrefix(content, "[") {
+			format = ImportFormatJSONLD
+		}
+	}
+
+	var recipes []*domain.Recipe
+	var err error
+	switch format {
+	case ImportFormatJSONLD:
+		recipes, err = u.importer.ParseJSONLD([]byte(content))
+	case ImportFormatHTML:
+		recipes, err = u.importer.ParseHTML([]byte(content))
+	default:
+		return nil, fmt.Errorf("%w: unsupported format %q", domain.ErrInvalidInput, format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	// Save each recipe to repository
+	for _, recipe := range recipes {
+		if err := u.repo.Create(ctx, recipe); err != nil {
+			return nil, fmt.Errorf("failed to import recipe %q: %w", recipe.Name, err)
+		}
+	}
+
+	return recipes, nil
+}
+
+// SearchRecipes retrieves catalog recipes by name or ingredient
+func (u *catalogUsecase) SearchRecipes(ctx context.Context, req SearchRecipesRequest) ([]*domain.Recipe, error) {
+	recipes, err := u.repo.Search(ctx, repository.RecipeFilter{
+		Query:      strings.TrimSpace(req.Query),
+		Ingredient: strings.TrimSpace(req.Ingredient),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search recipes: %w", err)
+	}
+
+	// Return empty slice instead of nil if no recipes found
+	if recipes == nil {
+		return []*domain.Recipe{}, nil
+	}
+
+	return recipes, nil
+}
+
+// GetRecipe retrieves a catalog recipe by ID
+func (u *catalogUsecase) GetRecipe(ctx context.Context, id int64) (*domain.Recipe, error) {
+	recipe, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recipe by id: %w", err)
+	}
+
+	return recipe, nil
+}
+
+// DeleteRecipe deletes a catalog recipe by ID
+func (u *catalogUsecase) DeleteRecipe(ctx context.Context, id int64) error {
+	recipe, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get recipe by id: %w", err)
+	}
+
+	if err := checkOwner(ctx, recipe.OwnerID); err != nil {
+		return err
+	}
+
+	if err := u.repo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete recipe: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,224 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRecipeRepository is a mock implementation of RecipeRepository
+type MockRecipeRepository struct {
+	mock.Mock
+}
+
+func (m *MockRecipeRepository) Create(ctx context.Context, recipe *domain.Recipe) error {
+	args := m.Called(ctx, recipe)
+	return args.Error(0)
+}
+
+func (m *MockRecipeRepository) GetByID(ctx context.Context, id int64) (*domain.Recipe, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Recipe), args.Error(1)
+}
+
+func (m *MockRecipeRepository) Search(ctx context.Context, filter repository.RecipeFilter) ([]*domain.Recipe, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Recipe), args.Error(1)
+}
+
+func (m *MockRecipeRepository) Delete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// MockRecipeImporter is a mock implementation of RecipeImporter
+type MockRecipeImporter struct {
+	mock.Mock
+}
+
+func (m *MockRecipeImporter) ParseJSONLD(data []byte) ([]*domain.Recipe, error) {
+	args := m.Called(data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Recipe), args.Error(1)
+}
+
+func (m *MockRecipeImporter) ParseHTML(html []byte) ([]*domain.Recipe, error) {
+	args := m.Called(html)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Recipe), args.Error(1)
+}
+
+// TestImportRecipes_DetectsJSONLD tests that JSON content is parsed as JSON-LD and stored
+func TestImportRecipes_DetectsJSONLD(t *testing.T) {
+	mockRepo := new(MockRecipeRepository)
+	mockImporter := new(MockRecipeImporter)
+	usecase := NewCatalogUsecase(mockRepo, mockImporter)
+
+	content := `{"@type": "Recipe", "name": "肉じゃが"}`
+	parsed := []*domain.Recipe{{Name: "肉じゃが"}}
+
+	mockImporter.On("ParseJSONLD", []byte(content)).Return(parsed, nil)
+	mockRepo.On("Create", mock.Anything, parsed[0]).Return(nil).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(*domain.Recipe).ID = 1
+		})
+
+	result, err := usecase.ImportRecipes(context.Background(), ImportRecipesRequest{Content: "  " + content})
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, int64(1), result[0].ID)
+	mockImporter.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestImportRecipes_HTML tests importing recipes from pasted HTML
+func TestImportRecipes_HTML(t *testing.T) {
+	mockRepo := new(MockRecipeRepository)
+	mockImporter := new(MockRecipeImporter)
+	usecase := NewCatalogUsecase(mockRepo, mockImporter)
+
+	content := `<html><script type="application/ld+json">{}</script></html>`
+	parsed := []*domain.Recipe{{Name: "カレー"}, {Name: "豚汁"}}
+
+	mockImporter.On("ParseHTML", []byte(content)).Return(parsed, nil)
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Recipe")).Return(nil)
+
+	result, err := usecase.ImportRecipes(context.Background(), ImportRecipesRequest{Content: content})
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	mockRepo.AssertNumberOfCalls(t, "Create", 2)
+}
+
+// TestImportRecipes_NoRecipe tests that parse failures are reported as invalid input
+func TestImportRecipes_NoRecipe(t *testing.T) {
+	mockRepo := new(MockRecipeRepository)
+	mockImporter := new(MockRecipeImporter)
+	usecase := NewCatalogUsecase(mockRepo, mockImporter)
+
+	mockImporter.On("ParseHTML", mock.Anything).Return(nil, errors.New("no schema.org Recipe found"))
+
+	result, err := usecase.ImportRecipes(context.Background(), ImportRecipesRequest{Content: "<html></html>"})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+	mockRepo.AssertNotCalled(t, "Create")
+}
+
+// TestImportRecipes_InvalidRequest tests validation of empty content and unknown formats
+func TestImportRecipes_InvalidRequest(t *testing.T) {
+	mockRepo := new(MockRecipeRepository)
+	mockImporter := new(MockRecipeImporter)
+	usecase := NewCatalogUsecase(mockRepo, mockImporter)
+
+	_, err := usecase.ImportRecipes(context.Background(), ImportRecipesRequest{Content: "   "})
+	assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+
+	_, err = usecase.ImportRecipes(context.Background(), ImportRecipesRequest{Format: "pdf", Content: "x"})
+	assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+	assert.Contains(t, err.Error(), "unsupported format")
+}
+
+// TestImportRecipes_RepositoryError tests error handling when saving fails
+func TestImportRecipes_RepositoryError(t *testing.T) {
+	mockRepo := new(MockRecipeRepository)
+	mockImporter := new(MockRecipeImporter)
+	usecase := NewCatalogUsecase(mockRepo, mockImporter)
+
+	mockImporter.On("ParseJSONLD", mock.Anything).Return([]*domain.Recipe{{Name: "肉じゃが"}}, nil)
+	mockRepo.On("Create", mock.Anything, mock.Anything).Return(errors.New("database error"))
+
+	_, err := usecase.ImportRecipes(context.Background(), ImportRecipesRequest{Format: ImportFormatJSONLD, Content: "{}"})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to import recipe")
+}
+
+// TestSearchRecipes_Success tests searching recipes with trimmed filters
+func TestSearchRecipes_Success(t *testing.T) {
+	mockRepo := new(MockRecipeRepository)
+	usecase := NewCatalogUsecase(mockRepo, new(MockRecipeImporter))
+
+	filter := repository.RecipeFilter{Query: "丼", Ingredient: "卵"}
+	mockRepo.On("Search", mock.Anything, filter).Return([]*domain.Recipe{{ID: 1, Name: "親子丼"}}, nil)
+
+	result, err := usecase.SearchRecipes(context.Background(), SearchRecipesRequest{Query: " 丼 ", Ingredient: "卵"})
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestSearchRecipes_NilResult tests that a nil result becomes an empty slice
+func TestSearchRecipes_NilResult(t *testing.T) {
+	mockRepo := new(MockRecipeRepository)
+	usecase := NewCatalogUsecase(mockRepo, new(MockRecipeImporter))
+
+	mockRepo.On("Search", mock.Anything, repository.RecipeFilter{}).Return(nil, nil)
+
+	result, err := usecase.SearchRecipes(context.Background(), SearchRecipesRequest{})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Len(t, result, 0)
+}
+
+// TestGetRecipe_NotFound tests that not-found errors are preserved
+func TestGetRecipe_NotFound(t *testing.T) {
+	mockRepo := new(MockRecipeRepository)
+	usecase := NewCatalogUsecase(mockRepo, new(MockRecipeImporter))
+
+	mockRepo.On("GetByID", mock.Anything, int64(999)).Return(nil, sql.ErrNoRows)
+
+	result, err := usecase.GetRecipe(context.Background(), 999)
+
+	assert.Nil(t, result)
+	assert.True(t, errors.Is(err, sql.ErrNoRows))
+}
+
+// TestDeleteRecipe_Success tests successful recipe deletion
+func TestDeleteRecipe_Success(t *testing.T) {
+	mockRepo := new(MockRecipeRepository)
+	usecase := NewCatalogUsecase(mockRepo, new(MockRecipeImporter))
+
+	mockRepo.On("GetByID", mock.Anything, int64(1)).Return(&domain.Recipe{ID: 1, Name: "肉じゃが"}, nil)
+	mockRepo.On("Delete", mock.Anything, int64(1)).Return(nil)
+
+	err := usecase.DeleteRecipe(context.Background(), 1)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestDeleteRecipe_OwnedByAnotherUser tests that only the user who imported a recipe can delete it
+func TestDeleteRecipe_OwnedByAnotherUser(t *testing.T) {
+	mockRepo := new(MockRecipeRepository)
+	usecase := NewCatalogUsecase(mockRepo, new(MockRecipeImporter))
+
+	owner := int64(1)
+	mockRepo.On("GetByID", mock.Anything, int64(1)).Return(&domain.Recipe{ID: 1, Name: "肉じゃが", OwnerID: &owner}, nil)
+
+	ctx := domain.WithUser(context.Background(), &domain.User{ID: 2, Username: "taro"})
+	err := usecase.DeleteRecipe(ctx, 1)
+
+	assert.True(t, errors.Is(err, domain.ErrForbidden))
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,16 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+)
+
+// ConsumptionUsecase defines the business logic interface for using up ingredients
+type ConsumptionUsecase interface {
+	// ConsumeIngredient takes an amount away from an ingredient, moving it to the trash once nothing is left
+	ConsumeIngredient(ctx context.Context, id int64, req ConsumeIngredientRequest) (*ConsumeIngredientResponse, error)
+
+	// GetConsumptions retrieves the recorded uses of an ingredient, newest first
+	GetConsumptions(ctx context.Context, id int64) ([]*domain.Consumption, error)
+}
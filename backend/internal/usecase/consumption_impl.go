@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/internal/repository"
+	"github.com/Rin0530/DinnerDecider/backend/pkg/quantity"
+)
+
+// usedUpThreshold is the remaining amount below which an ingredient counts as used up,
+// matching the two decimal places quantities are formatted with
+const usedUpThreshold = 0.005
+
+// consumptionUsecase implements the ConsumptionUsecase interface
+type consumptionUsecase struct {
+	ingredients  repository.IngredientRepository
+	consumptions repository.ConsumptionRepository
+	suggestions  repository.SuggestionRepository
+}
+
+// NewConsumptionUsecase creates a new instance of ConsumptionUsecase
+func NewConsumptionUsecase(ingredients repository.IngredientRepository, consumptions repository.ConsumptionRepository, suggestions repository.SuggestionRepository) ConsumptionUsecase {
+	return &consumptionUsecase{
+		ingredients:  ingredients,
+		consumptions: consumptions,
+		suggestions:  suggestions,
+	}
+}
+
+// ConsumeIngredient takes an amount away from an ingredient, moving it to the trash once nothing is left.
+// Without an amount, or when the amount covers what is left, the whole ingredient is used up.
+func (u *consumptionUsecase) ConsumeIngredient(ctx context.Context, id int64, req ConsumeIngredientRequest) (*ConsumeIngredientResponse, error) {
+	if !domain.IsValidConsumptionReason(req.Reason) {
+		return nil, fmt.Errorf("%w: unknown reason %q", domain.ErrInvalidInput, req.Reason)
+	}
+	if req.SuggestionID != nil && req.Reason != domain.ConsumptionReasonCooked {
+		return nil, fmt.Errorf("%w: suggestion_id requires the %q reason", domain.ErrInvalidInput, domain.ConsumptionReasonCooked)
+	}
+
+	ingredient, err := u.ingredients.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ingredient: %w", err)
+	}
+
+	if err := checkOwner(ctx, ingredient.OwnerID); err != nil {
+		return nil, err
+	}
+
+	if err := checkVersion(ingredient, req.ExpectedVersions); err != nil {
+		return nil, err
+	}
+
+	// Linking another household's suggestion would count the cooking in its statistics
+	if req.SuggestionID != nil {
+		exists, err := u.suggestions.Exists(ctx, *req.SuggestionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check suggestion: %w", err)
+		}
+		if !exists {
+			return nil, fmt.Errorf("%w: suggestion %d not found", domain.ErrInvalidInput, *req.SuggestionID)
+		}
+	}
+
+	consumption := &domain.Consumption{
+		IngredientID:  ingredient.ID,
+		Name:          ingredient.Name,
+		CanonicalName: ingredient.CanonicalName,
+		Category:      ingredient.Category,
+		Amount:        ingredient.Quantity,
+		UsedUp:        true,
+		Reason:        req.Reason,
+		Recipe:        strings.TrimSpace(req.Recipe),
+		SuggestionID:  req.SuggestionID,
+		PurchaseDate:  ingredient.PurchaseDate,
+	}
+
+	stored := ingredient.Quantity
+	if amount := strings.TrimSpace(req.Amount); amount != "" {
+		remaining, err := remainingQuantity(ingredient.Quantity, amount)
+		if err != nil {
+			return nil, err
+		}
+		if remaining.Amount >= usedUpThreshold {
+			consumption.Amount = amount
+			consumption.Remaining = remaining.String()
+			consumption.UsedUp = false
+			ingredient.Quantity = consumption.Remaining
+		}
+	}
+
+	if ingredient.Price != nil {
+		cost, err := u.estimateCost(ctx, ingredient.ID, *ingredient.Price, stored, consumption)
+		if err != nil {
+			return nil, err
+		}
+		consumption.Cost = &cost
+	}
+
+	// Cooking is told apart from plain API edits in the ingredient history
+	if req.Reason == domain.ConsumptionReasonCooked {
+		ctx = domain.WithEventSource(ctx, domain.EventSourceCook)
+	}
+
+	if err := u.ingredients.Consume(ctx, ingredient, consumption); err != nil {
+		return nil, fmt.Errorf("failed to consume ingredient: %w", err)
+	}
+
+	response := &ConsumeIngredientResponse{Consumption: consumption}
+	if !consumption.UsedUp {
+		response.Ingredient = ingredient
+	}
+
+	return response, nil
+}
+
+// GetConsumptions retrieves the recorded uses of an ingredient, newest first
+func (u *consumptionUsecase) GetConsumptions(ctx context.Context, id int64) ([]*domain.Consumption, error) {
+	consumptions, err := u.consumptions.GetByIngredient(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get consumptions: %w", err)
+	}
+
+	return consumptions, nil
+}
+
+// estimateCost prices a use of an ingredient as its share of the price the earlier uses left over,
+// so using an ingredient up always costs the rest of its price
+func (u *consumptionUsecase) estimateCost(ctx context.Context, id int64, price int, stored string, consumption *domain.Consumption) (int, error) {
+	earlier, err := u.consumptions.GetByIngredient(ctx, id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get consumptions: %w", err)
+	}
+
+	left := price
+	for _, c := range earlier {
+		if c.Cost != nil {
+			left -= *c.Cost
+		}
+	}
+	left = max(left, 0)
+
+	if consumption.UsedUp {
+		return left, nil
+	}
+
+	// The remaining quantity is in the unit of the stored one, so the amounts can be compared directly
+	current, _ := quantity.Parse(stored)
+	remaining, _ := quantity.Parse(consumption.Remaining)
+	return int(math.Round(float64(left) * (1 - remaining.Amount/current.Amount))), nil
+}
+
+// remainingQuantity subtracts the consumed amount from a stored quantity text
+func remainingQuantity(stored string, amount string) (quantity.Quantity, error) {
+	used, ok := quantity.Parse(amount)
+	if !ok || used.Amount <= 0 {
+		return quantity.Quantity{}, fmt.Errorf("%w: amount %q is not a positive quantity", domain.ErrInvalidInput, amount)
+	}
+
+	current, ok := quantity.Parse(stored)
+	if !ok {
+		return quantity.Quantity{}, fmt.Errorf("%w: quantity %q is not a number, so the ingredient can only be used up as a whole", domain.ErrInvalidInput, stored)
+	}
+
+	remaining, ok := quantity.Subtract(current, used)
+	if !ok {
+		return quantity.Quantity{}, fmt.Errorf("%w: cannot take %s from %s", domain.ErrInvalidInput, amount, stored)
+	}
+
+	return remaining, nil
+}
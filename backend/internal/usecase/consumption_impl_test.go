@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockConsumptionRepository is a mock implementation of ConsumptionRepository
+type MockConsumptionRepository struct {
+	mock.Mock
+}
+
+func (m *MockConsumptionRepository) GetByIngredient(ctx context.Context, ingredientID int64) ([]*domain.Consumption, error) {
+	args := m.Called(ctx, ingredientID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Consumption), args.Error(1)
+}
+
+// TestConsumeIngredient_Partial tests that a partial use decrements the quantity and keeps the ingredient
+func TestConsumeIngredient_Partial(t *testing.T) {
+	suggestionID := int64(7)
+	mockRepo := new(MockIngredientRepository)
+	mockSuggestions := new(MockSuggestionRepository)
+	usecase := NewConsumptionUsecase(mockRepo, new(MockConsumptionRepository), mockSuggestions)
+
+	mockSuggestions.On("Exists", mock.Anything, int64(7)).Return(true, nil)
+	mockRepo.On("GetByID", mock.Anything, int64(1)).
+		Return(&domain.Ingredient{ID: 1, Name: "豚バラ肉", CanonicalName: "豚バラ肉", Category: domain.CategoryMeat, Quantity: "1kg", Version: 2}, nil)
+	mockRepo.On("Consume", mock.Anything, mock.MatchedBy(func(ingredient *domain.Ingredient) bool {
+		return ingredient.Quantity == "0.8kg" && ingredient.Version == 2
+	}), mock.MatchedBy(func(consumption *domain.Consumption) bool {
+		return !consumption.UsedUp && consumption.Amount == "200g" && consumption.Remaining == "0.8kg" &&
+			consumption.SuggestionID != nil && *consumption.SuggestionID == 7
+	})).Run(func(args mock.Arguments) {
+		// Cooking is recorded as its own source in the history
+		assert.Equal(t, domain.EventSourceCook, domain.EventSource(args.Get(0).(context.Context)))
+	}).Return(nil)
+
+	result, err := usecase.ConsumeIngredient(context.Background(), 1, ConsumeIngredientRequest{
+		Amount:           "200g",
+		Reason:           domain.ConsumptionReasonCooked,
+		Recipe:           "豚汁",
+		SuggestionID:     &suggestionID,
+		ExpectedVersions: []int64{2},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "0.8kg", result.Ingredient.Quantity)
+	assert.Equal(t, "豚汁", result.Consumption.Recipe)
+	assert.Equal(t, domain.CategoryMeat, result.Consumption.Category)
+	mockRepo.AssertExpectations(t)
+	mockSuggestions.AssertExpectations(t)
+}
+
+// TestConsumeIngredient_UsedUp tests that the ingredient is used up without an amount or when nothing would be left
+func TestConsumeIngredient_UsedUp(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount string
+	}{
+		{"no amount", ""},
+		{"exact amount", "1本"},
+		{"more than left", "3本"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockIngredientRepository)
+			usecase := NewConsumptionUsecase(mockRepo, new(MockConsumptionRepository), new(MockSuggestionRepository))
+
+			mockRepo.On("GetByID", mock.Anything, int64(1)).
+				Return(&domain.Ingredient{ID: 1, Name: "牛乳", Quantity: "1本", Version: 1}, nil)
+			mockRepo.On("Consume", mock.Anything, mock.Anything, mock.MatchedBy(func(consumption *domain.Consumption) bool {
+				return consumption.UsedUp && consumption.Amount == "1本" && consumption.Remaining == ""
+			})).Return(nil)
+
+			result, err := usecase.ConsumeIngredient(context.Background(), 1, ConsumeIngredientRequest{
+				Amount: tt.amount,
+				Reason: domain.ConsumptionReasonEaten,
+			})
+
+			assert.NoError(t, err)
+			assert.Nil(t, result.Ingredient)
+			assert.True(t, result.Consumption.UsedUp)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// TestConsumeIngredient_Cost tests that a use costs its share of the price the earlier uses left over
+func TestConsumeIngredient_Cost(t *testing.T) {
+	price, earlierCost := 1000, 200
+	tests := []struct {
+		name    string
+		amount  string
+		earlier []*domain.Consumption
+		want    int
+	}{
+		{"first use", "250g", nil, 250},
+		{"after an earlier use", "250g", []*domain.Consumption{{Cost: &earlierCost}}, 200},
+		{"used up", "", []*domain.Consumption{{Cost: &earlierCost}, {}}, 800},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockIngredientRepository)
+			mockConsumptions := new(MockConsumptionRepository)
+			usecase := NewConsumptionUsecase(mockRepo, mockConsumptions, new(MockSuggestionRepository))
+
+			mockRepo.On("GetByID", mock.Anything, int64(1)).
+				Return(&domain.Ingredient{ID: 1, Name: "豚バラ肉", Quantity: "1kg", Price: &price, Version: 1}, nil)
+			mockConsumptions.On("GetByIngredient", mock.Anything, int64(1)).Return(tt.earlier, nil)
+			mockRepo.On("Consume", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			result, err := usecase.ConsumeIngredient(context.Background(), 1, ConsumeIngredientRequest{
+				Amount: tt.amount,
+				Reason: domain.ConsumptionReasonCooked,
+			})
+
+			assert.NoError(t, err)
+			if assert.NotNil(t, result.Consumption.Cost) {
+				assert.Equal(t, tt.want, *result.Consumption.Cost)
+			}
+		})
+	}
+}
+
+// TestConsumeIngredient_InvalidInput tests that amounts that cannot be taken from the quantity are rejected
+func TestConsumeIngredient_InvalidInput(t *testing.T) {
+	suggestionID := int64(7)
+	otherSuggestionID := int64(8)
+	tests := []struct {
+		name     string
+		quantity string
+		req      ConsumeIngredientRequest
+	}{
+		{"unknown reason", "2本", ConsumeIngredientRequest{Reason: "lost"}},
+		{"amount not a number", "2本", ConsumeIngredientRequest{Amount: "少し", Reason: domain.ConsumptionReasonCooked}},
+		{"zero amount", "2本", ConsumeIngredientRequest{Amount: "0本", Reason: domain.ConsumptionReasonCooked}},
+		{"quantity not a number", "適量", ConsumeIngredientRequest{Amount: "1本", Reason: domain.ConsumptionReasonCooked}},
+		{"incompatible units", "2本", ConsumeIngredientRequest{Amount: "200g", Reason: domain.ConsumptionReasonCooked}},
+		{"suggestion without cooking", "2本", ConsumeIngredientRequest{Reason: domain.ConsumptionReasonDiscarded, SuggestionID: &suggestionID}},
+		{"suggestion of another household", "2本", ConsumeIngredientRequest{Reason: domain.ConsumptionReasonCooked, SuggestionID: &otherSuggestionID}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockIngredientRepository)
+			mockSuggestions := new(MockSuggestionRepository)
+			usecase := NewConsumptionUsecase(mockRepo, new(MockConsumptionRepository), mockSuggestions)
+
+			mockRepo.On("GetByID", mock.Anything, int64(1)).
+				Return(&domain.Ingredient{ID: 1, Name: "にんじん", Quantity: tt.quantity, Version: 1}, nil).Maybe()
+			mockSuggestions.On("Exists", mock.Anything, otherSuggestionID).Return(false, nil).Maybe()
+
+			result, err := usecase.ConsumeIngredient(context.Background(), 1, tt.req)
+
+			assert.ErrorIs(t, err, domain.ErrInvalidInput)
+			assert.Nil(t, result)
+			mockRepo.AssertNotCalled(t, "Consume", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+// TestConsumeIngredient_VersionConflict tests that If-Match is checked before consuming
+func TestConsumeIngredient_VersionConflict(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	usecase := NewConsumptionUsecase(mockRepo, new(MockConsumptionRepository), new(MockSuggestionRepository))
+
+	mockRepo.On("GetByID", mock.Anything, int64(1)).
+		Return(&domain.Ingredient{ID: 1, Name: "牛乳", Quantity: "1本", Version: 3}, nil)
+
+	result, err := usecase.ConsumeIngredient(context.Background(), 1, ConsumeIngredientRequest{
+		Reason:           domain.ConsumptionReasonDiscarded,
+		ExpectedVersions: []int64{2},
+	})
+
+	assert.ErrorIs(t, err, domain.ErrVersionConflict)
+	assert.Nil(t, result)
+	mockRepo.AssertNotCalled(t, "Consume", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestConsumeIngredient_NotFound tests that consuming a missing ingredient reports not found
+func TestConsumeIngredient_NotFound(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	usecase := NewConsumptionUsecase(mockRepo, new(MockConsumptionRepository), new(MockSuggestionRepository))
+
+	mockRepo.On("GetByID", mock.Anything, int64(999)).
+		Return(nil, fmt.Errorf("ingredient not found: %w", sql.ErrNoRows))
+
+	result, err := usecase.ConsumeIngredient(context.Background(), 999, ConsumeIngredientRequest{Reason: domain.ConsumptionReasonExpired})
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.Nil(t, result)
+}
+
+// TestGetConsumptions_Success tests retrieving the recorded uses of an ingredient
+func TestGetConsumptions_Success(t *testing.T) {
+	mockConsumptions := new(MockConsumptionRepository)
+	usecase := NewConsumptionUsecase(new(MockIngredientRepository), mockConsumptions, new(MockSuggestionRepository))
+
+	expected := []*domain.Consumption{{ID: 2, IngredientID: 1, Reason: domain.ConsumptionReasonCooked}}
+	mockConsumptions.On("GetByIngredient", mock.Anything, int64(1)).Return(expected, nil)
+
+	result, err := usecase.GetConsumptions(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+	mockConsumptions.AssertExpectations(t)
+}
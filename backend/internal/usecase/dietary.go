@@ -0,0 +1,28 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+)
+
+// DietaryUsecase defines the business logic interface for the dietary profiles of household members
+type DietaryUsecase interface {
+	// ListRules retrieves the allergens and dietary restrictions profiles can list
+	ListRules(ctx context.Context) *DietaryRulesResponse
+
+	// ListProfiles retrieves all dietary profiles
+	ListProfiles(ctx context.Context) ([]*domain.DietaryProfile, error)
+
+	// GetProfile retrieves a dietary profile by ID
+	GetProfile(ctx context.Context, id int64) (*domain.DietaryProfile, error)
+
+	// CreateProfile creates a new dietary profile
+	CreateProfile(ctx context.Context, req SaveDietaryProfileRequest) (*domain.DietaryProfile, error)
+
+	// UpdateProfile replaces an existing dietary profile
+	UpdateProfile(ctx context.Context, id int64, req SaveDietaryProfileRequest) (*domain.DietaryProfile, error)
+
+	// DeleteProfile deletes a dietary profile by ID
+	DeleteProfile(ctx context.Context, id int64) error
+}
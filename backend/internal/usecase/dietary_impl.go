@@ -0,0 +1,176 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/internal/repository"
+	"github.com/Rin0530/DinnerDecider/backend/pkg/textnorm"
+)
+
+// dietaryUsecase implements the DietaryUsecase interface
+type dietaryUsecase struct {
+	repo repository.DietaryProfileRepository
+}
+
+// NewDietaryUsecase creates a new instance of DietaryUsecase
+func NewDietaryUsecase(repo repository.DietaryProfileRepository) DietaryUsecase {
+	return &dietaryUsecase{
+		repo: repo,
+	}
+}
+
+// ListRules retrieves the allergens and dietary restrictions profiles can list
+func (u *dietaryUsecase) ListRules(ctx context.Context) *DietaryRulesResponse {
+	return &DietaryRulesResponse{
+		Allergens:    domain.Allergens,
+		Restrictions: domain.DietaryRestrictions,
+	}
+}
+
+// ListProfiles retrieves all dietary profiles
+func (u *dietaryUsecase) ListProfiles(ctx context.Context) ([]*domain.DietaryProfile, error) {
+	profiles, err := u.repo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dietary profiles: %w", err)
+	}
+
+	// Return empty slice instead of nil if no profiles found
+	if profiles == nil {
+		return []*domain.DietaryProfile{}, nil
+	}
+
+	return profiles, nil
+}
+
+// GetProfile retrieves a dietary profile by ID
+func (u *dietaryUsecase) GetProfile(ctx context.Context, id int64) (*domain.DietaryProfile, error) {
+	profile, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dietary profile: %w", err)
+	}
+
+	return profile, nil
+}
+
+// CreateProfile creates a new dietary profile
+func (u *dietaryUsecase) CreateProfile(ctx context.Context, req SaveDietaryProfileRequest) (*domain.DietaryProfile, error) {
+	if err := checkWritable(ctx); err != nil {
+		return nil, err
+	}
+
+	profile, err := buildDietaryProfile(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.repo.Create(ctx, profile); err != nil {
+		return nil, fmt.Errorf("failed to create dietary profile: %w", err)
+	}
+
+	return profile, nil
+}
+
+// UpdateProfile replaces an existing dietary profile
+func (u *dietaryUsecase) UpdateProfile(ctx context.Context, id int64, req SaveDietaryProfileRequest) (*domain.DietaryProfile, error) {
+	existing, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dietary profile: %w", err)
+	}
+
+	if err := checkOwner(ctx, existing.OwnerID); err != nil {
+		return nil, err
+	}
+
+	profile, err := buildDietaryProfile(req)
+	if err != nil {
+		return nil, err
+	}
+	profile.ID = existing.ID
+	profile.CreatedAt = existing.CreatedAt
+	profile.OwnerID = existing.OwnerID
+	profile.HouseholdID = existing.HouseholdID
+
+	if err := u.repo.Update(ctx, profile); err != nil {
+		return nil, fmt.Errorf("failed to update dietary profile: %w", err)
+	}
+
+	return profile, nil
+}
+
+// DeleteProfile deletes a dietary profile by ID
+func (u *dietaryUsecase) DeleteProfile(ctx context.Context, id int64) error {
+	profile, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get dietary profile: %w", err)
+	}
+
+	if err := checkOwner(ctx, profile.OwnerID); err != nil {
+		return err
+	}
+
+	if err := u.repo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete dietary profile: %w", err)
+	}
+
+	return nil
+}
+
+// buildDietaryProfile validates a profile request. Allergens and restrictions may be given by code or
+// Japanese label and are stored as codes; blank and duplicate entries are dropped.
+func buildDietaryProfile(req SaveDietaryProfileRequest) (*domain.DietaryProfile, error) {
+	profile := &domain.DietaryProfile{
+		Name:         strings.TrimSpace(req.Name),
+		Allergens:    []string{},
+		Restrictions: []string{},
+		Dislikes:     []string{},
+	}
+	if profile.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", domain.ErrInvalidInput)
+	}
+
+	for _, value := range req.Allergens {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		allergen, ok := domain.LookupAllergen(value)
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown allergen %q", domain.ErrInvalidInput, value)
+		}
+		if !slices.Contains(profile.Allergens, allergen.Code) {
+			profile.Allergens = append(profile.Allergens, allergen.Code)
+		}
+	}
+
+	for _, value := range req.Restrictions {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		restriction, ok := domain.LookupDietaryRestriction(value)
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown dietary restriction %q", domain.ErrInvalidInput, value)
+		}
+		if !slices.Contains(profile.Restrictions, restriction.Code) {
+			profile.Restrictions = append(profile.Restrictions, restriction.Code)
+		}
+	}
+
+	// Compare dislikes in folded form so spelling variants are kept once
+	seen := make(map[string]bool)
+	for _, value := range req.Dislikes {
+		value = strings.TrimSpace(value)
+		folded := textnorm.Fold(value)
+		if folded == "" || seen[folded] {
+			continue
+		}
+		seen[folded] = true
+		profile.Dislikes = append(profile.Dislikes, value)
+	}
+
+	return profile, nil
+}
@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockDietaryProfileRepository is a mock implementation of DietaryProfileRepository
+type MockDietaryProfileRepository struct {
+	mock.Mock
+}
+
+func (m *MockDietaryProfileRepository) GetAll(ctx context.Context) ([]*domain.DietaryProfile, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.DietaryProfile), args.Error(1)
+}
+
+func (m *MockDietaryProfileRepository) GetByID(ctx context.Context, id int64) (*domain.DietaryProfile, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DietaryProfile), args.Error(1)
+}
+
+func (m *MockDietaryProfileRepository) Create(ctx context.Context, profile *domain.DietaryProfile) error {
+	args := m.Called(ctx, profile)
+	return args.Error(0)
+}
+
+func (m *MockDietaryProfileRepository) Update(ctx context.Context, profile *domain.DietaryProfile) error {
+	args := m.Called(ctx, profile)
+	return args.Error(0)
+}
+
+func (m *MockDietaryProfileRepository) Delete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// TestListProfiles_Empty tests that no profiles are returned as an empty slice
+func TestListProfiles_Empty(t *testing.T) {
+	mockRepo := new(MockDietaryProfileRepository)
+	usecase := NewDietaryUsecase(mockRepo)
+
+	mockRepo.On("GetAll", mock.Anything).Return(nil, nil)
+
+	profiles, err := usecase.ListProfiles(context.Background())
+
+	assert.NoError(t, err)
+	assert.NotNil(t, profiles)
+	assert.Len(t, profiles, 0)
+}
+
+// TestCreateProfile_NormalizesLists tests that labels become codes and blank or duplicate entries are dropped
+func TestCreateProfile_NormalizesLists(t *testing.T) {
+	mockRepo := new(MockDietaryProfileRepository)
+	usecase := NewDietaryUsecase(mockRepo)
+
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(profile *domain.DietaryProfile) bool {
+		return profile.Name == "太郎" &&
+			assert.ObjectsAreEqual([]string{domain.AllergenEgg, domain.AllergenPeanut}, profile.Allergens) &&
+			assert.ObjectsAreEqual([]string{domain.RestrictionHalal}, profile.Restrictions) &&
+			assert.ObjectsAreEqual([]string{"ピーマン"}, profile.Dislikes)
+	})).Return(nil)
+
+	profile, err := usecase.CreateProfile(context.Background(), SaveDietaryProfileRequest{
+		Name:         " 太郎 ",
+		Allergens:    []string{"卵", "peanut", "egg", ""},
+		Restrictions: []string{"ハラール"},
+		Dislikes:     []string{"ピーマン", "ﾋﾟｰﾏﾝ", " "},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "太郎", profile.Name)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestCreateProfile_Invalid tests that unknown allergens and restrictions are rejected
+func TestCreateProfile_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		req  SaveDietaryProfileRequest
+	}{
+		{"blank name", SaveDietaryProfileRequest{Name: " "}},
+		{"unknown allergen", SaveDietaryProfileRequest{Name: "太郎", Allergens: []string{"pollen"}}},
+		{"unknown restriction", SaveDietaryProfileRequest{Name: "太郎", Restrictions: []string{"keto"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockDietaryProfileRepository)
+			usecase := NewDietaryUsecase(mockRepo)
+
+			profile, err := usecase.CreateProfile(context.Background(), tt.req)
+
+			assert.Nil(t, profile)
+			assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+			mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+// TestUpdateProfile_KeepsCreatedAt tests that replacing a profile keeps its ID and creation time
+func TestUpdateProfile_KeepsCreatedAt(t *testing.T) {
+	mockRepo := new(MockDietaryProfileRepository)
+	usecase := NewDietaryUsecase(mockRepo)
+
+	createdAt := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	mockRepo.On("GetByID", mock.Anything, int64(1)).
+		Return(&domain.DietaryProfile{ID: 1, Name: "太郎", CreatedAt: createdAt}, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(profile *domain.DietaryProfile) bool {
+		return profile.ID == 1 && profile.CreatedAt.Equal(createdAt) && profile.Dislikes[0] == "セロリ"
+	})).Return(nil)
+
+	profile, err := usecase.UpdateProfile(context.Background(), 1, SaveDietaryProfileRequest{Name: "太郎", Dislikes: []string{"セロリ"}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), profile.ID)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestUpdateProfile_NotFound tests that replacing a missing profile is reported as not found
+func TestUpdateProfile_NotFound(t *testing.T) {
+	mockRepo := new(MockDietaryProfileRepository)
+	usecase := NewDietaryUsecase(mockRepo)
+
+	mockRepo.On("GetByID", mock.Anything, int64(9)).Return(nil, sql.ErrNoRows)
+
+	profile, err := usecase.UpdateProfile(context.Background(), 9, SaveDietaryProfileRequest{Name: "太郎"})
+
+	assert.Nil(t, profile)
+	assert.True(t, errors.Is(err, sql.ErrNoRows))
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
@@ -1,17 +1,400 @@
 package usecase
 
+import (
+	"time"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+)
+
 // CreateIngredientRequest represents the request body for creating a new ingredient
 type CreateIngredientRequest struct {
+	Name         string  `json:"name" binding:"required_without=Barcode"` // filled in from the product table when a known barcode is given
+	Category     string  `json:"category"`                                // detected from the name when empty
+	Location     string  `json:"location"`                                // "fridge", "freezer" or "pantry"; derived from the category when empty
+	Quantity     string  `json:"quantity"`                                // defaults to the product unit
+	Barcode      string  `json:"barcode"`                                 // JAN/EAN code of the packaged product
+	Price        *int    `json:"price"`                                   // yen paid for the ingredient as bought
+	Store        string  `json:"store"`                                   // where the ingredient was bought
+	PurchaseDate *string `json:"purchase_date"`                           // YYYY-MM-DD format
+	ExpiresAt    *string `json:"expires_at"`                              // YYYY-MM-DD format; derived from the product shelf life when empty
+}
+
+// UpdateIngredientRequest represents the request body for replacing an ingredient.
+// Every field is overwritten, so omitted optional fields are cleared or re-detected.
+type UpdateIngredientRequest struct {
 	Name         string  `json:"name" binding:"required"`
+	Category     string  `json:"category"` // detected from the name when empty
+	Location     string  `json:"location"` // derived from the category when empty
 	Quantity     string  `json:"quantity"`
+	Barcode      string  `json:"barcode"`
+	Price        *int    `json:"price"` // yen paid for the ingredient as bought
+	Store        string  `json:"store"`
 	PurchaseDate *string `json:"purchase_date"` // YYYY-MM-DD format
+	ExpiresAt    *string `json:"expires_at"`    // YYYY-MM-DD format
+
+	// ExpectedVersions lists the versions the replacement may apply to, typically from If-Match; empty means unconditional
+	ExpectedVersions []int64 `json:"-"`
 }
 
-// UpdateIngredientRequest represents the request body for updating an ingredient
-type UpdateIngredientRequest struct {
-	Name         *string `json:"name"`
-	Quantity     *string `json:"quantity"`
-	PurchaseDate *string `json:"purchase_date"` // YYYY-MM-DD format
+// Ingredient patch formats
+const (
+	// PatchFormatMerge applies an RFC 7396 JSON Merge Patch, where null clears a field
+	PatchFormatMerge = "merge"
+
+	// PatchFormatJSONPatch applies an RFC 6902 JSON Patch operation list
+	PatchFormatJSONPatch = "json-patch"
+)
+
+// PatchIngredientRequest represents a partial update of an ingredient.
+// The patch is applied to the UpdateIngredientRequest form of the current ingredient.
+type PatchIngredientRequest struct {
+	Format string // PatchFormatMerge or PatchFormatJSONPatch
+	Patch  []byte
+
+	// ExpectedVersions lists the versions the patch may apply to, typically from If-Match; empty means unconditional
+	ExpectedVersions []int64
+}
+
+// ListIngredientsRequest represents the query parameters for listing ingredients
+type ListIngredientsRequest struct {
+	Query           string `form:"q"`
+	Category        string `form:"category"`
+	Location        string `form:"location"`
+	PurchasedBefore string `form:"purchased_before"` // YYYY-MM-DD format
+	Sort            string `form:"sort"`             // "created_at", "name", "purchase_date" or "expires_at"
+	Order           string `form:"order"`            // "asc" or "desc"
+	Cursor          string `form:"cursor"`           // next_cursor of the previous page
+	Limit           int    `form:"limit"`
+}
+
+// IngredientListResponse represents one page of the ingredient list
+type IngredientListResponse struct {
+	Items      []*domain.Ingredient `json:"items"`
+	NextCursor string               `json:"next_cursor,omitempty"` // empty on the last page
+}
+
+// Ingredient batch modes
+const (
+	// BatchModeAtomic commits every operation or none of them
+	BatchModeAtomic = "atomic"
+
+	// BatchModeBestEffort commits the operations that succeed and reports the others as failed
+	BatchModeBestEffort = "best_effort"
+)
+
+// Ingredient batch operation kinds
+const (
+	BatchOpCreate = "create"
+	BatchOpUpdate = "update"
+	BatchOpDelete = "delete"
+)
+
+// Ingredient batch result statuses
+const (
+	// BatchStatusSucceeded marks an operation whose write was committed
+	BatchStatusSucceeded = "succeeded"
+
+	// BatchStatusFailed marks an operation that could not be applied
+	BatchStatusFailed = "failed"
+
+	// BatchStatusRolledBack marks an operation that succeeded but was undone because another one failed in atomic mode
+	BatchStatusRolledBack = "rolled_back"
+
+	// BatchStatusSkipped marks an operation that was not attempted because an earlier one failed in atomic mode
+	BatchStatusSkipped = "skipped"
+)
+
+// BatchIngredientsRequest represents the request body for applying several ingredient writes in one transaction
+type BatchIngredientsRequest struct {
+	Mode       string                     `json:"mode"` // "atomic" (default) or "best_effort"
+	Operations []BatchIngredientOperation `json:"operations" binding:"required"`
+}
+
+// BatchIngredientOperation represents a single create, update or delete within a batch
+type BatchIngredientOperation struct {
+	Op         string                   `json:"op"`         // "create", "update" or "delete"
+	ID         int64                    `json:"id"`         // target of update and delete
+	Version    int64                    `json:"version"`    // expected version for update and delete; 0 means unconditional
+	Ingredient *CreateIngredientRequest `json:"ingredient"` // contents for create, full replacement for update
+}
+
+// BatchIngredientResult represents the outcome of one batch operation
+type BatchIngredientResult struct {
+	Index      int                `json:"index"`
+	Op         string             `json:"op"`
+	Status     string             `json:"status"`
+	ID         int64              `json:"id,omitempty"`
+	Ingredient *domain.Ingredient `json:"ingredient,omitempty"`
+	Error      *ErrorResponse     `json:"error,omitempty"`
+
+	// Err is the failure behind a failed status; the handler renders it into Error
+	Err error `json:"-"`
+}
+
+// BatchIngredientsResponse represents the outcome of a batch, with one result per operation in request order
+type BatchIngredientsResponse struct {
+	Mode      string                  `json:"mode"`
+	Committed bool                    `json:"committed"`
+	Succeeded int                     `json:"succeeded"`
+	Failed    int                     `json:"failed"`
+	Results   []BatchIngredientResult `json:"results"`
+}
+
+// Inventory file formats
+const (
+	// InventoryFormatCSV is a CSV table whose first row names the columns
+	InventoryFormatCSV = "csv"
+
+	// InventoryFormatJSON is a JSON array of flat objects
+	InventoryFormatJSON = "json"
+)
+
+// Duplicate handling strategies for inventory imports.
+// A row is a duplicate when its canonical name matches a stored ingredient or an earlier row.
+const (
+	// DuplicateSkip keeps the existing ingredient and ignores the row
+	DuplicateSkip = "skip"
+
+	// DuplicateMerge adds the quantity of the row to the existing ingredient and keeps its other fields
+	DuplicateMerge = "merge"
+
+	// DuplicateOverwrite replaces the existing ingredient with the row
+	DuplicateOverwrite = "overwrite"
+)
+
+// Inventory import row actions
+const (
+	ImportActionCreate    = "create"
+	ImportActionMerge     = "merge"
+	ImportActionOverwrite = "overwrite"
+	ImportActionSkip      = "skip"
+	ImportActionError     = "error"
+)
+
+// ImportIngredientsRequest represents the request body for importing an inventory file
+type ImportIngredientsRequest struct {
+	Format      string            `json:"format"` // "csv" or "json"; detected from content when empty
+	Content     string            `json:"content" binding:"required"`
+	Mapping     map[string]string `json:"mapping"`      // ingredient field -> column name; detected from the column names when omitted
+	DateFormat  string            `json:"date_format"`  // e.g. "YYYY/MM/DD"; detected from the dates when empty
+	OnDuplicate string            `json:"on_duplicate"` // "skip" (default), "merge" or "overwrite"
+	DryRun      bool              `json:"dry_run"`      // report what would happen without saving anything
+}
+
+// ImportIngredientsResponse represents the outcome of an inventory import, with one entry per data row
+type ImportIngredientsResponse struct {
+	DryRun      bool                  `json:"dry_run"`
+	Format      string                `json:"format"`
+	DateFormat  string                `json:"date_format,omitempty"` // empty when the file has no dates
+	Mapping     map[string]string     `json:"mapping"`
+	Created     int                   `json:"created"`
+	Merged      int                   `json:"merged"`
+	Overwritten int                   `json:"overwritten"`
+	Skipped     int                   `json:"skipped"`
+	Failed      int                   `json:"failed"`
+	Rows        []ImportIngredientRow `json:"rows"`
+}
+
+// ImportIngredientRow represents the outcome of one imported row
+type ImportIngredientRow struct {
+	Line       int                `json:"line"`   // line number in a CSV file, or 1-based position in a JSON array
+	Action     string             `json:"action"` // "create", "merge", "overwrite", "skip" or "error"
+	Ingredient *domain.Ingredient `json:"ingredient,omitempty"`
+	Error      string             `json:"error,omitempty"`
+}
+
+// ParseReceiptRequest represents the request body for reading draft ingredients from receipt text
+type ParseReceiptRequest struct {
+	Text   string `json:"text" binding:"required"` // OCR'd receipt text
+	UseLLM bool   `json:"use_llm"`                 // also ask the configured LLM to read the text
+}
+
+// IngredientDraft represents an ingredient read from free text that has not been saved yet.
+// The embedded fields can be sent back unchanged as a create operation of a batch request.
+type IngredientDraft struct {
+	CreateIngredientRequest
+	Line string `json:"line,omitempty"` // the text the draft was read from
+}
+
+// IngredientDraftsResponse represents the draft ingredients read from free text
+type IngredientDraftsResponse struct {
+	PurchaseDate *string           `json:"purchase_date"` // YYYY-MM-DD format; nil when no date was found
+	Drafts       []IngredientDraft `json:"drafts"`
+	Ignored      []string          `json:"ignored"` // lines that were not read as food
+	UsedLLM      bool              `json:"used_llm"`
+	Warnings     []string          `json:"warnings,omitempty"`
+}
+
+// Quick add LLM modes
+const (
+	// LLMModeAuto asks the LLM only when the rules leave part of the text unread or find unknown names
+	LLMModeAuto = "auto"
+
+	// LLMModeAlways always asks the LLM
+	LLMModeAlways = "always"
+
+	// LLMModeNever reads the text with the rules only
+	LLMModeNever = "never"
+)
+
+// QuickAddRequest represents the request body for adding ingredients from a short shopping note.
+// Without confirm the drafts are only returned; with confirm they are saved. Drafts from an earlier
+// preview can be sent back instead of the text so that exactly what was confirmed is saved.
+type QuickAddRequest struct {
+	Text    string                    `json:"text"`    // e.g. "卵10個と牛乳1本、昨日買った鶏もも300g"; required unless drafts are given
+	LLM     string                    `json:"llm"`     // "auto" (default), "always" or "never"
+	Confirm bool                      `json:"confirm"` // save the drafts instead of only returning them
+	Drafts  []CreateIngredientRequest `json:"drafts"`  // drafts from an earlier preview, used instead of the text
+}
+
+// QuickAddResponse represents the drafts read from a shopping note and, when confirmed, the saved ingredients
+type QuickAddResponse struct {
+	IngredientDraftsResponse
+	Committed   bool                 `json:"committed"`
+	Ingredients []*domain.Ingredient `json:"ingredients,omitempty"`
+}
+
+// ImportProductsRequest represents the request body for importing a CSV dump of a product database
+type ImportProductsRequest struct {
+	Content          string            `json:"content" binding:"required"`
+	Mapping          map[string]string `json:"mapping"`           // product field to CSV column; detected from the header when omitted
+	OverwriteLearned bool              `json:"overwrite_learned"` // also replace products learned from user entries
+}
+
+// ImportProductsResponse represents the outcome of a product import
+type ImportProductsResponse struct {
+	Mapping  map[string]string    `json:"mapping"`
+	Imported int                  `json:"imported"`
+	Failed   int                  `json:"failed"`
+	Errors   []ImportProductError `json:"errors"`
+}
+
+// ImportProductError represents a row of a product dump that could not be imported
+type ImportProductError struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+// ConsumeIngredientRequest represents the request body for using part or all of an ingredient
+type ConsumeIngredientRequest struct {
+	Amount string `json:"amount"`                    // such as "200g" or "1"; the whole quantity when empty
+	Reason string `json:"reason" binding:"required"` // "cooked", "eaten", "discarded" or "expired"
+	Recipe string `json:"recipe"`                    // name of the dish, for "cooked"
+
+	// SuggestionID is the id of the recipe suggestion that was cooked; it requires the "cooked" reason and a suggestion made for the current household
+	SuggestionID *int64 `json:"suggestion_id"`
+
+	// ExpectedVersions lists the versions the consumption may apply to, typically from If-Match; empty means unconditional
+	ExpectedVersions []int64 `json:"-"`
+}
+
+// ConsumeIngredientResponse represents the recorded consumption and what is left of the ingredient
+type ConsumeIngredientResponse struct {
+	Consumption *domain.Consumption `json:"consumption"`
+	Ingredient  *domain.Ingredient  `json:"ingredient,omitempty"` // omitted when the ingredient was used up
+}
+
+// ListIngredientEventsRequest represents the query parameters for listing ingredient history.
+// Since and Until accept YYYY-MM-DD or RFC3339; a date alone covers the whole day.
+type ListIngredientEventsRequest struct {
+	Actor  string `form:"actor"`
+	Source string `form:"source"` // "api", "import", "cook" or "system"
+	Action string `form:"action"` // "create", "update", "delete", "restore" or "purge"
+	Since  string `form:"since"`
+	Until  string `form:"until"`
+	Cursor string `form:"cursor"` // next_cursor of the previous page
+	Limit  int    `form:"limit"`
+}
+
+// IngredientEventListResponse represents one page of ingredient history, newest first
+type IngredientEventListResponse struct {
+	Items      []*domain.IngredientEvent `json:"items"`
+	NextCursor string                    `json:"next_cursor,omitempty"` // empty on the last page
+}
+
+// InventorySnapshotResponse represents the inventory reconstructed from history at a past time
+type InventorySnapshotResponse struct {
+	At    time.Time            `json:"at"`
+	Items []*domain.Ingredient `json:"items"`
+}
+
+// StatsRequest represents the query parameters shared by the statistics endpoints.
+// Since and Until accept YYYY-MM-DD or RFC3339; a date alone covers the whole day.
+type StatsRequest struct {
+	Since   string `form:"since"`
+	Until   string `form:"until"`
+	Limit   int    `form:"limit"`    // number of ingredients in the most wasted ranking (default: 10, max: 100)
+	GroupBy string `form:"group_by"` // spend report grouping: "week" (default), "category" or "store"
+}
+
+// UsageStatsResponse represents the consumed and wasted uses per month and category
+type UsageStatsResponse struct {
+	Items     []*domain.CategoryUsage `json:"items"`
+	Consumed  int                     `json:"consumed"`
+	Wasted    int                     `json:"wasted"`
+	WasteRate float64                 `json:"waste_rate"` // wasted / (consumed + wasted); 0 without any use
+}
+
+// PurchaseToUseResponse represents the average days from purchase to use, overall and per category
+type PurchaseToUseResponse struct {
+	AverageDays float64                         `json:"average_days"`
+	Count       int                             `json:"count"`
+	Categories  []*domain.CategoryPurchaseToUse `json:"categories"`
+}
+
+// SpendReportResponse represents the grocery spend of a period, totalled per week, category or store
+type SpendReportResponse struct {
+	GroupBy string               `json:"group_by"`
+	Total   int                  `json:"total"` // yen
+	Count   int                  `json:"count"` // priced purchases
+	Items   []*domain.SpendTotal `json:"items"`
+}
+
+// Recipe import formats
+const (
+	// ImportFormatJSONLD imports a schema.org Recipe JSON-LD document
+	ImportFormatJSONLD = "jsonld"
+
+	// ImportFormatHTML imports the JSON-LD blocks embedded in an HTML page
+	ImportFormatHTML = "html"
+)
+
+// ImportRecipesRequest represents the request body for importing catalog recipes
+type ImportRecipesRequest struct {
+	Format  string `json:"format"` // "jsonld" or "html"; detected from content when empty
+	Content string `json:"content" binding:"required"`
+}
+
+// SearchRecipesRequest represents the query parameters for searching catalog recipes
+type SearchRecipesRequest struct {
+	Query      string `form:"q"`
+	Ingredient string `form:"ingredient"`
+}
+
+// SaveSynonymRequest represents the request body for creating or replacing a synonym dictionary entry
+type SaveSynonymRequest struct {
+	Aliases  []string `json:"aliases"`
+	Broader  string   `json:"broader"`  // canonical name of a more general ingredient
+	Category string   `json:"category"` // ingredient category used for auto-categorization
+}
+
+// ReindexResponse represents the result of re-normalizing stored ingredient names
+type ReindexResponse struct {
+	Updated int `json:"updated"`
+}
+
+// SaveDietaryProfileRequest represents the request body for creating or replacing a dietary profile
+type SaveDietaryProfileRequest struct {
+	Name         string   `json:"name" binding:"required"` // household member the profile belongs to
+	Allergens    []string `json:"allergens"`               // allergen codes or Japanese labels, such as "egg" or "卵"
+	Restrictions []string `json:"restrictions"`            // dietary restriction codes or Japanese labels
+	Dislikes     []string `json:"dislikes"`                // ingredient names to avoid where possible
+}
+
+// DietaryRulesResponse lists the allergens and dietary restrictions profiles can list
+type DietaryRulesResponse struct {
+	Allergens    []domain.Allergen           `json:"allergens"`
+	Restrictions []domain.DietaryRestriction `json:"restrictions"`
 }
 
 // ErrorResponse represents a standardized error response
@@ -19,3 +402,60 @@ type ErrorResponse struct {
 	Error   string `json:"error"`
 	Message string `json:"message"`
 }
+
+// RegisterRequest represents the request body for creating a user account
+type RegisterRequest struct {
+	Username string `json:"username" binding:"required"` // up to 64 characters without spaces
+	Password string `json:"password" binding:"required"` // 8 to 72 bytes
+}
+
+// LoginRequest represents the request body for signing in
+type LoginRequest struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// LoginResponse carries the session token issued to a signed-in user
+type LoginResponse struct {
+	Token     string       `json:"token"` // send as "Authorization: Bearer <token>"
+	ExpiresAt time.Time    `json:"expires_at"`
+	User      *domain.User `json:"user"`
+}
+
+// ChangePasswordRequest represents the request body for changing the password of the signed-in user
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required"`
+}
+
+// SaveHouseholdRequest represents the request body for creating or renaming a household
+type SaveHouseholdRequest struct {
+	Name string `json:"name" binding:"required"` // up to 255 characters, such as 山田家
+}
+
+// UpdateHouseholdMemberRequest represents the request body for changing the role of a household member
+type UpdateHouseholdMemberRequest struct {
+	Role string `json:"role" binding:"required"` // "owner", "member" or "viewer"
+}
+
+// CreateInvitationRequest represents the request body for issuing a household invitation code
+type CreateInvitationRequest struct {
+	Role string `json:"role"` // role given to the user who joins with the code; defaults to "member"
+}
+
+// JoinHouseholdRequest represents the request body for joining a household with an invitation code
+type JoinHouseholdRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// CreateAPIKeyRequest represents the request body for creating an API key
+type CreateAPIKeyRequest struct {
+	Name   string   `json:"name" binding:"required"`   // what uses the key, such as 台所タブレット; up to 64 characters
+	Scopes []string `json:"scopes" binding:"required"` // "inventory:read", "inventory:write", "recipes:read" and/or "recipes:write"
+}
+
+// CreateAPIKeyResponse carries a newly created API key, which is shown only this once
+type CreateAPIKeyResponse struct {
+	Key    string         `json:"key"` // send as "Authorization: Bearer <key>"
+	APIKey *domain.APIKey `json:"api_key"`
+}
@@ -0,0 +1,17 @@
+package usecase
+
+import (
+	"context"
+)
+
+// HistoryUsecase defines the business logic interface for the ingredient change history
+type HistoryUsecase interface {
+	// GetIngredientHistory retrieves one page of the changes to a single ingredient, newest first
+	GetIngredientHistory(ctx context.Context, id int64, req ListIngredientEventsRequest) (*IngredientEventListResponse, error)
+
+	// GetActivity retrieves one page of the changes to every ingredient, newest first
+	GetActivity(ctx context.Context, req ListIngredientEventsRequest) (*IngredientEventListResponse, error)
+
+	// GetInventoryAt reconstructs the inventory at a past time given as YYYY-MM-DD or RFC3339
+	GetInventoryAt(ctx context.Context, at string) (*InventorySnapshotResponse, error)
+}
@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/internal/repository"
+)
+
+// Ingredient history page sizes
+const (
+	defaultIngredientEventPageSize = 50
+	maxIngredientEventPageSize     = 200
+)
+
+// historyUsecase implements the HistoryUsecase interface
+type historyUsecase struct {
+	repo repository.IngredientEventRepository
+}
+
+// NewHistoryUsecase creates a new instance of HistoryUsecase
+func NewHistoryUsecase(repo repository.IngredientEventRepository) HistoryUsecase {
+	return &historyUsecase{
+		repo: repo,
+	}
+}
+
+// GetIngredientHistory retrieves one page of the changes to a single ingredient, newest first.
+// History outlives the ingredient, so purged ingredients still have theirs.
+func (u *historyUsecase) GetIngredientHistory(ctx context.Context, id int64, req ListIngredientEventsRequest) (*IngredientEventListResponse, error) {
+	filter, err := buildIngredientEventFilter(req)
+	if err != nil {
+		return nil, err
+	}
+	filter.IngredientID = id
+
+	return u.listEvents(ctx, filter)
+}
+
+// GetActivity retrieves one page of the changes to every ingredient, newest first
+func (u *historyUsecase) GetActivity(ctx context.Context, req ListIngredientEventsRequest) (*IngredientEventListResponse, error) {
+	filter, err := buildIngredientEventFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.listEvents(ctx, filter)
+}
+
+// GetInventoryAt reconstructs the inventory at a past time given as YYYY-MM-DD or RFC3339.
+// A date alone means the end of that day.
+func (u *historyUsecase) GetInventoryAt(ctx context.Context, at string) (*InventorySnapshotResponse, error) {
+	if at == "" {
+		return nil, fmt.Errorf("%w: at is required", domain.ErrInvalidInput)
+	}
+
+	start, end, err := parsePeriodBound(at)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid at format", domain.ErrInvalidInput)
+	}
+	if end.After(start) {
+		// A whole day was given; include everything recorded on it
+		start = end.Add(-time.Nanosecond)
+	}
+
+	ingredients, err := u.repo.InventoryAt(ctx, start)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inventory snapshot: %w", err)
+	}
+
+	if ingredients == nil {
+		ingredients = []*domain.Ingredient{}
+	}
+
+	return &InventorySnapshotResponse{At: start, Items: ingredients}, nil
+}
+
+// listEvents fetches one page of events and issues the cursor of the next one
+func (u *historyUsecase) listEvents(ctx context.Context, filter repository.IngredientEventFilter) (*IngredientEventListResponse, error) {
+	// Fetch one extra row to find out whether another page follows
+	pageSize := filter.Limit
+	filter.Limit = pageSize + 1
+
+	events, err := u.repo.Search(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ingredient history: %w", err)
+	}
+
+	// Return empty slice instead of nil if no events found
+	if events == nil {
+		events = []*domain.IngredientEvent{}
+	}
+
+	response := &IngredientEventListResponse{Items: events}
+	if len(events) > pageSize {
+		response.Items = events[:pageSize]
+		response.NextCursor = strconv.FormatInt(response.Items[pageSize-1].ID, 10)
+	}
+
+	return response, nil
+}
+
+// buildIngredientEventFilter validates the history query and converts it into a repository filter
+func buildIngredientEventFilter(req ListIngredientEventsRequest) (repository.IngredientEventFilter, error) {
+	filter := repository.IngredientEventFilter{
+		Actor:  req.Actor,
+		Source: req.Source,
+		Action: req.Action,
+		Limit:  defaultIngredientEventPageSize,
+	}
+
+	if req.Source != "" && !domain.IsValidEventSource(req.Source) {
+		return filter, fmt.Errorf("%w: unknown source %q", domain.ErrInvalidInput, req.Source)
+	}
+	if req.Action != "" && !domain.IsValidEventAction(req.Action) {
+		return filter, fmt.Errorf("%w: unknown action %q", domain.ErrInvalidInput, req.Action)
+	}
+
+	if req.Since != "" {
+		since, _, err := parsePeriodBound(req.Since)
+		if err != nil {
+			return filter, fmt.Errorf("%w: invalid since format", domain.ErrInvalidInput)
+		}
+		filter.Since = &since
+	}
+
+	if req.Until != "" {
+		_, until, err := parsePeriodBound(req.Until)
+		if err != nil {
+			return filter, fmt.Errorf("%w: invalid until format", domain.ErrInvalidInput)
+		}
+		filter.Until = &until
+	}
+
+	if req.Limit < 0 {
+		return filter, fmt.Errorf("%w: limit must not be negative", domain.ErrInvalidInput)
+	}
+	if req.Limit > 0 {
+		filter.Limit = min(req.Limit, maxIngredientEventPageSize)
+	}
+
+	if req.Cursor != "" {
+		beforeID, err := strconv.ParseInt(req.Cursor, 10, 64)
+		if err != nil || beforeID <= 0 {
+			return filter, fmt.Errorf("%w: invalid cursor", domain.ErrInvalidInput)
+		}
+		filter.BeforeID = beforeID
+	}
+
+	return filter, nil
+}
+
+// parsePeriodBound parses YYYY-MM-DD or RFC3339 into the half-open range [start, end) it covers.
+// A date covers the whole day; an exact time has start == end.
+func parsePeriodBound(value string) (time.Time, time.Time, error) {
+	if date, err := time.Parse("2006-01-02", value); err == nil {
+		return date, date.AddDate(0, 0, 1), nil
+	}
+
+	at, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return at, at, nil
+}
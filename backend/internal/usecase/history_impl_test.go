@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockIngredientEventRepository is a mock implementation of IngredientEventRepository
+type MockIngredientEventRepository struct {
+	mock.Mock
+}
+
+func (m *MockIngredientEventRepository) Search(ctx context.Context, filter repository.IngredientEventFilter) ([]*domain.IngredientEvent, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.IngredientEvent), args.Error(1)
+}
+
+func (m *MockIngredientEventRepository) InventoryAt(ctx context.Context, at time.Time) ([]*domain.Ingredient, error) {
+	args := m.Called(ctx, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Ingredient), args.Error(1)
+}
+
+// TestGetIngredientHistory_NextCursor tests that a full page issues the ID of its last event as the cursor
+func TestGetIngredientHistory_NextCursor(t *testing.T) {
+	mockRepo := new(MockIngredientEventRepository)
+	usecase := NewHistoryUsecase(mockRepo)
+
+	events := []*domain.IngredientEvent{{ID: 9}, {ID: 7}, {ID: 4}}
+	mockRepo.On("Search", mock.Anything, repository.IngredientEventFilter{
+		IngredientID: 1,
+		Action:       domain.EventActionUpdate,
+		BeforeID:     10,
+		Limit:        3,
+	}).Return(events, nil)
+
+	result, err := usecase.GetIngredientHistory(context.Background(), 1, ListIngredientEventsRequest{
+		Action: domain.EventActionUpdate,
+		Cursor: "10",
+		Limit:  2,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, events[:2], result.Items)
+	assert.Equal(t, "7", result.NextCursor)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestGetActivity_DateRange tests that a date-only until covers the whole day
+func TestGetActivity_DateRange(t *testing.T) {
+	mockRepo := new(MockIngredientEventRepository)
+	usecase := NewHistoryUsecase(mockRepo)
+
+	since := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 10, 3, 0, 0, 0, 0, time.UTC)
+	mockRepo.On("Search", mock.Anything, repository.IngredientEventFilter{
+		Actor: "alice",
+		Since: &since,
+		Until: &until,
+		Limit: defaultIngredientEventPageSize + 1,
+	}).Return(nil, nil)
+
+	result, err := usecase.GetActivity(context.Background(), ListIngredientEventsRequest{
+		Actor: "alice",
+		Since: "2026-10-01",
+		Until: "2026-10-02",
+	})
+
+	assert.NoError(t, err)
+	assert.Empty(t, result.Items)
+	assert.NotNil(t, result.Items)
+	assert.Empty(t, result.NextCursor)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestGetActivity_InvalidInput tests that malformed history queries are rejected before reaching the repository
+func TestGetActivity_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name string
+		req  ListIngredientEventsRequest
+	}{
+		{"unknown source", ListIngredientEventsRequest{Source: "fax"}},
+		{"unknown action", ListIngredientEventsRequest{Action: "eat"}},
+		{"invalid since", ListIngredientEventsRequest{Since: "yesterday"}},
+		{"negative limit", ListIngredientEventsRequest{Limit: -1}},
+		{"invalid cursor", ListIngredientEventsRequest{Cursor: "abc"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockIngredientEventRepository)
+			usecase := NewHistoryUsecase(mockRepo)
+
+			result, err := usecase.GetActivity(context.Background(), tt.req)
+
+			assert.ErrorIs(t, err, domain.ErrInvalidInput)
+			assert.Nil(t, result)
+			mockRepo.AssertNotCalled(t, "Search", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+// TestGetInventoryAt_Date tests that a date alone reconstructs the inventory at the end of that day
+func TestGetInventoryAt_Date(t *testing.T) {
+	mockRepo := new(MockIngredientEventRepository)
+	usecase := NewHistoryUsecase(mockRepo)
+
+	at := time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond)
+	ingredients := []*domain.Ingredient{{ID: 1, Name: "牛乳"}}
+	mockRepo.On("InventoryAt", mock.Anything, at).Return(ingredients, nil)
+
+	result, err := usecase.GetInventoryAt(context.Background(), "2026-10-01")
+
+	assert.NoError(t, err)
+	assert.Equal(t, at, result.At)
+	assert.Equal(t, ingredients, result.Items)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestGetInventoryAt_Missing tests that the time is required
+func TestGetInventoryAt_Missing(t *testing.T) {
+	mockRepo := new(MockIngredientEventRepository)
+	usecase := NewHistoryUsecase(mockRepo)
+
+	result, err := usecase.GetInventoryAt(context.Background(), "")
+
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	assert.Nil(t, result)
+}
@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+)
+
+// HouseholdUsecase defines the business logic interface for households, their members and invitation codes
+type HouseholdUsecase interface {
+	// Resolve returns the household the signed-in user works in: the one with householdID,
+	// or their first household when householdID is zero. Requests made with an API key always work in its household.
+	Resolve(ctx context.Context, householdID int64) (*domain.Household, error)
+
+	// ListHouseholds retrieves the households of the signed-in user
+	ListHouseholds(ctx context.Context) ([]*domain.Household, error)
+
+	// CreateHousehold creates a household owned by the signed-in user
+	CreateHousehold(ctx context.Context, req SaveHouseholdRequest) (*domain.Household, error)
+
+	// UpdateHousehold renames a household; only its owners may do so
+	UpdateHousehold(ctx context.Context, id int64, req SaveHouseholdRequest) (*domain.Household, error)
+
+	// ListMembers retrieves the members of a household the signed-in user belongs to
+	ListMembers(ctx context.Context, id int64) ([]*domain.HouseholdMember, error)
+
+	// UpdateMember changes the role of a member; only owners may do so, and the last owner cannot step down
+	UpdateMember(ctx context.Context, id int64, userID int64, req UpdateHouseholdMemberRequest) error
+
+	// RemoveMember removes a member from a household. Owners may remove anyone and every member may leave,
+	// except the last owner.
+	RemoveMember(ctx context.Context, id int64, userID int64) error
+
+	// ListInvitations retrieves the pending invitation codes of a household; only its owners may see them
+	ListInvitations(ctx context.Context, id int64) ([]*domain.HouseholdInvitation, error)
+
+	// CreateInvitation issues a single-use invitation code for a household; only its owners may do so
+	CreateInvitation(ctx context.Context, id int64, req CreateInvitationRequest) (*domain.HouseholdInvitation, error)
+
+	// RevokeInvitation deletes a pending invitation code of a household; only its owners may do so
+	RevokeInvitation(ctx context.Context, id int64, invitationID int64) error
+
+	// Join adds the signed-in user to the household of an invitation code
+	Join(ctx context.Context, req JoinHouseholdRequest) (*domain.Household, error)
+}
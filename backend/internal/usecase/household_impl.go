@@ -0,0 +1,360 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/internal/repository"
+)
+
+// Household limits
+const (
+	maxHouseholdNameLength = 255
+	invitationTTL          = 7 * 24 * time.Hour
+	invitationCodeLength   = 12
+)
+
+// invitationCodeAlphabet leaves out 0, 1, I and O so codes read out loud or copied by hand stay unambiguous.
+// Its 32 letters let each random byte pick one without bias.
+const invitationCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// householdUsecase implements the HouseholdUsecase interface
+type householdUsecase struct {
+	repo repository.HouseholdRepository
+	now  func() time.Time
+}
+
+// NewHouseholdUsecase creates a new instance of HouseholdUsecase
+func NewHouseholdUsecase(repo repository.HouseholdRepository) HouseholdUsecase {
+	return &householdUsecase{
+		repo: repo,
+		now:  time.Now,
+	}
+}
+
+// Resolve returns the household the signed-in user works in
+func (u *householdUsecase) Resolve(ctx context.Context, householdID int64) (*domain.Household, error) {
+	user, err := signedInUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// API keys only work in the household they were created in
+	if key := domain.CurrentAPIKey(ctx); key != nil {
+		if householdID != 0 && householdID != key.HouseholdID {
+			return nil, fmt.Errorf("%w: this api key belongs to household %d", domain.ErrForbidden, key.HouseholdID)
+		}
+		householdID = key.HouseholdID
+	}
+
+	if householdID != 0 {
+		household, err := u.repo.GetMembership(ctx, householdID, user.ID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, fmt.Errorf("%w: you do not belong to household %d", domain.ErrForbidden, householdID)
+			}
+			return nil, fmt.Errorf("failed to get household: %w", err)
+		}
+		return household, nil
+	}
+
+	households, err := u.repo.GetByUser(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get households: %w", err)
+	}
+	if len(households) == 0 {
+		return nil, fmt.Errorf("%w: create a household or join one with an invitation code first", domain.ErrForbidden)
+	}
+
+	return households[0], nil
+}
+
+// ListHouseholds retrieves the households of the signed-in user
+func (u *householdUsecase) ListHouseholds(ctx context.Context) ([]*domain.Household, error) {
+	user, err := signedInUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	households, err := u.repo.GetByUser(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get households: %w", err)
+	}
+
+	return households, nil
+}
+
+// CreateHousehold creates a household owned by the signed-in user
+func (u *householdUsecase) CreateHousehold(ctx context.Context, req SaveHouseholdRequest) (*domain.Household, error) {
+	user, err := signedInUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := validateHouseholdName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	household := &domain.Household{Name: name}
+	if err := u.repo.Create(ctx, household, user.ID); err != nil {
+		return nil, fmt.Errorf("failed to create household: %w", err)
+	}
+
+	return household, nil
+}
+
+// UpdateHousehold renames a household
+func (u *householdUsecase) UpdateHousehold(ctx context.Context, id int64, req SaveHouseholdRequest) (*domain.Household, error) {
+	household, err := u.requireRole(ctx, id, domain.HouseholdRoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := validateHouseholdName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	household.Name = name
+	if err := u.repo.Update(ctx, household); err != nil {
+		return nil, fmt.Errorf("failed to update household: %w", err)
+	}
+
+	return household, nil
+}
+
+// ListMembers retrieves the members of a household the signed-in user belongs to
+func (u *householdUsecase) ListMembers(ctx context.Context, id int64) ([]*domain.HouseholdMember, error) {
+	if _, err := u.requireRole(ctx, id); err != nil {
+		return nil, err
+	}
+
+	members, err := u.repo.GetMembers(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get household members: %w", err)
+	}
+
+	return members, nil
+}
+
+// UpdateMember changes the role of a member of a household
+func (u *householdUsecase) UpdateMember(ctx context.Context, id int64, userID int64, req UpdateHouseholdMemberRequest) error {
+	if !domain.IsValidHouseholdRole(req.Role) {
+		return fmt.Errorf("%w: unknown role %q", domain.ErrInvalidInput, req.Role)
+	}
+
+	if _, err := u.requireRole(ctx, id, domain.HouseholdRoleOwner); err != nil {
+		return err
+	}
+
+	if req.Role != domain.HouseholdRoleOwner {
+		if err := u.keepOwner(ctx, id, userID); err != nil {
+			return err
+		}
+	}
+
+	if err := u.repo.UpdateMemberRole(ctx, id, userID, req.Role); err != nil {
+		return fmt.Errorf("failed to update household member: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveMember removes a member from a household
+func (u *householdUsecase) RemoveMember(ctx context.Context, id int64, userID int64) error {
+	household, err := u.requireRole(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Everyone may leave, but only owners may remove others
+	if user := domain.CurrentUser(ctx); user.ID != userID && household.Role != domain.HouseholdRoleOwner {
+		return fmt.Errorf("%w: only owners of the household can remove its members", domain.ErrForbidden)
+	}
+
+	if err := u.keepOwner(ctx, id, userID); err != nil {
+		return err
+	}
+
+	if err := u.repo.RemoveMember(ctx, id, userID); err != nil {
+		return fmt.Errorf("failed to remove household member: %w", err)
+	}
+
+	return nil
+}
+
+// ListInvitations retrieves the pending invitation codes of a household
+func (u *householdUsecase) ListInvitations(ctx context.Context, id int64) ([]*domain.HouseholdInvitation, error) {
+	if _, err := u.requireRole(ctx, id, domain.HouseholdRoleOwner); err != nil {
+		return nil, err
+	}
+
+	invitations, err := u.repo.GetInvitations(ctx, id, u.now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invitations: %w", err)
+	}
+
+	return invitations, nil
+}
+
+// CreateInvitation issues a single-use invitation code for a household
+func (u *householdUsecase) CreateInvitation(ctx context.Context, id int64, req CreateInvitationRequest) (*domain.HouseholdInvitation, error) {
+	role := req.Role
+	if role == "" {
+		role = domain.HouseholdRoleMember
+	}
+	if !domain.IsValidHouseholdRole(role) {
+		return nil, fmt.Errorf("%w: unknown role %q", domain.ErrInvalidInput, role)
+	}
+
+	if _, err := u.requireRole(ctx, id, domain.HouseholdRoleOwner); err != nil {
+		return nil, err
+	}
+
+	code, err := newInvitationCode()
+	if err != nil {
+		return nil, err
+	}
+
+	invitation := &domain.HouseholdInvitation{
+		HouseholdID: id,
+		Code:        code,
+		Role:        role,
+		CreatedBy:   domain.OwnerID(ctx),
+		ExpiresAt:   u.now().Add(invitationTTL),
+	}
+	if err := u.repo.CreateInvitation(ctx, invitation); err != nil {
+		return nil, fmt.Errorf("failed to create invitation: %w", err)
+	}
+
+	return invitation, nil
+}
+
+// RevokeInvitation deletes a pending invitation code of a household
+func (u *householdUsecase) RevokeInvitation(ctx context.Context, id int64, invitationID int64) error {
+	if _, err := u.requireRole(ctx, id, domain.HouseholdRoleOwner); err != nil {
+		return err
+	}
+
+	if err := u.repo.DeleteInvitation(ctx, id, invitationID); err != nil {
+		return fmt.Errorf("failed to revoke invitation: %w", err)
+	}
+
+	return nil
+}
+
+// Join adds the signed-in user to the household of an invitation code
+func (u *householdUsecase) Join(ctx context.Context, req JoinHouseholdRequest) (*domain.Household, error) {
+	user, err := signedInUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	code := strings.ToUpper(strings.TrimSpace(req.Code))
+	household, err := u.repo.RedeemInvitation(ctx, code, user.ID, u.now())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: the invitation code is unknown, already used or expired", domain.ErrInvalidInput)
+		}
+		if errors.Is(err, domain.ErrInvalidInput) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to join household: %w", err)
+	}
+
+	return household, nil
+}
+
+// requireRole returns a household the signed-in user belongs to with one of roles, or with any role when none are
+// given. Households the user does not belong to are reported as missing so their IDs cannot be probed.
+func (u *householdUsecase) requireRole(ctx context.Context, id int64, roles ...string) (*domain.Household, error) {
+	user, err := signedInUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	household, err := u.repo.GetMembership(ctx, id, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get household: %w", err)
+	}
+
+	if len(roles) > 0 && !slices.Contains(roles, household.Role) {
+		return nil, fmt.Errorf("%w: only owners of the household can do this", domain.ErrForbidden)
+	}
+
+	return household, nil
+}
+
+// keepOwner rejects taking the owner role away from userID when they are the last owner of the household
+func (u *householdUsecase) keepOwner(ctx context.Context, id int64, userID int64) error {
+	members, err := u.repo.GetMembers(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get household members: %w", err)
+	}
+
+	owners, isOwner := 0, false
+	for _, member := range members {
+		if member.Role == domain.HouseholdRoleOwner {
+			owners++
+			isOwner = isOwner || member.UserID == userID
+		}
+	}
+
+	if isOwner && owners == 1 {
+		return fmt.Errorf("%w: a household needs at least one owner; make someone else an owner first", domain.ErrInvalidInput)
+	}
+
+	return nil
+}
+
+// signedInUser returns the user making the request, or an error when nobody is signed in
+func signedInUser(ctx context.Context) (*domain.User, error) {
+	user := domain.CurrentUser(ctx)
+	if user == nil {
+		return nil, fmt.Errorf("%w: sign in to use households", domain.ErrUnauthorized)
+	}
+	return user, nil
+}
+
+// checkWritable rejects changes made by a viewer of the current household
+func checkWritable(ctx context.Context) error {
+	if household := domain.CurrentHousehold(ctx); household != nil && !household.CanWrite() {
+		return fmt.Errorf("%w: viewers of the household cannot change its records", domain.ErrForbidden)
+	}
+	return nil
+}
+
+// validateHouseholdName trims a household name and checks its length
+func validateHouseholdName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("%w: household name cannot be empty", domain.ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(name) > maxHouseholdNameLength {
+		return "", fmt.Errorf("%w: household name must be at most %d characters", domain.ErrInvalidInput, maxHouseholdNameLength)
+	}
+	return name, nil
+}
+
+// newInvitationCode generates a random invitation code
+func newInvitationCode() (string, error) {
+	random := make([]byte, invitationCodeLength)
+	if _, err := rand.Read(random); err != nil {
+		return "", fmt.Errorf("failed to generate invitation code: %w", err)
+	}
+
+	code := make([]byte, invitationCodeLength)
+	for i, b := range random {
+		code[i] = invitationCodeAlphabet[int(b)%len(invitationCodeAlphabet)]
+	}
+	return string(code), nil
+}
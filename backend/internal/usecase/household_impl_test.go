@@ -0,0 +1,256 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockHouseholdRepository is a mock implementation of HouseholdRepository
+type MockHouseholdRepository struct {
+	mock.Mock
+}
+
+func (m *MockHouseholdRepository) GetByUser(ctx context.Context, userID int64) ([]*domain.Household, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Household), args.Error(1)
+}
+
+func (m *MockHouseholdRepository) GetMembership(ctx context.Context, householdID int64, userID int64) (*domain.Household, error) {
+	args := m.Called(ctx, householdID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Household), args.Error(1)
+}
+
+func (m *MockHouseholdRepository) Create(ctx context.Context, household *domain.Household, ownerID int64) error {
+	args := m.Called(ctx, household, ownerID)
+	return args.Error(0)
+}
+
+func (m *MockHouseholdRepository) Update(ctx context.Context, household *domain.Household) error {
+	args := m.Called(ctx, household)
+	return args.Error(0)
+}
+
+func (m *MockHouseholdRepository) GetMembers(ctx context.Context, householdID int64) ([]*domain.HouseholdMember, error) {
+	args := m.Called(ctx, householdID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.HouseholdMember), args.Error(1)
+}
+
+func (m *MockHouseholdRepository) UpdateMemberRole(ctx context.Context, householdID int64, userID int64, role string) error {
+	args := m.Called(ctx, householdID, userID, role)
+	return args.Error(0)
+}
+
+func (m *MockHouseholdRepository) RemoveMember(ctx context.Context, householdID int64, userID int64) error {
+	args := m.Called(ctx, householdID, userID)
+	return args.Error(0)
+}
+
+func (m *MockHouseholdRepository) CreateInvitation(ctx context.Context, invitation *domain.HouseholdInvitation) error {
+	args := m.Called(ctx, invitation)
+	return args.Error(0)
+}
+
+func (m *MockHouseholdRepository) GetInvitations(ctx context.Context, householdID int64, now time.Time) ([]*domain.HouseholdInvitation, error) {
+	args := m.Called(ctx, householdID, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.HouseholdInvitation), args.Error(1)
+}
+
+func (m *MockHouseholdRepository) DeleteInvitation(ctx context.Context, householdID int64, id int64) error {
+	args := m.Called(ctx, householdID, id)
+	return args.Error(0)
+}
+
+func (m *MockHouseholdRepository) RedeemInvitation(ctx context.Context, code string, userID int64, now time.Time) (*domain.Household, error) {
+	args := m.Called(ctx, code, userID, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Household), args.Error(1)
+}
+
+// signedIn returns a context whose requests are made by the user with the given ID
+func signedIn(id int64) context.Context {
+	return domain.WithUser(context.Background(), &domain.User{ID: id, Username: fmt.Sprintf("user%d", id)})
+}
+
+// TestResolveHousehold_Default tests that requests without a household work in the user's first household
+func TestResolveHousehold_Default(t *testing.T) {
+	mockRepo := new(MockHouseholdRepository)
+	usecase := NewHouseholdUsecase(mockRepo)
+
+	mockRepo.On("GetByUser", mock.Anything, int64(7)).Return([]*domain.Household{
+		{ID: 2, Name: "山田家", Role: domain.HouseholdRoleMember},
+		{ID: 5, Name: "実家", Role: domain.HouseholdRoleViewer},
+	}, nil)
+
+	household, err := usecase.Resolve(signedIn(7), 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), household.ID)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestResolveHousehold_NotMember tests that users cannot switch to a household they do not belong to
+func TestResolveHousehold_NotMember(t *testing.T) {
+	mockRepo := new(MockHouseholdRepository)
+	usecase := NewHouseholdUsecase(mockRepo)
+
+	mockRepo.On("GetMembership", mock.Anything, int64(3), int64(7)).
+		Return(nil, fmt.Errorf("household not found: %w", sql.ErrNoRows))
+
+	household, err := usecase.Resolve(signedIn(7), 3)
+
+	assert.Nil(t, household)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestResolveHousehold_NoHousehold tests that users without a household are asked to create or join one
+func TestResolveHousehold_NoHousehold(t *testing.T) {
+	mockRepo := new(MockHouseholdRepository)
+	usecase := NewHouseholdUsecase(mockRepo)
+
+	mockRepo.On("GetByUser", mock.Anything, int64(7)).Return([]*domain.Household{}, nil)
+
+	household, err := usecase.Resolve(signedIn(7), 0)
+
+	assert.Nil(t, household)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestCreateInvitation_Success tests that owners get a single-use code for members by default
+func TestCreateInvitation_Success(t *testing.T) {
+	mockRepo := new(MockHouseholdRepository)
+	usecase := NewHouseholdUsecase(mockRepo)
+
+	mockRepo.On("GetMembership", mock.Anything, int64(2), int64(7)).
+		Return(&domain.Household{ID: 2, Role: domain.HouseholdRoleOwner}, nil)
+	mockRepo.On("CreateInvitation", mock.Anything, mock.AnythingOfType("*domain.HouseholdInvitation")).Return(nil)
+
+	invitation, err := usecase.CreateInvitation(signedIn(7), 2, CreateInvitationRequest{})
+
+	assert.NoError(t, err)
+	assert.Len(t, invitation.Code, invitationCodeLength)
+	assert.Equal(t, domain.HouseholdRoleMember, invitation.Role)
+	assert.Equal(t, int64(7), *invitation.CreatedBy)
+	assert.WithinDuration(t, time.Now().Add(invitationTTL), invitation.ExpiresAt, time.Minute)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestCreateInvitation_NotOwner tests that only owners can invite
+func TestCreateInvitation_NotOwner(t *testing.T) {
+	mockRepo := new(MockHouseholdRepository)
+	usecase := NewHouseholdUsecase(mockRepo)
+
+	mockRepo.On("GetMembership", mock.Anything, int64(2), int64(7)).
+		Return(&domain.Household{ID: 2, Role: domain.HouseholdRoleMember}, nil)
+
+	invitation, err := usecase.CreateInvitation(signedIn(7), 2, CreateInvitationRequest{Role: domain.HouseholdRoleOwner})
+
+	assert.Nil(t, invitation)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	mockRepo.AssertNotCalled(t, "CreateInvitation", mock.Anything, mock.Anything)
+}
+
+// TestUpdateHouseholdMember_LastOwner tests that the last owner cannot step down
+func TestUpdateHouseholdMember_LastOwner(t *testing.T) {
+	mockRepo := new(MockHouseholdRepository)
+	usecase := NewHouseholdUsecase(mockRepo)
+
+	mockRepo.On("GetMembership", mock.Anything, int64(2), int64(7)).
+		Return(&domain.Household{ID: 2, Role: domain.HouseholdRoleOwner}, nil)
+	mockRepo.On("GetMembers", mock.Anything, int64(2)).Return([]*domain.HouseholdMember{
+		{HouseholdID: 2, UserID: 7, Role: domain.HouseholdRoleOwner},
+		{HouseholdID: 2, UserID: 8, Role: domain.HouseholdRoleMember},
+	}, nil)
+
+	err := usecase.UpdateMember(signedIn(7), 2, 7, UpdateHouseholdMemberRequest{Role: domain.HouseholdRoleMember})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	mockRepo.AssertNotCalled(t, "UpdateMemberRole", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestRemoveHouseholdMember_Leave tests that members can leave a household on their own
+func TestRemoveHouseholdMember_Leave(t *testing.T) {
+	mockRepo := new(MockHouseholdRepository)
+	usecase := NewHouseholdUsecase(mockRepo)
+
+	mockRepo.On("GetMembership", mock.Anything, int64(2), int64(8)).
+		Return(&domain.Household{ID: 2, Role: domain.HouseholdRoleViewer}, nil)
+	mockRepo.On("GetMembers", mock.Anything, int64(2)).Return([]*domain.HouseholdMember{
+		{HouseholdID: 2, UserID: 7, Role: domain.HouseholdRoleOwner},
+		{HouseholdID: 2, UserID: 8, Role: domain.HouseholdRoleViewer},
+	}, nil)
+	mockRepo.On("RemoveMember", mock.Anything, int64(2), int64(8)).Return(nil)
+
+	err := usecase.RemoveMember(signedIn(8), 2, 8)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestRemoveHouseholdMember_NotOwner tests that members cannot remove each other
+func TestRemoveHouseholdMember_NotOwner(t *testing.T) {
+	mockRepo := new(MockHouseholdRepository)
+	usecase := NewHouseholdUsecase(mockRepo)
+
+	mockRepo.On("GetMembership", mock.Anything, int64(2), int64(8)).
+		Return(&domain.Household{ID: 2, Role: domain.HouseholdRoleMember}, nil)
+
+	err := usecase.RemoveMember(signedIn(8), 2, 9)
+
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	mockRepo.AssertNotCalled(t, "RemoveMember", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestJoinHousehold_UnknownCode tests that codes are normalized and unusable codes are rejected
+func TestJoinHousehold_UnknownCode(t *testing.T) {
+	mockRepo := new(MockHouseholdRepository)
+	usecase := NewHouseholdUsecase(mockRepo)
+
+	mockRepo.On("RedeemInvitation", mock.Anything, "ABCD2345EFGH", int64(7), mock.Anything).
+		Return(nil, fmt.Errorf("invitation not found: %w", sql.ErrNoRows))
+
+	household, err := usecase.Join(signedIn(7), JoinHouseholdRequest{Code: " abcd2345efgh "})
+
+	assert.Nil(t, household)
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestCheckOwner_HouseholdRoles tests how household roles widen and narrow who can change a record
+func TestCheckOwner_HouseholdRoles(t *testing.T) {
+	otherUser := int64(9)
+	inHousehold := func(role string) context.Context {
+		return domain.WithHousehold(signedIn(7), &domain.Household{ID: 2, Role: role})
+	}
+
+	// Owners of the household can change records created by anyone in it
+	assert.NoError(t, checkOwner(inHousehold(domain.HouseholdRoleOwner), &otherUser))
+
+	// Members can only change their own records
+	assert.ErrorIs(t, checkOwner(inHousehold(domain.HouseholdRoleMember), &otherUser), domain.ErrForbidden)
+
+	// Viewers cannot change anything, not even records without an owner
+	assert.ErrorIs(t, checkOwner(inHousehold(domain.HouseholdRoleViewer), nil), domain.ErrForbidden)
+}
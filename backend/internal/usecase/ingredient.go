@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
 )
@@ -11,15 +12,37 @@ type IngredientUsecase interface {
 	// CreateIngredient creates a new ingredient
 	CreateIngredient(ctx context.Context, req CreateIngredientRequest) (*domain.Ingredient, error)
 
-	// GetAllIngredients retrieves all ingredients
-	GetAllIngredients(ctx context.Context) ([]*domain.Ingredient, error)
+	// GetAllIngredients retrieves one page of the ingredients matching the filter
+	GetAllIngredients(ctx context.Context, req ListIngredientsRequest) (*IngredientListResponse, error)
 
 	// GetIngredientByID retrieves an ingredient by ID
 	GetIngredientByID(ctx context.Context, id int64) (*domain.Ingredient, error)
 
-	// UpdateIngredient updates an existing ingredient
+	// UpdateIngredient replaces every editable field of an existing ingredient
 	UpdateIngredient(ctx context.Context, id int64, req UpdateIngredientRequest) (*domain.Ingredient, error)
 
-	// DeleteIngredient deletes an ingredient by ID
-	DeleteIngredient(ctx context.Context, id int64) error
+	// PatchIngredient partially updates an existing ingredient with a merge patch or JSON Patch
+	PatchIngredient(ctx context.Context, id int64, req PatchIngredientRequest) (*domain.Ingredient, error)
+
+	// DeleteIngredient moves an ingredient to the trash by ID.
+	// When expectedVersions is not empty the ingredient must currently be at one of those versions.
+	DeleteIngredient(ctx context.Context, id int64, expectedVersions []int64) error
+
+	// GetTrash retrieves the deleted ingredients that have not been purged yet, most recently deleted first
+	GetTrash(ctx context.Context) ([]*domain.Ingredient, error)
+
+	// RestoreIngredient takes a deleted ingredient out of the trash
+	RestoreIngredient(ctx context.Context, id int64) (*domain.Ingredient, error)
+
+	// PurgeTrash permanently removes the ingredients deleted more than retention ago and returns how many were removed
+	PurgeTrash(ctx context.Context, retention time.Duration) (int64, error)
+
+	// BatchIngredients applies creates, updates and deletes in a single transaction and reports each outcome
+	BatchIngredients(ctx context.Context, req BatchIngredientsRequest) (*BatchIngredientsResponse, error)
+
+	// ExportIngredients encodes every ingredient as a CSV or JSON inventory file, oldest first
+	ExportIngredients(ctx context.Context, format string) ([]byte, error)
+
+	// ImportIngredients loads an inventory file, reporting the outcome of every row
+	ImportIngredients(ctx context.Context, req ImportIngredientsRequest) (*ImportIngredientsResponse, error)
 }
@@ -1,72 +1,290 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
 	"github.com/Rin0530/DinnerDecider/backend/internal/repository"
+	"github.com/Rin0530/DinnerDecider/backend/internal/service"
+	"github.com/Rin0530/DinnerDecider/backend/pkg/barcode"
+	"github.com/Rin0530/DinnerDecider/backend/pkg/jsonpatch"
+	"github.com/Rin0530/DinnerDecider/backend/pkg/logger"
+	"github.com/Rin0530/DinnerDecider/backend/pkg/quantity"
+	"github.com/Rin0530/DinnerDecider/backend/pkg/textnorm"
+	"golang.org/x/text/unicode/norm"
 )
 
+// Ingredient list page sizes
+const (
+	defaultIngredientPageSize = 50
+	maxIngredientPageSize     = 200
+)
+
+// maxBatchOperations caps the number of operations in one ingredient batch
+const maxBatchOperations = 200
+
+// errBatchAborted stops an atomic batch after the first failed operation so the transaction rolls back
+var errBatchAborted = errors.New("batch aborted")
+
+// maxImportRows caps the number of data rows in one inventory import
+const maxImportRows = 1000
+
+// inventoryColumnAliases lists the column names recognized for each inventory field, compared after textnorm.Fold
+var inventoryColumnAliases = map[string][]string{
+	domain.InventoryColumnName:         {"name", "名前", "食材", "食材名", "品名", "品目", "item", "ingredient"},
+	domain.InventoryColumnCategory:     {"category", "カテゴリ", "カテゴリー", "分類", "種類"},
+	domain.InventoryColumnLocation:     {"location", "保存場所", "場所", "storage"},
+	domain.InventoryColumnQuantity:     {"quantity", "数量", "分量", "量", "amount", "qty"},
+	domain.InventoryColumnBarcode:      {"barcode", "jan", "janコード", "ean", "バーコード"},
+	domain.InventoryColumnPrice:        {"price", "価格", "値段", "金額", "購入価格", "cost"},
+	domain.InventoryColumnStore:        {"store", "購入店", "店舗", "店", "店名", "shop"},
+	domain.InventoryColumnPurchaseDate: {"purchase_date", "購入日", "purchased", "purchased_at"},
+	domain.InventoryColumnExpiresAt:    {"expires_at", "賞味期限", "消費期限", "期限", "expiry", "expiration_date"},
+}
+
+// importDateFormat is a date notation accepted in inventory imports
+type importDateFormat struct {
+	name   string
+	layout string
+}
+
+// importDateFormats lists the accepted date notations in detection order.
+// Ambiguous dates such as 01/02/2025 are read month first unless DD/MM/YYYY is requested.
+var importDateFormats = []importDateFormat{
+	{"YYYY-MM-DD", "2006-1-2"},
+	{"YYYY/MM/DD", "2006/1/2"},
+	{"YYYY.MM.DD", "2006.1.2"},
+	{"YYYYMMDD", "20060102"},
+	{"YYYY年MM月DD日", "2006年1月2日"},
+	{"MM/DD/YYYY", "1/2/2006"},
+	{"DD/MM/YYYY", "2/1/2006"},
+	{"DD.MM.YYYY", "2.1.2006"},
+	{"RFC3339", time.RFC3339},
+}
+
+// dryRunIngredientStore discards writes so an import can be previewed with the same code path
+type dryRunIngredientStore struct{}
+
+// ingredientPageToken is the decoded form of the opaque next_cursor.
+// It remembers the sort it was issued for so a cursor cannot be replayed against a different ordering.
+type ingredientPageToken struct {
+	Sort  string `json:"s,omitempty"`
+	Order string `json:"o,omitempty"`
+	repository.IngredientCursor
+}
+
 // ingredientUsecase implements the IngredientUsecase interface
 type ingredientUsecase struct {
-	repo repository.IngredientRepository
+	repo       repository.IngredientRepository
+	products   repository.ProductRepository
+	normalizer service.IngredientNormalizer
+	codec      service.InventoryCodec
+	nutrition  service.NutritionTable
 }
 
-// NewIngredientUsecase creates a new instance of IngredientUsecase
-func NewIngredientUsecase(repo repository.IngredientRepository) IngredientUsecase {
+// NewIngredientUsecase creates a new instance of IngredientUsecase.
+// products may be nil to disable filling in ingredients from their barcode and learning new products,
+// and nutrition may be nil to leave nutrition out of the ingredients returned.
+func NewIngredientUsecase(repo repository.IngredientRepository, products repository.ProductRepository, normalizer service.IngredientNormalizer, codec service.InventoryCodec, nutrition service.NutritionTable) IngredientUsecase {
 	return &ingredientUsecase{
-		repo: repo,
+		repo:       repo,
+		products:   products,
+		normalizer: normalizer,
+		codec:      codec,
+		nutrition:  nutrition,
 	}
 }
 
 // CreateIngredient creates a new ingredient
 func (u *ingredientUsecase) CreateIngredient(ctx context.Context, req CreateIngredientRequest) (*domain.Ingredient, error) {
+	if err := checkWritable(ctx); err != nil {
+		return nil, err
+	}
+
+	ingredient, err := u.createIngredient(ctx, u.repo, req)
+	if err != nil {
+		return nil, err
+	}
+
+	u.learnProduct(ctx, ingredient)
+	return ingredient, nil
+}
+
+// createIngredient validates the request and inserts the ingredient through store
+func (u *ingredientUsecase) createIngredient(ctx context.Context, store repository.IngredientStore, req CreateIngredientRequest) (*domain.Ingredient, error) {
+	req, err := u.fillFromProduct(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ingredient, err := u.newIngredient(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Save to repository
+	if err := store.Create(ctx, ingredient); err != nil {
+		return nil, fmt.Errorf("failed to create ingredient: %w", err)
+	}
+
+	return ingredient, nil
+}
+
+// newIngredient validates a create request and builds the ingredient it describes without saving it
+func (u *ingredientUsecase) newIngredient(req CreateIngredientRequest) (*domain.Ingredient, error) {
 	// Validate required fields
-	if req.Name == "" {
-		return nil, errors.New("name is required")
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", domain.ErrInvalidInput)
+	}
+
+	code, err := normalizeBarcode(req.Barcode)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validatePrice(req.Price); err != nil {
+		return nil, err
 	}
 
 	// Create ingredient domain model
 	ingredient := &domain.Ingredient{
-		Name:      req.Name,
-		Quantity:  req.Quantity,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		Name:          name,
+		CanonicalName: u.normalizer.Canonical(name),
+		Quantity:      req.Quantity,
+		Barcode:       code,
+		Price:         req.Price,
+		Store:         strings.TrimSpace(req.Store),
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
 	}
 
-	// Parse purchase date if provided
-	if req.PurchaseDate != nil && *req.PurchaseDate != "" {
-		purchaseDate, err := time.Parse("2006-01-02", *req.PurchaseDate)
-		if err != nil {
-			return nil, fmt.Errorf("invalid purchase_date format: %w", err)
-		}
-		ingredient.PurchaseDate = &purchaseDate
+	// Classify the ingredient, detecting what was not provided
+	category, err := u.resolveCategory(name, req.Category)
+	if err != nil {
+		return nil, err
 	}
+	ingredient.Category = category
 
-	// Save to repository
-	if err := u.repo.Create(ctx, ingredient); err != nil {
-		return nil, fmt.Errorf("failed to create ingredient: %w", err)
+	location, err := resolveLocation(category, req.Location)
+	if err != nil {
+		return nil, err
+	}
+	ingredient.Location = location
+
+	// Parse dates if provided
+	if ingredient.PurchaseDate, err = parseDate("purchase_date", req.PurchaseDate); err != nil {
+		return nil, err
+	}
+	if ingredient.ExpiresAt, err = parseDate("expires_at", req.ExpiresAt); err != nil {
+		return nil, err
 	}
 
 	return ingredient, nil
 }
 
-// GetAllIngredients retrieves all ingredients
-func (u *ingredientUsecase) GetAllIngredients(ctx context.Context) ([]*domain.Ingredient, error) {
-	ingredients, err := u.repo.GetAll(ctx)
+// GetAllIngredients retrieves one page of the ingredients matching the filter
+func (u *ingredientUsecase) GetAllIngredients(ctx context.Context, req ListIngredientsRequest) (*IngredientListResponse, error) {
+	filter, err := u.buildIngredientFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch one extra row to find out whether another page follows
+	pageSize := filter.Limit
+	filter.Limit = pageSize + 1
+
+	ingredients, err := u.repo.Search(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all ingredients: %w", err)
 	}
 
 	// Return empty slice instead of nil if no ingredients found
 	if ingredients == nil {
-		return []*domain.Ingredient{}, nil
+		ingredients = []*domain.Ingredient{}
 	}
 
-	return ingredients, nil
+	response := &IngredientListResponse{Items: ingredients}
+	if len(ingredients) > pageSize {
+		response.Items = ingredients[:pageSize]
+		response.NextCursor = encodeIngredientPageToken(ingredientPageToken{
+			Sort:             req.Sort,
+			Order:            req.Order,
+			IngredientCursor: repository.NewIngredientCursor(response.Items[pageSize-1], req.Sort),
+		})
+	}
+
+	for _, ingredient := range response.Items {
+		u.attachNutrition(ingredient)
+	}
+	return response, nil
+}
+
+// buildIngredientFilter validates the list query and converts it into a repository filter
+func (u *ingredientUsecase) buildIngredientFilter(req ListIngredientsRequest) (repository.IngredientFilter, error) {
+	filter := repository.IngredientFilter{
+		Category: req.Category,
+		Location: req.Location,
+		Sort:     req.Sort,
+		Order:    req.Order,
+		Limit:    defaultIngredientPageSize,
+	}
+
+	if req.Category != "" && !domain.IsValidCategory(req.Category) {
+		return filter, fmt.Errorf("%w: unknown category %q", domain.ErrInvalidInput, req.Category)
+	}
+	if req.Location != "" && !domain.IsValidLocation(req.Location) {
+		return filter, fmt.Errorf("%w: unknown location %q", domain.ErrInvalidInput, req.Location)
+	}
+	if req.Sort != "" && !repository.IsValidIngredientSort(req.Sort) {
+		return filter, fmt.Errorf("%w: unknown sort %q", domain.ErrInvalidInput, req.Sort)
+	}
+	if req.Order != "" && req.Order != repository.OrderAsc && req.Order != repository.OrderDesc {
+		return filter, fmt.Errorf("%w: order must be %q or %q", domain.ErrInvalidInput, repository.OrderAsc, repository.OrderDesc)
+	}
+
+	if query := strings.TrimSpace(req.Query); query != "" {
+		filter.Query = query
+		filter.CanonicalQuery = u.normalizer.Canonical(query)
+	}
+
+	if req.PurchasedBefore != "" {
+		purchasedBefore, err := time.Parse("2006-01-02", req.PurchasedBefore)
+		if err != nil {
+			return filter, fmt.Errorf("%w: invalid purchased_before format", domain.ErrInvalidInput)
+		}
+		filter.PurchasedBefore = &purchasedBefore
+	}
+
+	if req.Limit < 0 {
+		return filter, fmt.Errorf("%w: limit must not be negative", domain.ErrInvalidInput)
+	}
+	if req.Limit > 0 {
+		filter.Limit = min(req.Limit, maxIngredientPageSize)
+	}
+
+	if req.Cursor != "" {
+		token, err := decodeIngredientPageToken(req.Cursor)
+		if err != nil {
+			return filter, err
+		}
+		if token.Sort != req.Sort || token.Order != req.Order {
+			return filter, fmt.Errorf("%w: cursor was issued for a different sort order", domain.ErrInvalidInput)
+		}
+		filter.After = &token.IngredientCursor
+	}
+
+	return filter, nil
 }
 
 // GetIngredientByID retrieves an ingredient by ID
@@ -76,11 +294,54 @@ func (u *ingredientUsecase) GetIngredientByID(ctx context.Context, id int64) (*d
 		return nil, fmt.Errorf("failed to get ingredient by id: %w", err)
 	}
 
+	u.attachNutrition(ingredient)
 	return ingredient, nil
 }
 
-// UpdateIngredient updates an existing ingredient
+// attachNutrition looks up the nutrition of an ingredient when a nutrition table is configured
+func (u *ingredientUsecase) attachNutrition(ingredient *domain.Ingredient) {
+	if u.nutrition == nil {
+		return
+	}
+	ingredient.Nutrition = u.nutrition.ForIngredient(ingredient)
+}
+
+// UpdateIngredient replaces every editable field of an existing ingredient
 func (u *ingredientUsecase) UpdateIngredient(ctx context.Context, id int64, req UpdateIngredientRequest) (*domain.Ingredient, error) {
+	ingredient, err := u.updateIngredient(ctx, u.repo, id, req)
+	if err != nil {
+		return nil, err
+	}
+
+	u.learnProduct(ctx, ingredient)
+	return ingredient, nil
+}
+
+// updateIngredient replaces the ingredient through store
+func (u *ingredientUsecase) updateIngredient(ctx context.Context, store repository.IngredientStore, id int64, req UpdateIngredientRequest) (*domain.Ingredient, error) {
+	// Get existing ingredient
+	ingredient, err := store.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ingredient: %w", err)
+	}
+
+	if ingredient == nil {
+		return nil, errors.New("ingredient not found")
+	}
+
+	if err := checkOwner(ctx, ingredient.OwnerID); err != nil {
+		return nil, err
+	}
+
+	if err := checkVersion(ingredient, req.ExpectedVersions); err != nil {
+		return nil, err
+	}
+
+	return u.replaceIngredient(ctx, store, ingredient, req)
+}
+
+// PatchIngredient partially updates an existing ingredient with a merge patch or JSON Patch
+func (u *ingredientUsecase) PatchIngredient(ctx context.Context, id int64, req PatchIngredientRequest) (*domain.Ingredient, error) {
 	// Get existing ingredient
 	ingredient, err := u.repo.GetByID(ctx, id)
 	if err != nil {
@@ -91,43 +352,164 @@ func (u *ingredientUsecase) UpdateIngredient(ctx context.Context, id int64, req
 		return nil, errors.New("ingredient not found")
 	}
 
-	// Update fields if provided
-	if req.Name != nil {
-		ingredient.Name = *req.Name
+	if err := checkOwner(ctx, ingredient.OwnerID); err != nil {
+		return nil, err
 	}
 
-	if req.Quantity != nil {
-		ingredient.Quantity = *req.Quantity
+	if err := checkVersion(ingredient, req.ExpectedVersions); err != nil {
+		return nil, err
 	}
 
-	if req.PurchaseDate != nil {
-		if *req.PurchaseDate == "" {
-			// Clear purchase date
-			ingredient.PurchaseDate = nil
-		} else {
-			purchaseDate, err := time.Parse("2006-01-02", *req.PurchaseDate)
-			if err != nil {
-				return nil, fmt.Errorf("invalid purchase_date format: %w", err)
-			}
-			ingredient.PurchaseDate = &purchaseDate
-		}
+	// Patch the replacement form of the ingredient so PATCH and PUT share validation
+	current, err := json.Marshal(replacementOf(ingredient))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode ingredient: %w", err)
+	}
+
+	var patched []byte
+	switch req.Format {
+	case PatchFormatMerge:
+		patched, err = jsonpatch.MergePatch(current, req.Patch)
+	case PatchFormatJSONPatch:
+		patched, err = jsonpatch.Apply(current, req.Patch)
+	default:
+		return nil, fmt.Errorf("%w: unknown patch format %q", domain.ErrInvalidInput, req.Format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	// Reject members that are not editable, such as id or created_at
+	var replacement UpdateIngredientRequest
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&replacement); err != nil {
+		return nil, fmt.Errorf("%w: patched ingredient is invalid: %v", domain.ErrInvalidInput, err)
+	}
+
+	ingredient, err = u.replaceIngredient(ctx, u.repo, ingredient, replacement)
+	if err != nil {
+		return nil, err
 	}
 
+	u.learnProduct(ctx, ingredient)
+	return ingredient, nil
+}
+
+// replaceIngredient overwrites the editable fields of the ingredient with the request and saves it through store
+func (u *ingredientUsecase) replaceIngredient(ctx context.Context, store repository.IngredientStore, ingredient *domain.Ingredient, req UpdateIngredientRequest) (*domain.Ingredient, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name cannot be empty", domain.ErrInvalidInput)
+	}
+
+	category, err := u.resolveCategory(name, req.Category)
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := resolveLocation(category, req.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	purchaseDate, err := parseDate("purchase_date", req.PurchaseDate)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt, err := parseDate("expires_at", req.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := normalizeBarcode(req.Barcode)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validatePrice(req.Price); err != nil {
+		return nil, err
+	}
+
+	ingredient.Name = name
+	ingredient.CanonicalName = u.normalizer.Canonical(name)
+	ingredient.Category = category
+	ingredient.Location = location
+	ingredient.Quantity = req.Quantity
+	ingredient.Barcode = code
+	ingredient.Price = req.Price
+	ingredient.Store = strings.TrimSpace(req.Store)
+	ingredient.PurchaseDate = purchaseDate
+	ingredient.ExpiresAt = expiresAt
+
 	// Update timestamp
 	ingredient.UpdatedAt = time.Now()
 
 	// Save to repository
-	if err := u.repo.Update(ctx, ingredient); err != nil {
+	if err := store.Update(ctx, ingredient); err != nil {
 		return nil, fmt.Errorf("failed to update ingredient: %w", err)
 	}
 
 	return ingredient, nil
 }
 
-// DeleteIngredient deletes an ingredient by ID
-func (u *ingredientUsecase) DeleteIngredient(ctx context.Context, id int64) error {
-	// Check if ingredient exists
+// DeleteIngredient moves an ingredient to the trash by ID, optionally only at one of the expected versions
+func (u *ingredientUsecase) DeleteIngredient(ctx context.Context, id int64, expectedVersions []int64) error {
+	return u.deleteIngredient(ctx, u.repo, id, expectedVersions)
+}
+
+// GetTrash retrieves the deleted ingredients that have not been purged yet
+func (u *ingredientUsecase) GetTrash(ctx context.Context) ([]*domain.Ingredient, error) {
+	ingredients, err := u.repo.GetDeleted(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted ingredients: %w", err)
+	}
+
+	// Return empty slice instead of nil if the trash is empty
+	if ingredients == nil {
+		return []*domain.Ingredient{}, nil
+	}
+
+	return ingredients, nil
+}
+
+// RestoreIngredient takes a deleted ingredient out of the trash and returns it as it is now
+func (u *ingredientUsecase) RestoreIngredient(ctx context.Context, id int64) (*domain.Ingredient, error) {
+	if err := checkWritable(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := u.repo.Restore(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to restore ingredient: %w", err)
+	}
+
 	ingredient, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ingredient by id: %w", err)
+	}
+
+	return ingredient, nil
+}
+
+// PurgeTrash permanently removes the ingredients deleted more than retention ago
+func (u *ingredientUsecase) PurgeTrash(ctx context.Context, retention time.Duration) (int64, error) {
+	if retention < 0 {
+		return 0, fmt.Errorf("%w: retention must not be negative", domain.ErrInvalidInput)
+	}
+
+	purged, err := u.repo.Purge(ctx, time.Now().Add(-retention))
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge trash: %w", err)
+	}
+
+	return purged, nil
+}
+
+// deleteIngredient deletes the ingredient through store
+func (u *ingredientUsecase) deleteIngredient(ctx context.Context, store repository.IngredientStore, id int64, expectedVersions []int64) error {
+	// Check if ingredient exists
+	ingredient, err := store.GetByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("failed to get ingredient: %w", err)
 	}
@@ -136,10 +518,791 @@ func (u *ingredientUsecase) DeleteIngredient(ctx context.Context, id int64) erro
 		return errors.New("ingredient not found")
 	}
 
-	// Delete from repository
-	if err := u.repo.Delete(ctx, id); err != nil {
+	if err := checkOwner(ctx, ingredient.OwnerID); err != nil {
+		return err
+	}
+
+	if err := checkVersion(ingredient, expectedVersions); err != nil {
+		return err
+	}
+
+	// Delete from repository, guarding against writes that happened since the read
+	if err := store.Delete(ctx, id, ingredient.Version); err != nil {
 		return fmt.Errorf("failed to delete ingredient: %w", err)
 	}
 
 	return nil
 }
+
+// BatchIngredients applies creates, updates and deletes in a single transaction and reports each outcome.
+// In atomic mode the first failure rolls back the whole batch; in best-effort mode each operation runs
+// in its own savepoint, so a failure only undoes that operation.
+func (u *ingredientUsecase) BatchIngredients(ctx context.Context, req BatchIngredientsRequest) (*BatchIngredientsResponse, error) {
+	if err := checkWritable(ctx); err != nil {
+		return nil, err
+	}
+
+	mode := req.Mode
+	if mode == "" {
+		mode = BatchModeAtomic
+	}
+	if mode != BatchModeAtomic && mode != BatchModeBestEffort {
+		return nil, fmt.Errorf("%w: mode must be %q or %q", domain.ErrInvalidInput, BatchModeAtomic, BatchModeBestEffort)
+	}
+	if len(req.Operations) == 0 {
+		return nil, fmt.Errorf("%w: operations must not be empty", domain.ErrInvalidInput)
+	}
+	if len(req.Operations) > maxBatchOperations {
+		return nil, fmt.Errorf("%w: at most %d operations are allowed per batch", domain.ErrInvalidInput, maxBatchOperations)
+	}
+
+	response := &BatchIngredientsResponse{
+		Mode:    mode,
+		Results: make([]BatchIngredientResult, len(req.Operations)),
+	}
+	for i, op := range req.Operations {
+		response.Results[i] = BatchIngredientResult{Index: i, Op: op.Op, ID: op.ID, Status: BatchStatusSkipped}
+	}
+
+	err := u.repo.WithTx(ctx, func(tx repository.IngredientTx) error {
+		for i, op := range req.Operations {
+			result := &response.Results[i]
+
+			var err error
+			if mode == BatchModeBestEffort {
+				err = tx.Savepoint(ctx, func() error {
+					return u.applyBatchOperation(ctx, tx, op, result)
+				})
+			} else {
+				err = u.applyBatchOperation(ctx, tx, op, result)
+			}
+
+			if err != nil {
+				result.Status = BatchStatusFailed
+				result.Err = err
+				response.Failed++
+				if mode == BatchModeAtomic {
+					return errBatchAborted
+				}
+				continue
+			}
+
+			result.Status = BatchStatusSucceeded
+			response.Succeeded++
+		}
+		return nil
+	})
+
+	if errors.Is(err, errBatchAborted) {
+		// Nothing was written, so earlier successes are reported as undone
+		for i := range response.Results {
+			result := &response.Results[i]
+			if result.Status != BatchStatusSucceeded {
+				continue
+			}
+			result.Status = BatchStatusRolledBack
+			result.Ingredient = nil
+			if result.Op == BatchOpCreate {
+				result.ID = 0
+			}
+		}
+		response.Succeeded = 0
+		return response, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply ingredient batch: %w", err)
+	}
+
+	response.Committed = true
+	for _, result := range response.Results {
+		if result.Ingredient != nil {
+			u.learnProduct(ctx, result.Ingredient)
+		}
+	}
+	return response, nil
+}
+
+// applyBatchOperation runs one batch operation inside the transaction and records its output in result
+func (u *ingredientUsecase) applyBatchOperation(ctx context.Context, tx repository.IngredientTx, op BatchIngredientOperation, result *BatchIngredientResult) error {
+	var expectedVersions []int64
+	if op.Version != 0 {
+		expectedVersions = []int64{op.Version}
+	}
+
+	switch op.Op {
+	case BatchOpCreate:
+		if op.Ingredient == nil {
+			return fmt.Errorf("%w: ingredient is required for create", domain.ErrInvalidInput)
+		}
+		ingredient, err := u.createIngredient(ctx, tx, *op.Ingredient)
+		if err != nil {
+			return err
+		}
+		result.ID = ingredient.ID
+		result.Ingredient = ingredient
+	case BatchOpUpdate:
+		if op.ID == 0 || op.Ingredient == nil {
+			return fmt.Errorf("%w: id and ingredient are required for update", domain.ErrInvalidInput)
+		}
+		ingredient, err := u.updateIngredient(ctx, tx, op.ID, UpdateIngredientRequest{
+			Name:             op.Ingredient.Name,
+			Category:         op.Ingredient.Category,
+			Location:         op.Ingredient.Location,
+			Quantity:         op.Ingredient.Quantity,
+			Barcode:          op.Ingredient.Barcode,
+			Price:            op.Ingredient.Price,
+			Store:            op.Ingredient.Store,
+			PurchaseDate:     op.Ingredient.PurchaseDate,
+			ExpiresAt:        op.Ingredient.ExpiresAt,
+			ExpectedVersions: expectedVersions,
+		})
+		if err != nil {
+			return err
+		}
+		result.Ingredient = ingredient
+	case BatchOpDelete:
+		if op.ID == 0 {
+			return fmt.Errorf("%w: id is required for delete", domain.ErrInvalidInput)
+		}
+		if err := u.deleteIngredient(ctx, tx, op.ID, expectedVersions); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("%w: unknown op %q", domain.ErrInvalidInput, op.Op)
+	}
+
+	return nil
+}
+
+// checkVersion rejects a conditional write when the ingredient is not at any of the expected versions
+func checkVersion(ingredient *domain.Ingredient, expectedVersions []int64) error {
+	if len(expectedVersions) == 0 || slices.Contains(expectedVersions, ingredient.Version) {
+		return nil
+	}
+	return fmt.Errorf("ingredient %d is at version %d: %w", ingredient.ID, ingredient.Version, domain.ErrVersionConflict)
+}
+
+// replacementOf returns the full-replacement form of an ingredient, the document PATCH operates on
+func replacementOf(ingredient *domain.Ingredient) UpdateIngredientRequest {
+	return UpdateIngredientRequest{
+		Name:         ingredient.Name,
+		Category:     ingredient.Category,
+		Location:     ingredient.Location,
+		Quantity:     ingredient.Quantity,
+		Barcode:      ingredient.Barcode,
+		Price:        ingredient.Price,
+		Store:        ingredient.Store,
+		PurchaseDate: formatDate(ingredient.PurchaseDate),
+		ExpiresAt:    formatDate(ingredient.ExpiresAt),
+	}
+}
+
+// fillFromProduct fills the fields a create request leaves empty from the product with the same barcode.
+// Requests without a barcode, and barcodes missing from the product table, are returned unchanged.
+func (u *ingredientUsecase) fillFromProduct(ctx context.Context, req CreateIngredientRequest) (CreateIngredientRequest, error) {
+	code, err := normalizeBarcode(req.Barcode)
+	if err != nil || code == "" || u.products == nil {
+		return req, err
+	}
+	req.Barcode = code
+
+	product, err := u.products.GetByBarcode(ctx, code)
+	if errors.Is(err, sql.ErrNoRows) {
+		return req, nil
+	}
+	if err != nil {
+		return req, fmt.Errorf("failed to look up product: %w", err)
+	}
+
+	if strings.TrimSpace(req.Name) == "" {
+		req.Name = product.Name
+	}
+	if req.Category == "" && domain.IsValidCategory(product.Category) {
+		req.Category = product.Category
+	}
+	if req.Quantity == "" {
+		req.Quantity = product.Unit
+	}
+	if (req.ExpiresAt == nil || *req.ExpiresAt == "") && product.ShelfLifeDays != nil {
+		// The shelf life counts from the purchase date, or from today when it is not given
+		base := time.Now()
+		if purchaseDate, err := parseDate("purchase_date", req.PurchaseDate); err == nil && purchaseDate != nil {
+			base = *purchaseDate
+		}
+		expiresAt := base.AddDate(0, 0, *product.ShelfLifeDays).Format("2006-01-02")
+		req.ExpiresAt = &expiresAt
+	}
+
+	return req, nil
+}
+
+// learnProduct remembers the product of an ingredient registered with a barcode so later scans can fill it in.
+// Products from an imported dump are left as they are; learning failures are only logged.
+func (u *ingredientUsecase) learnProduct(ctx context.Context, ingredient *domain.Ingredient) {
+	if u.products == nil || ingredient.Barcode == "" {
+		return
+	}
+
+	product := &domain.Product{
+		Barcode:  ingredient.Barcode,
+		Name:     ingredient.Name,
+		Category: ingredient.Category,
+		Unit:     ingredient.Quantity,
+		Source:   domain.ProductSourceLearned,
+	}
+	if ingredient.PurchaseDate != nil && ingredient.ExpiresAt != nil && !ingredient.ExpiresAt.Before(*ingredient.PurchaseDate) {
+		days := int(ingredient.ExpiresAt.Sub(*ingredient.PurchaseDate).Hours() / 24)
+		product.ShelfLifeDays = &days
+	}
+
+	existing, err := u.products.GetByBarcode(ctx, ingredient.Barcode)
+	switch {
+	case err == nil && existing.Source != domain.ProductSourceLearned:
+		return
+	case err == nil:
+		// Keep what the earlier entries taught when this one leaves it out
+		product.CreatedAt = existing.CreatedAt
+		if product.Unit == "" {
+			product.Unit = existing.Unit
+		}
+		if product.ShelfLifeDays == nil {
+			product.ShelfLifeDays = existing.ShelfLifeDays
+		}
+	case !errors.Is(err, sql.ErrNoRows):
+		logger.WithError(err).Warn("Failed to look up product for learning")
+		return
+	}
+
+	if err := u.products.Save(ctx, product); err != nil {
+		logger.WithError(err).Warn("Failed to learn product")
+	}
+}
+
+// validatePrice rejects negative prices; a nil price means the price is unknown
+func validatePrice(price *int) error {
+	if price != nil && *price < 0 {
+		return fmt.Errorf("%w: price must not be negative", domain.ErrInvalidInput)
+	}
+	return nil
+}
+
+// normalizeBarcode validates an optional JAN/EAN barcode and returns its canonical form
+func normalizeBarcode(value string) (string, error) {
+	if strings.TrimSpace(value) == "" {
+		return "", nil
+	}
+
+	code, ok := barcode.Normalize(value)
+	if !ok {
+		return "", fmt.Errorf("%w: invalid barcode %q", domain.ErrInvalidInput, value)
+	}
+	return code, nil
+}
+
+// parseDate parses an optional YYYY-MM-DD date; nil and empty values yield no date
+func parseDate(field string, value *string) (*time.Time, error) {
+	if value == nil || *value == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse("2006-01-02", *value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid %s format: %v", domain.ErrInvalidInput, field, err)
+	}
+	return &date, nil
+}
+
+// formatDate formats an optional date as YYYY-MM-DD
+func formatDate(date *time.Time) *string {
+	if date == nil {
+		return nil
+	}
+	formatted := date.Format("2006-01-02")
+	return &formatted
+}
+
+// resolveCategory validates the requested category, or detects it from the name when empty
+func (u *ingredientUsecase) resolveCategory(name string, requested string) (string, error) {
+	if requested != "" {
+		if !domain.IsValidCategory(requested) {
+			return "", fmt.Errorf("%w: unknown category %q", domain.ErrInvalidInput, requested)
+		}
+		return requested, nil
+	}
+
+	if category := u.normalizer.Category(name); category != "" {
+		return category, nil
+	}
+	return domain.CategoryOther, nil
+}
+
+// resolveLocation validates the requested location, or derives it from the category when empty
+func resolveLocation(category string, requested string) (string, error) {
+	if requested != "" {
+		if !domain.IsValidLocation(requested) {
+			return "", fmt.Errorf("%w: unknown location %q", domain.ErrInvalidInput, requested)
+		}
+		return requested, nil
+	}
+
+	return domain.DefaultLocation(category), nil
+}
+
+// encodeIngredientPageToken serializes a page token into an opaque URL-safe cursor
+func encodeIngredientPageToken(token ingredientPageToken) string {
+	data, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeIngredientPageToken parses a cursor produced by encodeIngredientPageToken
+func decodeIngredientPageToken(cursor string) (ingredientPageToken, error) {
+	var token ingredientPageToken
+
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return token, fmt.Errorf("%w: invalid cursor", domain.ErrInvalidInput)
+	}
+	if err := json.Unmarshal(data, &token); err != nil {
+		return token, fmt.Errorf("%w: invalid cursor", domain.ErrInvalidInput)
+	}
+
+	return token, nil
+}
+
+// ExportIngredients encodes every ingredient as a CSV or JSON inventory file, oldest first
+func (u *ingredientUsecase) ExportIngredients(ctx context.Context, format string) ([]byte, error) {
+	if format == "" {
+		format = InventoryFormatCSV
+	}
+	if format != InventoryFormatCSV && format != InventoryFormatJSON {
+		return nil, fmt.Errorf("%w: format must be %q or %q", domain.ErrInvalidInput, InventoryFormatCSV, InventoryFormatJSON)
+	}
+
+	ingredients, err := u.repo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all ingredients: %w", err)
+	}
+
+	// Oldest first, so importing the file elsewhere recreates the ingredients in their original order
+	slices.Reverse(ingredients)
+
+	var buf bytes.Buffer
+	if format == InventoryFormatCSV {
+		err = u.codec.EncodeCSV(&buf, ingredients)
+	} else {
+		err = u.codec.EncodeJSON(&buf, ingredients)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to export ingredients: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ImportIngredients loads an inventory file, reporting the outcome of every row.
+// Rows that fail validation are reported and left out; the remaining rows are saved in one transaction.
+func (u *ingredientUsecase) ImportIngredients(ctx context.Context, req ImportIngredientsRequest) (*ImportIngredientsResponse, error) {
+	if err := checkWritable(ctx); err != nil {
+		return nil, err
+	}
+
+	content := strings.TrimSpace(req.Content)
+	if content == "" {
+		return nil, fmt.Errorf("%w: content is required", domain.ErrInvalidInput)
+	}
+
+	// Detect the format from the content when not specified
+	format := req.Format
+	if format == "" {
+		format = InventoryFormatCSV
+		if strings.HasPrefix(content, "[") {
+			format = InventoryFormatJSON
+		}
+	}
+
+	onDuplicate := req.OnDuplicate
+	if onDuplicate == "" {
+		onDuplicate = DuplicateSkip
+	}
+	if onDuplicate != DuplicateSkip && onDuplicate != DuplicateMerge && onDuplicate != DuplicateOverwrite {
+		return nil, fmt.Errorf("%w: on_duplicate must be %q, %q or %q", domain.ErrInvalidInput, DuplicateSkip, DuplicateMerge, DuplicateOverwrite)
+	}
+
+	var table *domain.InventoryTable
+	var err error
+	switch format {
+	case InventoryFormatCSV:
+		table, err = u.codec.DecodeCSV([]byte(content))
+	case InventoryFormatJSON:
+		table, err = u.codec.DecodeJSON([]byte(content))
+	default:
+		return nil, fmt.Errorf("%w: unsupported format %q", domain.ErrInvalidInput, format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+	if len(table.Rows) > maxImportRows {
+		return nil, fmt.Errorf("%w: at most %d rows are allowed per import", domain.ErrInvalidInput, maxImportRows)
+	}
+
+	mapping, err := resolveColumnMapping(table.Columns, req.Mapping, domain.InventoryColumns, inventoryColumnAliases, domain.InventoryColumnName)
+	if err != nil {
+		return nil, err
+	}
+
+	dateFormat, err := resolveImportDateFormat(req.DateFormat, table, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	// Record the changes as made by the import rather than by a plain API call
+	ctx = domain.WithEventSource(ctx, domain.EventSourceImport)
+
+	// Index stored ingredients by canonical name to find duplicates, preferring the newest
+	existing, err := u.repo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all ingredients: %w", err)
+	}
+	byCanonical := make(map[string]*domain.Ingredient, len(existing))
+	for _, ingredient := range existing {
+		if _, ok := byCanonical[ingredient.CanonicalName]; !ok {
+			byCanonical[ingredient.CanonicalName] = ingredient
+		}
+	}
+
+	response := &ImportIngredientsResponse{
+		DryRun:  req.DryRun,
+		Format:  format,
+		Mapping: mapping,
+		Rows:    make([]ImportIngredientRow, 0, len(table.Rows)),
+	}
+	if dateFormat != nil {
+		response.DateFormat = dateFormat.name
+	}
+
+	importRows := func(store repository.IngredientStore) error {
+		for _, row := range table.Rows {
+			result, err := u.importRow(ctx, store, row, mapping, dateFormat, onDuplicate, byCanonical)
+			if err != nil {
+				return fmt.Errorf("line %d: %w", row.Line, err)
+			}
+
+			switch result.Action {
+			case ImportActionCreate:
+				response.Created++
+			case ImportActionMerge:
+				response.Merged++
+			case ImportActionOverwrite:
+				response.Overwritten++
+			case ImportActionSkip:
+				response.Skipped++
+			default:
+				response.Failed++
+			}
+			response.Rows = append(response.Rows, result)
+		}
+		return nil
+	}
+
+	if req.DryRun {
+		err = importRows(dryRunIngredientStore{})
+	} else {
+		err = u.repo.WithTx(ctx, func(tx repository.IngredientTx) error {
+			return importRows(tx)
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to import ingredients: %w", err)
+	}
+
+	return response, nil
+}
+
+// importRow applies one inventory row through store according to the duplicate strategy.
+// Invalid rows are reported in the result; only failed writes are returned as errors.
+func (u *ingredientUsecase) importRow(ctx context.Context, store repository.IngredientStore, row domain.InventoryRow, mapping map[string]string,
+	dateFormat *importDateFormat, onDuplicate string, byCanonical map[string]*domain.Ingredient) (ImportIngredientRow, error) {
+	result := ImportIngredientRow{Line: row.Line}
+
+	req, err := inventoryRowRequest(row, mapping, dateFormat)
+	if err == nil {
+		req, err = u.fillFromProduct(ctx, req)
+	}
+	var candidate *domain.Ingredient
+	if err == nil {
+		candidate, err = u.newIngredient(req)
+	}
+	if err != nil {
+		result.Action = ImportActionError
+		result.Error = err.Error()
+		return result, nil
+	}
+
+	target, ok := byCanonical[candidate.CanonicalName]
+	switch {
+	case !ok:
+		if err := store.Create(ctx, candidate); err != nil {
+			return result, fmt.Errorf("failed to create ingredient: %w", err)
+		}
+		byCanonical[candidate.CanonicalName] = candidate
+		target = candidate
+		result.Action = ImportActionCreate
+	case onDuplicate == DuplicateSkip:
+		result.Action = ImportActionSkip
+	case checkOwner(ctx, target.OwnerID) != nil:
+		result.Action = ImportActionError
+		result.Error = fmt.Sprintf("%q belongs to another user", target.Name)
+		return result, nil
+	case onDuplicate == DuplicateMerge:
+		merged, ok := mergeQuantities(target.Quantity, candidate.Quantity)
+		if !ok {
+			result.Action = ImportActionError
+			result.Error = fmt.Sprintf("cannot add quantity %q to the existing quantity %q", candidate.Quantity, target.Quantity)
+			return result, nil
+		}
+		target.Quantity = merged
+		target.UpdatedAt = time.Now()
+		if err := store.Update(ctx, target); err != nil {
+			return result, fmt.Errorf("failed to update ingredient: %w", err)
+		}
+		result.Action = ImportActionMerge
+	default:
+		target.Name = candidate.Name
+		target.CanonicalName = candidate.CanonicalName
+		target.Category = candidate.Category
+		target.Location = candidate.Location
+		target.Quantity = candidate.Quantity
+		target.Barcode = candidate.Barcode
+		target.Price = candidate.Price
+		target.Store = candidate.Store
+		target.PurchaseDate = candidate.PurchaseDate
+		target.ExpiresAt = candidate.ExpiresAt
+		target.UpdatedAt = time.Now()
+		if err := store.Update(ctx, target); err != nil {
+			return result, fmt.Errorf("failed to update ingredient: %w", err)
+		}
+		result.Action = ImportActionOverwrite
+	}
+
+	// Later rows may change the same ingredient, so report it as it is now
+	snapshot := *target
+	result.Ingredient = &snapshot
+	return result, nil
+}
+
+// inventoryRowRequest converts a row into a create request, translating labels and dates
+func inventoryRowRequest(row domain.InventoryRow, mapping map[string]string, dateFormat *importDateFormat) (CreateIngredientRequest, error) {
+	value := func(field string) string {
+		return row.Values[mapping[field]]
+	}
+
+	req := CreateIngredientRequest{
+		Name:     value(domain.InventoryColumnName),
+		Category: categoryFromLabel(value(domain.InventoryColumnCategory)),
+		Location: locationFromLabel(value(domain.InventoryColumnLocation)),
+		Quantity: value(domain.InventoryColumnQuantity),
+		Barcode:  value(domain.InventoryColumnBarcode),
+		Store:    value(domain.InventoryColumnStore),
+	}
+
+	var err error
+	if req.Price, err = parseImportPrice(value(domain.InventoryColumnPrice)); err != nil {
+		return req, err
+	}
+	if req.PurchaseDate, err = parseImportDate(domain.InventoryColumnPurchaseDate, value(domain.InventoryColumnPurchaseDate), dateFormat); err != nil {
+		return req, err
+	}
+	if req.ExpiresAt, err = parseImportDate(domain.InventoryColumnExpiresAt, value(domain.InventoryColumnExpiresAt), dateFormat); err != nil {
+		return req, err
+	}
+
+	return req, nil
+}
+
+// resolveColumnMapping combines the requested column mapping with columns detected from their names.
+// fields lists the known fields in detection order; every field in required must end up with a column.
+func resolveColumnMapping(columns []string, requested map[string]string, fields []string, aliases map[string][]string, required ...string) (map[string]string, error) {
+	mapping := make(map[string]string, len(fields))
+	used := map[string]bool{}
+
+	for field, column := range requested {
+		if !slices.Contains(fields, field) {
+			return nil, fmt.Errorf("%w: unknown field %q in mapping", domain.ErrInvalidInput, field)
+		}
+		if !slices.Contains(columns, column) {
+			return nil, fmt.Errorf("%w: column %q mapped to %s not found", domain.ErrInvalidInput, column, field)
+		}
+		mapping[field] = column
+		used[column] = true
+	}
+
+	for _, field := range fields {
+		if _, ok := mapping[field]; ok {
+			continue
+		}
+		for _, column := range columns {
+			if !used[column] && isColumnAlias(aliases[field], column) {
+				mapping[field] = column
+				used[column] = true
+				break
+			}
+		}
+	}
+
+	for _, field := range required {
+		if _, ok := mapping[field]; !ok {
+			return nil, fmt.Errorf("%w: no column for %s; specify it in mapping", domain.ErrInvalidInput, field)
+		}
+	}
+
+	return mapping, nil
+}
+
+// isColumnAlias reports whether a column name is one of the recognized names of a field
+func isColumnAlias(aliases []string, column string) bool {
+	folded := textnorm.Fold(column)
+	for _, alias := range aliases {
+		if textnorm.Fold(alias) == folded {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveImportDateFormat returns the requested date format, or detects the one that reads the most dates in the file.
+// It returns nil when the file has no dates.
+func resolveImportDateFormat(requested string, table *domain.InventoryTable, mapping map[string]string) (*importDateFormat, error) {
+	if requested != "" {
+		for i := range importDateFormats {
+			if strings.EqualFold(importDateFormats[i].name, requested) {
+				return &importDateFormats[i], nil
+			}
+		}
+		return nil, fmt.Errorf("%w: unknown date_format %q", domain.ErrInvalidInput, requested)
+	}
+
+	var dates []string
+	for _, row := range table.Rows {
+		for _, field := range []string{domain.InventoryColumnPurchaseDate, domain.InventoryColumnExpiresAt} {
+			if column, ok := mapping[field]; ok && row.Values[column] != "" {
+				dates = append(dates, row.Values[column])
+			}
+		}
+	}
+	if len(dates) == 0 {
+		return nil, nil
+	}
+
+	var best *importDateFormat
+	bestCount := 0
+	for i := range importDateFormats {
+		count := 0
+		for _, date := range dates {
+			if _, err := time.Parse(importDateFormats[i].layout, date); err == nil {
+				count++
+			}
+		}
+		if count > bestCount {
+			best, bestCount = &importDateFormats[i], count
+		}
+		if count == len(dates) {
+			break
+		}
+	}
+
+	return best, nil
+}
+
+// parseImportDate converts a date cell into YYYY-MM-DD form; empty cells yield no date
+func parseImportDate(field string, value string, dateFormat *importDateFormat) (*string, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if dateFormat == nil {
+		return nil, fmt.Errorf("%w: unrecognized %s %q", domain.ErrInvalidInput, field, value)
+	}
+
+	date, err := time.Parse(dateFormat.layout, value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s %q does not match %s", domain.ErrInvalidInput, field, value, dateFormat.name)
+	}
+	return formatDate(&date), nil
+}
+
+// parseImportPrice reads a price cell in yen such as 1,280 or ¥1280円; empty cells yield no price
+func parseImportPrice(value string) (*int, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	// NFKC turns full-width digits and yen signs into their ASCII forms
+	digits := strings.NewReplacer(",", "", "¥", "", "円", "", " ", "").Replace(norm.NFKC.String(value))
+	price, err := strconv.Atoi(digits)
+	if err != nil {
+		return nil, fmt.Errorf("%w: price %q is not a whole number of yen", domain.ErrInvalidInput, value)
+	}
+	return &price, nil
+}
+
+// categoryFromLabel translates a Japanese category label such as 野菜 into its key; other values are returned as is
+func categoryFromLabel(value string) string {
+	for _, category := range domain.Categories {
+		if textnorm.Fold(value) == textnorm.Fold(domain.CategoryLabel(category)) {
+			return category
+		}
+	}
+	return value
+}
+
+// locationFromLabel translates a Japanese storage location label such as 冷蔵 into its key; other values are returned as is
+func locationFromLabel(value string) string {
+	for _, location := range domain.Locations {
+		if textnorm.Fold(value) == textnorm.Fold(domain.LocationLabel(location)) {
+			return location
+		}
+	}
+	return value
+}
+
+// mergeQuantities adds two quantity texts, such as 2本 and 3本 or 1kg and 500g.
+// An empty quantity counts as nothing; ok is false when the quantities cannot be added.
+func mergeQuantities(a string, b string) (string, bool) {
+	if strings.TrimSpace(b) == "" {
+		return a, true
+	}
+	if strings.TrimSpace(a) == "" {
+		return b, true
+	}
+
+	qa, okA := quantity.Parse(a)
+	qb, okB := quantity.Parse(b)
+	if !okA || !okB {
+		return "", false
+	}
+
+	sum, ok := quantity.Add(qa, qb)
+	if !ok {
+		return "", false
+	}
+	return sum.String(), true
+}
+
+// Create discards the ingredient
+func (dryRunIngredientStore) Create(ctx context.Context, ingredient *domain.Ingredient) error {
+	return nil
+}
+
+// GetByID is not used by imports and always fails
+func (dryRunIngredientStore) GetByID(ctx context.Context, id int64) (*domain.Ingredient, error) {
+	return nil, errors.New("dry run store cannot read ingredients")
+}
+
+// Update discards the change
+func (dryRunIngredientStore) Update(ctx context.Context, ingredient *domain.Ingredient) error {
+	return nil
+}
+
+// Delete discards the deletion
+func (dryRunIngredientStore) Delete(ctx context.Context, id int64, version int64) error {
+	return nil
+}
@@ -2,11 +2,15 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/internal/repository"
+	"github.com/Rin0530/DinnerDecider/backend/internal/service"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -29,6 +33,14 @@ func (m *MockIngredientRepository) GetAll(ctx context.Context) ([]*domain.Ingred
 	return args.Get(0).([]*domain.Ingredient), args.Error(1)
 }
 
+func (m *MockIngredientRepository) Search(ctx context.Context, filter repository.IngredientFilter) ([]*domain.Ingredient, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Ingredient), args.Error(1)
+}
+
 func (m *MockIngredientRepository) GetByID(ctx context.Context, id int64) (*domain.Ingredient, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -42,15 +54,70 @@ func (m *MockIngredientRepository) Update(ctx context.Context, ingredient *domai
 	return args.Error(0)
 }
 
-func (m *MockIngredientRepository) Delete(ctx context.Context, id int64) error {
+func (m *MockIngredientRepository) GetDeleted(ctx context.Context) ([]*domain.Ingredient, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Ingredient), args.Error(1)
+}
+
+func (m *MockIngredientRepository) Restore(ctx context.Context, id int64) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
+func (m *MockIngredientRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	args := m.Called(ctx, deletedBefore)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockIngredientRepository) UpdateClassification(ctx context.Context, id int64, canonicalName string, category string) error {
+	args := m.Called(ctx, id, canonicalName, category)
+	return args.Error(0)
+}
+
+func (m *MockIngredientRepository) Delete(ctx context.Context, id int64, version int64) error {
+	args := m.Called(ctx, id, version)
+	return args.Error(0)
+}
+
+func (m *MockIngredientRepository) Consume(ctx context.Context, ingredient *domain.Ingredient, consumption *domain.Consumption) error {
+	args := m.Called(ctx, ingredient, consumption)
+	return args.Error(0)
+}
+
+func (m *MockIngredientRepository) WithTx(ctx context.Context, fn func(tx repository.IngredientTx) error) error {
+	args := m.Called(ctx)
+	if err := args.Error(0); err != nil {
+		return err
+	}
+	return fn(&mockIngredientTx{m})
+}
+
+// mockIngredientTx forwards transactional calls to the mock repository; savepoints just run fn
+type mockIngredientTx struct {
+	*MockIngredientRepository
+}
+
+func (t *mockIngredientTx) Savepoint(ctx context.Context, fn func() error) error {
+	return fn()
+}
+
+// newTestNormalizer creates an ingredient normalizer backed by the bundled dictionary
+func newTestNormalizer(t *testing.T) service.IngredientNormalizer {
+	t.Helper()
+	entries, err := service.DefaultDictionary()
+	if err != nil {
+		t.Fatalf("Failed to load dictionary: %v", err)
+	}
+	return service.NewIngredientNormalizer(entries)
+}
+
 // TestCreateIngredient_Success tests successful ingredient creation
 func TestCreateIngredient_Success(t *testing.T) {
 	mockRepo := new(MockIngredientRepository)
-	usecase := NewIngredientUsecase(mockRepo)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
 
 	purchaseDate := "2025-12-31"
 	req := CreateIngredientRequest{
@@ -76,10 +143,113 @@ func TestCreateIngredient_Success(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+// TestCreateIngredient_WithExpiresAt tests that the expiry date is parsed and validated
+func TestCreateIngredient_WithExpiresAt(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Ingredient")).Return(nil)
+
+	expiresAt := "2026-01-05"
+	result, err := usecase.CreateIngredient(context.Background(), CreateIngredientRequest{Name: "牛乳", ExpiresAt: &expiresAt})
+
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), *result.ExpiresAt)
+
+	invalid := "05/01/2026"
+	_, err = usecase.CreateIngredient(context.Background(), CreateIngredientRequest{Name: "牛乳", ExpiresAt: &invalid})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid expires_at format")
+}
+
+// TestCreateIngredient_WithPrice tests that the price and store are stored and negative prices are rejected
+func TestCreateIngredient_WithPrice(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Ingredient")).Return(nil)
+
+	price := 238
+	result, err := usecase.CreateIngredient(context.Background(), CreateIngredientRequest{Name: "牛乳", Price: &price, Store: " スーパーA "})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 238, *result.Price)
+	assert.Equal(t, "スーパーA", result.Store)
+
+	negative := -1
+	_, err = usecase.CreateIngredient(context.Background(), CreateIngredientRequest{Name: "牛乳", Price: &negative})
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	mockRepo.AssertNumberOfCalls(t, "Create", 1)
+}
+
+// TestCreateIngredient_NormalizesName tests that the canonical name is stored alongside the display name
+func TestCreateIngredient_NormalizesName(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Ingredient")).Return(nil)
+
+	result, err := usecase.CreateIngredient(context.Background(), CreateIngredientRequest{Name: "  ﾌﾞﾀﾊﾞﾗ  "})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "ﾌﾞﾀﾊﾞﾗ", result.Name)
+	assert.Equal(t, "豚バラ肉", result.CanonicalName)
+
+	result, err = usecase.CreateIngredient(context.Background(), CreateIngredientRequest{Name: "pork belly"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "豚バラ肉", result.CanonicalName)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestCreateIngredient_AutoCategorizes tests that category and location are derived from the name
+func TestCreateIngredient_AutoCategorizes(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Ingredient")).Return(nil)
+
+	result, err := usecase.CreateIngredient(context.Background(), CreateIngredientRequest{Name: "鶏もも"})
+	assert.NoError(t, err)
+	assert.Equal(t, domain.CategoryMeat, result.Category)
+	assert.Equal(t, domain.LocationFridge, result.Location)
+
+	result, err = usecase.CreateIngredient(context.Background(), CreateIngredientRequest{Name: "しょうゆ"})
+	assert.NoError(t, err)
+	assert.Equal(t, domain.CategorySeasoning, result.Category)
+	assert.Equal(t, domain.LocationPantry, result.Location)
+
+	result, err = usecase.CreateIngredient(context.Background(), CreateIngredientRequest{Name: "チョコレート"})
+	assert.NoError(t, err)
+	assert.Equal(t, domain.CategoryOther, result.Category)
+
+	result, err = usecase.CreateIngredient(context.Background(), CreateIngredientRequest{
+		Name:     "鶏もも肉",
+		Location: domain.LocationFreezer,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, domain.CategoryMeat, result.Category)
+	assert.Equal(t, domain.LocationFreezer, result.Location)
+}
+
+// TestCreateIngredient_InvalidClassification tests validation of category and location
+func TestCreateIngredient_InvalidClassification(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+	_, err := usecase.CreateIngredient(context.Background(), CreateIngredientRequest{Name: "卵", Category: "snack"})
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+	_, err = usecase.CreateIngredient(context.Background(), CreateIngredientRequest{Name: "卵", Location: "basement"})
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
 // TestCreateIngredient_MissingName tests validation error when name is missing
 func TestCreateIngredient_MissingName(t *testing.T) {
 	mockRepo := new(MockIngredientRepository)
-	usecase := NewIngredientUsecase(mockRepo)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
 
 	req := CreateIngredientRequest{
 		Name:     "",
@@ -97,7 +267,7 @@ func TestCreateIngredient_MissingName(t *testing.T) {
 // TestCreateIngredient_InvalidPurchaseDate tests error handling for invalid date format
 func TestCreateIngredient_InvalidPurchaseDate(t *testing.T) {
 	mockRepo := new(MockIngredientRepository)
-	usecase := NewIngredientUsecase(mockRepo)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
 
 	invalidDate := "invalid-date"
 	req := CreateIngredientRequest{
@@ -117,7 +287,7 @@ func TestCreateIngredient_InvalidPurchaseDate(t *testing.T) {
 // TestCreateIngredient_RepositoryError tests error handling when repository fails
 func TestCreateIngredient_RepositoryError(t *testing.T) {
 	mockRepo := new(MockIngredientRepository)
-	usecase := NewIngredientUsecase(mockRepo)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
 
 	req := CreateIngredientRequest{
 		Name:     "にんじん",
@@ -138,7 +308,7 @@ func TestCreateIngredient_RepositoryError(t *testing.T) {
 // TestGetAllIngredients_Success tests successful retrieval of all ingredients
 func TestGetAllIngredients_Success(t *testing.T) {
 	mockRepo := new(MockIngredientRepository)
-	usecase := NewIngredientUsecase(mockRepo)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
 
 	now := time.Now()
 	purchaseDate := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
@@ -160,41 +330,168 @@ func TestGetAllIngredients_Success(t *testing.T) {
 		},
 	}
 
-	mockRepo.On("GetAll", mock.Anything).Return(mockIngredients, nil)
+	mockRepo.On("Search", mock.Anything, repository.IngredientFilter{Limit: defaultIngredientPageSize + 1}).Return(mockIngredients, nil)
 
-	result, err := usecase.GetAllIngredients(context.Background())
+	result, err := usecase.GetAllIngredients(context.Background(), ListIngredientsRequest{})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
-	assert.Len(t, result, 2)
-	assert.Equal(t, "にんじん", result[0].Name)
-	assert.Equal(t, "豚バラ肉", result[1].Name)
+	assert.Len(t, result.Items, 2)
+	assert.Equal(t, "にんじん", result.Items[0].Name)
+	assert.Equal(t, "豚バラ肉", result.Items[1].Name)
+	assert.Empty(t, result.NextCursor)
 	mockRepo.AssertExpectations(t)
 }
 
 // TestGetAllIngredients_EmptyResult tests handling of empty ingredient list
 func TestGetAllIngredients_EmptyResult(t *testing.T) {
 	mockRepo := new(MockIngredientRepository)
-	usecase := NewIngredientUsecase(mockRepo)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
 
-	mockRepo.On("GetAll", mock.Anything).Return(nil, nil)
+	mockRepo.On("Search", mock.Anything, repository.IngredientFilter{Limit: defaultIngredientPageSize + 1}).Return(nil, nil)
 
-	result, err := usecase.GetAllIngredients(context.Background())
+	result, err := usecase.GetAllIngredients(context.Background(), ListIngredientsRequest{})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
-	assert.Len(t, result, 0)
+	assert.NotNil(t, result.Items)
+	assert.Len(t, result.Items, 0)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestGetAllIngredients_WithFilter tests that category and location filters reach the repository
+func TestGetAllIngredients_WithFilter(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+	purchasedBefore := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	filter := repository.IngredientFilter{
+		Category:        domain.CategoryMeat,
+		Location:        domain.LocationFreezer,
+		Query:           "とりもも",
+		CanonicalQuery:  "鶏もも肉",
+		PurchasedBefore: &purchasedBefore,
+		Sort:            repository.IngredientSortExpiresAt,
+		Order:           repository.OrderDesc,
+		Limit:           21,
+	}
+	mockRepo.On("Search", mock.Anything, filter).Return([]*domain.Ingredient{{ID: 1, Name: "鶏もも肉"}}, nil)
+
+	result, err := usecase.GetAllIngredients(context.Background(), ListIngredientsRequest{
+		Query:           " とりもも ",
+		Category:        domain.CategoryMeat,
+		Location:        domain.LocationFreezer,
+		PurchasedBefore: "2025-12-01",
+		Sort:            repository.IngredientSortExpiresAt,
+		Order:           repository.OrderDesc,
+		Limit:           20,
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Items, 1)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestGetAllIngredients_Pagination tests that a full page yields a cursor that resumes after its last item
+func TestGetAllIngredients_Pagination(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+	rows := []*domain.Ingredient{
+		{ID: 3, Name: "キャベツ"},
+		{ID: 1, Name: "にんじん"},
+		{ID: 2, Name: "豚バラ肉"},
+	}
+	mockRepo.On("Search", mock.Anything, repository.IngredientFilter{Sort: repository.IngredientSortName, Limit: 3}).Return(rows, nil).Once()
+
+	first, err := usecase.GetAllIngredients(context.Background(), ListIngredientsRequest{Sort: repository.IngredientSortName, Limit: 2})
+
+	assert.NoError(t, err)
+	assert.Len(t, first.Items, 2)
+	assert.Equal(t, "にんじん", first.Items[1].Name)
+	assert.NotEmpty(t, first.NextCursor)
+
+	after := repository.IngredientCursor{Value: "にんじん", ID: 1}
+	mockRepo.On("Search", mock.Anything, repository.IngredientFilter{Sort: repository.IngredientSortName, After: &after, Limit: 3}).Return(rows[2:], nil).Once()
+
+	second, err := usecase.GetAllIngredients(context.Background(), ListIngredientsRequest{
+		Sort:   repository.IngredientSortName,
+		Cursor: first.NextCursor,
+		Limit:  2,
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, second.Items, 1)
+	assert.Empty(t, second.NextCursor)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestGetAllIngredients_LimitIsCapped tests that oversized page requests are clamped
+func TestGetAllIngredients_LimitIsCapped(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+	mockRepo.On("Search", mock.Anything, repository.IngredientFilter{Limit: maxIngredientPageSize + 1}).Return([]*domain.Ingredient{}, nil)
+
+	_, err := usecase.GetAllIngredients(context.Background(), ListIngredientsRequest{Limit: 10000})
+
+	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
 }
 
+// TestGetAllIngredients_CursorSortMismatch tests that a cursor cannot be reused with a different ordering
+func TestGetAllIngredients_CursorSortMismatch(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+	cursor := encodeIngredientPageToken(ingredientPageToken{
+		Sort:             repository.IngredientSortName,
+		IngredientCursor: repository.IngredientCursor{Value: "にんじん", ID: 1},
+	})
+
+	_, err := usecase.GetAllIngredients(context.Background(), ListIngredientsRequest{Sort: repository.IngredientSortExpiresAt, Cursor: cursor})
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+	_, err = usecase.GetAllIngredients(context.Background(), ListIngredientsRequest{Cursor: "not a cursor!"})
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+	mockRepo.AssertNotCalled(t, "Search", mock.Anything, mock.Anything)
+}
+
+// TestGetAllIngredients_InvalidFilter tests validation of filter values
+func TestGetAllIngredients_InvalidFilter(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+	_, err := usecase.GetAllIngredients(context.Background(), ListIngredientsRequest{Category: "snack"})
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+	_, err = usecase.GetAllIngredients(context.Background(), ListIngredientsRequest{Location: "basement"})
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+	_, err = usecase.GetAllIngredients(context.Background(), ListIngredientsRequest{Sort: "price"})
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+	_, err = usecase.GetAllIngredients(context.Background(), ListIngredientsRequest{Order: "up"})
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+	_, err = usecase.GetAllIngredients(context.Background(), ListIngredientsRequest{PurchasedBefore: "2025/12/01"})
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+	_, err = usecase.GetAllIngredients(context.Background(), ListIngredientsRequest{Limit: -1})
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+	mockRepo.AssertNotCalled(t, "Search", mock.Anything, mock.Anything)
+}
+
 // TestGetAllIngredients_RepositoryError tests error handling when repository fails
 func TestGetAllIngredients_RepositoryError(t *testing.T) {
 	mockRepo := new(MockIngredientRepository)
-	usecase := NewIngredientUsecase(mockRepo)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
 
-	mockRepo.On("GetAll", mock.Anything).Return(nil, errors.New("database error"))
+	mockRepo.On("Search", mock.Anything, repository.IngredientFilter{Limit: defaultIngredientPageSize + 1}).Return(nil, errors.New("database error"))
 
-	result, err := usecase.GetAllIngredients(context.Background())
+	result, err := usecase.GetAllIngredients(context.Background(), ListIngredientsRequest{})
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -205,7 +502,7 @@ func TestGetAllIngredients_RepositoryError(t *testing.T) {
 // TestGetIngredientByID_Success tests successful ingredient retrieval by ID
 func TestGetIngredientByID_Success(t *testing.T) {
 	mockRepo := new(MockIngredientRepository)
-	usecase := NewIngredientUsecase(mockRepo)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
 
 	now := time.Now()
 	expectedIngredient := &domain.Ingredient{
@@ -227,10 +524,28 @@ func TestGetIngredientByID_Success(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+// TestGetIngredientByID_AttachesNutrition tests that ingredients in the nutrition table carry their nutrition
+func TestGetIngredientByID_AttachesNutrition(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), newTestNutritionTable(t))
+
+	mockRepo.On("GetByID", mock.Anything, int64(1)).
+		Return(&domain.Ingredient{ID: 1, Name: "にんじん", CanonicalName: "にんじん", Quantity: "2本"}, nil)
+
+	result, err := usecase.GetIngredientByID(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result.Nutrition)
+	assert.Equal(t, 35.0, result.Nutrition.Per100g.EnergyKcal)
+	assert.NotNil(t, result.Nutrition.Total)
+	assert.Equal(t, 105.0, result.Nutrition.Total.EnergyKcal)
+	mockRepo.AssertExpectations(t)
+}
+
 // TestGetIngredientByID_NotFound tests error handling when ingredient doesn't exist
 func TestGetIngredientByID_NotFound(t *testing.T) {
 	mockRepo := new(MockIngredientRepository)
-	usecase := NewIngredientUsecase(mockRepo)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
 
 	mockRepo.On("GetByID", mock.Anything, int64(999)).Return(nil, errors.New("not found"))
 
@@ -245,7 +560,7 @@ func TestGetIngredientByID_NotFound(t *testing.T) {
 // TestGetIngredientByID_RepositoryError tests error handling when repository fails
 func TestGetIngredientByID_RepositoryError(t *testing.T) {
 	mockRepo := new(MockIngredientRepository)
-	usecase := NewIngredientUsecase(mockRepo)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
 
 	mockRepo.On("GetByID", mock.Anything, int64(1)).Return(nil, errors.New("database error"))
 
@@ -257,25 +572,27 @@ func TestGetIngredientByID_RepositoryError(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
-// TestUpdateIngredient_Success tests successful ingredient update
+// TestUpdateIngredient_Success tests that PUT replaces every editable field
 func TestUpdateIngredient_Success(t *testing.T) {
 	mockRepo := new(MockIngredientRepository)
-	usecase := NewIngredientUsecase(mockRepo)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
 
 	now := time.Now()
+	purchaseDate := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
 	existingIngredient := &domain.Ingredient{
-		ID:        1,
-		Name:      "にんじん",
-		Quantity:  "2本",
-		CreatedAt: now,
-		UpdatedAt: now,
+		ID:           1,
+		Name:         "にんじん",
+		Category:     domain.CategoryVegetable,
+		Location:     domain.LocationFreezer,
+		Quantity:     "2本",
+		PurchaseDate: &purchaseDate,
+		CreatedAt:    now,
+		UpdatedAt:    now,
 	}
 
-	newName := "大根"
-	newQuantity := "3本"
 	req := UpdateIngredientRequest{
-		Name:     &newName,
-		Quantity: &newQuantity,
+		Name:     "大根",
+		Quantity: "3本",
 	}
 
 	mockRepo.On("GetByID", mock.Anything, int64(1)).Return(existingIngredient, nil)
@@ -287,48 +604,34 @@ func TestUpdateIngredient_Success(t *testing.T) {
 	assert.NotNil(t, result)
 	assert.Equal(t, "大根", result.Name)
 	assert.Equal(t, "3本", result.Quantity)
+	// Omitted fields are cleared or re-detected rather than kept
+	assert.Nil(t, result.PurchaseDate)
+	assert.Equal(t, domain.CategoryVegetable, result.Category)
+	assert.Equal(t, domain.LocationFridge, result.Location)
 	mockRepo.AssertExpectations(t)
 }
 
-// TestUpdateIngredient_PartialUpdate tests partial update of ingredient fields
-func TestUpdateIngredient_PartialUpdate(t *testing.T) {
+// TestUpdateIngredient_EmptyName tests that a blank name is rejected
+func TestUpdateIngredient_EmptyName(t *testing.T) {
 	mockRepo := new(MockIngredientRepository)
-	usecase := NewIngredientUsecase(mockRepo)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
 
-	now := time.Now()
-	existingIngredient := &domain.Ingredient{
-		ID:        1,
-		Name:      "にんじん",
-		Quantity:  "2本",
-		CreatedAt: now,
-		UpdatedAt: now,
-	}
-
-	newQuantity := "3本"
-	req := UpdateIngredientRequest{
-		Quantity: &newQuantity,
-	}
-
-	mockRepo.On("GetByID", mock.Anything, int64(1)).Return(existingIngredient, nil)
-	mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Ingredient")).Return(nil)
+	mockRepo.On("GetByID", mock.Anything, int64(1)).Return(&domain.Ingredient{ID: 1, Name: "にんじん"}, nil)
 
-	result, err := usecase.UpdateIngredient(context.Background(), 1, req)
+	result, err := usecase.UpdateIngredient(context.Background(), 1, UpdateIngredientRequest{Name: "  "})
 
-	assert.NoError(t, err)
-	assert.NotNil(t, result)
-	assert.Equal(t, "にんじん", result.Name)
-	assert.Equal(t, "3本", result.Quantity)
-	mockRepo.AssertExpectations(t)
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	assert.Nil(t, result)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
 }
 
 // TestUpdateIngredient_NotFound tests error handling when ingredient doesn't exist
 func TestUpdateIngredient_NotFound(t *testing.T) {
 	mockRepo := new(MockIngredientRepository)
-	usecase := NewIngredientUsecase(mockRepo)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
 
-	newName := "大根"
 	req := UpdateIngredientRequest{
-		Name: &newName,
+		Name: "大根",
 	}
 
 	mockRepo.On("GetByID", mock.Anything, int64(999)).Return(nil, errors.New("ingredient not found"))
@@ -345,7 +648,7 @@ func TestUpdateIngredient_NotFound(t *testing.T) {
 // TestUpdateIngredient_InvalidPurchaseDate tests error handling for invalid date format
 func TestUpdateIngredient_InvalidPurchaseDate(t *testing.T) {
 	mockRepo := new(MockIngredientRepository)
-	usecase := NewIngredientUsecase(mockRepo)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
 
 	now := time.Now()
 	existingIngredient := &domain.Ingredient{
@@ -358,6 +661,7 @@ func TestUpdateIngredient_InvalidPurchaseDate(t *testing.T) {
 
 	invalidDate := "invalid-date"
 	req := UpdateIngredientRequest{
+		Name:         "にんじん",
 		PurchaseDate: &invalidDate,
 	}
 
@@ -365,7 +669,7 @@ func TestUpdateIngredient_InvalidPurchaseDate(t *testing.T) {
 
 	result, err := usecase.UpdateIngredient(context.Background(), 1, req)
 
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
 	assert.Nil(t, result)
 	assert.Contains(t, err.Error(), "invalid purchase_date format")
 	mockRepo.AssertExpectations(t)
@@ -375,7 +679,7 @@ func TestUpdateIngredient_InvalidPurchaseDate(t *testing.T) {
 // TestUpdateIngredient_RepositoryError tests error handling when repository fails
 func TestUpdateIngredient_RepositoryError(t *testing.T) {
 	mockRepo := new(MockIngredientRepository)
-	usecase := NewIngredientUsecase(mockRepo)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
 
 	now := time.Now()
 	existingIngredient := &domain.Ingredient{
@@ -386,9 +690,8 @@ func TestUpdateIngredient_RepositoryError(t *testing.T) {
 		UpdatedAt: now,
 	}
 
-	newName := "大根"
 	req := UpdateIngredientRequest{
-		Name: &newName,
+		Name: "大根",
 	}
 
 	mockRepo.On("GetByID", mock.Anything, int64(1)).Return(existingIngredient, nil)
@@ -403,37 +706,222 @@ func TestUpdateIngredient_RepositoryError(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+// TestUpdateIngredient_ExpectedVersion tests that replacements only apply to the expected version
+func TestUpdateIngredient_ExpectedVersion(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+	mockRepo.On("GetByID", mock.Anything, int64(1)).Return(&domain.Ingredient{ID: 1, Name: "にんじん", Version: 3}, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(ingredient *domain.Ingredient) bool {
+		return ingredient.Version == 3
+	})).Return(nil).Once()
+
+	_, err := usecase.UpdateIngredient(context.Background(), 1, UpdateIngredientRequest{Name: "大根", ExpectedVersions: []int64{2, 3}})
+	assert.NoError(t, err)
+
+	_, err = usecase.UpdateIngredient(context.Background(), 1, UpdateIngredientRequest{Name: "大根", ExpectedVersions: []int64{2}})
+	assert.ErrorIs(t, err, domain.ErrVersionConflict)
+
+	mockRepo.AssertExpectations(t)
+}
+
+// TestUpdateIngredient_ConcurrentWrite tests that a conflict detected by the repository is passed through
+func TestUpdateIngredient_ConcurrentWrite(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+	mockRepo.On("GetByID", mock.Anything, int64(1)).Return(&domain.Ingredient{ID: 1, Name: "にんじん", Version: 3}, nil)
+	mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Ingredient")).
+		Return(fmt.Errorf("ingredient 1 is at version 4: %w", domain.ErrVersionConflict))
+
+	_, err := usecase.UpdateIngredient(context.Background(), 1, UpdateIngredientRequest{Name: "大根"})
+
+	assert.ErrorIs(t, err, domain.ErrVersionConflict)
+	mockRepo.AssertExpectations(t)
+}
+
+// newPatchTarget returns an ingredient with every editable field set, for patch tests
+func newPatchTarget() *domain.Ingredient {
+	purchaseDate := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	return &domain.Ingredient{
+		ID:           1,
+		Name:         "鶏もも肉",
+		Category:     domain.CategoryMeat,
+		Location:     domain.LocationFreezer,
+		Quantity:     "300g",
+		PurchaseDate: &purchaseDate,
+	}
+}
+
+// TestPatchIngredient_MergePatch tests that a merge patch keeps omitted fields and clears null ones
+func TestPatchIngredient_MergePatch(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+	mockRepo.On("GetByID", mock.Anything, int64(1)).Return(newPatchTarget(), nil)
+	mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Ingredient")).Return(nil)
+
+	result, err := usecase.PatchIngredient(context.Background(), 1, PatchIngredientRequest{
+		Format: PatchFormatMerge,
+		Patch:  []byte(`{"quantity": "150g", "purchase_date": null, "expires_at": "2025-12-10"}`),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "鶏もも肉", result.Name)
+	assert.Equal(t, domain.LocationFreezer, result.Location)
+	assert.Equal(t, "150g", result.Quantity)
+	assert.Nil(t, result.PurchaseDate)
+	assert.Equal(t, time.Date(2025, 12, 10, 0, 0, 0, 0, time.UTC), *result.ExpiresAt)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestPatchIngredient_MergePatchNullCategory tests that clearing the category re-detects it from the name
+func TestPatchIngredient_MergePatchNullCategory(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+	target := newPatchTarget()
+	target.Category = domain.CategoryOther
+	mockRepo.On("GetByID", mock.Anything, int64(1)).Return(target, nil)
+	mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Ingredient")).Return(nil)
+
+	result, err := usecase.PatchIngredient(context.Background(), 1, PatchIngredientRequest{
+		Format: PatchFormatMerge,
+		Patch:  []byte(`{"category": null}`),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.CategoryMeat, result.Category)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestPatchIngredient_JSONPatch tests RFC 6902 operations including a guarding test op
+func TestPatchIngredient_JSONPatch(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+	mockRepo.On("GetByID", mock.Anything, int64(1)).Return(newPatchTarget(), nil)
+	mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Ingredient")).Return(nil)
+
+	result, err := usecase.PatchIngredient(context.Background(), 1, PatchIngredientRequest{
+		Format: PatchFormatJSONPatch,
+		Patch: []byte(`[
+			{"op": "test", "path": "/quantity", "value": "300g"},
+			{"op": "replace", "path": "/name", "value": "とりもも"},
+			{"op": "remove", "path": "/purchase_date"}
+		]`),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "とりもも", result.Name)
+	assert.Equal(t, "鶏もも肉", result.CanonicalName)
+	assert.Nil(t, result.PurchaseDate)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestPatchIngredient_ExpectedVersion tests that a patch with a stale version is rejected before it is applied
+func TestPatchIngredient_ExpectedVersion(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+	target := newPatchTarget()
+	target.Version = 5
+	mockRepo.On("GetByID", mock.Anything, int64(1)).Return(target, nil)
+
+	_, err := usecase.PatchIngredient(context.Background(), 1, PatchIngredientRequest{
+		Format:           PatchFormatMerge,
+		Patch:            []byte(`{"quantity": "1個"}`),
+		ExpectedVersions: []int64{4},
+	})
+
+	assert.ErrorIs(t, err, domain.ErrVersionConflict)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+// TestPatchIngredient_InvalidPatch tests that malformed or invalid patches are rejected before saving
+func TestPatchIngredient_InvalidPatch(t *testing.T) {
+	tests := []struct {
+		name string
+		req  PatchIngredientRequest
+	}{
+		{"malformed merge patch", PatchIngredientRequest{Format: PatchFormatMerge, Patch: []byte(`{"name":`)}},
+		{"read-only field", PatchIngredientRequest{Format: PatchFormatMerge, Patch: []byte(`{"id": 5}`)}},
+		{"wrong type", PatchIngredientRequest{Format: PatchFormatMerge, Patch: []byte(`{"quantity": 3}`)}},
+		{"null name", PatchIngredientRequest{Format: PatchFormatMerge, Patch: []byte(`{"name": null}`)}},
+		{"invalid date", PatchIngredientRequest{Format: PatchFormatMerge, Patch: []byte(`{"expires_at": "tomorrow"}`)}},
+		{"failed test op", PatchIngredientRequest{Format: PatchFormatJSONPatch, Patch: []byte(`[{"op": "test", "path": "/quantity", "value": "1kg"}]`)}},
+		{"unknown format", PatchIngredientRequest{Format: "xml", Patch: []byte(`<name/>`)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockIngredientRepository)
+			usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+			mockRepo.On("GetByID", mock.Anything, int64(1)).Return(newPatchTarget(), nil)
+
+			result, err := usecase.PatchIngredient(context.Background(), 1, tt.req)
+
+			assert.ErrorIs(t, err, domain.ErrInvalidInput)
+			assert.Nil(t, result)
+			mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		})
+	}
+}
+
 // TestDeleteIngredient_Success tests successful ingredient deletion
 func TestDeleteIngredient_Success(t *testing.T) {
 	mockRepo := new(MockIngredientRepository)
-	usecase := NewIngredientUsecase(mockRepo)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
 
 	now := time.Now()
 	existingIngredient := &domain.Ingredient{
 		ID:        1,
 		Name:      "にんじん",
 		Quantity:  "2本",
+		Version:   2,
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
 
 	mockRepo.On("GetByID", mock.Anything, int64(1)).Return(existingIngredient, nil)
-	mockRepo.On("Delete", mock.Anything, int64(1)).Return(nil)
+	mockRepo.On("Delete", mock.Anything, int64(1), int64(2)).Return(nil)
 
-	err := usecase.DeleteIngredient(context.Background(), 1)
+	err := usecase.DeleteIngredient(context.Background(), 1, nil)
 
 	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
 }
 
+// TestDeleteIngredient_OwnedByAnotherUser tests that users cannot delete ingredients registered by someone else
+func TestDeleteIngredient_OwnedByAnotherUser(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+	owner := int64(1)
+	mockRepo.On("GetByID", mock.Anything, int64(1)).
+		Return(&domain.Ingredient{ID: 1, Name: "にんじん", Version: 2, OwnerID: &owner}, nil)
+
+	ctx := domain.WithUser(context.Background(), &domain.User{ID: 2, Username: "taro"})
+	err := usecase.DeleteIngredient(ctx, 1, nil)
+
+	assert.True(t, errors.Is(err, domain.ErrForbidden))
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+
+	// The owner can delete it
+	mockRepo.On("Delete", mock.Anything, int64(1), int64(2)).Return(nil)
+	ctx = domain.WithUser(context.Background(), &domain.User{ID: 1, Username: "hanako"})
+	assert.NoError(t, usecase.DeleteIngredient(ctx, 1, nil))
+}
+
 // TestDeleteIngredient_NotFound tests error handling when ingredient doesn't exist
 func TestDeleteIngredient_NotFound(t *testing.T) {
 	mockRepo := new(MockIngredientRepository)
-	usecase := NewIngredientUsecase(mockRepo)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
 
 	mockRepo.On("GetByID", mock.Anything, int64(999)).Return(nil, errors.New("ingredient not found"))
 
-	err := usecase.DeleteIngredient(context.Background(), 999)
+	err := usecase.DeleteIngredient(context.Background(), 999, nil)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to get ingredient")
@@ -444,7 +932,7 @@ func TestDeleteIngredient_NotFound(t *testing.T) {
 // TestDeleteIngredient_RepositoryError tests error handling when repository fails
 func TestDeleteIngredient_RepositoryError(t *testing.T) {
 	mockRepo := new(MockIngredientRepository)
-	usecase := NewIngredientUsecase(mockRepo)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
 
 	now := time.Now()
 	existingIngredient := &domain.Ingredient{
@@ -456,11 +944,458 @@ func TestDeleteIngredient_RepositoryError(t *testing.T) {
 	}
 
 	mockRepo.On("GetByID", mock.Anything, int64(1)).Return(existingIngredient, nil)
-	mockRepo.On("Delete", mock.Anything, int64(1)).Return(errors.New("database error"))
+	mockRepo.On("Delete", mock.Anything, int64(1), int64(0)).Return(errors.New("database error"))
 
-	err := usecase.DeleteIngredient(context.Background(), 1)
+	err := usecase.DeleteIngredient(context.Background(), 1, nil)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to delete ingredient")
 	mockRepo.AssertExpectations(t)
 }
+
+// TestDeleteIngredient_VersionMismatch tests that a stale If-Match version prevents deletion
+func TestDeleteIngredient_VersionMismatch(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+	mockRepo.On("GetByID", mock.Anything, int64(1)).Return(&domain.Ingredient{ID: 1, Name: "にんじん", Version: 3}, nil)
+
+	err := usecase.DeleteIngredient(context.Background(), 1, []int64{2})
+
+	assert.ErrorIs(t, err, domain.ErrVersionConflict)
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestBatchIngredients_Atomic tests a batch of creates, updates and deletes committed together
+func TestBatchIngredients_Atomic(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+	mockRepo.On("WithTx", mock.Anything).Return(nil)
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Ingredient")).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(*domain.Ingredient).ID = 10
+		}).Return(nil)
+	mockRepo.On("GetByID", mock.Anything, int64(1)).Return(&domain.Ingredient{ID: 1, Name: "にんじん", Version: 2}, nil)
+	mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Ingredient")).Return(nil)
+	mockRepo.On("GetByID", mock.Anything, int64(2)).Return(&domain.Ingredient{ID: 2, Name: "玉ねぎ", Version: 1}, nil)
+	mockRepo.On("Delete", mock.Anything, int64(2), int64(1)).Return(nil)
+
+	result, err := usecase.BatchIngredients(context.Background(), BatchIngredientsRequest{
+		Operations: []BatchIngredientOperation{
+			{Op: BatchOpCreate, Ingredient: &CreateIngredientRequest{Name: "豚バラ肉", Quantity: "200g"}},
+			{Op: BatchOpUpdate, ID: 1, Version: 2, Ingredient: &CreateIngredientRequest{Name: "にんじん", Quantity: "1本"}},
+			{Op: BatchOpDelete, ID: 2},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, BatchModeAtomic, result.Mode)
+	assert.True(t, result.Committed)
+	assert.Equal(t, 3, result.Succeeded)
+	assert.Equal(t, 0, result.Failed)
+	assert.Equal(t, int64(10), result.Results[0].ID)
+	assert.Equal(t, "豚バラ肉", result.Results[0].Ingredient.Name)
+	assert.Equal(t, "1本", result.Results[1].Ingredient.Quantity)
+	for _, item := range result.Results {
+		assert.Equal(t, BatchStatusSucceeded, item.Status)
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+// TestBatchIngredients_AtomicRollback tests that one failure undoes the whole atomic batch
+func TestBatchIngredients_AtomicRollback(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+	mockRepo.On("WithTx", mock.Anything).Return(nil)
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Ingredient")).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(*domain.Ingredient).ID = 10
+		}).Return(nil)
+	mockRepo.On("GetByID", mock.Anything, int64(1)).Return(&domain.Ingredient{ID: 1, Name: "にんじん", Version: 3}, nil)
+
+	result, err := usecase.BatchIngredients(context.Background(), BatchIngredientsRequest{
+		Mode: BatchModeAtomic,
+		Operations: []BatchIngredientOperation{
+			{Op: BatchOpCreate, Ingredient: &CreateIngredientRequest{Name: "豚バラ肉"}},
+			{Op: BatchOpUpdate, ID: 1, Version: 2, Ingredient: &CreateIngredientRequest{Name: "にんじん"}},
+			{Op: BatchOpDelete, ID: 2},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.False(t, result.Committed)
+	assert.Equal(t, 0, result.Succeeded)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, BatchStatusRolledBack, result.Results[0].Status)
+	assert.Zero(t, result.Results[0].ID)
+	assert.Nil(t, result.Results[0].Ingredient)
+	assert.Equal(t, BatchStatusFailed, result.Results[1].Status)
+	assert.ErrorIs(t, result.Results[1].Err, domain.ErrVersionConflict)
+	assert.Equal(t, BatchStatusSkipped, result.Results[2].Status)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestBatchIngredients_BestEffort tests that failed operations do not stop a best-effort batch
+func TestBatchIngredients_BestEffort(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+	mockRepo.On("WithTx", mock.Anything).Return(nil)
+	mockRepo.On("GetByID", mock.Anything, int64(999)).Return(nil, fmt.Errorf("ingredient not found: %w", sql.ErrNoRows))
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Ingredient")).Return(nil)
+
+	result, err := usecase.BatchIngredients(context.Background(), BatchIngredientsRequest{
+		Mode: BatchModeBestEffort,
+		Operations: []BatchIngredientOperation{
+			{Op: BatchOpCreate, Ingredient: &CreateIngredientRequest{Name: "  "}},
+			{Op: BatchOpDelete, ID: 999},
+			{Op: "rename"},
+			{Op: BatchOpCreate, Ingredient: &CreateIngredientRequest{Name: "玉ねぎ"}},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, result.Committed)
+	assert.Equal(t, 1, result.Succeeded)
+	assert.Equal(t, 3, result.Failed)
+	assert.ErrorIs(t, result.Results[0].Err, domain.ErrInvalidInput)
+	assert.ErrorIs(t, result.Results[1].Err, sql.ErrNoRows)
+	assert.ErrorIs(t, result.Results[2].Err, domain.ErrInvalidInput)
+	assert.Equal(t, BatchStatusSucceeded, result.Results[3].Status)
+	mockRepo.AssertNumberOfCalls(t, "Create", 1)
+}
+
+// TestBatchIngredients_InvalidRequest tests request-level validation of a batch
+func TestBatchIngredients_InvalidRequest(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+	_, err := usecase.BatchIngredients(context.Background(), BatchIngredientsRequest{Mode: "eventually"})
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+	_, err = usecase.BatchIngredients(context.Background(), BatchIngredientsRequest{})
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+	_, err = usecase.BatchIngredients(context.Background(), BatchIngredientsRequest{
+		Operations: make([]BatchIngredientOperation, maxBatchOperations+1),
+	})
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+	mockRepo.AssertNotCalled(t, "WithTx", mock.Anything)
+}
+
+// TestBatchIngredients_TransactionError tests that a failed commit is reported as an error
+func TestBatchIngredients_TransactionError(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+	mockRepo.On("WithTx", mock.Anything).Return(errors.New("failed to begin transaction"))
+
+	result, err := usecase.BatchIngredients(context.Background(), BatchIngredientsRequest{
+		Operations: []BatchIngredientOperation{{Op: BatchOpDelete, ID: 1}},
+	})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "failed to apply ingredient batch")
+}
+
+// TestExportIngredients_CSV tests that the export lists ingredients oldest first
+func TestExportIngredients_CSV(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+	mockRepo.On("GetAll", mock.Anything).Return([]*domain.Ingredient{
+		{ID: 2, Name: "玉ねぎ", Category: domain.CategoryVegetable, Location: domain.LocationPantry, Quantity: "2個"},
+		{ID: 1, Name: "にんじん", Category: domain.CategoryVegetable, Location: domain.LocationFridge, Quantity: "3本"},
+	}, nil)
+
+	data, err := usecase.ExportIngredients(context.Background(), "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "\uFEFFname,category,location,quantity,barcode,price,store,purchase_date,expires_at\n"+
+		"にんじん,vegetable,fridge,3本,,,,,\n"+
+		"玉ねぎ,vegetable,pantry,2個,,,,,\n", string(data))
+}
+
+// TestExportIngredients_InvalidFormat tests that an unknown export format is rejected
+func TestExportIngredients_InvalidFormat(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+	_, err := usecase.ExportIngredients(context.Background(), "xlsx")
+
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	mockRepo.AssertNotCalled(t, "GetAll", mock.Anything)
+}
+
+// TestImportIngredients_DryRun tests column detection, labels, date detection and row errors without saving
+func TestImportIngredients_DryRun(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+	mockRepo.On("GetAll", mock.Anything).Return([]*domain.Ingredient{}, nil)
+
+	content := "品名,数量,保存場所,購入日,メモ\n" +
+		"にんじん,3本,冷蔵,2025/12/01,特売\n" +
+		",1個,,,\n" +
+		"鶏もも肉,300g,冷凍,2025/13/01,\n"
+
+	result, err := usecase.ImportIngredients(context.Background(), ImportIngredientsRequest{Content: content, DryRun: true})
+
+	assert.NoError(t, err)
+	assert.True(t, result.DryRun)
+	assert.Equal(t, InventoryFormatCSV, result.Format)
+	assert.Equal(t, "YYYY/MM/DD", result.DateFormat)
+	assert.Equal(t, map[string]string{"name": "品名", "quantity": "数量", "location": "保存場所", "purchase_date": "購入日"}, result.Mapping)
+	assert.Equal(t, 1, result.Created)
+	assert.Equal(t, 2, result.Failed)
+
+	assert.Equal(t, 2, result.Rows[0].Line)
+	assert.Equal(t, ImportActionCreate, result.Rows[0].Action)
+	assert.Equal(t, domain.LocationFridge, result.Rows[0].Ingredient.Location)
+	assert.Equal(t, "2025-12-01", result.Rows[0].Ingredient.PurchaseDate.Format("2006-01-02"))
+	assert.Equal(t, ImportActionError, result.Rows[1].Action)
+	assert.Contains(t, result.Rows[1].Error, "name is required")
+	assert.Equal(t, 4, result.Rows[2].Line)
+	assert.Contains(t, result.Rows[2].Error, "does not match YYYY/MM/DD")
+
+	mockRepo.AssertNotCalled(t, "WithTx", mock.Anything)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+// TestImportIngredients_PriceAndStore tests that imported rows keep what they cost and where they were bought
+func TestImportIngredients_PriceAndStore(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+	mockRepo.On("GetAll", mock.Anything).Return([]*domain.Ingredient{}, nil)
+
+	content := "品名,価格,購入店\n" +
+		"にんじん,\"1,280円\",スーパーA\n" +
+		"玉ねぎ,￥９８,\n" +
+		"鶏もも肉,時価,\n"
+
+	result, err := usecase.ImportIngredients(context.Background(), ImportIngredientsRequest{Content: content, DryRun: true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "価格", result.Mapping["price"])
+	assert.Equal(t, "購入店", result.Mapping["store"])
+	assert.Equal(t, 2, result.Created)
+	assert.Equal(t, 1280, *result.Rows[0].Ingredient.Price)
+	assert.Equal(t, "スーパーA", result.Rows[0].Ingredient.Store)
+	assert.Equal(t, 98, *result.Rows[1].Ingredient.Price)
+	assert.Equal(t, ImportActionError, result.Rows[2].Action)
+	assert.Contains(t, result.Rows[2].Error, "price")
+}
+
+// TestImportIngredients_Merge tests that duplicates add their quantities to the stored ingredient
+func TestImportIngredients_Merge(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+	stored := &domain.Ingredient{ID: 1, Name: "にんじん", CanonicalName: "にんじん", Quantity: "2本", Version: 4}
+	mockRepo.On("GetAll", mock.Anything).Return([]*domain.Ingredient{stored}, nil)
+	mockRepo.On("WithTx", mock.Anything).Return(nil)
+	mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Ingredient")).Return(nil)
+
+	content := `[{"name": "人参", "quantity": "3本"}, {"name": "にんじん", "quantity": "200g"}]`
+
+	result, err := usecase.ImportIngredients(context.Background(), ImportIngredientsRequest{
+		Content:     content,
+		OnDuplicate: DuplicateMerge,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, InventoryFormatJSON, result.Format)
+	assert.Equal(t, 1, result.Merged)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, ImportActionMerge, result.Rows[0].Action)
+	assert.Equal(t, "5本", result.Rows[0].Ingredient.Quantity)
+	assert.Equal(t, "にんじん", result.Rows[0].Ingredient.Name)
+	assert.Contains(t, result.Rows[1].Error, "cannot add quantity")
+	mockRepo.AssertNumberOfCalls(t, "Update", 1)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+// TestImportIngredients_SkipAndOverwrite tests the other duplicate strategies, including duplicates within the file
+func TestImportIngredients_SkipAndOverwrite(t *testing.T) {
+	content := "name,quantity,expires_at\n" +
+		"卵,10個,01/02/2026\n" +
+		"たまご,6個,\n"
+
+	tests := []struct {
+		onDuplicate string
+		action      string
+		quantity    string
+		updates     int
+	}{
+		{DuplicateSkip, ImportActionSkip, "10個", 0},
+		{DuplicateOverwrite, ImportActionOverwrite, "6個", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.onDuplicate, func(t *testing.T) {
+			mockRepo := new(MockIngredientRepository)
+			usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+			mockRepo.On("GetAll", mock.Anything).Return([]*domain.Ingredient{}, nil)
+			mockRepo.On("WithTx", mock.Anything).Return(nil)
+			mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Ingredient")).
+				Run(func(args mock.Arguments) {
+					args.Get(1).(*domain.Ingredient).ID = 7
+				}).Return(nil)
+			mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Ingredient")).Return(nil)
+
+			result, err := usecase.ImportIngredients(context.Background(), ImportIngredientsRequest{
+				Content:     content,
+				DateFormat:  "dd/mm/yyyy",
+				OnDuplicate: tt.onDuplicate,
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, "DD/MM/YYYY", result.DateFormat)
+			assert.Equal(t, ImportActionCreate, result.Rows[0].Action)
+			assert.Equal(t, "2026-02-01", result.Rows[0].Ingredient.ExpiresAt.Format("2006-01-02"))
+			assert.Equal(t, "10個", result.Rows[0].Ingredient.Quantity)
+			assert.Equal(t, tt.action, result.Rows[1].Action)
+			assert.Equal(t, int64(7), result.Rows[1].Ingredient.ID)
+			assert.Equal(t, tt.quantity, result.Rows[1].Ingredient.Quantity)
+			mockRepo.AssertNumberOfCalls(t, "Create", 1)
+			mockRepo.AssertNumberOfCalls(t, "Update", tt.updates)
+		})
+	}
+}
+
+// TestImportIngredients_InvalidRequest tests request-level validation of an import
+func TestImportIngredients_InvalidRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		req  ImportIngredientsRequest
+	}{
+		{"empty content", ImportIngredientsRequest{Content: "  "}},
+		{"unknown format", ImportIngredientsRequest{Content: "name\n卵", Format: "xlsx"}},
+		{"unknown strategy", ImportIngredientsRequest{Content: "name\n卵", OnDuplicate: "replace"}},
+		{"no name column", ImportIngredientsRequest{Content: "数量\n10個"}},
+		{"unknown mapped column", ImportIngredientsRequest{Content: "item\n卵", Mapping: map[string]string{"name": "品名"}}},
+		{"unknown mapped field", ImportIngredientsRequest{Content: "item\n卵", Mapping: map[string]string{"weight": "item"}}},
+		{"unknown date format", ImportIngredientsRequest{Content: "name\n卵", DateFormat: "YY-M-D"}},
+		{"malformed JSON", ImportIngredientsRequest{Content: `[{"name": }]`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockIngredientRepository)
+			usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+			_, err := usecase.ImportIngredients(context.Background(), tt.req)
+
+			assert.ErrorIs(t, err, domain.ErrInvalidInput)
+			mockRepo.AssertNotCalled(t, "GetAll", mock.Anything)
+		})
+	}
+}
+
+// TestImportIngredients_WriteError tests that a failed write aborts the whole import
+func TestImportIngredients_WriteError(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+	mockRepo.On("GetAll", mock.Anything).Return([]*domain.Ingredient{}, nil)
+	mockRepo.On("WithTx", mock.Anything).Return(nil)
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Ingredient")).Return(errors.New("database error"))
+
+	result, err := usecase.ImportIngredients(context.Background(), ImportIngredientsRequest{Content: "name\n卵\n"})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "line 2")
+}
+
+// TestMergeQuantities tests adding quantity texts
+func TestMergeQuantities(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected string
+		ok       bool
+	}{
+		{"2本", "3本", "5本", true},
+		{"1kg", "500g", "1500g", true},
+		{"", "3本", "3本", true},
+		{"2本", "", "2本", true},
+		{"適量", "3本", "", false},
+		{"2本", "200g", "", false},
+	}
+
+	for _, tt := range tests {
+		result, ok := mergeQuantities(tt.a, tt.b)
+		assert.Equal(t, tt.ok, ok, tt.a+"+"+tt.b)
+		assert.Equal(t, tt.expected, result, tt.a+"+"+tt.b)
+	}
+}
+
+// TestGetTrash_Success tests listing the ingredients in the trash
+func TestGetTrash_Success(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+	deletedAt := time.Now()
+	expected := []*domain.Ingredient{{ID: 1, Name: "にんじん", DeletedAt: &deletedAt}}
+	mockRepo.On("GetDeleted", mock.Anything).Return(expected, nil)
+
+	result, err := usecase.GetTrash(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestRestoreIngredient_Success tests that a restored ingredient is returned as it is now
+func TestRestoreIngredient_Success(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+	restored := &domain.Ingredient{ID: 1, Name: "にんじん", Version: 4}
+	mockRepo.On("Restore", mock.Anything, int64(1)).Return(nil)
+	mockRepo.On("GetByID", mock.Anything, int64(1)).Return(restored, nil)
+
+	result, err := usecase.RestoreIngredient(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, restored, result)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestRestoreIngredient_NotInTrash tests that restoring an ingredient missing from the trash is reported as not found
+func TestRestoreIngredient_NotInTrash(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+	mockRepo.On("Restore", mock.Anything, int64(999)).Return(fmt.Errorf("ingredient not found in trash: %w", sql.ErrNoRows))
+
+	result, err := usecase.RestoreIngredient(context.Background(), 999)
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.Nil(t, result)
+	mockRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+}
+
+// TestPurgeTrash_Success tests that only ingredients deleted before the retention period are purged
+func TestPurgeTrash_Success(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	usecase := NewIngredientUsecase(mockRepo, nil, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+	retention := 30 * 24 * time.Hour
+	mockRepo.On("Purge", mock.Anything, mock.MatchedBy(func(deletedBefore time.Time) bool {
+		return time.Since(deletedBefore) >= retention && time.Since(deletedBefore) < retention+time.Minute
+	})).Return(int64(2), nil)
+
+	purged, err := usecase.PurgeTrash(context.Background(), retention)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), purged)
+	mockRepo.AssertExpectations(t)
+}
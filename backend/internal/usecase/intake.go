@@ -0,0 +1,14 @@
+package usecase
+
+import (
+	"context"
+)
+
+// IntakeUsecase defines the business logic interface for turning free text into ingredients
+type IntakeUsecase interface {
+	// ParseReceipt reads draft ingredients from OCR'd receipt text without saving them
+	ParseReceipt(ctx context.Context, req ParseReceiptRequest) (*IngredientDraftsResponse, error)
+
+	// QuickAdd reads draft ingredients from a short shopping note, saving them when the request is confirmed
+	QuickAdd(ctx context.Context, req QuickAddRequest) (*QuickAddResponse, error)
+}
@@ -0,0 +1,276 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/internal/service"
+	"github.com/Rin0530/DinnerDecider/backend/pkg/logger"
+)
+
+// maxIntakeTextLength is the maximum number of characters of free text read in one request
+const maxIntakeTextLength = 10000
+
+// intakeUsecase implements the IntakeUsecase interface
+type intakeUsecase struct {
+	ingredients   IngredientUsecase
+	normalizer    service.IngredientNormalizer
+	parser        service.IngredientTextParser
+	ollamaService service.OllamaService
+	now           func() time.Time
+}
+
+// NewIntakeUsecase creates a new instance of IntakeUsecase.
+// Confirmed drafts are saved through ingredients, and ollamaService may be nil,
+// in which case only the rule-based parser is used.
+func NewIntakeUsecase(
+	ingredients IngredientUsecase,
+	normalizer service.IngredientNormalizer,
+	parser service.IngredientTextParser,
+	ollamaService service.OllamaService,
+) IntakeUsecase {
+	return &intakeUsecase{
+		ingredients:   ingredients,
+		normalizer:    normalizer,
+		parser:        parser,
+		ollamaService: ollamaService,
+		now:           time.Now,
+	}
+}
+
+// ParseReceipt reads draft ingredients from OCR'd receipt text without saving them
+func (u *intakeUsecase) ParseReceipt(ctx context.Context, req ParseReceiptRequest) (*IngredientDraftsResponse, error) {
+	text, err := validateIntakeText(req.Text)
+	if err != nil {
+		return nil, err
+	}
+
+	// The rules always run; they find the prices and the ignored lines even when the LLM reads the items
+	parsed := u.parser.ParseReceipt(text)
+	response := &IngredientDraftsResponse{Ignored: parsed.Ignored}
+
+	if req.UseLLM {
+		extracted, warning := u.extract(ctx, text)
+		if warning != "" {
+			response.Warnings = append(response.Warnings, warning)
+		} else {
+			response.UsedLLM = true
+			parsed = u.mergeExtracted(parsed, extracted)
+		}
+	}
+
+	response.PurchaseDate = formatDate(parsed.PurchaseDate)
+	response.Drafts = u.buildDrafts(parsed)
+	if response.Ignored == nil {
+		response.Ignored = []string{}
+	}
+
+	return response, nil
+}
+
+// QuickAdd reads draft ingredients from a short shopping note, saving them when the request is confirmed
+func (u *intakeUsecase) QuickAdd(ctx context.Context, req QuickAddRequest) (*QuickAddResponse, error) {
+	var response *QuickAddResponse
+	if len(req.Drafts) > 0 {
+		// Confirming an earlier preview saves the drafts exactly as the client corrected them
+		response = &QuickAddResponse{IngredientDraftsResponse: IngredientDraftsResponse{Ignored: []string{}}}
+		for _, draft := range req.Drafts {
+			response.Drafts = append(response.Drafts, IngredientDraft{CreateIngredientRequest: draft})
+		}
+	} else {
+		drafts, err := u.readQuickAdd(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		response = &QuickAddResponse{IngredientDraftsResponse: *drafts}
+	}
+
+	if !req.Confirm {
+		return response, nil
+	}
+	if len(response.Drafts) == 0 {
+		return nil, fmt.Errorf("%w: no ingredients were found in the text", domain.ErrInvalidInput)
+	}
+
+	// Save every draft or none of them
+	batch := BatchIngredientsRequest{Mode: BatchModeAtomic}
+	for i := range response.Drafts {
+		batch.Operations = append(batch.Operations, BatchIngredientOperation{
+			Op:         BatchOpCreate,
+			Ingredient: &response.Drafts[i].CreateIngredientRequest,
+		})
+	}
+
+	result, err := u.ingredients.BatchIngredients(ctx, batch)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range result.Results {
+		if item.Status == BatchStatusFailed {
+			return nil, fmt.Errorf("failed to add %q: %w", response.Drafts[item.Index].Name, item.Err)
+		}
+	}
+
+	response.Committed = true
+	for _, item := range result.Results {
+		response.Ingredients = append(response.Ingredients, item.Ingredient)
+	}
+	return response, nil
+}
+
+// readQuickAdd reads drafts from the text of a quick add request with the rules, asking the LLM as the mode allows
+func (u *intakeUsecase) readQuickAdd(ctx context.Context, req QuickAddRequest) (*IngredientDraftsResponse, error) {
+	mode := req.LLM
+	if mode == "" {
+		mode = LLMModeAuto
+	}
+	if mode != LLMModeAuto && mode != LLMModeAlways && mode != LLMModeNever {
+		return nil, fmt.Errorf("%w: llm must be %q, %q or %q", domain.ErrInvalidInput, LLMModeAuto, LLMModeAlways, LLMModeNever)
+	}
+
+	text, err := validateIntakeText(req.Text)
+	if err != nil {
+		return nil, err
+	}
+
+	now := u.now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	parsed := u.parser.ParseQuickAdd(text, today)
+	response := &IngredientDraftsResponse{}
+
+	if mode == LLMModeAlways || (mode == LLMModeAuto && !u.understood(parsed)) {
+		extracted, warning := u.extract(ctx, text)
+		if warning != "" {
+			response.Warnings = append(response.Warnings, warning)
+		} else {
+			// The LLM read the segments the rules could not, so none of them are reported as ignored
+			response.UsedLLM = true
+			parsed = u.mergeExtracted(parsed, extracted)
+			parsed.Ignored = nil
+		}
+	}
+
+	// Items without a date of their own were bought today
+	for i := range parsed.Items {
+		if parsed.Items[i].PurchaseDate == nil {
+			parsed.Items[i].PurchaseDate = &today
+		}
+	}
+
+	response.PurchaseDate = formatDate(parsed.PurchaseDate)
+	response.Drafts = u.buildDrafts(parsed)
+	response.Ignored = parsed.Ignored
+	if response.Ignored == nil {
+		response.Ignored = []string{}
+	}
+
+	return response, nil
+}
+
+// understood reports whether the rules read the whole text into items whose names are in the dictionary
+func (u *intakeUsecase) understood(parsed *domain.ParsedIngredients) bool {
+	if len(parsed.Items) == 0 || len(parsed.Ignored) > 0 {
+		return false
+	}
+	for _, item := range parsed.Items {
+		if _, ok := u.normalizer.Lookup(item.Name); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// extract asks the LLM to read the text, returning a warning instead when it cannot be used
+func (u *intakeUsecase) extract(ctx context.Context, text string) (*domain.ParsedIngredients, string) {
+	if u.ollamaService == nil {
+		return nil, "LLM is not configured; showing rule-based results"
+	}
+
+	extracted, err := u.ollamaService.ExtractIngredients(ctx, text, u.now())
+	if err != nil {
+		logger.WithError(err).Warn("LLM extraction failed, using rule-based results")
+		return nil, "LLM extraction failed; showing rule-based results"
+	}
+	if len(extracted.Items) == 0 {
+		return nil, "LLM found no items; showing rule-based results"
+	}
+
+	return extracted, ""
+}
+
+// mergeExtracted uses the items the LLM read, keeping the prices and lines the rules found for the same items
+func (u *intakeUsecase) mergeExtracted(parsed, extracted *domain.ParsedIngredients) *domain.ParsedIngredients {
+	byCanonical := make(map[string]domain.ParsedIngredient, len(parsed.Items))
+	for _, item := range parsed.Items {
+		byCanonical[u.normalizer.Canonical(item.Name)] = item
+	}
+
+	merged := &domain.ParsedIngredients{PurchaseDate: extracted.PurchaseDate, Ignored: parsed.Ignored}
+	if merged.PurchaseDate == nil {
+		merged.PurchaseDate = parsed.PurchaseDate
+	}
+
+	for _, item := range extracted.Items {
+		if ruled, ok := byCanonical[u.normalizer.Canonical(item.Name)]; ok {
+			item.Price = ruled.Price
+			item.Line = ruled.Line
+			if item.Quantity == "" {
+				item.Quantity = ruled.Quantity
+			}
+		}
+		merged.Items = append(merged.Items, item)
+	}
+
+	return merged
+}
+
+// buildDrafts converts parsed items into create requests, detecting the category and location of each
+func (u *intakeUsecase) buildDrafts(parsed *domain.ParsedIngredients) []IngredientDraft {
+	drafts := make([]IngredientDraft, 0, len(parsed.Items))
+	for _, item := range parsed.Items {
+		category := u.normalizer.Category(item.Name)
+		if category == "" {
+			category = domain.CategoryOther
+		}
+
+		purchaseDate := item.PurchaseDate
+		if purchaseDate == nil {
+			purchaseDate = parsed.PurchaseDate
+		}
+
+		// Receipt prices are after line discounts; items read without one are left unpriced
+		var price *int
+		if item.Price > 0 {
+			price = &item.Price
+		}
+
+		drafts = append(drafts, IngredientDraft{
+			CreateIngredientRequest: CreateIngredientRequest{
+				Name:         item.Name,
+				Category:     category,
+				Location:     domain.DefaultLocation(category),
+				Quantity:     item.Quantity,
+				Price:        price,
+				PurchaseDate: formatDate(purchaseDate),
+			},
+			Line: item.Line,
+		})
+	}
+	return drafts
+}
+
+// validateIntakeText trims free text and checks that it is neither empty nor too long
+func validateIntakeText(text string) (string, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", fmt.Errorf("%w: text is required", domain.ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(text) > maxIntakeTextLength {
+		return "", fmt.Errorf("%w: text must be at most %d characters", domain.ErrInvalidInput, maxIntakeTextLength)
+	}
+	return text, nil
+}
@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// testReceipt is a short supermarket receipt used by the intake tests
+const testReceipt = `2026/10/17 18:32
+鶏もも 300g ¥598
+牛乳 ¥238
+ティッシュ ¥298
+合計 ¥1,134`
+
+// newTestIntakeUsecase creates an intake usecase with the bundled dictionary and a fixed clock
+func newTestIntakeUsecase(t *testing.T, repo *MockIngredientRepository, ollamaService service.OllamaService) *intakeUsecase {
+	t.Helper()
+	normalizer := newTestNormalizer(t)
+	ingredientUsecase := NewIngredientUsecase(repo, nil, normalizer, service.NewInventoryCodec(), nil)
+	u := NewIntakeUsecase(ingredientUsecase, normalizer, service.NewIngredientTextParser(), ollamaService).(*intakeUsecase)
+	u.now = func() time.Time { return time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC) }
+	return u
+}
+
+// TestParseReceipt_Rules tests reading drafts from a receipt with the rules only
+func TestParseReceipt_Rules(t *testing.T) {
+	mockService := new(MockOllamaService)
+	usecase := newTestIntakeUsecase(t, new(MockIngredientRepository), mockService)
+
+	result, err := usecase.ParseReceipt(context.Background(), ParseReceiptRequest{Text: testReceipt})
+
+	assert.NoError(t, err)
+	assert.False(t, result.UsedLLM)
+	assert.Equal(t, "2026-10-17", *result.PurchaseDate)
+	assert.Len(t, result.Drafts, 2)
+	assert.Equal(t, "鶏もも", result.Drafts[0].Name)
+	assert.Equal(t, "300g", result.Drafts[0].Quantity)
+	assert.Equal(t, domain.CategoryMeat, result.Drafts[0].Category)
+	assert.Equal(t, domain.LocationFridge, result.Drafts[0].Location)
+	assert.Equal(t, "2026-10-17", *result.Drafts[0].PurchaseDate)
+	assert.Equal(t, 598, *result.Drafts[0].Price)
+	assert.Equal(t, domain.CategoryDairy, result.Drafts[1].Category)
+	assert.Contains(t, result.Ignored, "ティッシュ ¥298")
+	assert.Contains(t, result.Ignored, "合計 ¥1,134")
+	mockService.AssertNotCalled(t, "ExtractIngredients", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestParseReceipt_LLM tests that LLM items replace the rule-based ones and keep their prices
+func TestParseReceipt_LLM(t *testing.T) {
+	mockService := new(MockOllamaService)
+	usecase := newTestIntakeUsecase(t, new(MockIngredientRepository), mockService)
+
+	extracted := &domain.ParsedIngredients{
+		Items: []domain.ParsedIngredient{
+			{Name: "鶏もも肉", Quantity: "300g"},
+			{Name: "牛乳", Quantity: "1本"},
+		},
+	}
+	mockService.On("ExtractIngredients", mock.Anything, strings.TrimSpace(testReceipt), usecase.now()).Return(extracted, nil)
+
+	result, err := usecase.ParseReceipt(context.Background(), ParseReceiptRequest{Text: testReceipt, UseLLM: true})
+
+	assert.NoError(t, err)
+	assert.True(t, result.UsedLLM)
+	assert.Empty(t, result.Warnings)
+	assert.Equal(t, "2026-10-17", *result.PurchaseDate)
+	assert.Len(t, result.Drafts, 2)
+	assert.Equal(t, "鶏もも肉", result.Drafts[0].Name)
+	assert.Equal(t, 598, *result.Drafts[0].Price)
+	assert.Equal(t, "1本", result.Drafts[1].Quantity)
+	assert.Equal(t, 238, *result.Drafts[1].Price)
+	mockService.AssertExpectations(t)
+}
+
+// TestParseReceipt_LLMFailure tests that an LLM error falls back to the rules with a warning
+func TestParseReceipt_LLMFailure(t *testing.T) {
+	mockService := new(MockOllamaService)
+	usecase := newTestIntakeUsecase(t, new(MockIngredientRepository), mockService)
+
+	mockService.On("ExtractIngredients", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("connection refused"))
+
+	result, err := usecase.ParseReceipt(context.Background(), ParseReceiptRequest{Text: testReceipt, UseLLM: true})
+
+	assert.NoError(t, err)
+	assert.False(t, result.UsedLLM)
+	assert.Len(t, result.Warnings, 1)
+	assert.Len(t, result.Drafts, 2)
+}
+
+// TestParseReceipt_InvalidText tests that empty and oversized text is rejected
+func TestParseReceipt_InvalidText(t *testing.T) {
+	usecase := newTestIntakeUsecase(t, new(MockIngredientRepository), nil)
+
+	_, err := usecase.ParseReceipt(context.Background(), ParseReceiptRequest{Text: "  \n "})
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+	_, err = usecase.ParseReceipt(context.Background(), ParseReceiptRequest{Text: strings.Repeat("あ", maxIntakeTextLength+1)})
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+}
+
+// TestQuickAdd_Preview tests reading drafts from a shopping note without saving them
+func TestQuickAdd_Preview(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	mockService := new(MockOllamaService)
+	usecase := newTestIntakeUsecase(t, mockRepo, mockService)
+
+	result, err := usecase.QuickAdd(context.Background(), QuickAddRequest{Text: "卵10個と牛乳1本、昨日買った鶏もも300g"})
+
+	assert.NoError(t, err)
+	assert.False(t, result.Committed)
+	assert.False(t, result.UsedLLM)
+	assert.Len(t, result.Drafts, 3)
+	assert.Equal(t, "卵", result.Drafts[0].Name)
+	assert.Equal(t, "10個", result.Drafts[0].Quantity)
+	assert.Equal(t, "2026-10-18", *result.Drafts[0].PurchaseDate)
+	assert.Equal(t, "鶏もも", result.Drafts[2].Name)
+	assert.Equal(t, "2026-10-17", *result.Drafts[2].PurchaseDate)
+	assert.Equal(t, domain.CategoryMeat, result.Drafts[2].Category)
+	mockService.AssertNotCalled(t, "ExtractIngredients", mock.Anything, mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "WithTx", mock.Anything)
+}
+
+// TestQuickAdd_LLMFallback tests that the LLM is asked when the rules cannot read the whole note
+func TestQuickAdd_LLMFallback(t *testing.T) {
+	mockService := new(MockOllamaService)
+	usecase := newTestIntakeUsecase(t, new(MockIngredientRepository), mockService)
+
+	yesterday := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	extracted := &domain.ParsedIngredients{
+		PurchaseDate: &yesterday,
+		Items: []domain.ParsedIngredient{
+			{Name: "卵", PurchaseDate: &yesterday},
+			{Name: "牛乳"},
+		},
+	}
+	mockService.On("ExtractIngredients", mock.Anything, "昨日、卵と牛乳を買った", usecase.now()).Return(extracted, nil)
+
+	result, err := usecase.QuickAdd(context.Background(), QuickAddRequest{Text: "昨日、卵と牛乳を買った"})
+
+	assert.NoError(t, err)
+	assert.True(t, result.UsedLLM)
+	assert.Len(t, result.Drafts, 2)
+	assert.Equal(t, "2026-10-17", *result.Drafts[0].PurchaseDate)
+	assert.Equal(t, "2026-10-18", *result.Drafts[1].PurchaseDate)
+	assert.Empty(t, result.Ignored)
+	mockService.AssertExpectations(t)
+
+	// The rules' result is kept when the LLM is turned off
+	result, err = usecase.QuickAdd(context.Background(), QuickAddRequest{Text: "昨日、卵と牛乳を買った", LLM: LLMModeNever})
+
+	assert.NoError(t, err)
+	assert.False(t, result.UsedLLM)
+	assert.Len(t, result.Drafts, 1)
+	assert.Equal(t, "卵と牛乳", result.Drafts[0].Name)
+}
+
+// TestQuickAdd_Confirm tests that confirmed drafts are saved in one transaction
+func TestQuickAdd_Confirm(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	usecase := newTestIntakeUsecase(t, mockRepo, nil)
+
+	mockRepo.On("WithTx", mock.Anything).Return(nil)
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Ingredient")).Return(nil).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.Ingredient).ID = 1
+	})
+
+	purchaseDate := "2026-10-17"
+	result, err := usecase.QuickAdd(context.Background(), QuickAddRequest{
+		Confirm: true,
+		Drafts:  []CreateIngredientRequest{{Name: "卵", Quantity: "10個", PurchaseDate: &purchaseDate}},
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, result.Committed)
+	assert.Len(t, result.Ingredients, 1)
+	assert.Equal(t, "卵", result.Ingredients[0].Name)
+	assert.Equal(t, domain.CategoryDairy, result.Ingredients[0].Category)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestQuickAdd_InvalidRequest tests that invalid quick add requests are rejected
+func TestQuickAdd_InvalidRequest(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	usecase := newTestIntakeUsecase(t, mockRepo, nil)
+
+	mockRepo.On("WithTx", mock.Anything).Return(nil)
+
+	_, err := usecase.QuickAdd(context.Background(), QuickAddRequest{Text: "卵", LLM: "sometimes"})
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+	_, err = usecase.QuickAdd(context.Background(), QuickAddRequest{Text: "買った", LLM: LLMModeNever, Confirm: true})
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+	badDate := "yesterday"
+	_, err = usecase.QuickAdd(context.Background(), QuickAddRequest{
+		Confirm: true,
+		Drafts:  []CreateIngredientRequest{{Name: "卵", PurchaseDate: &badDate}},
+	})
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+}
@@ -0,0 +1,16 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+)
+
+// ProductUsecase defines the business logic interface for the local barcode product database
+type ProductUsecase interface {
+	// GetProduct retrieves a product by its JAN/EAN barcode
+	GetProduct(ctx context.Context, barcode string) (*domain.Product, error)
+
+	// ImportProducts loads products from a CSV dump of a product database
+	ImportProducts(ctx context.Context, req ImportProductsRequest) (*ImportProductsResponse, error)
+}
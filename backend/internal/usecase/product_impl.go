@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/internal/repository"
+	"github.com/Rin0530/DinnerDecider/backend/internal/service"
+)
+
+// maxProductImportRows caps the number of data rows in one product dump
+const maxProductImportRows = 100000
+
+// productColumnAliases lists the column names recognized for each product field, compared after textnorm.Fold
+var productColumnAliases = map[string][]string{
+	domain.ProductColumnBarcode:       {"barcode", "jan", "janコード", "ean", "gtin", "code", "バーコード", "商品コード"},
+	domain.ProductColumnName:          {"name", "商品名", "品名", "名前", "product_name"},
+	domain.ProductColumnCategory:      {"category", "カテゴリ", "カテゴリー", "分類"},
+	domain.ProductColumnUnit:          {"unit", "単位", "内容量", "容量", "規格"},
+	domain.ProductColumnShelfLifeDays: {"shelf_life_days", "shelf_life", "賞味期間", "日持ち"},
+}
+
+// productUsecase implements the ProductUsecase interface
+type productUsecase struct {
+	repo       repository.ProductRepository
+	normalizer service.IngredientNormalizer
+	codec      service.InventoryCodec
+}
+
+// NewProductUsecase creates a new instance of ProductUsecase
+func NewProductUsecase(repo repository.ProductRepository, normalizer service.IngredientNormalizer, codec service.InventoryCodec) ProductUsecase {
+	return &productUsecase{
+		repo:       repo,
+		normalizer: normalizer,
+		codec:      codec,
+	}
+}
+
+// GetProduct retrieves a product by its JAN/EAN barcode
+func (u *productUsecase) GetProduct(ctx context.Context, barcode string) (*domain.Product, error) {
+	code, err := normalizeBarcode(barcode)
+	if err != nil {
+		return nil, err
+	}
+	if code == "" {
+		return nil, fmt.Errorf("%w: barcode is required", domain.ErrInvalidInput)
+	}
+
+	product, err := u.repo.GetByBarcode(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+
+	return product, nil
+}
+
+// ImportProducts loads products from a CSV dump of a product database.
+// Invalid rows are reported and skipped; the valid rows are saved together.
+// The product catalog is shared by every household, so only server administrators may import into it.
+func (u *productUsecase) ImportProducts(ctx context.Context, req ImportProductsRequest) (*ImportProductsResponse, error) {
+	if err := checkWritable(ctx); err != nil {
+		return nil, err
+	}
+	if err := checkAdmin(ctx, "import products"); err != nil {
+		return nil, err
+	}
+
+	table, err := u.codec.DecodeCSV([]byte(req.Content))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+	if len(table.Rows) > maxProductImportRows {
+		return nil, fmt.Errorf("%w: at most %d rows are allowed per import", domain.ErrInvalidInput, maxProductImportRows)
+	}
+
+	mapping, err := resolveColumnMapping(table.Columns, req.Mapping, domain.ProductColumns, productColumnAliases,
+		domain.ProductColumnBarcode, domain.ProductColumnName)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ImportProductsResponse{
+		Mapping: mapping,
+		Errors:  []ImportProductError{},
+	}
+
+	// Later rows replace earlier rows with the same barcode
+	products := make([]*domain.Product, 0, len(table.Rows))
+	index := make(map[string]int, len(table.Rows))
+	for _, row := range table.Rows {
+		product, err := u.productFromRow(row, mapping)
+		if err != nil {
+			response.Failed++
+			response.Errors = append(response.Errors, ImportProductError{Line: row.Line, Error: err.Error()})
+			continue
+		}
+
+		if i, ok := index[product.Barcode]; ok {
+			products[i] = product
+			continue
+		}
+		index[product.Barcode] = len(products)
+		products = append(products, product)
+	}
+
+	if len(products) > 0 {
+		if err := u.repo.Import(ctx, products, !req.OverwriteLearned); err != nil {
+			return nil, fmt.Errorf("failed to import products: %w", err)
+		}
+	}
+	response.Imported = len(products)
+
+	return response, nil
+}
+
+// productFromRow converts a dump row into a product, classifying it when the category is missing or unknown
+func (u *productUsecase) productFromRow(row domain.InventoryRow, mapping map[string]string) (*domain.Product, error) {
+	value := func(field string) string {
+		return strings.TrimSpace(row.Values[mapping[field]])
+	}
+
+	code, err := normalizeBarcode(value(domain.ProductColumnBarcode))
+	if err != nil {
+		return nil, err
+	}
+	if code == "" {
+		return nil, fmt.Errorf("%w: barcode is required", domain.ErrInvalidInput)
+	}
+
+	name := value(domain.ProductColumnName)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", domain.ErrInvalidInput)
+	}
+
+	category := categoryFromLabel(value(domain.ProductColumnCategory))
+	if !domain.IsValidCategory(category) {
+		category = u.normalizer.Category(name)
+	}
+	if category == "" {
+		category = domain.CategoryOther
+	}
+
+	product := &domain.Product{
+		Barcode:  code,
+		Name:     name,
+		Category: category,
+		Unit:     value(domain.ProductColumnUnit),
+		Source:   domain.ProductSourceImport,
+	}
+
+	if shelfLife := strings.TrimSuffix(value(domain.ProductColumnShelfLifeDays), "日"); shelfLife != "" {
+		days, err := strconv.Atoi(strings.TrimSpace(shelfLife))
+		if err != nil || days < 0 {
+			return nil, fmt.Errorf("%w: invalid shelf_life_days %q", domain.ErrInvalidInput, value(domain.ProductColumnShelfLifeDays))
+		}
+		product.ShelfLifeDays = &days
+	}
+
+	return product, nil
+}
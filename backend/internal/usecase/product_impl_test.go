@@ -0,0 +1,260 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockProductRepository is a mock implementation of ProductRepository
+type MockProductRepository struct {
+	mock.Mock
+}
+
+func (m *MockProductRepository) GetByBarcode(ctx context.Context, barcode string) (*domain.Product, error) {
+	args := m.Called(ctx, barcode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) Save(ctx context.Context, product *domain.Product) error {
+	args := m.Called(ctx, product)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Import(ctx context.Context, products []*domain.Product, keepLearned bool) error {
+	args := m.Called(ctx, products, keepLearned)
+	return args.Error(0)
+}
+
+// TestGetProduct_NormalizesBarcode tests that a scanned barcode is looked up in its canonical form
+func TestGetProduct_NormalizesBarcode(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	usecase := NewProductUsecase(mockRepo, newTestNormalizer(t), service.NewInventoryCodec())
+
+	expected := &domain.Product{Barcode: "4901234567894", Name: "納豆", Source: domain.ProductSourceImport}
+	mockRepo.On("GetByBarcode", mock.Anything, "4901234567894").Return(expected, nil)
+
+	result, err := usecase.GetProduct(context.Background(), "49-0123 456789４")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestGetProduct_InvalidBarcode tests that a barcode with a wrong check digit is rejected
+func TestGetProduct_InvalidBarcode(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	usecase := NewProductUsecase(mockRepo, newTestNormalizer(t), service.NewInventoryCodec())
+
+	result, err := usecase.GetProduct(context.Background(), "4901234567890")
+
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	assert.Nil(t, result)
+	mockRepo.AssertNotCalled(t, "GetByBarcode", mock.Anything, mock.Anything)
+}
+
+// TestGetProduct_NotFound tests that an unknown barcode is reported as not found
+func TestGetProduct_NotFound(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	usecase := NewProductUsecase(mockRepo, newTestNormalizer(t), service.NewInventoryCodec())
+
+	mockRepo.On("GetByBarcode", mock.Anything, "49021028").Return(nil, fmt.Errorf("product not found: %w", sql.ErrNoRows))
+
+	result, err := usecase.GetProduct(context.Background(), "49021028")
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.Nil(t, result)
+}
+
+// TestImportProducts_Success tests importing a dump with Japanese headers, skipping invalid rows
+func TestImportProducts_Success(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	usecase := NewProductUsecase(mockRepo, newTestNormalizer(t), service.NewInventoryCodec())
+
+	content := "JANコード,商品名,分類,内容量,賞味期間\n" +
+		"4901234567894,にんじん,野菜,3本,14日\n" +
+		"4901234567890,壊れたコード,,,\n" +
+		"49021028,牛乳,,1000ml,\n"
+
+	var imported []*domain.Product
+	mockRepo.On("Import", mock.Anything, mock.AnythingOfType("[]*domain.Product"), true).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			imported = args.Get(1).([]*domain.Product)
+		})
+
+	result, err := usecase.ImportProducts(adminContext(), ImportProductsRequest{Content: content})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Imported)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, 3, result.Errors[0].Line)
+	assert.Equal(t, "JANコード", result.Mapping[domain.ProductColumnBarcode])
+	assert.Equal(t, "内容量", result.Mapping[domain.ProductColumnUnit])
+
+	assert.Len(t, imported, 2)
+	assert.Equal(t, "vegetable", imported[0].Category)
+	assert.Equal(t, 14, *imported[0].ShelfLifeDays)
+	assert.Equal(t, domain.ProductSourceImport, imported[0].Source)
+	assert.Equal(t, "dairy", imported[1].Category)
+	assert.Nil(t, imported[1].ShelfLifeDays)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestImportProducts_OverwriteLearned tests that learned products are only replaced on request
+func TestImportProducts_OverwriteLearned(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	usecase := NewProductUsecase(mockRepo, newTestNormalizer(t), service.NewInventoryCodec())
+
+	mockRepo.On("Import", mock.Anything, mock.AnythingOfType("[]*domain.Product"), false).Return(nil)
+
+	_, err := usecase.ImportProducts(adminContext(), ImportProductsRequest{
+		Content:          "code,品名\n49021028,牛乳\n",
+		OverwriteLearned: true,
+	})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestImportProducts_MissingBarcodeColumn tests that a dump without a barcode column is rejected
+func TestImportProducts_MissingBarcodeColumn(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	usecase := NewProductUsecase(mockRepo, newTestNormalizer(t), service.NewInventoryCodec())
+
+	_, err := usecase.ImportProducts(adminContext(), ImportProductsRequest{Content: "商品名\n牛乳\n"})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	mockRepo.AssertNotCalled(t, "Import", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestImportProducts_Forbidden tests that only server administrators with write access can change the shared catalog
+func TestImportProducts_Forbidden(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	usecase := NewProductUsecase(mockRepo, newTestNormalizer(t), service.NewInventoryCodec())
+
+	user := domain.WithUser(context.Background(), &domain.User{ID: 2, Username: "hanako"})
+	admin := domain.WithUser(context.Background(), &domain.User{ID: 1, Username: "admin", IsAdmin: true})
+	contexts := map[string]context.Context{
+		"household owner": domain.WithHousehold(user, &domain.Household{ID: 2, Role: domain.HouseholdRoleOwner}),
+		"viewer":          domain.WithHousehold(user, &domain.Household{ID: 1, Role: domain.HouseholdRoleViewer}),
+		"viewer admin":    domain.WithHousehold(admin, &domain.Household{ID: 1, Role: domain.HouseholdRoleViewer}),
+	}
+	for name, ctx := range contexts {
+		result, err := usecase.ImportProducts(ctx, ImportProductsRequest{Content: "code,品名\n49021028,牛乳\n"})
+		assert.Nil(t, result, name)
+		assert.ErrorIs(t, err, domain.ErrForbidden, name)
+	}
+
+	mockRepo.AssertNotCalled(t, "Import", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestCreateIngredient_FillsFromProduct tests that a scanned barcode fills in the fields left empty
+func TestCreateIngredient_FillsFromProduct(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	mockProducts := new(MockProductRepository)
+	usecase := NewIngredientUsecase(mockRepo, mockProducts, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+	shelfLife := 7
+	product := &domain.Product{Barcode: "49021028", Name: "牛乳", Category: "dairy", Unit: "1000ml", ShelfLifeDays: &shelfLife, Source: domain.ProductSourceImport}
+	mockProducts.On("GetByBarcode", mock.Anything, "49021028").Return(product, nil)
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Ingredient")).Return(nil)
+
+	purchaseDate := "2026-10-10"
+	result, err := usecase.CreateIngredient(context.Background(), CreateIngredientRequest{
+		Barcode:      "4902-1028",
+		PurchaseDate: &purchaseDate,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "牛乳", result.Name)
+	assert.Equal(t, "dairy", result.Category)
+	assert.Equal(t, "1000ml", result.Quantity)
+	assert.Equal(t, "49021028", result.Barcode)
+	assert.Equal(t, time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC), *result.ExpiresAt)
+
+	// Imported products are not replaced by what the user entered
+	mockProducts.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestCreateIngredient_LearnsProduct tests that the first entry of an unknown barcode teaches the product
+func TestCreateIngredient_LearnsProduct(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	mockProducts := new(MockProductRepository)
+	usecase := NewIngredientUsecase(mockRepo, mockProducts, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+	mockProducts.On("GetByBarcode", mock.Anything, "4901234567894").Return(nil, fmt.Errorf("product not found: %w", sql.ErrNoRows))
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Ingredient")).Return(nil)
+
+	var learned *domain.Product
+	mockProducts.On("Save", mock.Anything, mock.AnythingOfType("*domain.Product")).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			learned = args.Get(1).(*domain.Product)
+		})
+
+	purchaseDate := "2026-10-10"
+	expiresAt := "2026-10-13"
+	_, err := usecase.CreateIngredient(context.Background(), CreateIngredientRequest{
+		Name:         "にんじん",
+		Quantity:     "3本",
+		Barcode:      "4901234567894",
+		PurchaseDate: &purchaseDate,
+		ExpiresAt:    &expiresAt,
+	})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, learned)
+	assert.Equal(t, "にんじん", learned.Name)
+	assert.Equal(t, "vegetable", learned.Category)
+	assert.Equal(t, "3本", learned.Unit)
+	assert.Equal(t, 3, *learned.ShelfLifeDays)
+	assert.Equal(t, domain.ProductSourceLearned, learned.Source)
+	mockProducts.AssertExpectations(t)
+}
+
+// TestCreateIngredient_InvalidBarcode tests that a barcode with a wrong check digit is rejected
+func TestCreateIngredient_InvalidBarcode(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	mockProducts := new(MockProductRepository)
+	usecase := NewIngredientUsecase(mockRepo, mockProducts, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+	_, err := usecase.CreateIngredient(context.Background(), CreateIngredientRequest{Name: "牛乳", Barcode: "49021029"})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+// TestImportIngredients_FillsFromProduct tests that imported rows keep their barcode and fill empty cells from the product
+func TestImportIngredients_FillsFromProduct(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	mockProducts := new(MockProductRepository)
+	usecase := NewIngredientUsecase(mockRepo, mockProducts, newTestNormalizer(t), service.NewInventoryCodec(), nil)
+
+	product := &domain.Product{Barcode: "49021028", Name: "牛乳", Category: "dairy", Unit: "1000ml", Source: domain.ProductSourceImport}
+	mockProducts.On("GetByBarcode", mock.Anything, "49021028").Return(product, nil)
+	mockRepo.On("GetAll", mock.Anything).Return([]*domain.Ingredient{}, nil)
+
+	result, err := usecase.ImportIngredients(context.Background(), ImportIngredientsRequest{
+		Content: "品名,JANコード\n,49021028\n",
+		DryRun:  true,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "JANコード", result.Mapping["barcode"])
+	assert.Equal(t, 1, result.Created)
+	assert.Equal(t, "牛乳", result.Rows[0].Ingredient.Name)
+	assert.Equal(t, "1000ml", result.Rows[0].Ingredient.Quantity)
+	assert.Equal(t, "49021028", result.Rows[0].Ingredient.Barcode)
+}
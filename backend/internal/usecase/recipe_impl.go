@@ -3,26 +3,64 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sort"
 
 	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
 	"github.com/Rin0530/DinnerDecider/backend/internal/repository"
 	"github.com/Rin0530/DinnerDecider/backend/internal/service"
+	"github.com/Rin0530/DinnerDecider/backend/pkg/logger"
+)
+
+// Catalog grounding limits
+const (
+	// maxGroundingRecipes is the maximum number of catalog recipes passed to the LLM
+	maxGroundingRecipes = 5
+
+	// minGroundingCoverage is the minimum fraction of a recipe's ingredients that must be in stock
+	minGroundingCoverage = 0.5
 )
 
 // recipeUsecase implements the RecipeUsecase interface
 type recipeUsecase struct {
 	ingredientRepo repository.IngredientRepository
+	recipeRepo     repository.RecipeRepository
+	suggestionRepo repository.SuggestionRepository
+	profileRepo    repository.DietaryProfileRepository
 	ollamaService  service.OllamaService
+	fallback       service.FallbackRecommender
+	matcher        service.IngredientMatcher
+	dietary        service.DietaryChecker
+	nutrition      service.NutritionTable
 }
 
-// NewRecipeUsecase creates a new instance of RecipeUsecase
+// NewRecipeUsecase creates a new instance of RecipeUsecase.
+// recipeRepo may be nil to disable grounding suggestions in catalog recipes, suggestionRepo may be nil
+// to stop logging suggestions, and fallback may be nil, in which case Ollama errors are returned to the caller.
+// profileRepo may be nil to ignore dietary profiles; otherwise dietary validates every suggestion against them.
+// matcher is used to verify the LLM's missing items against the inventory, and nutrition, which may be nil,
+// estimates the nutrition per serving of each suggestion.
 func NewRecipeUsecase(
 	ingredientRepo repository.IngredientRepository,
+	recipeRepo repository.RecipeRepository,
+	suggestionRepo repository.SuggestionRepository,
+	profileRepo repository.DietaryProfileRepository,
 	ollamaService service.OllamaService,
+	fallback service.FallbackRecommender,
+	matcher service.IngredientMatcher,
+	dietary service.DietaryChecker,
+	nutrition service.NutritionTable,
 ) RecipeUsecase {
 	return &recipeUsecase{
 		ingredientRepo: ingredientRepo,
+		recipeRepo:     recipeRepo,
+		suggestionRepo: suggestionRepo,
+		profileRepo:    profileRepo,
 		ollamaService:  ollamaService,
+		fallback:       fallback,
+		matcher:        matcher,
+		dietary:        dietary,
+		nutrition:      nutrition,
 	}
 }
 
@@ -39,11 +77,163 @@ func (u *recipeUsecase) GetRecipeSuggestion(ctx context.Context) (*domain.Recipe
 		ingredients = []*domain.Ingredient{}
 	}
 
+	// Dietary profiles are safety constraints, so suggesting without them is not an option
+	opts := service.SuggestionOptions{}
+	if u.profileRepo != nil {
+		profiles, err := u.profileRepo.GetAll(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get dietary profiles: %w", err)
+		}
+		opts.DietaryProfiles = profiles
+	}
+
+	// Ground the suggestions in household catalog recipes that the refrigerator allows
+	if u.recipeRepo != nil {
+		catalogRecipes, err := u.recipeRepo.Search(ctx, repository.RecipeFilter{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get catalog recipes: %w", err)
+		}
+		opts.CatalogRecipes = u.selectGroundingRecipes(catalogRecipes, ingredients, opts.DietaryProfiles)
+	}
+
 	// Generate recipe suggestions using Ollama service
-	recipeResponse, err := u.ollamaService.GenerateRecipeSuggestion(ctx, ingredients)
+	recipeResponse, err := u.ollamaService.GenerateRecipeSuggestion(ctx, ingredients, opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate recipe suggestion: %w", err)
+		if u.fallback == nil {
+			return nil, fmt.Errorf("failed to generate recipe suggestion: %w", err)
+		}
+
+		// Fall back to the local catalog so suggestions do not depend on the LLM
+		logger.WithError(err).Warn("Ollama unavailable, using catalog recommender")
+		fallbackResponse, fallbackErr := u.fallback.Recommend(ctx, ingredients)
+		if fallbackErr != nil {
+			return nil, fmt.Errorf("failed to generate recipe suggestion: %w (fallback: %v)", err, fallbackErr)
+		}
+		u.enforceDietaryProfiles(fallbackResponse, opts.DietaryProfiles)
+		u.estimateNutrition(fallbackResponse)
+		u.recordSuggestions(ctx, fallbackResponse)
+		return fallbackResponse, nil
 	}
 
+	// The LLM often lists ingredients that are already in stock as missing
+	u.matcher.Reconcile(recipeResponse, ingredients)
+
+	recipeResponse.Source = domain.RecipeSourceLLM
+	u.enforceDietaryProfiles(recipeResponse, opts.DietaryProfiles)
+	u.estimateNutrition(recipeResponse)
+	u.recordSuggestions(ctx, recipeResponse)
 	return recipeResponse, nil
 }
+
+// enforceDietaryProfiles drops the suggestions that break an allergen or restriction of a profile, listing them
+// as excluded, and flags the disliked ingredients of the suggestions kept. The LLM is told about the profiles,
+// but its answers are never trusted to respect them.
+func (u *recipeUsecase) enforceDietaryProfiles(response *domain.RecipeResponse, profiles []*domain.DietaryProfile) {
+	response.Excluded = nil
+	if len(profiles) == 0 || u.dietary == nil {
+		return
+	}
+
+	kept := make([]domain.RecipeSuggestion, 0, len(response.Suggestions))
+	for _, suggestion := range response.Suggestions {
+		violations := u.dietary.Check(&suggestion, profiles)
+
+		var hard, warnings []domain.DietaryViolation
+		for _, v := range violations {
+			if v.IsHard() {
+				hard = append(hard, v)
+			} else {
+				warnings = append(warnings, v)
+			}
+		}
+
+		if len(hard) > 0 {
+			logger.WithField("recipe", suggestion.Name).Warn("Dropped recipe suggestion breaking a dietary profile")
+			response.Excluded = append(response.Excluded, domain.ExcludedSuggestion{Name: suggestion.Name, Violations: hard})
+			continue
+		}
+
+		suggestion.DietaryWarnings = warnings
+		kept = append(kept, suggestion)
+	}
+	response.Suggestions = kept
+}
+
+// estimateNutrition adds a nutrition estimate to each suggestion with ingredient quantities
+func (u *recipeUsecase) estimateNutrition(response *domain.RecipeResponse) {
+	if u.nutrition == nil {
+		return
+	}
+
+	for i := range response.Suggestions {
+		suggestion := &response.Suggestions[i]
+		suggestion.Nutrition = u.nutrition.EstimateRecipe(suggestion.Ingredients, suggestion.Servings)
+		if suggestion.Nutrition != nil {
+			suggestion.Servings = suggestion.Nutrition.Servings
+		}
+	}
+}
+
+// recordSuggestions logs the suggestions so cooking them can be measured.
+// Logging is best effort and never fails the suggestion itself.
+func (u *recipeUsecase) recordSuggestions(ctx context.Context, response *domain.RecipeResponse) {
+	if u.suggestionRepo == nil {
+		return
+	}
+
+	if err := u.suggestionRepo.Record(ctx, response); err != nil {
+		logger.WithError(err).Warn("Failed to record recipe suggestions")
+	}
+}
+
+// selectGroundingRecipes picks the catalog recipes best covered by the available ingredients,
+// leaving out those that break an allergen or restriction of the profiles
+func (u *recipeUsecase) selectGroundingRecipes(recipes []*domain.Recipe, ingredients []*domain.Ingredient, profiles []*domain.DietaryProfile) []*domain.Recipe {
+	type candidate struct {
+		recipe   *domain.Recipe
+		coverage float64
+	}
+
+	var candidates []candidate
+	for _, recipe := range recipes {
+		if len(recipe.Ingredients) == 0 || u.breaksDietaryProfiles(recipe, profiles) {
+			continue
+		}
+
+		matched := 0
+		for _, ri := range recipe.Ingredients {
+			if u.matcher.Find(ri.Name, ingredients) != nil {
+				matched++
+			}
+		}
+
+		coverage := float64(matched) / float64(len(recipe.Ingredients))
+		if coverage >= minGroundingCoverage {
+			candidates = append(candidates, candidate{recipe: recipe, coverage: coverage})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].coverage > candidates[j].coverage
+	})
+
+	if len(candidates) > maxGroundingRecipes {
+		candidates = candidates[:maxGroundingRecipes]
+	}
+
+	selected := make([]*domain.Recipe, 0, len(candidates))
+	for _, c := range candidates {
+		selected = append(selected, c.recipe)
+	}
+	return selected
+}
+
+// breaksDietaryProfiles reports whether a catalog recipe breaks an allergen or restriction of the profiles
+func (u *recipeUsecase) breaksDietaryProfiles(recipe *domain.Recipe, profiles []*domain.DietaryProfile) bool {
+	if len(profiles) == 0 || u.dietary == nil {
+		return false
+	}
+
+	suggestion := &domain.RecipeSuggestion{Name: recipe.Name, Ingredients: recipe.Ingredients}
+	return slices.ContainsFunc(u.dietary.Check(suggestion, profiles), domain.DietaryViolation.IsHard)
+}
@@ -7,6 +7,9 @@ import (
 	"time"
 
 	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/internal/repository"
+	"github.com/Rin0530/DinnerDecider/backend/internal/service"
+	"github.com/Rin0530/DinnerDecider/backend/pkg/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -16,7 +19,28 @@ type MockOllamaService struct {
 	mock.Mock
 }
 
-func (m *MockOllamaService) GenerateRecipeSuggestion(ctx context.Context, ingredients []*domain.Ingredient) (*domain.RecipeResponse, error) {
+func (m *MockOllamaService) GenerateRecipeSuggestion(ctx context.Context, ingredients []*domain.Ingredient, opts service.SuggestionOptions) (*domain.RecipeResponse, error) {
+	args := m.Called(ctx, ingredients, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.RecipeResponse), args.Error(1)
+}
+
+func (m *MockOllamaService) ExtractIngredients(ctx context.Context, text string, today time.Time) (*domain.ParsedIngredients, error) {
+	args := m.Called(ctx, text, today)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ParsedIngredients), args.Error(1)
+}
+
+// MockFallbackRecommender is a mock implementation of FallbackRecommender
+type MockFallbackRecommender struct {
+	mock.Mock
+}
+
+func (m *MockFallbackRecommender) Recommend(ctx context.Context, ingredients []*domain.Ingredient) (*domain.RecipeResponse, error) {
 	args := m.Called(ctx, ingredients)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -24,11 +48,52 @@ func (m *MockOllamaService) GenerateRecipeSuggestion(ctx context.Context, ingred
 	return args.Get(0).(*domain.RecipeResponse), args.Error(1)
 }
 
+// MockSuggestionRepository is a mock implementation of SuggestionRepository
+type MockSuggestionRepository struct {
+	mock.Mock
+}
+
+func (m *MockSuggestionRepository) Record(ctx context.Context, response *domain.RecipeResponse) error {
+	args := m.Called(ctx, response)
+	return args.Error(0)
+}
+
+func (m *MockSuggestionRepository) Exists(ctx context.Context, id int64) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+// newTestMatcher creates an ingredient matcher backed by the bundled dictionary
+func newTestMatcher(t *testing.T) service.IngredientMatcher {
+	t.Helper()
+	return service.NewIngredientMatcher(newTestNormalizer(t))
+}
+
+// newTestDietaryChecker creates a dietary checker backed by the bundled allergen dictionary and dictionary
+func newTestDietaryChecker(t *testing.T) service.DietaryChecker {
+	t.Helper()
+	checker, err := service.NewDietaryChecker(&config.DietaryConfig{}, newTestNormalizer(t))
+	if err != nil {
+		t.Fatalf("Failed to load allergen dictionary: %v", err)
+	}
+	return checker
+}
+
+// newTestNutritionTable creates a nutrition table backed by the bundled table and dictionary
+func newTestNutritionTable(t *testing.T) service.NutritionTable {
+	t.Helper()
+	table, err := service.NewNutritionTable(&config.NutritionConfig{}, newTestNormalizer(t))
+	if err != nil {
+		t.Fatalf("Failed to load nutrition table: %v", err)
+	}
+	return table
+}
+
 // TestGetRecipeSuggestion_Success tests successful recipe suggestion generation
 func TestGetRecipeSuggestion_Success(t *testing.T) {
 	mockRepo := new(MockIngredientRepository)
 	mockService := new(MockOllamaService)
-	usecase := NewRecipeUsecase(mockRepo, mockService)
+	usecase := NewRecipeUsecase(mockRepo, nil, nil, nil, mockService, nil, newTestMatcher(t), nil, nil)
 
 	now := time.Now()
 	purchaseDate := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
@@ -66,7 +131,7 @@ func TestGetRecipeSuggestion_Success(t *testing.T) {
 	}
 
 	mockRepo.On("GetAll", mock.Anything).Return(mockIngredients, nil)
-	mockService.On("GenerateRecipeSuggestion", mock.Anything, mockIngredients).
+	mockService.On("GenerateRecipeSuggestion", mock.Anything, mockIngredients, service.SuggestionOptions{}).
 		Return(mockRecipeResponse, nil)
 
 	result, err := usecase.GetRecipeSuggestion(context.Background())
@@ -76,6 +141,46 @@ func TestGetRecipeSuggestion_Success(t *testing.T) {
 	assert.Len(t, result.Suggestions, 2)
 	assert.Equal(t, "カレーライス", result.Suggestions[0].Name)
 	assert.Equal(t, "豚汁", result.Suggestions[1].Name)
+	assert.Equal(t, domain.RecipeSourceLLM, result.Source)
+	mockRepo.AssertExpectations(t)
+	mockService.AssertExpectations(t)
+}
+
+// TestGetRecipeSuggestion_CorrectsMissingItems tests that in-stock items are removed from missing items
+func TestGetRecipeSuggestion_CorrectsMissingItems(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	mockService := new(MockOllamaService)
+	usecase := NewRecipeUsecase(mockRepo, nil, nil, nil, mockService, nil, newTestMatcher(t), nil, nil)
+
+	mockIngredients := []*domain.Ingredient{
+		{ID: 1, Name: "タマネギ", Quantity: "3個"},
+		{ID: 2, Name: "豚こま", Quantity: "300g"},
+	}
+
+	mockRecipeResponse := &domain.RecipeResponse{
+		Suggestions: []domain.RecipeSuggestion{
+			{
+				Name: "豚の生姜焼き",
+				Ingredients: []domain.RecipeIngredient{
+					{Name: "豚肉", Quantity: "200g"},
+					{Name: "玉ねぎ", Quantity: "1/2個"},
+					{Name: "生姜", Quantity: "1かけ"},
+				},
+				Steps:        []string{"玉ねぎを切る", "豚肉を焼く", "タレを絡める"},
+				MissingItems: []string{"たまねぎ", "生姜"},
+			},
+		},
+	}
+
+	mockRepo.On("GetAll", mock.Anything).Return(mockIngredients, nil)
+	mockService.On("GenerateRecipeSuggestion", mock.Anything, mockIngredients, service.SuggestionOptions{}).
+		Return(mockRecipeResponse, nil)
+
+	result, err := usecase.GetRecipeSuggestion(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"豚肉", "玉ねぎ"}, result.Suggestions[0].AvailableItems)
+	assert.Equal(t, []string{"生姜"}, result.Suggestions[0].MissingItems)
 	mockRepo.AssertExpectations(t)
 	mockService.AssertExpectations(t)
 }
@@ -84,7 +189,7 @@ func TestGetRecipeSuggestion_Success(t *testing.T) {
 func TestGetRecipeSuggestion_EmptyIngredients(t *testing.T) {
 	mockRepo := new(MockIngredientRepository)
 	mockService := new(MockOllamaService)
-	usecase := NewRecipeUsecase(mockRepo, mockService)
+	usecase := NewRecipeUsecase(mockRepo, nil, nil, nil, mockService, nil, newTestMatcher(t), nil, nil)
 
 	mockRecipeResponse := &domain.RecipeResponse{
 		Suggestions: []domain.RecipeSuggestion{
@@ -97,7 +202,7 @@ func TestGetRecipeSuggestion_EmptyIngredients(t *testing.T) {
 	}
 
 	mockRepo.On("GetAll", mock.Anything).Return([]*domain.Ingredient{}, nil)
-	mockService.On("GenerateRecipeSuggestion", mock.Anything, []*domain.Ingredient{}).
+	mockService.On("GenerateRecipeSuggestion", mock.Anything, []*domain.Ingredient{}, service.SuggestionOptions{}).
 		Return(mockRecipeResponse, nil)
 
 	result, err := usecase.GetRecipeSuggestion(context.Background())
@@ -113,7 +218,7 @@ func TestGetRecipeSuggestion_EmptyIngredients(t *testing.T) {
 func TestGetRecipeSuggestion_NilIngredients(t *testing.T) {
 	mockRepo := new(MockIngredientRepository)
 	mockService := new(MockOllamaService)
-	usecase := NewRecipeUsecase(mockRepo, mockService)
+	usecase := NewRecipeUsecase(mockRepo, nil, nil, nil, mockService, nil, newTestMatcher(t), nil, nil)
 
 	mockRecipeResponse := &domain.RecipeResponse{
 		Suggestions: []domain.RecipeSuggestion{
@@ -126,7 +231,7 @@ func TestGetRecipeSuggestion_NilIngredients(t *testing.T) {
 	}
 
 	mockRepo.On("GetAll", mock.Anything).Return(nil, nil)
-	mockService.On("GenerateRecipeSuggestion", mock.Anything, []*domain.Ingredient{}).
+	mockService.On("GenerateRecipeSuggestion", mock.Anything, []*domain.Ingredient{}, service.SuggestionOptions{}).
 		Return(mockRecipeResponse, nil)
 
 	result, err := usecase.GetRecipeSuggestion(context.Background())
@@ -141,7 +246,7 @@ func TestGetRecipeSuggestion_NilIngredients(t *testing.T) {
 func TestGetRecipeSuggestion_RepositoryError(t *testing.T) {
 	mockRepo := new(MockIngredientRepository)
 	mockService := new(MockOllamaService)
-	usecase := NewRecipeUsecase(mockRepo, mockService)
+	usecase := NewRecipeUsecase(mockRepo, nil, nil, nil, mockService, nil, newTestMatcher(t), nil, nil)
 
 	mockRepo.On("GetAll", mock.Anything).Return(nil, errors.New("database error"))
 
@@ -158,7 +263,7 @@ func TestGetRecipeSuggestion_RepositoryError(t *testing.T) {
 func TestGetRecipeSuggestion_ServiceError(t *testing.T) {
 	mockRepo := new(MockIngredientRepository)
 	mockService := new(MockOllamaService)
-	usecase := NewRecipeUsecase(mockRepo, mockService)
+	usecase := NewRecipeUsecase(mockRepo, nil, nil, nil, mockService, nil, newTestMatcher(t), nil, nil)
 
 	now := time.Now()
 	mockIngredients := []*domain.Ingredient{
@@ -172,7 +277,7 @@ func TestGetRecipeSuggestion_ServiceError(t *testing.T) {
 	}
 
 	mockRepo.On("GetAll", mock.Anything).Return(mockIngredients, nil)
-	mockService.On("GenerateRecipeSuggestion", mock.Anything, mockIngredients).
+	mockService.On("GenerateRecipeSuggestion", mock.Anything, mockIngredients, service.SuggestionOptions{}).
 		Return(nil, errors.New("ollama service unavailable"))
 
 	result, err := usecase.GetRecipeSuggestion(context.Background())
@@ -183,3 +288,313 @@ func TestGetRecipeSuggestion_ServiceError(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 	mockService.AssertExpectations(t)
 }
+
+// TestGetRecipeSuggestion_FallbackOnServiceError tests that the catalog recommender is used when Ollama fails
+func TestGetRecipeSuggestion_FallbackOnServiceError(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	mockService := new(MockOllamaService)
+	mockFallback := new(MockFallbackRecommender)
+	usecase := NewRecipeUsecase(mockRepo, nil, nil, nil, mockService, mockFallback, newTestMatcher(t), nil, nil)
+
+	mockIngredients := []*domain.Ingredient{
+		{ID: 1, Name: "卵", Quantity: "6個"},
+	}
+
+	fallbackResponse := &domain.RecipeResponse{
+		Suggestions: []domain.RecipeSuggestion{
+			{Name: "卵焼き", Steps: []string{"卵を溶く", "焼く"}, MissingItems: []string{}},
+		},
+		Source: domain.RecipeSourceCatalog,
+	}
+
+	mockRepo.On("GetAll", mock.Anything).Return(mockIngredients, nil)
+	mockService.On("GenerateRecipeSuggestion", mock.Anything, mockIngredients, service.SuggestionOptions{}).
+		Return(nil, errors.New("ollama service unavailable"))
+	mockFallback.On("Recommend", mock.Anything, mockIngredients).Return(fallbackResponse, nil)
+
+	result, err := usecase.GetRecipeSuggestion(context.Background())
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, domain.RecipeSourceCatalog, result.Source)
+	assert.Equal(t, "卵焼き", result.Suggestions[0].Name)
+	mockRepo.AssertExpectations(t)
+	mockService.AssertExpectations(t)
+	mockFallback.AssertExpectations(t)
+}
+
+// TestGetRecipeSuggestion_FallbackError tests error handling when both Ollama and the fallback fail
+func TestGetRecipeSuggestion_FallbackError(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	mockService := new(MockOllamaService)
+	mockFallback := new(MockFallbackRecommender)
+	usecase := NewRecipeUsecase(mockRepo, nil, nil, nil, mockService, mockFallback, newTestMatcher(t), nil, nil)
+
+	mockRepo.On("GetAll", mock.Anything).Return([]*domain.Ingredient{}, nil)
+	mockService.On("GenerateRecipeSuggestion", mock.Anything, []*domain.Ingredient{}, service.SuggestionOptions{}).
+		Return(nil, errors.New("ollama service unavailable"))
+	mockFallback.On("Recommend", mock.Anything, []*domain.Ingredient{}).
+		Return(nil, errors.New("catalog error"))
+
+	result, err := usecase.GetRecipeSuggestion(context.Background())
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "ollama service unavailable")
+	mockFallback.AssertExpectations(t)
+}
+
+// TestGetRecipeSuggestion_GroundsInCatalog tests that well-covered catalog recipes are passed to the LLM
+func TestGetRecipeSuggestion_GroundsInCatalog(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	mockRecipeRepo := new(MockRecipeRepository)
+	mockService := new(MockOllamaService)
+	usecase := NewRecipeUsecase(mockRepo, mockRecipeRepo, nil, nil, mockService, nil, newTestMatcher(t), nil, nil)
+
+	mockIngredients := []*domain.Ingredient{
+		{ID: 1, Name: "鶏もも肉"},
+		{ID: 2, Name: "卵"},
+	}
+
+	oyakodon := &domain.Recipe{
+		ID:   1,
+		Name: "うちの親子丼",
+		Ingredients: []domain.RecipeIngredient{
+			{Name: "鶏もも肉"}, {Name: "卵"}, {Name: "玉ねぎ"},
+		},
+	}
+	salmon := &domain.Recipe{
+		ID:   2,
+		Name: "鮭のホイル焼き",
+		Ingredients: []domain.RecipeIngredient{
+			{Name: "鮭"}, {Name: "しめじ"},
+		},
+	}
+
+	mockResponse := &domain.RecipeResponse{
+		Suggestions: []domain.RecipeSuggestion{{Name: "うちの親子丼"}},
+	}
+
+	mockRepo.On("GetAll", mock.Anything).Return(mockIngredients, nil)
+	mockRecipeRepo.On("Search", mock.Anything, repository.RecipeFilter{}).
+		Return([]*domain.Recipe{oyakodon, salmon}, nil)
+	mockService.On("GenerateRecipeSuggestion", mock.Anything, mockIngredients,
+		service.SuggestionOptions{CatalogRecipes: []*domain.Recipe{oyakodon}}).
+		Return(mockResponse, nil)
+
+	result, err := usecase.GetRecipeSuggestion(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "うちの親子丼", result.Suggestions[0].Name)
+	mockRecipeRepo.AssertExpectations(t)
+	mockService.AssertExpectations(t)
+}
+
+// TestGetRecipeSuggestion_CatalogError tests error handling when the catalog cannot be read
+func TestGetRecipeSuggestion_CatalogError(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	mockRecipeRepo := new(MockRecipeRepository)
+	mockService := new(MockOllamaService)
+	usecase := NewRecipeUsecase(mockRepo, mockRecipeRepo, nil, nil, mockService, nil, newTestMatcher(t), nil, nil)
+
+	mockRepo.On("GetAll", mock.Anything).Return([]*domain.Ingredient{}, nil)
+	mockRecipeRepo.On("Search", mock.Anything, repository.RecipeFilter{}).
+		Return(nil, errors.New("database error"))
+
+	result, err := usecase.GetRecipeSuggestion(context.Background())
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "failed to get catalog recipes")
+	mockService.AssertNotCalled(t, "GenerateRecipeSuggestion")
+}
+
+// TestGetRecipeSuggestion_RecordsSuggestions tests that suggestions are logged and returned with their IDs
+func TestGetRecipeSuggestion_RecordsSuggestions(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	mockService := new(MockOllamaService)
+	mockSuggestions := new(MockSuggestionRepository)
+	usecase := NewRecipeUsecase(mockRepo, nil, mockSuggestions, nil, mockService, nil, newTestMatcher(t), nil, nil)
+
+	mockRepo.On("GetAll", mock.Anything).Return([]*domain.Ingredient{}, nil)
+	mockService.On("GenerateRecipeSuggestion", mock.Anything, mock.Anything, mock.Anything).
+		Return(&domain.RecipeResponse{Suggestions: []domain.RecipeSuggestion{{Name: "豚汁"}}}, nil)
+	mockSuggestions.On("Record", mock.Anything, mock.MatchedBy(func(response *domain.RecipeResponse) bool {
+		return response.Source == domain.RecipeSourceLLM
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.RecipeResponse).Suggestions[0].ID = 12
+	}).Return(nil)
+
+	result, err := usecase.GetRecipeSuggestion(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), result.Suggestions[0].ID)
+	mockSuggestions.AssertExpectations(t)
+}
+
+// TestGetRecipeSuggestion_RecordError tests that failing to log suggestions does not fail the request
+func TestGetRecipeSuggestion_RecordError(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	mockService := new(MockOllamaService)
+	mockSuggestions := new(MockSuggestionRepository)
+	usecase := NewRecipeUsecase(mockRepo, nil, mockSuggestions, nil, mockService, nil, newTestMatcher(t), nil, nil)
+
+	mockRepo.On("GetAll", mock.Anything).Return([]*domain.Ingredient{}, nil)
+	mockService.On("GenerateRecipeSuggestion", mock.Anything, mock.Anything, mock.Anything).
+		Return(&domain.RecipeResponse{Suggestions: []domain.RecipeSuggestion{{Name: "豚汁"}}}, nil)
+	mockSuggestions.On("Record", mock.Anything, mock.Anything).Return(errors.New("database is down"))
+
+	result, err := usecase.GetRecipeSuggestion(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Suggestions, 1)
+	assert.Zero(t, result.Suggestions[0].ID)
+}
+
+// TestGetRecipeSuggestion_EstimatesNutrition tests that suggestions carry a nutrition estimate per serving
+func TestGetRecipeSuggestion_EstimatesNutrition(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	mockService := new(MockOllamaService)
+	usecase := NewRecipeUsecase(mockRepo, nil, nil, nil, mockService, nil, newTestMatcher(t), nil, newTestNutritionTable(t))
+
+	mockRepo.On("GetAll", mock.Anything).Return([]*domain.Ingredient{}, nil)
+	mockService.On("GenerateRecipeSuggestion", mock.Anything, mock.Anything, mock.Anything).
+		Return(&domain.RecipeResponse{Suggestions: []domain.RecipeSuggestion{
+			{
+				Name:     "目玉焼き",
+				Servings: 1,
+				Ingredients: []domain.RecipeIngredient{
+					{Name: "卵", Quantity: "2個"},
+					{Name: "塩", Quantity: "少々"},
+				},
+			},
+			{Name: "おまかせ", Ingredients: []domain.RecipeIngredient{{Name: "塩", Quantity: "適量"}}},
+		}}, nil)
+
+	result, err := usecase.GetRecipeSuggestion(context.Background())
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result.Suggestions[0].Nutrition)
+	assert.Equal(t, 1, result.Suggestions[0].Servings)
+	assert.Equal(t, 142.0, result.Suggestions[0].Nutrition.PerServing.EnergyKcal)
+	assert.Equal(t, []string{"卵"}, result.Suggestions[0].Nutrition.Counted)
+	assert.Equal(t, []string{"塩"}, result.Suggestions[0].Nutrition.Uncounted)
+	assert.Nil(t, result.Suggestions[1].Nutrition)
+	assert.Equal(t, 0, result.Suggestions[1].Servings)
+}
+
+// TestGetRecipeSuggestion_EnforcesDietaryProfiles tests that suggestions breaking an allergy are dropped
+// and disliked ingredients are flagged
+func TestGetRecipeSuggestion_EnforcesDietaryProfiles(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	mockProfiles := new(MockDietaryProfileRepository)
+	mockService := new(MockOllamaService)
+	mockSuggestions := new(MockSuggestionRepository)
+	usecase := NewRecipeUsecase(mockRepo, nil, mockSuggestions, mockProfiles, mockService, nil, newTestMatcher(t), newTestDietaryChecker(t), nil)
+
+	profiles := []*domain.DietaryProfile{
+		{Name: "太郎", Allergens: []string{domain.AllergenPeanut}, Dislikes: []string{"ピーマン"}},
+	}
+
+	mockRepo.On("GetAll", mock.Anything).Return([]*domain.Ingredient{}, nil)
+	mockProfiles.On("GetAll", mock.Anything).Return(profiles, nil)
+	mockService.On("GenerateRecipeSuggestion", mock.Anything, mock.Anything, service.SuggestionOptions{DietaryProfiles: profiles}).
+		Return(&domain.RecipeResponse{Suggestions: []domain.RecipeSuggestion{
+			{
+				Name:         "ピーナッツ和え",
+				Ingredients:  []domain.RecipeIngredient{{Name: "ほうれん草", Quantity: "1束"}},
+				MissingItems: []string{"ピーナッツ"},
+			},
+			{
+				Name:        "ピーマンの炒め物",
+				Ingredients: []domain.RecipeIngredient{{Name: "ピーマン", Quantity: "3個"}},
+			},
+		}}, nil)
+	mockSuggestions.On("Record", mock.Anything, mock.MatchedBy(func(response *domain.RecipeResponse) bool {
+		return len(response.Suggestions) == 1
+	})).Return(nil)
+
+	result, err := usecase.GetRecipeSuggestion(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Suggestions, 1)
+	assert.Equal(t, "ピーマンの炒め物", result.Suggestions[0].Name)
+	assert.Len(t, result.Suggestions[0].DietaryWarnings, 2)
+	assert.Equal(t, domain.ViolationDislike, result.Suggestions[0].DietaryWarnings[0].Kind)
+	assert.Len(t, result.Excluded, 1)
+	assert.Equal(t, "ピーナッツ和え", result.Excluded[0].Name)
+	assert.Equal(t, domain.AllergenPeanut, result.Excluded[0].Violations[0].Code)
+	mockSuggestions.AssertExpectations(t)
+}
+
+// TestGetRecipeSuggestion_EnforcesDietaryProfilesOnFallback tests that catalog suggestions are validated too
+func TestGetRecipeSuggestion_EnforcesDietaryProfilesOnFallback(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	mockProfiles := new(MockDietaryProfileRepository)
+	mockService := new(MockOllamaService)
+	mockFallback := new(MockFallbackRecommender)
+	usecase := NewRecipeUsecase(mockRepo, nil, nil, mockProfiles, mockService, mockFallback, newTestMatcher(t), newTestDietaryChecker(t), nil)
+
+	mockRepo.On("GetAll", mock.Anything).Return([]*domain.Ingredient{}, nil)
+	mockProfiles.On("GetAll", mock.Anything).
+		Return([]*domain.DietaryProfile{{Name: "花子", Restrictions: []string{domain.RestrictionVegetarian}}}, nil)
+	mockService.On("GenerateRecipeSuggestion", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("ollama service unavailable"))
+	mockFallback.On("Recommend", mock.Anything, mock.Anything).Return(&domain.RecipeResponse{
+		Suggestions: []domain.RecipeSuggestion{
+			{Name: "肉じゃが", Ingredients: []domain.RecipeIngredient{{Name: "豚肉"}, {Name: "じゃがいも"}}},
+			{Name: "卵焼き", Ingredients: []domain.RecipeIngredient{{Name: "卵"}}},
+		},
+		Source: domain.RecipeSourceCatalog,
+	}, nil)
+
+	result, err := usecase.GetRecipeSuggestion(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Suggestions, 1)
+	assert.Equal(t, "卵焼き", result.Suggestions[0].Name)
+	assert.Equal(t, "肉じゃが", result.Excluded[0].Name)
+	assert.Equal(t, domain.ViolationRestriction, result.Excluded[0].Violations[0].Kind)
+}
+
+// TestGetRecipeSuggestion_SkipsGroundingRecipesBreakingProfiles tests that catalog recipes breaking an allergy are not offered to the LLM
+func TestGetRecipeSuggestion_SkipsGroundingRecipesBreakingProfiles(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	mockRecipeRepo := new(MockRecipeRepository)
+	mockProfiles := new(MockDietaryProfileRepository)
+	mockService := new(MockOllamaService)
+	usecase := NewRecipeUsecase(mockRepo, mockRecipeRepo, nil, mockProfiles, mockService, nil, newTestMatcher(t), newTestDietaryChecker(t), nil)
+
+	mockIngredients := []*domain.Ingredient{{ID: 1, Name: "鶏もも肉"}, {ID: 2, Name: "卵"}}
+	oyakodon := &domain.Recipe{ID: 1, Name: "うちの親子丼", Ingredients: []domain.RecipeIngredient{{Name: "鶏もも肉"}, {Name: "卵"}}}
+	profiles := []*domain.DietaryProfile{{Name: "太郎", Allergens: []string{domain.AllergenEgg}}}
+
+	mockRepo.On("GetAll", mock.Anything).Return(mockIngredients, nil)
+	mockProfiles.On("GetAll", mock.Anything).Return(profiles, nil)
+	mockRecipeRepo.On("Search", mock.Anything, repository.RecipeFilter{}).Return([]*domain.Recipe{oyakodon}, nil)
+	mockService.On("GenerateRecipeSuggestion", mock.Anything, mockIngredients,
+		service.SuggestionOptions{CatalogRecipes: []*domain.Recipe{}, DietaryProfiles: profiles}).
+		Return(&domain.RecipeResponse{Suggestions: []domain.RecipeSuggestion{}}, nil)
+
+	_, err := usecase.GetRecipeSuggestion(context.Background())
+
+	assert.NoError(t, err)
+	mockService.AssertExpectations(t)
+}
+
+// TestGetRecipeSuggestion_DietaryProfilesError tests that suggestions are refused when the profiles cannot be loaded
+func TestGetRecipeSuggestion_DietaryProfilesError(t *testing.T) {
+	mockRepo := new(MockIngredientRepository)
+	mockProfiles := new(MockDietaryProfileRepository)
+	mockService := new(MockOllamaService)
+	usecase := NewRecipeUsecase(mockRepo, nil, nil, mockProfiles, mockService, nil, newTestMatcher(t), newTestDietaryChecker(t), nil)
+
+	mockRepo.On("GetAll", mock.Anything).Return([]*domain.Ingredient{}, nil)
+	mockProfiles.On("GetAll", mock.Anything).Return(nil, errors.New("database error"))
+
+	result, err := usecase.GetRecipeSuggestion(context.Background())
+
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "failed to get dietary profiles")
+	mockService.AssertNotCalled(t, "GenerateRecipeSuggestion", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,28 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+)
+
+// StatsUsecase defines the business logic interface for food waste and cooking statistics
+type StatsUsecase interface {
+	// GetUsage counts the consumed and wasted uses per month and category
+	GetUsage(ctx context.Context, req StatsRequest) (*UsageStatsResponse, error)
+
+	// GetPurchaseToUse averages the days from purchase to being cooked or eaten
+	GetPurchaseToUse(ctx context.Context, req StatsRequest) (*PurchaseToUseResponse, error)
+
+	// GetMostWasted ranks ingredients by how often they were thrown away
+	GetMostWasted(ctx context.Context, req StatsRequest) ([]*domain.WastedIngredient, error)
+
+	// GetSuggestionStats measures how many recipe suggestions were actually cooked
+	GetSuggestionStats(ctx context.Context, req StatsRequest) (*domain.SuggestionStats, error)
+
+	// GetSpend totals the grocery spend per week, category or store
+	GetSpend(ctx context.Context, req StatsRequest) (*SpendReportResponse, error)
+
+	// GetRecipeCosts estimates what each cooked recipe cost from the prices of the ingredients used
+	GetRecipeCosts(ctx context.Context, req StatsRequest) ([]*domain.RecipeCost, error)
+}
@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/internal/repository"
+)
+
+// Most wasted ranking sizes
+const (
+	defaultMostWastedLimit = 10
+	maxMostWastedLimit     = 100
+)
+
+// statsUsecase implements the StatsUsecase interface
+type statsUsecase struct {
+	repo repository.StatsRepository
+}
+
+// NewStatsUsecase creates a new instance of StatsUsecase
+func NewStatsUsecase(repo repository.StatsRepository) StatsUsecase {
+	return &statsUsecase{
+		repo: repo,
+	}
+}
+
+// GetUsage counts the consumed and wasted uses per month and category, with the totals and waste rate of the period
+func (u *statsUsecase) GetUsage(ctx context.Context, req StatsRequest) (*UsageStatsResponse, error) {
+	filter, err := buildStatsFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	usage, err := u.repo.UsageByCategoryMonth(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get usage statistics: %w", err)
+	}
+
+	response := &UsageStatsResponse{Items: usage}
+	if response.Items == nil {
+		response.Items = []*domain.CategoryUsage{}
+	}
+	for _, item := range response.Items {
+		response.Consumed += item.Consumed
+		response.Wasted += item.Wasted
+	}
+	response.WasteRate = ratio(response.Wasted, response.Consumed+response.Wasted)
+
+	return response, nil
+}
+
+// GetPurchaseToUse averages the days from purchase to being cooked or eaten, overall and per category
+func (u *statsUsecase) GetPurchaseToUse(ctx context.Context, req StatsRequest) (*PurchaseToUseResponse, error) {
+	filter, err := buildStatsFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := u.repo.PurchaseToUse(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get purchase to use statistics: %w", err)
+	}
+
+	response := &PurchaseToUseResponse{Categories: categories}
+	if response.Categories == nil {
+		response.Categories = []*domain.CategoryPurchaseToUse{}
+	}
+
+	// Weight each category by its number of uses to get the overall average
+	var totalDays float64
+	for _, category := range response.Categories {
+		totalDays += category.AverageDays * float64(category.Count)
+		response.Count += category.Count
+	}
+	if response.Count > 0 {
+		response.AverageDays = totalDays / float64(response.Count)
+	}
+
+	return response, nil
+}
+
+// GetMostWasted ranks ingredients by how often they were thrown away
+func (u *statsUsecase) GetMostWasted(ctx context.Context, req StatsRequest) ([]*domain.WastedIngredient, error) {
+	filter, err := buildStatsFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Limit < 0 {
+		return nil, fmt.Errorf("%w: limit must not be negative", domain.ErrInvalidInput)
+	}
+	limit := defaultMostWastedLimit
+	if req.Limit > 0 {
+		limit = min(req.Limit, maxMostWastedLimit)
+	}
+
+	wasted, err := u.repo.MostWasted(ctx, filter, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get most wasted ingredients: %w", err)
+	}
+
+	// Return empty slice instead of nil if nothing was wasted
+	if wasted == nil {
+		wasted = []*domain.WastedIngredient{}
+	}
+
+	return wasted, nil
+}
+
+// GetSuggestionStats measures how many recipe suggestions were actually cooked
+func (u *statsUsecase) GetSuggestionStats(ctx context.Context, req StatsRequest) (*domain.SuggestionStats, error) {
+	filter, err := buildStatsFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := u.repo.SuggestionCooking(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get suggestion statistics: %w", err)
+	}
+
+	stats.CookedRate = ratio(stats.Cooked, stats.Suggested)
+	return stats, nil
+}
+
+// GetSpend totals the prices of the purchases made in the period per week, category or store
+func (u *statsUsecase) GetSpend(ctx context.Context, req StatsRequest) (*SpendReportResponse, error) {
+	filter, err := buildStatsFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	groupBy := req.GroupBy
+	if groupBy == "" {
+		groupBy = repository.SpendGroupWeek
+	}
+	if !repository.IsValidSpendGroup(groupBy) {
+		return nil, fmt.Errorf("%w: unknown group_by %q", domain.ErrInvalidInput, req.GroupBy)
+	}
+
+	totals, err := u.repo.Spend(ctx, filter, groupBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spend: %w", err)
+	}
+
+	response := &SpendReportResponse{GroupBy: groupBy, Items: totals}
+	if response.Items == nil {
+		response.Items = []*domain.SpendTotal{}
+	}
+	for _, item := range response.Items {
+		response.Total += item.Total
+		response.Count += item.Count
+	}
+
+	return response, nil
+}
+
+// GetRecipeCosts estimates what each recipe cooked in the period cost, most recently cooked first
+func (u *statsUsecase) GetRecipeCosts(ctx context.Context, req StatsRequest) ([]*domain.RecipeCost, error) {
+	filter, err := buildStatsFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	costs, err := u.repo.RecipeCosts(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recipe costs: %w", err)
+	}
+
+	// Return empty slice instead of nil if nothing was cooked
+	if costs == nil {
+		costs = []*domain.RecipeCost{}
+	}
+	for _, cost := range costs {
+		if cost.TimesCooked > 0 {
+			cost.AverageCost = float64(cost.TotalCost) / float64(cost.TimesCooked)
+		}
+	}
+
+	return costs, nil
+}
+
+// buildStatsFilter validates the statistics period and converts it into a repository filter
+func buildStatsFilter(req StatsRequest) (repository.StatsFilter, error) {
+	var filter repository.StatsFilter
+
+	if req.Since != "" {
+		since, _, err := parsePeriodBound(req.Since)
+		if err != nil {
+			return filter, fmt.Errorf("%w: invalid since format", domain.ErrInvalidInput)
+		}
+		filter.Since = &since
+	}
+
+	if req.Until != "" {
+		_, until, err := parsePeriodBound(req.Until)
+		if err != nil {
+			return filter, fmt.Errorf("%w: invalid until format", domain.ErrInvalidInput)
+		}
+		filter.Until = &until
+	}
+
+	if filter.Since != nil && filter.Until != nil && !filter.Since.Before(*filter.Until) {
+		return filter, fmt.Errorf("%w: since must be before until", domain.ErrInvalidInput)
+	}
+
+	return filter, nil
+}
+
+// ratio divides part by whole, returning 0 for an empty whole
+func ratio(part int, whole int) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return float64(part) / float64(whole)
+}
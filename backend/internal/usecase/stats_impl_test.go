@@ -0,0 +1,261 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockStatsRepository is a mock implementation of StatsRepository
+type MockStatsRepository struct {
+	mock.Mock
+}
+
+func (m *MockStatsRepository) UsageByCategoryMonth(ctx context.Context, filter repository.StatsFilter) ([]*domain.CategoryUsage, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.CategoryUsage), args.Error(1)
+}
+
+func (m *MockStatsRepository) PurchaseToUse(ctx context.Context, filter repository.StatsFilter) ([]*domain.CategoryPurchaseToUse, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.CategoryPurchaseToUse), args.Error(1)
+}
+
+func (m *MockStatsRepository) MostWasted(ctx context.Context, filter repository.StatsFilter, limit int) ([]*domain.WastedIngredient, error) {
+	args := m.Called(ctx, filter, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.WastedIngredient), args.Error(1)
+}
+
+func (m *MockStatsRepository) SuggestionCooking(ctx context.Context, filter repository.StatsFilter) (*domain.SuggestionStats, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SuggestionStats), args.Error(1)
+}
+
+func (m *MockStatsRepository) Spend(ctx context.Context, filter repository.StatsFilter, groupBy string) ([]*domain.SpendTotal, error) {
+	args := m.Called(ctx, filter, groupBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.SpendTotal), args.Error(1)
+}
+
+func (m *MockStatsRepository) RecipeCosts(ctx context.Context, filter repository.StatsFilter) ([]*domain.RecipeCost, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.RecipeCost), args.Error(1)
+}
+
+// TestGetUsage_Success tests that the usage totals and waste rate are computed from the monthly counts
+func TestGetUsage_Success(t *testing.T) {
+	mockRepo := new(MockStatsRepository)
+	usecase := NewStatsUsecase(mockRepo)
+
+	since := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	mockRepo.On("UsageByCategoryMonth", mock.Anything, repository.StatsFilter{Since: &since, Until: &until}).
+		Return([]*domain.CategoryUsage{
+			{Month: "2026-09", Category: domain.CategoryVegetable, Consumed: 5, Wasted: 1},
+			{Month: "2026-10", Category: domain.CategoryMeat, Consumed: 1, Wasted: 1},
+		}, nil)
+
+	result, err := usecase.GetUsage(context.Background(), StatsRequest{Since: "2026-09-01", Until: "2026-10-31"})
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Items, 2)
+	assert.Equal(t, 6, result.Consumed)
+	assert.Equal(t, 2, result.Wasted)
+	assert.Equal(t, 0.25, result.WasteRate)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestGetUsage_Empty tests that an empty period returns an empty list and a zero waste rate
+func TestGetUsage_Empty(t *testing.T) {
+	mockRepo := new(MockStatsRepository)
+	usecase := NewStatsUsecase(mockRepo)
+
+	mockRepo.On("UsageByCategoryMonth", mock.Anything, repository.StatsFilter{}).Return(nil, nil)
+
+	result, err := usecase.GetUsage(context.Background(), StatsRequest{})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result.Items)
+	assert.Empty(t, result.Items)
+	assert.Zero(t, result.WasteRate)
+}
+
+// TestGetUsage_InvalidPeriod tests that malformed or reversed periods are rejected
+func TestGetUsage_InvalidPeriod(t *testing.T) {
+	tests := []struct {
+		name string
+		req  StatsRequest
+	}{
+		{"invalid since", StatsRequest{Since: "yesterday"}},
+		{"invalid until", StatsRequest{Until: "2026/10/01"}},
+		{"since after until", StatsRequest{Since: "2026-10-02", Until: "2026-10-01"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockStatsRepository)
+			usecase := NewStatsUsecase(mockRepo)
+
+			result, err := usecase.GetUsage(context.Background(), tt.req)
+
+			assert.ErrorIs(t, err, domain.ErrInvalidInput)
+			assert.Nil(t, result)
+			mockRepo.AssertNotCalled(t, "UsageByCategoryMonth", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+// TestGetPurchaseToUse_Success tests that the overall average is weighted by the number of uses per category
+func TestGetPurchaseToUse_Success(t *testing.T) {
+	mockRepo := new(MockStatsRepository)
+	usecase := NewStatsUsecase(mockRepo)
+
+	mockRepo.On("PurchaseToUse", mock.Anything, repository.StatsFilter{}).
+		Return([]*domain.CategoryPurchaseToUse{
+			{Category: domain.CategoryMeat, AverageDays: 2, Count: 3},
+			{Category: domain.CategoryVegetable, AverageDays: 6, Count: 1},
+		}, nil)
+
+	result, err := usecase.GetPurchaseToUse(context.Background(), StatsRequest{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, result.Count)
+	assert.Equal(t, 3.0, result.AverageDays)
+	assert.Len(t, result.Categories, 2)
+}
+
+// TestGetMostWasted_Limit tests the default, capped and negative limits
+func TestGetMostWasted_Limit(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit int
+		want  int
+	}{
+		{"default", 0, defaultMostWastedLimit},
+		{"custom", 3, 3},
+		{"capped", 1000, maxMostWastedLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockStatsRepository)
+			usecase := NewStatsUsecase(mockRepo)
+
+			mockRepo.On("MostWasted", mock.Anything, repository.StatsFilter{}, tt.want).Return(nil, nil)
+
+			result, err := usecase.GetMostWasted(context.Background(), StatsRequest{Limit: tt.limit})
+
+			assert.NoError(t, err)
+			assert.NotNil(t, result)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("negative", func(t *testing.T) {
+		usecase := NewStatsUsecase(new(MockStatsRepository))
+
+		result, err := usecase.GetMostWasted(context.Background(), StatsRequest{Limit: -1})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+		assert.Nil(t, result)
+	})
+}
+
+// TestGetSuggestionStats_Success tests that the cooked rate is computed from the counts
+func TestGetSuggestionStats_Success(t *testing.T) {
+	mockRepo := new(MockStatsRepository)
+	usecase := NewStatsUsecase(mockRepo)
+
+	mockRepo.On("SuggestionCooking", mock.Anything, repository.StatsFilter{}).
+		Return(&domain.SuggestionStats{Suggested: 8, Cooked: 2}, nil)
+
+	result, err := usecase.GetSuggestionStats(context.Background(), StatsRequest{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0.25, result.CookedRate)
+}
+
+// TestGetSuggestionStats_RepositoryError tests that repository errors are wrapped
+func TestGetSuggestionStats_RepositoryError(t *testing.T) {
+	mockRepo := new(MockStatsRepository)
+	usecase := NewStatsUsecase(mockRepo)
+
+	mockRepo.On("SuggestionCooking", mock.Anything, repository.StatsFilter{}).Return(nil, errors.New("database error"))
+
+	result, err := usecase.GetSuggestionStats(context.Background(), StatsRequest{})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get suggestion statistics")
+	assert.Nil(t, result)
+}
+
+// TestGetSpend_Success tests that the spend defaults to weekly totals and sums them up
+func TestGetSpend_Success(t *testing.T) {
+	mockRepo := new(MockStatsRepository)
+	usecase := NewStatsUsecase(mockRepo)
+
+	mockRepo.On("Spend", mock.Anything, repository.StatsFilter{}, repository.SpendGroupWeek).
+		Return([]*domain.SpendTotal{
+			{Key: "2026-10-05", Total: 3200, Count: 8},
+			{Key: "2026-10-12", Total: 1800, Count: 5},
+		}, nil)
+
+	result, err := usecase.GetSpend(context.Background(), StatsRequest{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, repository.SpendGroupWeek, result.GroupBy)
+	assert.Equal(t, 5000, result.Total)
+	assert.Equal(t, 13, result.Count)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestGetSpend_InvalidGroup tests that unknown groupings are rejected
+func TestGetSpend_InvalidGroup(t *testing.T) {
+	mockRepo := new(MockStatsRepository)
+	usecase := NewStatsUsecase(mockRepo)
+
+	result, err := usecase.GetSpend(context.Background(), StatsRequest{GroupBy: "month"})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	assert.Nil(t, result)
+	mockRepo.AssertNotCalled(t, "Spend", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestGetRecipeCosts_Success tests that the average cost is computed per time cooked
+func TestGetRecipeCosts_Success(t *testing.T) {
+	mockRepo := new(MockStatsRepository)
+	usecase := NewStatsUsecase(mockRepo)
+
+	mockRepo.On("RecipeCosts", mock.Anything, repository.StatsFilter{}).
+		Return([]*domain.RecipeCost{{Recipe: "豚汁", TimesCooked: 2, TotalCost: 900}}, nil)
+
+	result, err := usecase.GetRecipeCosts(context.Background(), StatsRequest{})
+
+	assert.NoError(t, err)
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, 450.0, result[0].AverageCost)
+	}
+}
@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+)
+
+// SynonymUsecase defines the business logic interface for the ingredient synonym dictionary
+type SynonymUsecase interface {
+	// ListSynonyms retrieves all dictionary entries
+	ListSynonyms(ctx context.Context) ([]domain.DictionaryEntry, error)
+
+	// GetSynonym retrieves a dictionary entry by its canonical name
+	GetSynonym(ctx context.Context, canonical string) (*domain.DictionaryEntry, error)
+
+	// SaveSynonym creates or replaces a dictionary entry
+	SaveSynonym(ctx context.Context, canonical string, req SaveSynonymRequest) (*domain.DictionaryEntry, error)
+
+	// DeleteSynonym deletes a dictionary entry by its canonical name
+	DeleteSynonym(ctx context.Context, canonical string) error
+
+	// ReindexIngredients recomputes the canonical names and missing categories of stored ingredients
+	ReindexIngredients(ctx context.Context) (*ReindexResponse, error)
+}
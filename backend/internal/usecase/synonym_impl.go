@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/internal/repository"
+	"github.com/Rin0530/DinnerDecider/backend/internal/service"
+	"github.com/Rin0530/DinnerDecider/backend/pkg/textnorm"
+)
+
+// synonymUsecase implements the SynonymUsecase interface
+type synonymUsecase struct {
+	repo           repository.SynonymRepository
+	ingredientRepo repository.IngredientRepository
+	normalizer     service.IngredientNormalizer
+}
+
+// NewSynonymUsecase creates a new instance of SynonymUsecase
+func NewSynonymUsecase(
+	repo repository.SynonymRepository,
+	ingredientRepo repository.IngredientRepository,
+	normalizer service.IngredientNormalizer,
+) SynonymUsecase {
+	return &synonymUsecase{
+		repo:           repo,
+		ingredientRepo: ingredientRepo,
+		normalizer:     normalizer,
+	}
+}
+
+// ListSynonyms retrieves all dictionary entries
+func (u *synonymUsecase) ListSynonyms(ctx context.Context) ([]domain.DictionaryEntry, error) {
+	entries, err := u.repo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get synonyms: %w", err)
+	}
+
+	// Return empty slice instead of nil if no entries found
+	if entries == nil {
+		return []domain.DictionaryEntry{}, nil
+	}
+
+	return entries, nil
+}
+
+// GetSynonym retrieves a dictionary entry by its canonical name
+func (u *synonymUsecase) GetSynonym(ctx context.Context, canonical string) (*domain.DictionaryEntry, error) {
+	entry, err := u.repo.GetByCanonical(ctx, strings.TrimSpace(canonical))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get synonym: %w", err)
+	}
+
+	return entry, nil
+}
+
+// SaveSynonym creates or replaces a dictionary entry, then re-normalizes the ingredients of the current household
+func (u *synonymUsecase) SaveSynonym(ctx context.Context, canonical string, req SaveSynonymRequest) (*domain.DictionaryEntry, error) {
+	if err := checkDictionaryEditor(ctx); err != nil {
+		return nil, err
+	}
+
+	canonical = strings.TrimSpace(canonical)
+	if canonical == "" {
+		return nil, fmt.Errorf("%w: canonical name is required", domain.ErrInvalidInput)
+	}
+
+	entry := &domain.DictionaryEntry{
+		Canonical: canonical,
+		Aliases:   []string{},
+		Broader:   strings.TrimSpace(req.Broader),
+		Category:  req.Category,
+	}
+	if entry.Category != "" && !domain.IsValidCategory(entry.Category) {
+		return nil, fmt.Errorf("%w: unknown category %q", domain.ErrInvalidInput, entry.Category)
+	}
+	if textnorm.Fold(entry.Broader) == textnorm.Fold(canonical) {
+		return nil, fmt.Errorf("%w: broader must differ from the canonical name", domain.ErrInvalidInput)
+	}
+
+	// Drop blank and duplicate aliases, comparing them in folded form
+	seen := map[string]bool{textnorm.Fold(canonical): true}
+	for _, alias := range req.Aliases {
+		alias = strings.TrimSpace(alias)
+		folded := textnorm.Fold(alias)
+		if folded == "" || seen[folded] {
+			continue
+		}
+		seen[folded] = true
+		entry.Aliases = append(entry.Aliases, alias)
+	}
+
+	// An alias must not already name a different ingredient
+	existing, err := u.repo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get synonyms: %w", err)
+	}
+	for _, other := range existing {
+		if other.Canonical == canonical {
+			continue
+		}
+		for _, name := range append([]string{other.Canonical}, other.Aliases...) {
+			if seen[textnorm.Fold(name)] {
+				return nil, fmt.Errorf("%w: %q already refers to %q", domain.ErrInvalidInput, name, other.Canonical)
+			}
+		}
+	}
+
+	if err := u.repo.Save(ctx, entry); err != nil {
+		return nil, fmt.Errorf("failed to save synonym: %w", err)
+	}
+
+	if err := u.reload(ctx); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// DeleteSynonym deletes a dictionary entry by its canonical name, then re-normalizes the ingredients of the current household
+func (u *synonymUsecase) DeleteSynonym(ctx context.Context, canonical string) error {
+	if err := checkDictionaryEditor(ctx); err != nil {
+		return err
+	}
+
+	if err := u.repo.Delete(ctx, strings.TrimSpace(canonical)); err != nil {
+		return fmt.Errorf("failed to delete synonym: %w", err)
+	}
+
+	return u.reload(ctx)
+}
+
+// ReindexIngredients recomputes the canonical names of stored ingredients.
+// Ingredients without a category are categorized as well; existing categories are kept.
+// Requests reindex the current household and need the same rights as dictionary changes. Background jobs made
+// with domain.WithAllHouseholds, such as the reindex at startup, cover every household.
+func (u *synonymUsecase) ReindexIngredients(ctx context.Context) (*ReindexResponse, error) {
+	if !domain.AllHouseholds(ctx) {
+		if err := checkDictionaryEditor(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return u.reindex(ctx)
+}
+
+// reindex recomputes the canonical names and missing categories of the ingredients ctx may see
+func (u *synonymUsecase) reindex(ctx context.Context) (*ReindexResponse, error) {
+	ingredients, err := u.ingredientRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ingredients: %w", err)
+	}
+
+	updated := 0
+	for _, ingredient := range ingredients {
+		canonical := u.normalizer.Canonical(ingredient.Name)
+		category := ingredient.Category
+		if category == "" {
+			if category = u.normalizer.Category(ingredient.Name); category == "" {
+				category = domain.CategoryOther
+			}
+		}
+		if canonical == ingredient.CanonicalName && category == ingredient.Category {
+			continue
+		}
+		if err := u.ingredientRepo.UpdateClassification(ctx, ingredient.ID, canonical, category); err != nil {
+			return nil, fmt.Errorf("failed to reindex ingredient %d: %w", ingredient.ID, err)
+		}
+		updated++
+	}
+
+	return &ReindexResponse{Updated: updated}, nil
+}
+
+// reload applies the stored dictionary to the normalizer and re-normalizes the ingredients of the current household.
+// The other households catch up at the reindex on the next startup.
+func (u *synonymUsecase) reload(ctx context.Context) error {
+	entries, err := u.repo.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get synonyms: %w", err)
+	}
+	u.normalizer.Reload(entries)
+
+	if _, err := u.reindex(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// checkDictionaryEditor rejects changes to the synonym dictionary unless the signed-in user administers the server
+// and may change the records of the current household, whose ingredients are reindexed afterwards.
+// The dictionary is shared by every household, so everyone else may only read it.
+func checkDictionaryEditor(ctx context.Context) error {
+	if err := checkWritable(ctx); err != nil {
+		return err
+	}
+	return checkAdmin(ctx, "change the synonym dictionary")
+}
@@ -0,0 +1,214 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSynonymRepository is a mock implementation of SynonymRepository
+type MockSynonymRepository struct {
+	mock.Mock
+}
+
+func (m *MockSynonymRepository) GetAll(ctx context.Context) ([]domain.DictionaryEntry, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.DictionaryEntry), args.Error(1)
+}
+
+func (m *MockSynonymRepository) GetByCanonical(ctx context.Context, canonical string) (*domain.DictionaryEntry, error) {
+	args := m.Called(ctx, canonical)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DictionaryEntry), args.Error(1)
+}
+
+func (m *MockSynonymRepository) Save(ctx context.Context, entry *domain.DictionaryEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockSynonymRepository) Delete(ctx context.Context, canonical string) error {
+	args := m.Called(ctx, canonical)
+	return args.Error(0)
+}
+
+// adminContext returns the context of a server administrator writing in a household
+func adminContext() context.Context {
+	ctx := domain.WithUser(context.Background(), &domain.User{ID: 1, Username: "admin", IsAdmin: true})
+	return domain.WithHousehold(ctx, &domain.Household{ID: 1, Role: domain.HouseholdRoleMember})
+}
+
+// TestListSynonyms_Empty tests that an empty dictionary is returned as an empty slice
+func TestListSynonyms_Empty(t *testing.T) {
+	mockRepo := new(MockSynonymRepository)
+	usecase := NewSynonymUsecase(mockRepo, new(MockIngredientRepository), service.NewIngredientNormalizer(nil))
+
+	mockRepo.On("GetAll", mock.Anything).Return(nil, nil)
+
+	entries, err := usecase.ListSynonyms(context.Background())
+
+	assert.NoError(t, err)
+	assert.NotNil(t, entries)
+	assert.Len(t, entries, 0)
+}
+
+// TestGetSynonym_NotFound tests that a missing entry is reported as not found
+func TestGetSynonym_NotFound(t *testing.T) {
+	mockRepo := new(MockSynonymRepository)
+	usecase := NewSynonymUsecase(mockRepo, new(MockIngredientRepository), service.NewIngredientNormalizer(nil))
+
+	mockRepo.On("GetByCanonical", mock.Anything, "豚肉").
+		Return(nil, sql.ErrNoRows)
+
+	entry, err := usecase.GetSynonym(context.Background(), " 豚肉 ")
+
+	assert.Nil(t, entry)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+// TestSaveSynonym_Success tests that saving an entry reloads the normalizer and reindexes ingredients
+func TestSaveSynonym_Success(t *testing.T) {
+	mockRepo := new(MockSynonymRepository)
+	mockIngredientRepo := new(MockIngredientRepository)
+	normalizer := service.NewIngredientNormalizer(nil)
+	usecase := NewSynonymUsecase(mockRepo, mockIngredientRepo, normalizer)
+
+	saved := domain.DictionaryEntry{
+		Canonical: "豚バラ肉",
+		Aliases:   []string{"豚バラ", "ﾌﾞﾀﾊﾞﾗ", "pork belly"},
+		Broader:   "豚肉",
+		Category:  domain.CategoryMeat,
+	}
+
+	mockRepo.On("GetAll", mock.Anything).Return([]domain.DictionaryEntry{}, nil).Once()
+	mockRepo.On("Save", mock.Anything, &saved).Return(nil)
+	mockRepo.On("GetAll", mock.Anything).Return([]domain.DictionaryEntry{saved}, nil).Once()
+	// Only the ingredients of the administrator's own household are reindexed
+	ownHousehold := mock.MatchedBy(func(ctx context.Context) bool {
+		id := domain.HouseholdID(ctx)
+		return id != nil && *id == 1 && !domain.AllHouseholds(ctx)
+	})
+	mockIngredientRepo.On("GetAll", ownHousehold).Return([]*domain.Ingredient{
+		{ID: 1, Name: "豚バラ", CanonicalName: "豚ばら", Category: domain.CategoryOther},
+		{ID: 2, Name: "にんじん", CanonicalName: "にんじん", Category: domain.CategoryVegetable},
+		{ID: 3, Name: "豚バラ肉", CanonicalName: "豚バラ肉"},
+	}, nil)
+	mockIngredientRepo.On("UpdateClassification", mock.Anything, int64(1), "豚バラ肉", domain.CategoryOther).Return(nil)
+	mockIngredientRepo.On("UpdateClassification", mock.Anything, int64(3), "豚バラ肉", domain.CategoryMeat).Return(nil)
+
+	entry, err := usecase.SaveSynonym(adminContext(), "豚バラ肉", SaveSynonymRequest{
+		Aliases:  []string{" 豚バラ ", "ﾌﾞﾀﾊﾞﾗ", "", "ぶたばら", "pork belly", "豚バラ肉"},
+		Broader:  "豚肉",
+		Category: domain.CategoryMeat,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &saved, entry)
+	assert.Equal(t, "豚バラ肉", normalizer.Canonical("PORK BELLY"))
+	mockRepo.AssertExpectations(t)
+	mockIngredientRepo.AssertExpectations(t)
+}
+
+// TestSaveSynonym_AliasConflict tests that an alias of another entry is rejected
+func TestSaveSynonym_AliasConflict(t *testing.T) {
+	mockRepo := new(MockSynonymRepository)
+	usecase := NewSynonymUsecase(mockRepo, new(MockIngredientRepository), service.NewIngredientNormalizer(nil))
+
+	mockRepo.On("GetAll", mock.Anything).Return([]domain.DictionaryEntry{
+		{Canonical: "長ねぎ", Aliases: []string{"ねぎ"}},
+	}, nil)
+
+	entry, err := usecase.SaveSynonym(adminContext(), "青ねぎ", SaveSynonymRequest{Aliases: []string{"ネギ"}})
+
+	assert.Nil(t, entry)
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	assert.Contains(t, err.Error(), "長ねぎ")
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+// TestSaveSynonym_InvalidInput tests validation of the canonical and broader names
+func TestSaveSynonym_InvalidInput(t *testing.T) {
+	usecase := NewSynonymUsecase(new(MockSynonymRepository), new(MockIngredientRepository), service.NewIngredientNormalizer(nil))
+
+	_, err := usecase.SaveSynonym(adminContext(), "  ", SaveSynonymRequest{})
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+	_, err = usecase.SaveSynonym(adminContext(), "豚肉", SaveSynonymRequest{Broader: " 豚肉 "})
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+	_, err = usecase.SaveSynonym(adminContext(), "豚肉", SaveSynonymRequest{Category: "snack"})
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+}
+
+// TestDeleteSynonym_RepositoryError tests error handling when the entry cannot be deleted
+func TestDeleteSynonym_RepositoryError(t *testing.T) {
+	mockRepo := new(MockSynonymRepository)
+	usecase := NewSynonymUsecase(mockRepo, new(MockIngredientRepository), service.NewIngredientNormalizer(nil))
+
+	mockRepo.On("Delete", mock.Anything, "豚肉").Return(sql.ErrNoRows)
+
+	err := usecase.DeleteSynonym(adminContext(), "豚肉")
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	mockRepo.AssertNotCalled(t, "GetAll", mock.Anything)
+}
+
+// TestSaveSynonym_Forbidden tests that only server administrators change the shared dictionary,
+// even when they own the household they are in
+func TestSaveSynonym_Forbidden(t *testing.T) {
+	mockRepo := new(MockSynonymRepository)
+	mockIngredientRepo := new(MockIngredientRepository)
+	usecase := NewSynonymUsecase(mockRepo, mockIngredientRepo, service.NewIngredientNormalizer(nil))
+
+	user := domain.WithUser(context.Background(), &domain.User{ID: 2, Username: "hanako"})
+	admin := domain.WithUser(context.Background(), &domain.User{ID: 1, Username: "admin", IsAdmin: true})
+	contexts := map[string]context.Context{
+		"household owner": domain.WithHousehold(user, &domain.Household{ID: 2, Role: domain.HouseholdRoleOwner}),
+		"member":          domain.WithHousehold(user, &domain.Household{ID: 1, Role: domain.HouseholdRoleMember}),
+		"no household":    user,
+		"viewer admin":    domain.WithHousehold(admin, &domain.Household{ID: 1, Role: domain.HouseholdRoleViewer}),
+	}
+	for name, ctx := range contexts {
+		entry, err := usecase.SaveSynonym(ctx, "豚肉", SaveSynonymRequest{Aliases: []string{"ぶたにく"}})
+		assert.Nil(t, entry, name)
+		assert.ErrorIs(t, err, domain.ErrForbidden, name)
+
+		err = usecase.DeleteSynonym(ctx, "豚肉")
+		assert.ErrorIs(t, err, domain.ErrForbidden, name)
+
+		result, err := usecase.ReindexIngredients(ctx)
+		assert.Nil(t, result, name)
+		assert.ErrorIs(t, err, domain.ErrForbidden, name)
+	}
+
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	mockIngredientRepo.AssertNotCalled(t, "GetAll", mock.Anything)
+}
+
+// TestReindexIngredients_Background tests that the startup reindex covers every household without a signed-in user
+func TestReindexIngredients_Background(t *testing.T) {
+	mockIngredientRepo := new(MockIngredientRepository)
+	usecase := NewSynonymUsecase(new(MockSynonymRepository), mockIngredientRepo, service.NewIngredientNormalizer(nil))
+
+	allHouseholds := mock.MatchedBy(func(ctx context.Context) bool { return domain.AllHouseholds(ctx) })
+	mockIngredientRepo.On("GetAll", allHouseholds).Return([]*domain.Ingredient{
+		{ID: 1, Name: "にんじん", CanonicalName: "にんじん", Category: domain.CategoryVegetable},
+	}, nil)
+
+	result, err := usecase.ReindexIngredients(domain.WithAllHouseholds(context.Background()))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result.Updated)
+	mockIngredientRepo.AssertExpectations(t)
+}
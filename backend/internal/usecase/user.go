@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+)
+
+// UserUsecase defines the business logic interface for user accounts and sign-in
+type UserUsecase interface {
+	// Register creates an account without signing in, which is only allowed for the first user
+	// or while open registration is enabled, and signs the new user in
+	Register(ctx context.Context, req RegisterRequest) (*LoginResponse, error)
+
+	// Login checks a username and password and issues a session token
+	Login(ctx context.Context, req LoginRequest) (*LoginResponse, error)
+
+	// Authenticate resolves a session token to the user it was issued to
+	Authenticate(ctx context.Context, token string) (*domain.User, error)
+
+	// ListUsers retrieves the members of the current household
+	ListUsers(ctx context.Context) ([]*domain.User, error)
+
+	// CreateUser creates an account on behalf of the signed-in user, who must administer the server unless
+	// registration is open
+	CreateUser(ctx context.Context, req RegisterRequest) (*domain.User, error)
+
+	// ChangePassword replaces the password of the signed-in user, revoking their tokens, and issues a fresh token
+	ChangePassword(ctx context.Context, req ChangePasswordRequest) (*LoginResponse, error)
+}
@@ -0,0 +1,304 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/internal/repository"
+	"github.com/Rin0530/DinnerDecider/backend/internal/service"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Password and username limits
+const (
+	minPasswordLength = 8
+	maxPasswordLength = 72 // bcrypt ignores anything longer
+	maxUsernameLength = 64
+)
+
+// dummyPasswordHash is compared against when a username does not exist,
+// so that unknown users take as long to reject as wrong passwords
+const dummyPasswordHash = "$2a$10$c1pei3O1WmOaZw6sLerXPu86V8/ujFuenO0Ymg7Evq/wRQRHCP45K"
+
+// errInvalidCredentials is returned for unknown usernames and wrong passwords alike
+var errInvalidCredentials = fmt.Errorf("%w: invalid username or password", domain.ErrUnauthorized)
+
+// userUsecase implements the UserUsecase interface
+type userUsecase struct {
+	repo             repository.UserRepository
+	households       repository.HouseholdRepository
+	tokens           service.TokenService
+	openRegistration bool
+}
+
+// NewUserUsecase creates a new instance of UserUsecase.
+// Unless openRegistration is set, only the first user can register; later accounts are created by administrators.
+func NewUserUsecase(repo repository.UserRepository, households repository.HouseholdRepository, tokens service.TokenService, openRegistration bool) UserUsecase {
+	return &userUsecase{
+		repo:             repo,
+		households:       households,
+		tokens:           tokens,
+		openRegistration: openRegistration,
+	}
+}
+
+// Register creates an account without signing in and signs the new user in.
+// The first user becomes the owner of the households nobody belongs to yet.
+func (u *userUsecase) Register(ctx context.Context, req RegisterRequest) (*LoginResponse, error) {
+	// Answer closed registrations before hashing; the repository checks again while registering
+	count, err := u.repo.Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count users: %w", err)
+	}
+	if err := u.checkRegistration(count); err != nil {
+		return nil, err
+	}
+
+	user, err := u.newUser(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.repo.Register(ctx, user, u.checkRegistration); err != nil {
+		if errors.Is(err, domain.ErrForbidden) || errors.Is(err, domain.ErrInvalidInput) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to register user: %w", err)
+	}
+
+	return u.issue(user)
+}
+
+// checkRegistration rejects registrations once count users exist, unless registration is open
+func (u *userUsecase) checkRegistration(count int) error {
+	if count > 0 && !u.openRegistration {
+		return fmt.Errorf("%w: registration is closed; ask a signed-in user to create your account", domain.ErrForbidden)
+	}
+	return nil
+}
+
+// Login checks a username and password and issues a session token
+func (u *userUsecase) Login(ctx context.Context, req LoginRequest) (*LoginResponse, error) {
+	user, err := u.repo.GetByUsername(ctx, strings.TrimSpace(req.Username))
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		_ = bcrypt.CompareHashAndPassword([]byte(dummyPasswordHash), []byte(req.Password))
+		return nil, errInvalidCredentials
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
+		return nil, errInvalidCredentials
+	}
+
+	return u.issue(user)
+}
+
+// Authenticate resolves a session token to the user it was issued to
+func (u *userUsecase) Authenticate(ctx context.Context, token string) (*domain.User, error) {
+	userID, sessionVersion, err := u.tokens.Verify(token)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tokens of deleted users stop working right away
+	user, err := u.repo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: user no longer exists", domain.ErrUnauthorized)
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// so do the tokens issued before the password last changed
+	if user.SessionVersion != sessionVersion {
+		return nil, fmt.Errorf("%w: token was revoked by a password change", domain.ErrUnauthorized)
+	}
+
+	return user, nil
+}
+
+// ListUsers retrieves the members of the current household
+func (u *userUsecase) ListUsers(ctx context.Context) ([]*domain.User, error) {
+	household := domain.CurrentHousehold(ctx)
+	if household == nil {
+		return nil, domain.ErrNoHousehold
+	}
+
+	users, err := u.repo.GetByHousehold(ctx, household.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	return users, nil
+}
+
+// CreateUser creates an account on behalf of the signed-in user. While registration is closed only server
+// administrators may, since owning a household proves nothing: anyone can create one.
+// The new user joins households through invitations like everyone else.
+func (u *userUsecase) CreateUser(ctx context.Context, req RegisterRequest) (*domain.User, error) {
+	if domain.CurrentUser(ctx) == nil {
+		return nil, fmt.Errorf("%w: sign in to create accounts", domain.ErrUnauthorized)
+	}
+	if !u.openRegistration {
+		if err := checkAdmin(ctx, "create accounts while registration is closed"); err != nil {
+			return nil, err
+		}
+	}
+
+	return u.createUser(ctx, req)
+}
+
+// ChangePassword replaces the password of the signed-in user. Every token issued before stops working,
+// so a fresh one is returned to keep the caller signed in.
+func (u *userUsecase) ChangePassword(ctx context.Context, req ChangePasswordRequest) (*LoginResponse, error) {
+	current := domain.CurrentUser(ctx)
+	if current == nil {
+		return nil, fmt.Errorf("%w: sign in to change your password", domain.ErrUnauthorized)
+	}
+
+	// Compare against the stored hash rather than the one cached in the context
+	user, err := u.repo.GetByID(ctx, current.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.CurrentPassword)); err != nil {
+		return nil, fmt.Errorf("%w: current password is incorrect", domain.ErrInvalidInput)
+	}
+
+	hash, err := hashPassword(req.NewPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	user.PasswordHash = hash
+	if err := u.repo.UpdatePassword(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update password: %w", err)
+	}
+
+	return u.issue(user)
+}
+
+// createUser validates the credentials and stores a new user with a hashed password
+func (u *userUsecase) createUser(ctx context.Context, req RegisterRequest) (*domain.User, error) {
+	user, err := u.newUser(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.repo.Create(ctx, user); err != nil {
+		// Concurrent requests for the same username pass the check in newUser and meet at the unique key
+		if errors.Is(err, domain.ErrInvalidInput) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	return user, nil
+}
+
+// newUser validates the credentials and builds a user with a hashed password without saving it
+func (u *userUsecase) newUser(ctx context.Context, req RegisterRequest) (*domain.User, error) {
+	username, err := validateUsername(req.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := hashPassword(req.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := u.repo.GetByUsername(ctx, username); err == nil {
+		return nil, fmt.Errorf("%w: username %q is already taken", domain.ErrInvalidInput, username)
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return &domain.User{Username: username, PasswordHash: hash}, nil
+}
+
+// issue signs a session token for user
+func (u *userUsecase) issue(user *domain.User) (*LoginResponse, error) {
+	token, expiresAt, err := u.tokens.Issue(user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue token: %w", err)
+	}
+
+	return &LoginResponse{Token: token, ExpiresAt: expiresAt, User: user}, nil
+}
+
+// validateUsername trims a username and checks its length and characters
+func validateUsername(username string) (string, error) {
+	username = strings.TrimSpace(username)
+	if username == "" {
+		return "", fmt.Errorf("%w: username cannot be empty", domain.ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(username) > maxUsernameLength {
+		return "", fmt.Errorf("%w: username must be at most %d characters", domain.ErrInvalidInput, maxUsernameLength)
+	}
+	if strings.IndexFunc(username, unicode.IsSpace) >= 0 {
+		return "", fmt.Errorf("%w: username cannot contain spaces", domain.ErrInvalidInput)
+	}
+	return username, nil
+}
+
+// hashPassword checks the length of a password and hashes it with bcrypt
+func hashPassword(password string) (string, error) {
+	if len(password) < minPasswordLength {
+		return "", fmt.Errorf("%w: password must be at least %d characters", domain.ErrInvalidInput, minPasswordLength)
+	}
+	if len(password) > maxPasswordLength {
+		return "", fmt.Errorf("%w: password must be at most %d bytes", domain.ErrInvalidInput, maxPasswordLength)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	return string(hash), nil
+}
+
+// checkOwner rejects changes to a record owned by someone other than the signed-in user, and any change made by
+// a viewer of the current household. Owners of the household can change every record in it, and records without
+// an owner, such as those stored before user accounts existed, can be changed by anyone else.
+func checkOwner(ctx context.Context, ownerID *int64) error {
+	if err := checkWritable(ctx); err != nil {
+		return err
+	}
+
+	if ownerID == nil {
+		return nil
+	}
+
+	if household := domain.CurrentHousehold(ctx); household != nil && household.Role == domain.HouseholdRoleOwner {
+		return nil
+	}
+
+	if user := domain.CurrentUser(ctx); user != nil && user.ID == *ownerID {
+		return nil
+	}
+
+	return fmt.Errorf("%w: the record belongs to another user", domain.ErrForbidden)
+}
+
+// checkAdmin rejects requests unless the signed-in user administers the server.
+// Household owners cannot pass it, since anyone can become one by creating a household.
+func checkAdmin(ctx context.Context, action string) error {
+	user := domain.CurrentUser(ctx)
+	if user == nil {
+		return fmt.Errorf("%w: sign in to %s", domain.ErrUnauthorized, action)
+	}
+	if !user.IsAdmin {
+		return fmt.Errorf("%w: only server administrators can %s", domain.ErrForbidden, action)
+	}
+	return nil
+}
@@ -0,0 +1,338 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Rin0530/DinnerDecider/backend/internal/domain"
+	"github.com/Rin0530/DinnerDecider/backend/internal/service"
+	"github.com/Rin0530/DinnerDecider/backend/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// MockUserRepository is a mock implementation of UserRepository
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) GetByHousehold(ctx context.Context, householdID int64) ([]*domain.User, error) {
+	args := m.Called(ctx, householdID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetByID(ctx context.Context, id int64) (*domain.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	args := m.Called(ctx, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) Count(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockUserRepository) Create(ctx context.Context, user *domain.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) Register(ctx context.Context, user *domain.User, allow func(count int) error) error {
+	args := m.Called(ctx, user)
+	if err := allow(args.Int(0)); err != nil {
+		return err
+	}
+	return args.Error(1)
+}
+
+func (m *MockUserRepository) UpdatePassword(ctx context.Context, user *domain.User) error {
+	args := m.Called(ctx, user)
+	if err := args.Error(0); err != nil {
+		return err
+	}
+	user.SessionVersion++
+	return nil
+}
+
+// newTestTokenService creates a token service with a fixed secret
+func newTestTokenService(t *testing.T) service.TokenService {
+	t.Helper()
+	tokens, err := service.NewTokenService(&config.AuthConfig{TokenSecret: "0123456789abcdef0123456789abcdef"})
+	if err != nil {
+		t.Fatalf("Failed to create token service: %v", err)
+	}
+	return tokens
+}
+
+// newTestUser creates a user whose password is password
+func newTestUser(t *testing.T, id int64, username string, password string) *domain.User {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("Failed to hash password: %v", err)
+	}
+	return &domain.User{ID: id, Username: username, PasswordHash: string(hash)}
+}
+
+// TestRegister_FirstUser tests that the first user can register and is signed in
+func TestRegister_FirstUser(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	mockHouseholds := new(MockHouseholdRepository)
+	tokens := newTestTokenService(t)
+	usecase := NewUserUsecase(mockRepo, mockHouseholds, tokens, false)
+
+	mockRepo.On("Count", mock.Anything).Return(0, nil)
+	mockRepo.On("GetByUsername", mock.Anything, "hanako").Return(nil, fmt.Errorf("user not found: %w", sql.ErrNoRows))
+	// The repository makes the first user the owner of the households nobody belongs to
+	mockRepo.On("Register", mock.Anything, mock.MatchedBy(func(user *domain.User) bool {
+		return user.Username == "hanako" && bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte("correct horse")) == nil
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.User).ID = 1
+	}).Return(0, nil)
+
+	result, err := usecase.Register(context.Background(), RegisterRequest{Username: " hanako ", Password: "correct horse"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), result.User.ID)
+	userID, _, err := tokens.Verify(result.Token)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), userID)
+	mockRepo.AssertExpectations(t)
+	mockHouseholds.AssertExpectations(t)
+}
+
+// TestRegister_Closed tests that registration is closed once a user exists
+func TestRegister_Closed(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	usecase := NewUserUsecase(mockRepo, new(MockHouseholdRepository), newTestTokenService(t), false)
+
+	mockRepo.On("Count", mock.Anything).Return(1, nil)
+
+	result, err := usecase.Register(context.Background(), RegisterRequest{Username: "taro", Password: "correct horse"})
+
+	assert.Nil(t, result)
+	assert.True(t, errors.Is(err, domain.ErrForbidden))
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+// TestRegister_ClosedConcurrently tests that a registration losing the race for the first account is rejected
+func TestRegister_ClosedConcurrently(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	usecase := NewUserUsecase(mockRepo, new(MockHouseholdRepository), newTestTokenService(t), false)
+
+	// No user existed when the request arrived, but another registration finished first
+	mockRepo.On("Count", mock.Anything).Return(0, nil)
+	mockRepo.On("GetByUsername", mock.Anything, "taro").Return(nil, fmt.Errorf("user not found: %w", sql.ErrNoRows))
+	mockRepo.On("Register", mock.Anything, mock.AnythingOfType("*domain.User")).Return(1, nil)
+
+	result, err := usecase.Register(context.Background(), RegisterRequest{Username: "taro", Password: "correct horse"})
+
+	assert.Nil(t, result)
+	assert.True(t, errors.Is(err, domain.ErrForbidden))
+}
+
+// TestRegister_ShortPassword tests that short passwords are rejected
+func TestRegister_ShortPassword(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	usecase := NewUserUsecase(mockRepo, new(MockHouseholdRepository), newTestTokenService(t), true)
+
+	mockRepo.On("Count", mock.Anything).Return(1, nil)
+
+	result, err := usecase.Register(context.Background(), RegisterRequest{Username: "taro", Password: "short"})
+
+	assert.Nil(t, result)
+	assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+}
+
+// TestLogin_Success tests that a correct password issues a token the user can authenticate with
+func TestLogin_Success(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	usecase := NewUserUsecase(mockRepo, new(MockHouseholdRepository), newTestTokenService(t), false)
+
+	user := newTestUser(t, 3, "hanako", "correct horse")
+	mockRepo.On("GetByUsername", mock.Anything, "hanako").Return(user, nil)
+	mockRepo.On("GetByID", mock.Anything, int64(3)).Return(user, nil)
+
+	result, err := usecase.Login(context.Background(), LoginRequest{Username: "hanako", Password: "correct horse"})
+	assert.NoError(t, err)
+
+	authenticated, err := usecase.Authenticate(context.Background(), result.Token)
+	assert.NoError(t, err)
+	assert.Equal(t, "hanako", authenticated.Username)
+}
+
+// TestLogin_InvalidCredentials tests that wrong passwords and unknown users are rejected alike
+func TestLogin_InvalidCredentials(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	usecase := NewUserUsecase(mockRepo, new(MockHouseholdRepository), newTestTokenService(t), false)
+
+	mockRepo.On("GetByUsername", mock.Anything, "hanako").Return(newTestUser(t, 3, "hanako", "correct horse"), nil)
+	mockRepo.On("GetByUsername", mock.Anything, "nobody").Return(nil, fmt.Errorf("user not found: %w", sql.ErrNoRows))
+
+	_, wrongPassword := usecase.Login(context.Background(), LoginRequest{Username: "hanako", Password: "wrong password"})
+	_, unknownUser := usecase.Login(context.Background(), LoginRequest{Username: "nobody", Password: "correct horse"})
+
+	assert.True(t, errors.Is(wrongPassword, domain.ErrUnauthorized))
+	assert.Equal(t, wrongPassword.Error(), unknownUser.Error())
+}
+
+// TestAuthenticate_DeletedUser tests that tokens of users that no longer exist are rejected
+func TestAuthenticate_DeletedUser(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	tokens := newTestTokenService(t)
+	usecase := NewUserUsecase(mockRepo, new(MockHouseholdRepository), tokens, false)
+
+	token, _, _ := tokens.Issue(&domain.User{ID: 9, Username: "ghost"})
+	mockRepo.On("GetByID", mock.Anything, int64(9)).Return(nil, fmt.Errorf("user not found: %w", sql.ErrNoRows))
+
+	user, err := usecase.Authenticate(context.Background(), token)
+
+	assert.Nil(t, user)
+	assert.True(t, errors.Is(err, domain.ErrUnauthorized))
+}
+
+// TestCreateUser_DuplicateUsername tests that usernames must be unique
+func TestCreateUser_DuplicateUsername(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	usecase := NewUserUsecase(mockRepo, new(MockHouseholdRepository), newTestTokenService(t), false)
+
+	mockRepo.On("GetByUsername", mock.Anything, "taro").Return(&domain.User{ID: 2, Username: "taro"}, nil)
+
+	ctx := domain.WithUser(context.Background(), &domain.User{ID: 1, Username: "hanako", IsAdmin: true})
+	user, err := usecase.CreateUser(ctx, RegisterRequest{Username: "taro", Password: "correct horse"})
+
+	assert.Nil(t, user)
+	assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+// TestCreateUser_ConcurrentDuplicate tests that a username taken between the check and the insert is still a 400
+func TestCreateUser_ConcurrentDuplicate(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	usecase := NewUserUsecase(mockRepo, new(MockHouseholdRepository), newTestTokenService(t), false)
+
+	mockRepo.On("GetByUsername", mock.Anything, "taro").Return(nil, fmt.Errorf("user not found: %w", sql.ErrNoRows))
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.User")).
+		Return(fmt.Errorf("%w: username \"taro\" is already taken", domain.ErrInvalidInput))
+
+	ctx := domain.WithUser(context.Background(), &domain.User{ID: 1, Username: "hanako", IsAdmin: true})
+	user, err := usecase.CreateUser(ctx, RegisterRequest{Username: "taro", Password: "correct horse"})
+
+	assert.Nil(t, user)
+	assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+	assert.Equal(t, `invalid input: username "taro" is already taken`, err.Error())
+}
+
+// TestCreateUser_NotAdmin tests that owning a household, which anyone can create, does not let users create
+// accounts while registration is closed
+func TestCreateUser_NotAdmin(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	usecase := NewUserUsecase(mockRepo, new(MockHouseholdRepository), newTestTokenService(t), false)
+
+	ctx := domain.WithUser(context.Background(), &domain.User{ID: 2, Username: "hanako"})
+	ctx = domain.WithHousehold(ctx, &domain.Household{ID: 3, Name: "自分で作った世帯", Role: domain.HouseholdRoleOwner})
+	user, err := usecase.CreateUser(ctx, RegisterRequest{Username: "taro", Password: "correct horse"})
+
+	assert.Nil(t, user)
+	assert.True(t, errors.Is(err, domain.ErrForbidden))
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+// TestCreateUser_OpenRegistration tests that any signed-in user creates accounts while registration is open
+func TestCreateUser_OpenRegistration(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	usecase := NewUserUsecase(mockRepo, new(MockHouseholdRepository), newTestTokenService(t), true)
+
+	mockRepo.On("GetByUsername", mock.Anything, "taro").Return(nil, fmt.Errorf("user not found: %w", sql.ErrNoRows))
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.User")).Return(nil)
+
+	ctx := domain.WithUser(context.Background(), &domain.User{ID: 2, Username: "hanako"})
+	user, err := usecase.CreateUser(ctx, RegisterRequest{Username: "taro", Password: "correct horse"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "taro", user.Username)
+	assert.False(t, user.IsAdmin)
+}
+
+// TestListUsers_CurrentHousehold tests that only the members of the current household are listed
+func TestListUsers_CurrentHousehold(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	usecase := NewUserUsecase(mockRepo, new(MockHouseholdRepository), newTestTokenService(t), false)
+
+	members := []*domain.User{{ID: 1, Username: "hanako"}, {ID: 3, Username: "taro"}}
+	mockRepo.On("GetByHousehold", mock.Anything, int64(2)).Return(members, nil)
+
+	ctx := domain.WithHousehold(context.Background(), &domain.Household{ID: 2, Role: domain.HouseholdRoleViewer})
+	users, err := usecase.ListUsers(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, members, users)
+
+	// Outside of a household nobody is listed
+	users, err = usecase.ListUsers(context.Background())
+	assert.Nil(t, users)
+	assert.True(t, errors.Is(err, domain.ErrForbidden))
+	mockRepo.AssertNumberOfCalls(t, "GetByHousehold", 1)
+}
+
+// TestChangePassword_WrongCurrentPassword tests that the current password must be confirmed
+func TestChangePassword_WrongCurrentPassword(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	usecase := NewUserUsecase(mockRepo, new(MockHouseholdRepository), newTestTokenService(t), false)
+
+	user := newTestUser(t, 3, "hanako", "correct horse")
+	mockRepo.On("GetByID", mock.Anything, int64(3)).Return(user, nil)
+
+	ctx := domain.WithUser(context.Background(), user)
+	result, err := usecase.ChangePassword(ctx, ChangePasswordRequest{CurrentPassword: "wrong password", NewPassword: "battery staple"})
+
+	assert.Nil(t, result)
+	assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+	mockRepo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything)
+}
+
+// TestChangePassword_RevokesTokens tests that tokens issued before a password change stop working
+// while the token returned by the change keeps the caller signed in
+func TestChangePassword_RevokesTokens(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	tokens := newTestTokenService(t)
+	usecase := NewUserUsecase(mockRepo, new(MockHouseholdRepository), tokens, false)
+
+	user := newTestUser(t, 3, "hanako", "correct horse")
+	mockRepo.On("GetByID", mock.Anything, int64(3)).Return(user, nil)
+	mockRepo.On("UpdatePassword", mock.Anything, mock.MatchedBy(func(user *domain.User) bool {
+		return bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte("battery staple")) == nil
+	})).Return(nil)
+
+	oldToken, _, err := tokens.Issue(user)
+	assert.NoError(t, err)
+
+	ctx := domain.WithUser(context.Background(), user)
+	result, err := usecase.ChangePassword(ctx, ChangePasswordRequest{CurrentPassword: "correct horse", NewPassword: "battery staple"})
+	assert.NoError(t, err)
+
+	_, err = usecase.Authenticate(context.Background(), oldToken)
+	assert.True(t, errors.Is(err, domain.ErrUnauthorized))
+
+	authenticated, err := usecase.Authenticate(context.Background(), result.Token)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), authenticated.ID)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,4 @@
+-- Add normalized ingredient name used for searching, de-duplication and matching
+ALTER TABLE ingredients
+    ADD COLUMN canonical_name VARCHAR(255) NOT NULL DEFAULT '' AFTER name,
+    ADD INDEX idx_canonical_name (canonical_name);
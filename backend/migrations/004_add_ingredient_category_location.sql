@@ -0,0 +1,7 @@
+-- Add ingredient category and storage location
+-- An empty category is filled in from the synonym dictionary when the server starts
+ALTER TABLE ingredients
+    ADD COLUMN category VARCHAR(32) NOT NULL DEFAULT '' AFTER canonical_name,
+    ADD COLUMN location VARCHAR(32) NOT NULL DEFAULT 'fridge' AFTER category,
+    ADD INDEX idx_category (category),
+    ADD INDEX idx_location (location);
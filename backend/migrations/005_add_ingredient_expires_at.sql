@@ -0,0 +1,4 @@
+-- Add ingredient expiry date used for sorting the ingredient list
+ALTER TABLE ingredients
+    ADD COLUMN expires_at DATE NULL AFTER purchase_date,
+    ADD INDEX idx_expires_at (expires_at);
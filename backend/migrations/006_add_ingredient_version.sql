@@ -0,0 +1,3 @@
+-- Add ingredient row version used for optimistic concurrency control (ETag / If-Match)
+ALTER TABLE ingredients
+    ADD COLUMN version BIGINT NOT NULL DEFAULT 1 AFTER expires_at;
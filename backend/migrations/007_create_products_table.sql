@@ -0,0 +1,16 @@
+-- Add ingredient barcode and the local product table used to resolve JAN/EAN codes offline
+ALTER TABLE ingredients
+    ADD COLUMN barcode VARCHAR(14) NOT NULL DEFAULT '' AFTER quantity,
+    ADD INDEX idx_barcode (barcode);
+
+CREATE TABLE IF NOT EXISTS products (
+    barcode VARCHAR(14) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    category VARCHAR(32) NOT NULL DEFAULT 'other',
+    unit VARCHAR(100) NOT NULL DEFAULT '',
+    shelf_life_days INT NULL,
+    source VARCHAR(16) NOT NULL DEFAULT 'import',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_name (name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,4 @@
+-- Soft-delete ingredients: deleted rows stay in the trash until restored or purged after the retention period
+ALTER TABLE ingredients
+    ADD COLUMN deleted_at TIMESTAMP NULL DEFAULT NULL AFTER updated_at,
+    ADD INDEX idx_deleted_at (deleted_at);
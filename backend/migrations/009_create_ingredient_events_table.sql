@@ -0,0 +1,14 @@
+-- Append-only history of ingredient changes, written in the same transaction as the change itself
+CREATE TABLE IF NOT EXISTS ingredient_events (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    ingredient_id BIGINT NOT NULL,
+    action VARCHAR(16) NOT NULL,
+    actor VARCHAR(255) NOT NULL DEFAULT '',
+    source VARCHAR(16) NOT NULL DEFAULT 'api',
+    before_value JSON NULL,
+    after_value JSON NULL,
+    created_at TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    INDEX idx_ingredient_id (ingredient_id, id),
+    INDEX idx_created_at (created_at),
+    INDEX idx_actor (actor)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
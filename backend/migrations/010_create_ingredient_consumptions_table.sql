@@ -0,0 +1,19 @@
+-- Record every use of an ingredient with its reason, keeping enough of the ingredient to aggregate after it is purged
+CREATE TABLE IF NOT EXISTS ingredient_consumptions (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    ingredient_id BIGINT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    canonical_name VARCHAR(255) NOT NULL DEFAULT '',
+    category VARCHAR(32) NOT NULL DEFAULT 'other',
+    amount VARCHAR(100) NOT NULL DEFAULT '',
+    remaining VARCHAR(100) NOT NULL DEFAULT '',
+    used_up BOOLEAN NOT NULL DEFAULT FALSE,
+    reason VARCHAR(16) NOT NULL,
+    recipe VARCHAR(255) NOT NULL DEFAULT '',
+    purchase_date DATE NULL,
+    actor VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    INDEX idx_ingredient_id (ingredient_id, id),
+    INDEX idx_reason_created_at (reason, created_at),
+    INDEX idx_canonical_name (canonical_name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,12 @@
+-- Log recipe suggestions so cooking them can be measured, and link consumptions to the suggestion they cooked
+CREATE TABLE IF NOT EXISTS recipe_suggestions (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    source VARCHAR(16) NOT NULL DEFAULT '',
+    created_at TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    INDEX idx_created_at (created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+ALTER TABLE ingredient_consumptions
+    ADD COLUMN suggestion_id BIGINT NULL AFTER recipe,
+    ADD INDEX idx_suggestion_id (suggestion_id);
@@ -0,0 +1,20 @@
+-- Track what ingredients cost: the price and store of each ingredient, a purchase ledger that outlives purges,
+-- and the estimated cost of each use
+ALTER TABLE ingredients
+    ADD COLUMN price INT NULL AFTER barcode,
+    ADD COLUMN store VARCHAR(255) NOT NULL DEFAULT '' AFTER price;
+
+CREATE TABLE IF NOT EXISTS ingredient_purchases (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    ingredient_id BIGINT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    category VARCHAR(32) NOT NULL DEFAULT 'other',
+    store VARCHAR(255) NOT NULL DEFAULT '',
+    price INT NOT NULL,
+    purchased_on DATE NOT NULL,
+    UNIQUE KEY uk_ingredient_id (ingredient_id),
+    INDEX idx_purchased_on (purchased_on)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+ALTER TABLE ingredient_consumptions
+    ADD COLUMN cost INT NULL AFTER suggestion_id;
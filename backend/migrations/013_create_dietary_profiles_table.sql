@@ -0,0 +1,11 @@
+-- Dietary profiles of household members: allergens and restrictions that recipe suggestions must respect,
+-- and disliked foods they should avoid, each stored as a JSON array
+CREATE TABLE IF NOT EXISTS dietary_profiles (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    allergens JSON NOT NULL,
+    restrictions JSON NOT NULL,
+    dislikes JSON NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
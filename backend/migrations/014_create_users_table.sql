@@ -0,0 +1,22 @@
+-- User accounts that sign in to the API, and the user who created each ingredient, recipe and dietary profile.
+-- Records stored before accounts existed keep a NULL owner and can be changed by every user.
+CREATE TABLE IF NOT EXISTS users (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    username VARCHAR(64) NOT NULL,
+    password_hash VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uk_username (username)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+ALTER TABLE ingredients
+    ADD COLUMN owner_id BIGINT NULL AFTER deleted_at,
+    ADD INDEX idx_owner_id (owner_id);
+
+ALTER TABLE recipes
+    ADD COLUMN owner_id BIGINT NULL AFTER updated_at,
+    ADD INDEX idx_owner_id (owner_id);
+
+ALTER TABLE dietary_profiles
+    ADD COLUMN owner_id BIGINT NULL AFTER updated_at,
+    ADD INDEX idx_owner_id (owner_id);
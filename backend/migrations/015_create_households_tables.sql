@@ -0,0 +1,74 @@
+-- Households that share an inventory, recipes and dietary profiles, the users belonging to each with their role,
+-- and single-use invitation codes for joining one.
+-- Everything stored before households existed moves into a default household whose owners are all existing users;
+-- when there are no users yet, the first user to register claims it.
+CREATE TABLE IF NOT EXISTS households (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS household_members (
+    household_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    role VARCHAR(16) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (household_id, user_id),
+    INDEX idx_user_id (user_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS household_invitations (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    household_id BIGINT NOT NULL,
+    code VARCHAR(32) NOT NULL,
+    role VARCHAR(16) NOT NULL,
+    created_by BIGINT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    used_by BIGINT NULL,
+    used_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uk_code (code),
+    INDEX idx_household_id (household_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+INSERT INTO households (id, name) VALUES (1, '既定の世帯');
+
+INSERT INTO household_members (household_id, user_id, role)
+SELECT 1, id, 'owner' FROM users;
+
+ALTER TABLE ingredients
+    ADD COLUMN household_id BIGINT NULL AFTER owner_id,
+    ADD INDEX idx_household_id (household_id);
+
+ALTER TABLE recipes
+    ADD COLUMN household_id BIGINT NULL AFTER owner_id,
+    ADD INDEX idx_household_id (household_id);
+
+ALTER TABLE dietary_profiles
+    ADD COLUMN household_id BIGINT NULL AFTER owner_id,
+    ADD INDEX idx_household_id (household_id);
+
+ALTER TABLE ingredient_events
+    ADD COLUMN household_id BIGINT NULL,
+    ADD INDEX idx_household_id (household_id);
+
+ALTER TABLE ingredient_consumptions
+    ADD COLUMN household_id BIGINT NULL,
+    ADD INDEX idx_household_id (household_id);
+
+ALTER TABLE ingredient_purchases
+    ADD COLUMN household_id BIGINT NULL,
+    ADD INDEX idx_household_id (household_id);
+
+ALTER TABLE recipe_suggestions
+    ADD COLUMN household_id BIGINT NULL,
+    ADD INDEX idx_household_id (household_id);
+
+UPDATE ingredients SET household_id = 1;
+UPDATE recipes SET household_id = 1;
+UPDATE dietary_profiles SET household_id = 1;
+UPDATE ingredient_events SET household_id = 1;
+UPDATE ingredient_consumptions SET household_id = 1;
+UPDATE ingredient_purchases SET household_id = 1;
+UPDATE recipe_suggestions SET household_id = 1;
@@ -0,0 +1,15 @@
+-- API keys for scripts and devices such as kitchen tablets. Each key acts on behalf of the user who created it,
+-- within one household and the scopes stored as a JSON array. Only the SHA-256 hash of a key is stored.
+CREATE TABLE IF NOT EXISTS api_keys (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(64) NOT NULL,
+    prefix VARCHAR(16) NOT NULL,
+    key_hash CHAR(64) NOT NULL,
+    scopes JSON NOT NULL,
+    user_id BIGINT NOT NULL,
+    household_id BIGINT NOT NULL,
+    last_used_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uk_key_hash (key_hash),
+    INDEX idx_household_id (household_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,6 @@
+-- Server administrators manage what every household shares, such as the synonym dictionary and the product
+-- database, and create accounts while registration is closed. The first user becomes the administrator.
+ALTER TABLE users
+    ADD COLUMN is_admin BOOLEAN NOT NULL DEFAULT FALSE AFTER password_hash;
+
+UPDATE users SET is_admin = TRUE ORDER BY id LIMIT 1;
@@ -0,0 +1,5 @@
+-- The recipes scope is split into recipes:read and recipes:write. Existing keys keep what they could do:
+-- recipes:write includes recipes:read. Dietary profiles are no longer available to API keys at all.
+UPDATE api_keys
+SET scopes = JSON_REPLACE(scopes, JSON_UNQUOTE(JSON_SEARCH(scopes, 'one', 'recipes')), 'recipes:write')
+WHERE JSON_CONTAINS(scopes, '"recipes"');
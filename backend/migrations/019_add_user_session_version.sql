@@ -0,0 +1,4 @@
+-- Session tokens carry the session version of their user. Changing the password raises it,
+-- so every token issued before the change is rejected.
+ALTER TABLE users
+    ADD COLUMN session_version INT NOT NULL DEFAULT 0 AFTER is_admin;
@@ -0,0 +1,53 @@
+package barcode
+
+import (
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalize validates a JAN/EAN/UPC barcode and returns it in canonical form.
+// Full-width digits, spaces and hyphens are accepted. UPC-A codes and GTIN-14 codes
+// with a leading zero are returned as the equivalent 13-digit EAN so that the same
+// product always has the same key; EAN-8 codes are kept as they are.
+func Normalize(code string) (string, bool) {
+	code = norm.NFKC.String(code)
+	code = strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(code))
+
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return "", false
+		}
+	}
+
+	switch len(code) {
+	case 8, 13:
+	case 12:
+		code = "0" + code
+	case 14:
+		if code[0] == '0' {
+			code = code[1:]
+		}
+	default:
+		return "", false
+	}
+
+	if !validCheckDigit(code) {
+		return "", false
+	}
+	return code, true
+}
+
+// validCheckDigit verifies the GS1 mod-10 check digit at the end of code
+func validCheckDigit(code string) bool {
+	sum := 0
+	for i := len(code) - 2; i >= 0; i-- {
+		digit := int(code[i] - '0')
+		// Weights alternate 3, 1, 3, ... starting from the digit next to the check digit
+		if (len(code)-2-i)%2 == 0 {
+			digit *= 3
+		}
+		sum += digit
+	}
+	return (10-sum%10)%10 == int(code[len(code)-1]-'0')
+}
@@ -0,0 +1,35 @@
+package barcode
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		ok       bool
+	}{
+		{"4901234567894", "4901234567894", true},
+		{"４９０１２３４５６７８９４", "4901234567894", true},
+		{"49-0123 4567894", "4901234567894", true},
+		{"49021028", "49021028", true},
+		{"036000291452", "0036000291452", true},
+		{"04901234567894", "4901234567894", true},
+		{"14901234567891", "14901234567891", true},
+		{"4901234567890", "", false},
+		{"490123456789", "", false},
+		{"49012345678X4", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, ok := Normalize(tt.input)
+			if ok != tt.ok {
+				t.Fatalf("Expected ok=%v, got %v", tt.ok, ok)
+			}
+			if result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
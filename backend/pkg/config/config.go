@@ -10,16 +10,47 @@ import (
 
 // Config represents the application configuration
 type Config struct {
-	Server   ServerConfig   `mapstructure:"server"`
-	Database DatabaseConfig `mapstructure:"database"`
-	Ollama   OllamaConfig   `mapstructure:"ollama"`
-	Logging  LoggingConfig  `mapstructure:"logging"`
+	Server     ServerConfig     `mapstructure:"server"`
+	CORS       CORSConfig       `mapstructure:"cors"`
+	Security   SecurityConfig   `mapstructure:"security"`
+	Database   DatabaseConfig   `mapstructure:"database"`
+	Ollama     OllamaConfig     `mapstructure:"ollama"`
+	Fallback   FallbackConfig   `mapstructure:"fallback"`
+	Catalog    CatalogConfig    `mapstructure:"catalog"`
+	Dictionary DictionaryConfig `mapstructure:"dictionary"`
+	Nutrition  NutritionConfig  `mapstructure:"nutrition"`
+	Dietary    DietaryConfig    `mapstructure:"dietary"`
+	Trash      TrashConfig      `mapstructure:"trash"`
+	Auth       AuthConfig       `mapstructure:"auth"`
+	RateLimit  RateLimitConfig  `mapstructure:"rate_limit"`
+	Logging    LoggingConfig    `mapstructure:"logging"`
 }
 
-// ServerConfig represents server configuration
+// ServerConfig represents server configuration.
+// Request bodies larger than MaxBodySize bytes are rejected; zero leaves them unlimited.
+// Client IP addresses are read from X-Forwarded-For only behind the TrustedProxies (IP addresses or CIDR ranges).
 type ServerConfig struct {
-	Port string `mapstructure:"port"`
-	Host string `mapstructure:"host"`
+	Port           string   `mapstructure:"port"`
+	Host           string   `mapstructure:"host"`
+	MaxBodySize    int64    `mapstructure:"max_body_size"`
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
+}
+
+// CORSConfig represents configuration for cross-origin requests from browsers, such as the frontend.
+// No AllowedOrigins disables CORS; "*" allows every origin.
+type CORSConfig struct {
+	AllowedOrigins   []string      `mapstructure:"allowed_origins"`
+	AllowedMethods   []string      `mapstructure:"allowed_methods"`
+	AllowCredentials bool          `mapstructure:"allow_credentials"`
+	MaxAge           time.Duration `mapstructure:"max_age"` // how long browsers may cache preflight responses
+}
+
+// SecurityConfig represents configuration for the security headers added to every response.
+// Empty values leave the header out.
+type SecurityConfig struct {
+	ContentSecurityPolicy        string `mapstructure:"content_security_policy"`
+	SwaggerContentSecurityPolicy string `mapstructure:"swagger_content_security_policy"` // Swagger UI needs its own scripts, styles and images
+	ReferrerPolicy               string `mapstructure:"referrer_policy"`
 }
 
 // DatabaseConfig represents database configuration
@@ -40,6 +71,67 @@ type OllamaConfig struct {
 	Timeout  time.Duration `mapstructure:"timeout"`
 }
 
+// FallbackConfig represents configuration for the rule-based recipe recommender
+// used when the Ollama API is unavailable
+type FallbackConfig struct {
+	Enabled        bool   `mapstructure:"enabled"`
+	CatalogPath    string `mapstructure:"catalog_path"`
+	MaxSuggestions int    `mapstructure:"max_suggestions"`
+}
+
+// CatalogConfig represents configuration for the local recipe catalog
+type CatalogConfig struct {
+	GroundSuggestions bool `mapstructure:"ground_suggestions"`
+}
+
+// DictionaryConfig represents configuration for the ingredient synonym dictionary
+type DictionaryConfig struct {
+	Path string `mapstructure:"path"`
+}
+
+// NutritionConfig represents configuration for the ingredient nutrition table
+type NutritionConfig struct {
+	TablePath string `mapstructure:"table_path"`
+}
+
+// DietaryConfig represents configuration for the allergen dictionary used to validate recipe suggestions
+type DietaryConfig struct {
+	DictionaryPath string `mapstructure:"dictionary_path"`
+}
+
+// TrashConfig represents configuration for deleted ingredients kept in the trash.
+// A zero Retention keeps them until they are restored.
+type TrashConfig struct {
+	Retention     time.Duration `mapstructure:"retention"`
+	PurgeInterval time.Duration `mapstructure:"purge_interval"`
+}
+
+// AuthConfig represents configuration for user accounts and the session tokens issued at login.
+// An empty TokenSecret makes the server sign tokens with a random key, so they expire on restart.
+type AuthConfig struct {
+	TokenSecret      string        `mapstructure:"token_secret"`
+	TokenTTL         time.Duration `mapstructure:"token_ttl"`
+	OpenRegistration bool          `mapstructure:"open_registration"`
+}
+
+// RateLimitConfig represents configuration for the per-client rate limits of the API.
+// Each route group uses one of the policies; clients are told apart by API key, signed-in user or IP address.
+type RateLimitConfig struct {
+	Enabled         bool            `mapstructure:"enabled"`
+	Default         RateLimitPolicy `mapstructure:"default"`          // routes without a policy of their own
+	Auth            RateLimitPolicy `mapstructure:"auth"`             // sign-in and registration, by IP address
+	Recipes         RateLimitPolicy `mapstructure:"recipes"`          // recipe suggestions, which call the LLM
+	IngredientReads RateLimitPolicy `mapstructure:"ingredient_reads"` // GET requests for ingredients
+}
+
+// RateLimitPolicy represents a token bucket: up to Burst requests at once, refilled at Requests per Period.
+// Zero Requests leaves the routes unlimited.
+type RateLimitPolicy struct {
+	Requests int           `mapstructure:"requests"`
+	Period   time.Duration `mapstructure:"period"`
+	Burst    int           `mapstructure:"burst"`
+}
+
 // LoggingConfig represents logging configuration
 type LoggingConfig struct {
 	Level  string `mapstructure:"level"`
@@ -90,6 +182,19 @@ func setDefaults(v *viper.Viper) {
 	// Server defaults
 	v.SetDefault("server.port", "8080")
 	v.SetDefault("server.host", "0.0.0.0")
+	v.SetDefault("server.max_body_size", 4<<20)
+	v.SetDefault("server.trusted_proxies", []string{})
+
+	// CORS defaults
+	v.SetDefault("cors.allowed_origins", []string{"http://localhost:3000"})
+	v.SetDefault("cors.allowed_methods", []string{"GET", "POST", "PUT", "PATCH", "DELETE"})
+	v.SetDefault("cors.allow_credentials", false)
+	v.SetDefault("cors.max_age", "12h")
+
+	// Security header defaults
+	v.SetDefault("security.content_security_policy", "default-src 'none'; frame-ancestors 'none'")
+	v.SetDefault("security.swagger_content_security_policy", "default-src 'self'; img-src 'self' data:; style-src 'self' 'unsafe-inline'; frame-ancestors 'none'")
+	v.SetDefault("security.referrer_policy", "no-referrer")
 
 	// Database defaults
 	v.SetDefault("database.host", "localhost")
@@ -105,6 +210,47 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("ollama.model", "llama2")
 	v.SetDefault("ollama.timeout", "30s")
 
+	// Fallback recommender defaults
+	v.SetDefault("fallback.enabled", true)
+	v.SetDefault("fallback.catalog_path", "")
+	v.SetDefault("fallback.max_suggestions", 3)
+
+	// Catalog defaults
+	v.SetDefault("catalog.ground_suggestions", true)
+
+	// Synonym dictionary defaults
+	v.SetDefault("dictionary.path", "")
+
+	// Nutrition table defaults
+	v.SetDefault("nutrition.table_path", "")
+
+	// Dietary restriction defaults
+	v.SetDefault("dietary.dictionary_path", "")
+
+	// Trash defaults
+	v.SetDefault("trash.retention", "720h")
+	v.SetDefault("trash.purge_interval", "1h")
+
+	// Authentication defaults
+	v.SetDefault("auth.token_secret", "")
+	v.SetDefault("auth.token_ttl", "168h")
+	v.SetDefault("auth.open_registration", false)
+
+	// Rate limit defaults
+	v.SetDefault("rate_limit.enabled", true)
+	v.SetDefault("rate_limit.default.requests", 120)
+	v.SetDefault("rate_limit.default.period", "1m")
+	v.SetDefault("rate_limit.default.burst", 60)
+	v.SetDefault("rate_limit.auth.requests", 10)
+	v.SetDefault("rate_limit.auth.period", "1m")
+	v.SetDefault("rate_limit.auth.burst", 5)
+	v.SetDefault("rate_limit.recipes.requests", 20)
+	v.SetDefault("rate_limit.recipes.period", "1h")
+	v.SetDefault("rate_limit.recipes.burst", 5)
+	v.SetDefault("rate_limit.ingredient_reads.requests", 600)
+	v.SetDefault("rate_limit.ingredient_reads.period", "1m")
+	v.SetDefault("rate_limit.ingredient_reads.burst", 120)
+
 	// Logging defaults
 	v.SetDefault("logging.level", "info")
 	v.SetDefault("logging.format", "json")
@@ -0,0 +1,137 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// assertJSONEqual compares two JSON documents ignoring member order
+func assertJSONEqual(t *testing.T, expected string, actual []byte) {
+	t.Helper()
+
+	var want, got interface{}
+	if err := json.Unmarshal([]byte(expected), &want); err != nil {
+		t.Fatalf("Invalid expected JSON: %v", err)
+	}
+	if err := json.Unmarshal(actual, &got); err != nil {
+		t.Fatalf("Invalid result JSON: %v", err)
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("Expected %s, got %s", expected, actual)
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	// Cases taken from RFC 7396 appendix A
+	tests := []struct {
+		doc      string
+		patch    string
+		expected string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.patch, func(t *testing.T) {
+			result, err := MergePatch([]byte(tt.doc), []byte(tt.patch))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			assertJSONEqual(t, tt.expected, result)
+		})
+	}
+}
+
+func TestMergePatch_InvalidPatch(t *testing.T) {
+	if _, err := MergePatch([]byte(`{}`), []byte(`{"a":`)); !errors.Is(err, ErrInvalidPatch) {
+		t.Errorf("Expected ErrInvalidPatch, got %v", err)
+	}
+}
+
+func TestApply(t *testing.T) {
+	// Cases adapted from RFC 6902 appendix A
+	tests := []struct {
+		name     string
+		doc      string
+		patch    string
+		expected string
+	}{
+		{"add member", `{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":"qux"}]`, `{"baz":"qux","foo":"bar"}`},
+		{"add array element", `{"foo":["bar","baz"]}`, `[{"op":"add","path":"/foo/1","value":"qux"}]`, `{"foo":["bar","qux","baz"]}`},
+		{"append array element", `{"foo":["bar"]}`, `[{"op":"add","path":"/foo/-","value":"qux"}]`, `{"foo":["bar","qux"]}`},
+		{"add null value", `{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":null}]`, `{"foo":"bar","baz":null}`},
+		{"remove member", `{"baz":"qux","foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, `{"foo":"bar"}`},
+		{"remove array element", `{"foo":["bar","qux","baz"]}`, `[{"op":"remove","path":"/foo/1"}]`, `{"foo":["bar","baz"]}`},
+		{"replace value", `{"baz":"qux","foo":"bar"}`, `[{"op":"replace","path":"/baz","value":"boo"}]`, `{"baz":"boo","foo":"bar"}`},
+		{"move value", `{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`, `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`, `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`},
+		{"move array element", `{"foo":["all","grass","cows","eat"]}`, `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`, `{"foo":["all","cows","eat","grass"]}`},
+		{"copy value", `{"foo":{"bar":"baz"}}`, `[{"op":"copy","from":"/foo","path":"/qux"}]`, `{"foo":{"bar":"baz"},"qux":{"bar":"baz"}}`},
+		{"test then replace", `{"baz":"qux"}`, `[{"op":"test","path":"/baz","value":"qux"},{"op":"replace","path":"/baz","value":"x"}]`, `{"baz":"x"}`},
+		{"escaped path", `{"a/b":1,"m~n":2}`, `[{"op":"replace","path":"/a~1b","value":3},{"op":"remove","path":"/m~0n"}]`, `{"a/b":3}`},
+		{"replace whole document", `{"foo":"bar"}`, `[{"op":"replace","path":"","value":{"baz":1}}]`, `{"baz":1}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Apply([]byte(tt.doc), []byte(tt.patch))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			assertJSONEqual(t, tt.expected, result)
+		})
+	}
+}
+
+func TestApply_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		patch    string
+		expected error
+	}{
+		{"not an array", `{"op":"add"}`, ErrInvalidPatch},
+		{"unknown op", `[{"op":"frobnicate","path":"/foo"}]`, ErrInvalidPatch},
+		{"missing value", `[{"op":"add","path":"/baz"}]`, ErrInvalidPatch},
+		{"replace missing member", `[{"op":"replace","path":"/baz","value":1}]`, ErrInvalidPatch},
+		{"remove missing member", `[{"op":"remove","path":"/baz"}]`, ErrInvalidPatch},
+		{"add to missing parent", `[{"op":"add","path":"/baz/bat","value":"qux"}]`, ErrInvalidPatch},
+		{"index out of range", `[{"op":"add","path":"/list/5","value":1}]`, ErrInvalidPatch},
+		{"leading zero index", `[{"op":"remove","path":"/list/01"}]`, ErrInvalidPatch},
+		{"path without slash", `[{"op":"remove","path":"foo"}]`, ErrInvalidPatch},
+		{"move into child", `[{"op":"move","from":"/obj","path":"/obj/child"}]`, ErrInvalidPatch},
+		{"test mismatch", `[{"op":"test","path":"/foo","value":"baz"}]`, ErrTestFailed},
+	}
+
+	doc := []byte(`{"foo":"bar","list":[1,2],"obj":{}}`)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Apply(doc, []byte(tt.patch)); !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestApply_IsAtomic(t *testing.T) {
+	doc := []byte(`{"foo":"bar"}`)
+
+	_, err := Apply(doc, []byte(`[{"op":"replace","path":"/foo","value":"baz"},{"op":"remove","path":"/missing"}]`))
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+	assertJSONEqual(t, `{"foo":"bar"}`, doc)
+}
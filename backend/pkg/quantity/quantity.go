@@ -0,0 +1,110 @@
+package quantity
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// quantityPattern matches a leading number, optionally written as a fraction, followed by the unit
+var quantityPattern = regexp.MustCompile(`^(\d+(?:\.\d+)?)(?:\s*[/⁄]\s*(\d+))?\s*(.*)$`)
+
+// unitScale converts a metric unit to the base unit of its dimension
+type unitScale struct {
+	base   string
+	factor float64
+}
+
+// unitScales lists the units that can be converted into each other when adding
+var unitScales = map[string]unitScale{
+	"mg": {"g", 0.001},
+	"g":  {"g", 1},
+	"kg": {"g", 1000},
+	"ml": {"ml", 1},
+	"cc": {"ml", 1},
+	"dl": {"ml", 100},
+	"l":  {"ml", 1000},
+}
+
+// Quantity is an amount with an optional unit, such as 200g or 2本
+type Quantity struct {
+	Amount float64
+	Unit   string
+}
+
+// Parse reads a quantity written as a number followed by a unit, such as "200g", "1.5 kg", "1/2個" or "３本".
+// Full-width characters are accepted. ok is false when s does not start with a number.
+func Parse(s string) (Quantity, bool) {
+	s = strings.TrimSpace(norm.NFKC.String(s))
+
+	match := quantityPattern.FindStringSubmatch(s)
+	if match == nil {
+		return Quantity{}, false
+	}
+
+	amount, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return Quantity{}, false
+	}
+	if match[2] != "" {
+		denominator, err := strconv.ParseFloat(match[2], 64)
+		if err != nil || denominator == 0 {
+			return Quantity{}, false
+		}
+		amount /= denominator
+	}
+
+	return Quantity{Amount: amount, Unit: strings.TrimSpace(match[3])}, true
+}
+
+// Add sums two quantities. Metric units of the same dimension, such as g and kg, are converted to
+// their base unit first; any other units must match exactly. ok is false when the units are incompatible.
+func Add(a Quantity, b Quantity) (Quantity, bool) {
+	if strings.EqualFold(a.Unit, b.Unit) {
+		return Quantity{Amount: a.Amount + b.Amount, Unit: a.Unit}, true
+	}
+
+	scaleA, okA := unitScales[strings.ToLower(a.Unit)]
+	scaleB, okB := unitScales[strings.ToLower(b.Unit)]
+	if !okA || !okB || scaleA.base != scaleB.base {
+		return Quantity{}, false
+	}
+
+	return Quantity{Amount: a.Amount*scaleA.factor + b.Amount*scaleB.factor, Unit: scaleA.base}, true
+}
+
+// Subtract takes b away from a and expresses the result in the unit of a, so 1kg minus 200g is 0.8kg.
+// Units convert as in Add, and a b without a unit counts in the unit of a. ok is false when the units
+// are incompatible. The result may be negative.
+func Subtract(a Quantity, b Quantity) (Quantity, bool) {
+	if b.Unit == "" || strings.EqualFold(a.Unit, b.Unit) {
+		return Quantity{Amount: a.Amount - b.Amount, Unit: a.Unit}, true
+	}
+
+	scaleA, okA := unitScales[strings.ToLower(a.Unit)]
+	scaleB, okB := unitScales[strings.ToLower(b.Unit)]
+	if !okA || !okB || scaleA.base != scaleB.base {
+		return Quantity{}, false
+	}
+
+	return Quantity{Amount: a.Amount - b.Amount*scaleB.factor/scaleA.factor, Unit: a.Unit}, true
+}
+
+// ToBase converts a quantity in a metric unit to the base unit of its dimension, g or ml, so 1.5kg becomes 1500g.
+// ok is false when the unit is not metric.
+func ToBase(q Quantity) (Quantity, bool) {
+	scale, ok := unitScales[strings.ToLower(q.Unit)]
+	if !ok {
+		return Quantity{}, false
+	}
+	return Quantity{Amount: q.Amount * scale.factor, Unit: scale.base}, true
+}
+
+// String formats the quantity with at most two decimal places, such as "1.5kg" or "3本"
+func (q Quantity) String() string {
+	amount := math.Round(q.Amount*100) / 100
+	return strconv.FormatFloat(amount, 'f', -1, 64) + q.Unit
+}
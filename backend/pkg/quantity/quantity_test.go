@@ -0,0 +1,125 @@
+package quantity
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Quantity
+		ok       bool
+	}{
+		{"200g", Quantity{200, "g"}, true},
+		{"1.5 kg", Quantity{1.5, "kg"}, true},
+		{"1/2個", Quantity{0.5, "個"}, true},
+		{"３本", Quantity{3, "本"}, true},
+		{"½個", Quantity{0.5, "個"}, true},
+		{"4", Quantity{4, ""}, true},
+		{"適量", Quantity{}, false},
+		{"大さじ2", Quantity{}, false},
+		{"1/0個", Quantity{}, false},
+		{"", Quantity{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, ok := Parse(tt.input)
+			if ok != tt.ok {
+				t.Fatalf("Expected ok=%v, got %v", tt.ok, ok)
+			}
+			if result != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestAdd(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected string
+		ok       bool
+	}{
+		{"2本", "3本", "5本", true},
+		{"1kg", "500g", "1500g", true},
+		{"200ml", "1L", "1200ml", true},
+		{"1/3個", "1/3個", "0.67個", true},
+		{"2", "3", "5", true},
+		{"2本", "200g", "", false},
+		{"1kg", "1l", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"+"+tt.b, func(t *testing.T) {
+			a, _ := Parse(tt.a)
+			b, _ := Parse(tt.b)
+
+			result, ok := Add(a, b)
+			if ok != tt.ok {
+				t.Fatalf("Expected ok=%v, got %v", tt.ok, ok)
+			}
+			if ok && result.String() != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, result.String())
+			}
+		})
+	}
+}
+
+func TestSubtract(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected string
+		ok       bool
+	}{
+		{"3本", "1本", "2本", true},
+		{"1kg", "200g", "0.8kg", true},
+		{"500ml", "0.2l", "300ml", true},
+		{"10個", "3", "7個", true},
+		{"1本", "2本", "-1本", true},
+		{"2本", "200g", "", false},
+		{"1kg", "100ml", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"-"+tt.b, func(t *testing.T) {
+			a, _ := Parse(tt.a)
+			b, _ := Parse(tt.b)
+
+			result, ok := Subtract(a, b)
+			if ok != tt.ok {
+				t.Fatalf("Expected ok=%v, got %v", tt.ok, ok)
+			}
+			if ok && result.String() != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, result.String())
+			}
+		})
+	}
+}
+
+func TestToBase(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		ok       bool
+	}{
+		{"1.5kg", "1500g", true},
+		{"200g", "200g", true},
+		{"2dl", "200ml", true},
+		{"1L", "1000ml", true},
+		{"2本", "", false},
+		{"3", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			q, _ := Parse(tt.input)
+
+			result, ok := ToBase(q)
+			if ok != tt.ok {
+				t.Fatalf("Expected ok=%v, got %v", tt.ok, ok)
+			}
+			if ok && result.String() != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, result.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Limiter is a token-bucket rate limiter with one bucket per client key.
+// Each bucket holds up to burst requests and refills at a steady rate; idle buckets are dropped once full again.
+type Limiter struct {
+	burst    int
+	interval time.Duration // time to earn back one request
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// bucket is the state of one client: its tokens when last seen
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// Result describes the outcome of a request and the state of its bucket afterwards
+type Result struct {
+	Allowed    bool
+	Limit      int           // requests the bucket holds when full
+	Remaining  int           // requests left right now
+	Reset      time.Duration // until the bucket is full again
+	RetryAfter time.Duration // until the next request is allowed; zero when this one was
+}
+
+// New creates a Limiter allowing requests per period on average with bursts of up to burst requests.
+// A burst below one defaults to requests. It returns nil when requests or period is not positive,
+// which Allow treats as unlimited.
+func New(requests int, period time.Duration, burst int) *Limiter {
+	if requests <= 0 || period <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = requests
+	}
+
+	return &Limiter{
+		burst:    burst,
+		interval: period / time.Duration(requests),
+		buckets:  make(map[string]*bucket),
+		now:      time.Now,
+	}
+}
+
+// Allow takes one request from the bucket of key, reporting whether it was allowed
+func (l *Limiter) Allow(key string) Result {
+	if l == nil {
+		return Result{Allowed: true}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.burst), updated: now}
+		l.buckets[key] = b
+	}
+	b.tokens = l.refill(b, now)
+	b.updated = now
+
+	result := Result{Limit: l.burst}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = l.duration(1 - b.tokens)
+	}
+	result.Remaining = int(b.tokens)
+	result.Reset = l.duration(float64(l.burst) - b.tokens)
+
+	return result
+}
+
+// refill returns the tokens of b at now
+func (l *Limiter) refill(b *bucket, now time.Time) float64 {
+	earned := float64(now.Sub(b.updated)) / float64(l.interval)
+	return math.Min(float64(l.burst), b.tokens+earned)
+}
+
+// duration returns how long it takes to earn tokens, rounded up to whole milliseconds
+func (l *Limiter) duration(tokens float64) time.Duration {
+	if tokens <= 0 {
+		return 0
+	}
+	return time.Duration(math.Ceil(tokens*float64(l.interval)/float64(time.Millisecond))) * time.Millisecond
+}
+
+// sweep drops the buckets that have filled up again, at most once per time to fill a bucket,
+// so that clients seen once do not stay in memory
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < time.Duration(l.burst)*l.interval {
+		return
+	}
+	l.lastSweep = now
+
+	for key, b := range l.buckets {
+		if l.refill(b, now) >= float64(l.burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
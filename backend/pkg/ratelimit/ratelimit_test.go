@@ -0,0 +1,92 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+// newTestLimiter returns a limiter whose clock is advanced by the returned function
+func newTestLimiter(requests int, period time.Duration, burst int) (*Limiter, func(time.Duration)) {
+	l := New(requests, period, burst)
+	now := time.Date(2026, 10, 18, 19, 0, 0, 0, time.UTC)
+	l.now = func() time.Time { return now }
+	return l, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestAllow_Burst(t *testing.T) {
+	l, _ := newTestLimiter(60, time.Minute, 3)
+
+	for i := 0; i < 3; i++ {
+		result := l.Allow("user:1")
+		if !result.Allowed {
+			t.Fatalf("Expected request %d to be allowed", i+1)
+		}
+		if result.Remaining != 2-i {
+			t.Errorf("Expected %d remaining after request %d, got %d", 2-i, i+1, result.Remaining)
+		}
+	}
+
+	result := l.Allow("user:1")
+	if result.Allowed {
+		t.Fatal("Expected the fourth request to be limited")
+	}
+	if result.RetryAfter != time.Second {
+		t.Errorf("Expected to retry after 1s, got %v", result.RetryAfter)
+	}
+	if result.Reset != 3*time.Second {
+		t.Errorf("Expected the bucket to be full after 3s, got %v", result.Reset)
+	}
+
+	// Other clients have their own bucket
+	if !l.Allow("user:2").Allowed {
+		t.Error("Expected another client to be allowed")
+	}
+}
+
+func TestAllow_Refill(t *testing.T) {
+	l, advance := newTestLimiter(60, time.Minute, 2)
+
+	l.Allow("ip:192.0.2.1")
+	l.Allow("ip:192.0.2.1")
+	if l.Allow("ip:192.0.2.1").Allowed {
+		t.Fatal("Expected the bucket to be empty")
+	}
+
+	advance(time.Second)
+	if !l.Allow("ip:192.0.2.1").Allowed {
+		t.Fatal("Expected one request to be earned back after a second")
+	}
+
+	// Tokens never exceed the burst however long the client was idle
+	advance(time.Hour)
+	result := l.Allow("ip:192.0.2.1")
+	if result.Remaining != 1 {
+		t.Errorf("Expected 1 remaining, got %d", result.Remaining)
+	}
+}
+
+func TestAllow_Sweep(t *testing.T) {
+	l, advance := newTestLimiter(60, time.Minute, 2)
+
+	l.Allow("user:1")
+	advance(time.Minute)
+	l.Allow("user:2")
+
+	if _, ok := l.buckets["user:1"]; ok {
+		t.Error("Expected the full bucket of an idle client to be dropped")
+	}
+	if _, ok := l.buckets["user:2"]; !ok {
+		t.Error("Expected the bucket of an active client to be kept")
+	}
+}
+
+func TestNew_Unlimited(t *testing.T) {
+	l := New(0, time.Minute, 10)
+	if l != nil {
+		t.Fatal("Expected no limiter without requests")
+	}
+
+	if !l.Allow("user:1").Allowed {
+		t.Error("Expected a nil limiter to allow every request")
+	}
+}
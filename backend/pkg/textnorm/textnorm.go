@@ -0,0 +1,82 @@
+package textnorm
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Fold normalizes width, case, kana and spacing so that spelling variants compare equal.
+// Full-width alphanumerics become half-width, half-width katakana become full-width,
+// katakana become hiragana, letters are lower-cased, separators are removed and
+// English words are singularized.
+func Fold(s string) string {
+	// NFKC unifies full-width/half-width forms
+	s = norm.NFKC.String(s)
+	s = strings.ToLower(s)
+
+	var words []string
+	for _, word := range strings.FieldsFunc(s, isSeparator) {
+		words = append(words, Singularize(word))
+	}
+	s = strings.Join(words, "")
+
+	return KatakanaToHiragana(s)
+}
+
+// KatakanaToHiragana converts katakana characters to their hiragana equivalents.
+// The prolonged sound mark and characters without a hiragana form are kept as is.
+func KatakanaToHiragana(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'ァ' && r <= 'ヶ' {
+			return r - 0x60
+		}
+		return r
+	}, s)
+}
+
+// fPlurals maps the known plurals ending in "ves" to their singular form.
+// Other words ending in "ves", such as olives and cloves, just drop the "s".
+var fPlurals = map[string]string{
+	"leaves": "leaf",
+	"halves": "half",
+	"loaves": "loaf",
+	"knives": "knife",
+}
+
+// Singularize converts a plural English word to its singular form using simple rules.
+// Words containing non-ASCII letters are returned unchanged.
+func Singularize(word string) string {
+	for _, r := range word {
+		if r > unicode.MaxASCII || !unicode.IsLetter(r) {
+			return word
+		}
+	}
+
+	if len(word) <= 3 || strings.HasSuffix(word, "ss") {
+		return word
+	}
+
+	switch {
+	case strings.HasSuffix(word, "ies"):
+		return strings.TrimSuffix(word, "ies") + "y"
+	case fPlurals[word] != "":
+		return fPlurals[word]
+	case strings.HasSuffix(word, "oes"),
+		strings.HasSuffix(word, "ches"),
+		strings.HasSuffix(word, "shes"),
+		strings.HasSuffix(word, "xes"),
+		strings.HasSuffix(word, "zes"):
+		return strings.TrimSuffix(word, "es")
+	case strings.HasSuffix(word, "s"):
+		return strings.TrimSuffix(word, "s")
+	}
+
+	return word
+}
+
+// isSeparator reports whether r separates words in an ingredient name
+func isSeparator(r rune) bool {
+	return unicode.IsSpace(r) || r == '・' || r == '･' || r == '-' || r == '_' || r == '/'
+}
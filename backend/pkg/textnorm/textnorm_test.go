@@ -0,0 +1,57 @@
+package textnorm
+
+import "testing"
+
+func TestFold(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"タマネギ", "たまねぎ"},
+		{"たまねぎ", "たまねぎ"},
+		{"ﾀﾏﾈｷﾞ", "たまねぎ"},
+		{"豚バラ　肉", "豚ばら肉"},
+		{"ＭＩＬＫ", "milk"},
+		{"Pork Bellies", "porkbelly"},
+		{"ベーコン・ブロック", "べーこんぶろっく"},
+		{" Tomatoes ", "tomato"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if result := Fold(tt.input); result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestSingularize(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"eggs", "egg"},
+		{"potatoes", "potato"},
+		{"berries", "berry"},
+		{"leaves", "leaf"},
+		{"knives", "knife"},
+		{"olives", "olive"},
+		{"cloves", "clove"},
+		{"peaches", "peach"},
+		{"radishes", "radish"},
+		{"boxes", "box"},
+		{"grass", "grass"},
+		{"oats", "oat"},
+		{"gas", "gas"},
+		{"卵", "卵"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if result := Singularize(tt.input); result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+'use client';
+
+import { useRouter } from 'next/navigation';
+import { type LoginFormMode, LoginForm } from '../../src/components/LoginForm';
+import { useAuth } from '../../src/hooks/useAuth';
+import type { AuthCredentials } from '../../src/types';
+
+/**
+ * ログインページコンポーネント
+ * ログインまたは最初のアカウントの登録を行い、ホームへ戻る
+ */
+export default function LoginPage() {
+  const router = useRouter();
+  const { isLoading, error, login, register } = useAuth();
+
+  // フォーム送信時のハンドラー
+  const handleSubmit = async (mode: LoginFormMode, credentials: AuthCredentials) => {
+    try {
+      if (mode === 'login') {
+        await login(credentials);
+      } else {
+        await register(credentials);
+      }
+      router.replace('/');
+    } catch (err) {
+      // エラーはフックで処理済み
+      console.error('Failed to sign in:', err);
+    }
+  };
+
+  return (
+    <div className="max-w-md mx-auto">
+      <div className="mb-6">
+        <h1 className="text-3xl font-bold text-gray-900 mb-2">DinnerDecider にログイン</h1>
+        <p className="text-gray-600">
+          2人目以降のアカウントは、サーバー管理者が作成し、世帯のオーナーが招待コードで世帯に招きます
+        </p>
+      </div>
+
+      <LoginForm onSubmit={handleSubmit} isSubmitting={isLoading} error={error} />
+    </div>
+  );
+}
@@ -0,0 +1,40 @@
+'use client';
+
+import { usePathname, useRouter } from 'next/navigation';
+import { type ReactNode, useEffect } from 'react';
+import { useAuthStore } from '../stores/authStore';
+
+// ログインせずに表示できるパス
+const PUBLIC_PATHS = ['/login'];
+
+/**
+ * 認証ガードコンポーネント
+ * 未ログインの場合はログインページへリダイレクトする
+ */
+const AuthGuard = ({ children }: { children: ReactNode }) => {
+  const router = useRouter();
+  const pathname = usePathname();
+  const { token, isLoaded, loadSession } = useAuthStore();
+
+  const isPublic = PUBLIC_PATHS.includes(pathname);
+
+  // マウント時に保存済みのログイン情報を読み込み
+  useEffect(() => {
+    loadSession();
+  }, [loadSession]);
+
+  // 未ログインで保護されたページを開いた場合、またはトークンが無効になった場合はログインページへ
+  useEffect(() => {
+    if (isLoaded && !token && !isPublic) {
+      router.replace('/login');
+    }
+  }, [isLoaded, token, isPublic, router]);
+
+  if (!isPublic && (!isLoaded || !token)) {
+    return null;
+  }
+
+  return <>{children}</>;
+};
+
+export default AuthGuard;
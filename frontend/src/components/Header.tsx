@@ -1,7 +1,8 @@
 'use client';
 
 import Link from 'next/link';
-import { usePathname } from 'next/navigation';
+import { usePathname, useRouter } from 'next/navigation';
+import { useAuth } from '../hooks/useAuth';
 
 /**
  * ヘッダーコンポーネント
@@ -9,6 +10,8 @@ import { usePathname } from 'next/navigation';
  */
 const Header = () => {
   const pathname = usePathname();
+  const router = useRouter();
+  const { user, isAuthenticated, logout } = useAuth();
 
   // ナビゲーションアイテムの定義
   const navItems = [
@@ -20,6 +23,12 @@ const Header = () => {
   // 現在のパスがアクティブかどうかを判定
   const isActive = (path: string) => pathname === path;
 
+  // ログアウトしてログインページへ
+  const handleLogout = () => {
+    logout();
+    router.replace('/login');
+  };
+
   return (
     <header className="bg-white shadow-sm">
       <div className="max-w-7xl mx-auto px-4 sm:px-6 lg:px-8">
@@ -32,29 +41,39 @@ const Header = () => {
             DinnerDecider
           </Link>
 
-          <nav aria-label="メインナビゲーション">
-            <ul className="flex space-x-2 sm:space-x-4">
-              {navItems.map((item) => (
-                <li key={item.path}>
-                  <Link
-                    href={item.path}
-                    className={`
-                      px-3 py-2 rounded-md text-sm font-medium transition-colors
-                      focus:outline-none focus:ring-2 focus:ring-blue-500 focus:ring-offset-2
-                      ${
-                        isActive(item.path)
-                          ? 'bg-blue-100 text-blue-700'
-                          : 'text-gray-700 hover:bg-gray-100 hover:text-gray-900'
-                      }
-                    `}
-                    aria-current={isActive(item.path) ? 'page' : undefined}
-                  >
-                    {item.label}
-                  </Link>
-                </li>
-              ))}
-            </ul>
-          </nav>
+          {isAuthenticated && (
+            <nav aria-label="メインナビゲーション" className="flex items-center space-x-2 sm:space-x-4">
+              <ul className="flex space-x-2 sm:space-x-4">
+                {navItems.map((item) => (
+                  <li key={item.path}>
+                    <Link
+                      href={item.path}
+                      className={`
+                        px-3 py-2 rounded-md text-sm font-medium transition-colors
+                        focus:outline-none focus:ring-2 focus:ring-blue-500 focus:ring-offset-2
+                        ${
+                          isActive(item.path)
+                            ? 'bg-blue-100 text-blue-700'
+                            : 'text-gray-700 hover:bg-gray-100 hover:text-gray-900'
+                        }
+                      `}
+                      aria-current={isActive(item.path) ? 'page' : undefined}
+                    >
+                      {item.label}
+                    </Link>
+                  </li>
+                ))}
+              </ul>
+              <span className="hidden sm:inline text-sm text-gray-600">{user?.username}</span>
+              <button
+                type="button"
+                onClick={handleLogout}
+                className="px-3 py-2 rounded-md text-sm font-medium text-gray-700 hover:bg-gray-100 hover:text-gray-900 focus:outline-none focus:ring-2 focus:ring-blue-500 focus:ring-offset-2"
+              >
+                ログアウト
+              </button>
+            </nav>
+          )}
         </div>
       </div>
     </header>
@@ -0,0 +1,59 @@
+import { beforeEach, describe, expect, it, vi } from 'vitest';
+import { render, screen } from '@/src/test/utils';
+import userEvent from '@testing-library/user-event';
+import { LoginForm } from './LoginForm';
+
+describe('LoginForm', () => {
+  const mockOnSubmit = vi.fn();
+
+  beforeEach(() => {
+    mockOnSubmit.mockClear();
+  });
+
+  it('should submit trimmed credentials in login mode', async () => {
+    const user = userEvent.setup();
+    render(<LoginForm onSubmit={mockOnSubmit} />);
+
+    await user.type(screen.getByLabelText('ユーザー名'), ' hanako ');
+    await user.type(screen.getByLabelText('パスワード'), 'secret');
+    await user.click(screen.getByRole('button', { name: 'ログイン' }));
+
+    expect(mockOnSubmit).toHaveBeenCalledWith('login', { username: 'hanako', password: 'secret' });
+  });
+
+  it('should require username and password', async () => {
+    const user = userEvent.setup();
+    render(<LoginForm onSubmit={mockOnSubmit} />);
+
+    await user.click(screen.getByRole('button', { name: 'ログイン' }));
+
+    expect(screen.getByRole('alert')).toHaveTextContent('ユーザー名とパスワードを入力してください');
+    expect(mockOnSubmit).not.toHaveBeenCalled();
+  });
+
+  it('should switch to register mode and check the password length', async () => {
+    const user = userEvent.setup();
+    render(<LoginForm onSubmit={mockOnSubmit} />);
+
+    await user.click(screen.getByRole('button', { name: '最初のアカウントを登録する' }));
+    expect(screen.getByText('アカウントを登録')).toBeInTheDocument();
+
+    await user.type(screen.getByLabelText('ユーザー名'), 'hanako');
+    await user.type(screen.getByLabelText('パスワード'), 'short');
+    await user.click(screen.getByRole('button', { name: '登録' }));
+    expect(screen.getByRole('alert')).toHaveTextContent('パスワードは8文字以上で入力してください');
+    expect(mockOnSubmit).not.toHaveBeenCalled();
+
+    await user.type(screen.getByLabelText('パスワード'), '-password');
+    await user.click(screen.getByRole('button', { name: '登録' }));
+    expect(mockOnSubmit).toHaveBeenCalledWith('register', {
+      username: 'hanako',
+      password: 'short-password',
+    });
+  });
+
+  it('should show errors from the server', () => {
+    render(<LoginForm onSubmit={mockOnSubmit} error="ユーザー名またはパスワードが正しくありません" />);
+    expect(screen.getByRole('alert')).toHaveTextContent('ユーザー名またはパスワードが正しくありません');
+  });
+});
@@ -0,0 +1,116 @@
+'use client';
+
+import { type FormEvent, useState } from 'react';
+import type { AuthCredentials } from '../types';
+
+// ログインフォームのモード
+export type LoginFormMode = 'login' | 'register';
+
+// ログインフォームのプロパティ
+interface LoginFormProps {
+  onSubmit: (mode: LoginFormMode, credentials: AuthCredentials) => void; // 送信時のコールバック
+  isSubmitting?: boolean; // 送信中かどうか
+  error?: string | null; // サーバーから返されたエラーメッセージ
+}
+
+/**
+ * ログイン・新規登録フォームコンポーネント
+ */
+export const LoginForm = ({ onSubmit, isSubmitting = false, error }: LoginFormProps) => {
+  const [mode, setMode] = useState<LoginFormMode>('login');
+  const [credentials, setCredentials] = useState<AuthCredentials>({ username: '', password: '' });
+  const [validationError, setValidationError] = useState<string | null>(null);
+
+  // フィールド値変更時のハンドラー
+  const handleChange = (field: keyof AuthCredentials, value: string) => {
+    setCredentials((prev) => ({ ...prev, [field]: value }));
+    setValidationError(null);
+  };
+
+  // フォーム送信時のハンドラー
+  const handleSubmit = (e: FormEvent) => {
+    e.preventDefault();
+
+    const username = credentials.username.trim();
+    if (!username || !credentials.password) {
+      setValidationError('ユーザー名とパスワードを入力してください');
+      return;
+    }
+    // パスワードの長さはバックエンドと同じく8文字以上
+    if (mode === 'register' && credentials.password.length < 8) {
+      setValidationError('パスワードは8文字以上で入力してください');
+      return;
+    }
+
+    onSubmit(mode, { username, password: credentials.password });
+  };
+
+  const message = validationError ?? error;
+  const isLogin = mode === 'login';
+
+  return (
+    <form
+      onSubmit={handleSubmit}
+      className="bg-white p-6 rounded-lg shadow-md"
+      aria-label={isLogin ? 'ログインフォーム' : '新規登録フォーム'}
+    >
+      <h2 className="text-xl font-bold mb-4">{isLogin ? 'ログイン' : 'アカウントを登録'}</h2>
+
+      <div className="mb-4">
+        <label htmlFor="username" className="block text-sm font-medium text-gray-700 mb-1">
+          ユーザー名
+        </label>
+        <input
+          type="text"
+          id="username"
+          value={credentials.username}
+          onChange={(e) => handleChange('username', e.target.value)}
+          className="w-full px-3 py-2 border border-gray-300 rounded-md focus:outline-none focus:ring-2 focus:ring-blue-500"
+          autoComplete="username"
+          aria-required="true"
+        />
+      </div>
+
+      <div className="mb-6">
+        <label htmlFor="password" className="block text-sm font-medium text-gray-700 mb-1">
+          パスワード
+        </label>
+        <input
+          type="password"
+          id="password"
+          value={credentials.password}
+          onChange={(e) => handleChange('password', e.target.value)}
+          className="w-full px-3 py-2 border border-gray-300 rounded-md focus:outline-none focus:ring-2 focus:ring-blue-500"
+          autoComplete={isLogin ? 'current-password' : 'new-password'}
+          aria-required="true"
+        />
+      </div>
+
+      {message && (
+        <p className="text-red-500 text-sm mb-4" role="alert">
+          {message}
+        </p>
+      )}
+
+      <div className="flex flex-col gap-3">
+        <button
+          type="submit"
+          disabled={isSubmitting}
+          className="px-4 py-2 text-white bg-blue-600 rounded-md hover:bg-blue-700 focus:outline-none focus:ring-2 focus:ring-blue-500 disabled:opacity-50 min-h-[44px]"
+        >
+          {isLogin ? 'ログイン' : '登録'}
+        </button>
+        <button
+          type="button"
+          onClick={() => {
+            setMode(isLogin ? 'register' : 'login');
+            setValidationError(null);
+          }}
+          className="text-sm text-blue-600 hover:text-blue-700 focus:outline-none focus:ring-2 focus:ring-blue-500 rounded"
+        >
+          {isLogin ? '最初のアカウントを登録する' : 'ログインに戻る'}
+        </button>
+      </div>
+    </form>
+  );
+};
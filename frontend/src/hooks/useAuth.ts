@@ -0,0 +1,73 @@
+import { useCallback, useState } from 'react';
+import { authApi } from '../services/authApi';
+import { useAuthStore } from '../stores/authStore';
+import type { ApiError, AuthCredentials, User } from '../types';
+
+// useAuthフックの戻り値の型定義
+export interface UseAuthReturn {
+  user: User | null; // ログイン中のユーザー
+  isAuthenticated: boolean; // ログイン済みかどうか
+  isLoading: boolean; // ローディング状態
+  error: string | null; // エラーメッセージ
+  login: (credentials: AuthCredentials) => Promise<void>; // ログイン
+  register: (credentials: AuthCredentials) => Promise<void>; // 新規登録
+  logout: () => void; // ログアウト
+}
+
+/**
+ * 認証カスタムフック
+ * ログイン、新規登録、ログアウトを行う
+ */
+export const useAuth = (): UseAuthReturn => {
+  const { token, user, setSession, clearSession } = useAuthStore();
+  const [isLoading, setLoading] = useState(false);
+  const [error, setError] = useState<string | null>(null);
+
+  // ログイン
+  const login = useCallback(
+    async (credentials: AuthCredentials) => {
+      setLoading(true);
+      setError(null);
+
+      try {
+        setSession(await authApi.login(credentials));
+      } catch (err) {
+        const apiError = err as ApiError;
+        setError(apiError.message || 'ログインに失敗しました。');
+        throw err;
+      } finally {
+        setLoading(false);
+      }
+    },
+    [setSession],
+  );
+
+  // 新規登録（登録後はそのままログイン状態になる）
+  const register = useCallback(
+    async (credentials: AuthCredentials) => {
+      setLoading(true);
+      setError(null);
+
+      try {
+        setSession(await authApi.register(credentials));
+      } catch (err) {
+        const apiError = err as ApiError;
+        setError(apiError.message || 'アカウントの登録に失敗しました。');
+        throw err;
+      } finally {
+        setLoading(false);
+      }
+    },
+    [setSession],
+  );
+
+  return {
+    user,
+    isAuthenticated: token !== null,
+    isLoading,
+    error,
+    login,
+    register,
+    logout: clearSession,
+  };
+};
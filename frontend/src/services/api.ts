@@ -1,3 +1,4 @@
+import { useAuthStore } from '../stores/authStore';
 import type { ApiError } from '../types';
 
 // APIのベースURL（環境変数から取得、デフォルトはlocalhost）
@@ -20,10 +21,14 @@ class ApiClient {
   private async request<T>(endpoint: string, options: RequestInit = {}): Promise<T> {
     const url = `${this.baseUrl}${endpoint}`;
 
+    // ログイン中はセッショントークンを付与
+    const token = useAuthStore.getState().token;
+
     const config: RequestInit = {
       ...options,
       headers: {
         'Content-Type': 'application/json',
+        ...(token ? { Authorization: `Bearer ${token}` } : {}),
         ...options.headers,
       },
     };
@@ -31,6 +36,11 @@ class ApiClient {
     try {
       const response = await fetch(url, config);
 
+      // トークンが無効・期限切れの場合はログアウトしてログイン画面に戻す
+      if (response.status === 401 && token) {
+        useAuthStore.getState().clearSession();
+      }
+
       // レスポンスがエラーの場合
       if (!response.ok) {
         const errorData = await response.json().catch(() => ({
@@ -95,6 +105,20 @@ class ApiClient {
     });
   }
 
+  /**
+   * PATCHリクエストを実行（JSONマージパッチ）
+   * 指定したフィールドのみ更新され、nullを指定したフィールドはクリアされる
+   */
+  async patch<T>(endpoint: string, data: unknown): Promise<T> {
+    return this.request<T>(endpoint, {
+      method: 'PATCH',
+      body: JSON.stringify(data),
+      headers: {
+        'Content-Type': 'application/merge-patch+json',
+      },
+    });
+  }
+
   /**
    * DELETEリクエストを実行
    */
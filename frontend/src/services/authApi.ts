@@ -0,0 +1,22 @@
+import type { AuthCredentials, LoginResponse } from '../types';
+import { apiClient } from './api';
+
+/**
+ * 認証API
+ * ログインと新規登録を行うAPIメソッド
+ */
+export const authApi = {
+  /**
+   * ユーザー名とパスワードでログイン
+   */
+  login: (credentials: AuthCredentials): Promise<LoginResponse> => {
+    return apiClient.post<LoginResponse>('/auth/login', credentials);
+  },
+
+  /**
+   * アカウントを新規登録（最初のユーザー、または登録が開放されている場合のみ）
+   */
+  register: (credentials: AuthCredentials): Promise<LoginResponse> => {
+    return apiClient.post<LoginResponse>('/auth/register', credentials);
+  },
+};
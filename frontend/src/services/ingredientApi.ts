@@ -1,4 +1,4 @@
-import type { Ingredient, IngredientInput } from '../types';
+import type { Ingredient, IngredientInput, IngredientListResponse } from '../types';
 import { apiClient } from './api';
 
 /**
@@ -8,9 +8,20 @@ import { apiClient } from './api';
 export const ingredientApi = {
   /**
    * 全ての食材を取得
+   * 一覧はページ単位で返されるため、next_cursor をたどって全ページを取得する
    */
-  getAll: (): Promise<Ingredient[]> => {
-    return apiClient.get<Ingredient[]>('/ingredients');
+  getAll: async (): Promise<Ingredient[]> => {
+    const ingredients: Ingredient[] = [];
+    let cursor: string | undefined;
+
+    do {
+      const query = cursor ? `?cursor=${encodeURIComponent(cursor)}` : '';
+      const page = await apiClient.get<IngredientListResponse>(`/ingredients${query}`);
+      ingredients.push(...page.items);
+      cursor = page.next_cursor;
+    } while (cursor);
+
+    return ingredients;
   },
 
   /**
@@ -22,9 +33,10 @@ export const ingredientApi = {
 
   /**
    * 食材を更新
+   * 入力されたフィールドのみを変更するため、PUTではなくマージパッチを使用する
    */
   update: (id: number, data: IngredientInput): Promise<Ingredient> => {
-    return apiClient.put<Ingredient>(`/ingredients/${id}`, data);
+    return apiClient.patch<Ingredient>(`/ingredients/${id}`, data);
   },
 
   /**
@@ -0,0 +1,96 @@
+import { create } from 'zustand';
+import type { LoginResponse, User } from '../types';
+
+// LocalStorageのキー
+const AUTH_KEY = 'dinner-decider-auth';
+
+// 保存するログイン情報の型定義
+interface StoredSession {
+  token: string;
+  expiresAt: string;
+  user: User;
+}
+
+/**
+ * ログイン情報をLocalStorageに保存（nullの場合は削除）
+ */
+const saveSessionToStorage = (session: StoredSession | null): void => {
+  // Check if running in browser (client-side)
+  if (typeof window === 'undefined') return;
+
+  try {
+    if (session) {
+      localStorage.setItem(AUTH_KEY, JSON.stringify(session));
+    } else {
+      localStorage.removeItem(AUTH_KEY);
+    }
+  } catch (error) {
+    console.error('Failed to save session to localStorage:', error);
+  }
+};
+
+/**
+ * LocalStorageからログイン情報を読み込み（期限切れの場合は破棄）
+ */
+const loadSessionFromStorage = (): StoredSession | null => {
+  // Check if running in browser (client-side)
+  if (typeof window === 'undefined') return null;
+
+  try {
+    const stored = localStorage.getItem(AUTH_KEY);
+    if (!stored) return null;
+
+    const session = JSON.parse(stored) as StoredSession;
+    if (new Date(session.expiresAt).getTime() <= Date.now()) {
+      localStorage.removeItem(AUTH_KEY);
+      return null;
+    }
+    return session;
+  } catch (error) {
+    console.error('Failed to load session from localStorage:', error);
+    return null;
+  }
+};
+
+// 認証ストアの状態とアクションの型定義
+interface AuthState {
+  token: string | null; // セッショントークン
+  user: User | null; // ログイン中のユーザー
+  isLoaded: boolean; // LocalStorageから読み込み済みかどうか
+  loadSession: () => void; // LocalStorageからログイン情報を読み込み
+  setSession: (response: LoginResponse) => void; // ログイン結果を保存
+  clearSession: () => void; // ログアウト
+}
+
+/**
+ * 認証ストア
+ * Zustandを使用したセッショントークンの状態管理
+ * サーバーとクライアントの描画を一致させるため、ログイン情報はマウント後にloadSessionで読み込む
+ */
+export const useAuthStore = create<AuthState>((set) => ({
+  token: null,
+  user: null,
+  isLoaded: false,
+
+  // LocalStorageからログイン情報を読み込み
+  loadSession: () => {
+    const session = loadSessionFromStorage();
+    set({ token: session?.token ?? null, user: session?.user ?? null, isLoaded: true });
+  },
+
+  // ログイン結果を保存
+  setSession: (response) => {
+    saveSessionToStorage({
+      token: response.token,
+      expiresAt: response.expires_at,
+      user: response.user,
+    });
+    set({ token: response.token, user: response.user });
+  },
+
+  // ログアウト
+  clearSession: () => {
+    saveSessionToStorage(null);
+    set({ token: null, user: null });
+  },
+}));
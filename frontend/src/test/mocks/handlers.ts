@@ -27,7 +27,7 @@ const mockRecipes: RecipeSuggestionResponse = {
 export const handlers = [
   // GET /ingredients
   http.get(`${API_BASE_URL}/ingredients`, () => {
-    return HttpResponse.json(mockIngredients);
+    return HttpResponse.json({ items: mockIngredients });
   }),
 
   // POST /ingredients
@@ -40,8 +40,8 @@ export const handlers = [
     return HttpResponse.json(newIngredient, { status: 201 });
   }),
 
-  // PUT /ingredients/:id
-  http.put(`${API_BASE_URL}/ingredients/:id`, async ({ params, request }) => {
+  // PATCH /ingredients/:id
+  http.patch(`${API_BASE_URL}/ingredients/:id`, async ({ params, request }) => {
     const { id } = params;
     const body = (await request.json()) as Partial<Ingredient>;
     const updatedIngredient: Ingredient = {
@@ -8,6 +8,12 @@ export interface Ingredient {
   updated_at?: string;
 }
 
+// 食材一覧レスポンスの型定義（1ページ分）
+export interface IngredientListResponse {
+  items: Ingredient[];
+  next_cursor?: string;
+}
+
 // 食材入力用の型定義
 export interface IngredientInput {
   name: string;
@@ -27,6 +33,27 @@ export interface RecipeSuggestionResponse {
   suggestions: Recipe[];
 }
 
+// ユーザーの型定義
+export interface User {
+  id: number;
+  username: string;
+  created_at?: string;
+  updated_at?: string;
+}
+
+// ログイン・新規登録リクエストの型定義
+export interface AuthCredentials {
+  username: string;
+  password: string;
+}
+
+// ログイン・新規登録レスポンスの型定義
+export interface LoginResponse {
+  token: string; // Authorization: Bearer <token> として送信する
+  expires_at: string;
+  user: User;
+}
+
 // APIエラーの型定義
 export interface ApiError {
   error?: string;